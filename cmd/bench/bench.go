@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Operations lists the operations Run supports.
+var Operations = []string{"list", "options", "purge"}
+
+// Config controls a run.
+type Config struct {
+	// Operation is one of Operations
+	Operation string
+
+	// Duration is how long workers start new operations
+	Duration time.Duration
+
+	// Concurrency is the number of workers; zero uses 1
+	Concurrency int
+
+	// Services, Options and Paths size the list page, the bulk update and
+	// the purge respectively
+	Services int
+	Options  int
+	Paths    int
+
+	// Latency is added to every fake API response
+	Latency time.Duration
+}
+
+// Result summarizes a run.
+type Result struct {
+	Operation string
+	Ops       int
+	Errors    int
+	Elapsed   time.Duration
+
+	// P50 and P99 are operation latencies
+	P50 time.Duration
+	P99 time.Duration
+
+	// AllocsPerOp and BytesPerOp are heap allocations of the whole process,
+	// fake server included, divided by Ops
+	AllocsPerOp uint64
+	BytesPerOp  uint64
+
+	// Requests is the number of API requests served
+	Requests int64
+}
+
+// OpsPerSecond returns the throughput of the run.
+func (r Result) OpsPerSecond() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Ops) / r.Elapsed.Seconds()
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%-8s %8d ops %10.1f ops/s  p50 %-10s p99 %-10s %8d allocs/op %10d B/op %8d requests %d errors",
+		r.Operation, r.Ops, r.OpsPerSecond(), r.P50, r.P99, r.AllocsPerOp, r.BytesPerOp, r.Requests, r.Errors)
+}
+
+// Run starts a fake API and runs the operation against it until the
+// duration has passed or ctx ends.
+func Run(ctx context.Context, cfg Config) (*Result, error) {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Options <= 0 {
+		cfg.Options = 50
+	}
+	if cfg.Paths <= 0 {
+		cfg.Paths = 10000
+	}
+
+	server := fakeapi.New(fakeapi.Config{Services: cfg.Services, Options: cfg.Options, Latency: cfg.Latency})
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("bench-token"), cachefly.WithBaseURL(server.URL))
+	op, err := operation(client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var mu sync.Mutex
+	var latencies []time.Duration
+	errors := 0
+
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.Concurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; ctx.Err() == nil; i++ {
+				opStart := time.Now()
+				err := op(context.Background(), worker, i)
+				elapsed := time.Since(opStart)
+
+				mu.Lock()
+				latencies = append(latencies, elapsed)
+				if err != nil {
+					errors++
+				}
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	result := &Result{
+		Operation: cfg.Operation,
+		Ops:       len(latencies),
+		Errors:    errors,
+		Elapsed:   elapsed,
+		Requests:  server.Requests(),
+	}
+	if result.Ops > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		result.P50 = latencies[len(latencies)/2]
+		result.P99 = latencies[len(latencies)*99/100]
+		result.AllocsPerOp = (after.Mallocs - before.Mallocs) / uint64(result.Ops)
+		result.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / uint64(result.Ops)
+	}
+	return result, nil
+}
+
+// operation returns the function performing one operation. Operations run
+// to completion once started so that their latency is not cut short.
+func operation(client *cachefly.Client, cfg Config) (func(ctx context.Context, worker, i int) error, error) {
+	switch cfg.Operation {
+	case "list":
+		return func(ctx context.Context, worker, i int) error {
+			_, err := client.Services.List(ctx, api.ListOptions{Limit: cfg.Services})
+			return err
+		}, nil
+
+	case "options":
+		// Every worker owns a service and flips all its options each time
+		return func(ctx context.Context, worker, i int) error {
+			desired := make(api.ServiceOptions, cfg.Options)
+			for o := 0; o < cfg.Options; o++ {
+				desired[fmt.Sprintf("option%d", o)] = i%2 == 0
+			}
+			_, err := client.ServiceOptions.Apply(ctx, fakeapi.ServiceID(worker), desired, api.ApplyOptions{})
+			return err
+		}, nil
+
+	case "purge":
+		paths := make([]string, cfg.Paths)
+		for p := range paths {
+			paths[p] = fmt.Sprintf("/assets/%d/image.png", p)
+		}
+		return func(ctx context.Context, worker, i int) error {
+			report, err := client.Purge.PathsWithOptions(ctx, fakeapi.ServiceID(worker), paths, api.PurgeOptions{Concurrency: 4, Interval: -1})
+			if err == nil && report.Failed > 0 {
+				err = fmt.Errorf("%d paths failed", report.Failed)
+			}
+			return err
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown operation %q", cfg.Operation)
+	}
+}
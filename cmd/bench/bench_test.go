@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	for _, op := range Operations {
+		t.Run(op, func(t *testing.T) {
+			result, err := Run(context.Background(), Config{
+				Operation:   op,
+				Duration:    50 * time.Millisecond,
+				Concurrency: 2,
+				Services:    20,
+				Options:     5,
+				Paths:       100,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result.Ops == 0 || result.Errors != 0 {
+				t.Errorf("Expected operations without errors, got %d ops and %d errors", result.Ops, result.Errors)
+			}
+			if result.Requests < int64(result.Ops) {
+				t.Errorf("Expected at least one request per operation, got %d for %d ops", result.Requests, result.Ops)
+			}
+			if result.P99 < result.P50 {
+				t.Errorf("Expected p99 >= p50, got %s and %s", result.P99, result.P50)
+			}
+		})
+	}
+}
+
+func TestRun_UnknownOperation(t *testing.T) {
+	if _, err := Run(context.Background(), Config{Operation: "delete", Duration: time.Millisecond}); err == nil {
+		t.Error("Expected error for unknown operation")
+	}
+}
@@ -0,0 +1,67 @@
+// Command bench runs the SDK's high-volume operations against an in-memory
+// fake API for a fixed duration and reports throughput, latency and
+// allocations. It complements the package benchmarks with a soak run that
+// exercises the client concurrently for long enough to surface leaks and
+// contention.
+//
+// Usage:
+//
+//	bench -op list -duration 30s -concurrency 8
+//	bench -op purge -paths 20000 -latency 5ms
+//	bench -op all -duration 5m
+//
+// Operations are list (decode a page of services), options (apply a bulk
+// option update) and purge (purge a path list in chunks).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+func main() {
+	var (
+		op          = flag.String("op", "all", "operation to run: list, options, purge or all")
+		duration    = flag.Duration("duration", 10*time.Second, "how long to run each operation")
+		concurrency = flag.Int("concurrency", 4, "concurrent workers per operation")
+		services    = flag.Int("services", 1000, "services per list page")
+		options     = flag.Int("options", 50, "options changed per bulk update")
+		paths       = flag.Int("paths", 10000, "paths per purge")
+		latency     = flag.Duration("latency", 0, "latency added to every fake API response")
+	)
+	flag.Parse()
+
+	ops := []string{*op}
+	if *op == "all" {
+		ops = Operations
+	}
+
+	var failed []string
+	for _, name := range ops {
+		result, err := Run(context.Background(), Config{
+			Operation:   name,
+			Duration:    *duration,
+			Concurrency: *concurrency,
+			Services:    *services,
+			Options:     *options,
+			Paths:       *paths,
+			Latency:     *latency,
+		})
+		if err != nil {
+			log.Fatalf("bench: %v", err)
+		}
+		fmt.Println(result)
+		if result.Errors > 0 {
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintln(os.Stderr, "bench: operations with errors:", strings.Join(failed, ", "))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func (a *app) accountsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "accounts",
+		Aliases: []string{"account"},
+		Short:   "Inspect the current account and its child accounts",
+	}
+
+	get := &cobra.Command{
+		Use:   "get [ACCOUNT_ID]",
+		Short: "Show an account, by default the current one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			var account *api.Account
+			if len(args) == 0 {
+				account, err = client.Accounts.Get(cmd.Context(), "")
+			} else {
+				account, err = client.Accounts.GetByID(cmd.Context(), args[0], "")
+			}
+			if err != nil {
+				return err
+			}
+			return a.print(account, func() table { return accountTable(*account) })
+		},
+	}
+
+	var opts api.ListAccountsOptions
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List accounts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			resp, err := client.Accounts.List(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			return a.print(resp, func() table { return accountTable(resp.Accounts...) })
+		},
+	}
+	list.Flags().BoolVar(&opts.IsChild, "child", false, "only list child accounts")
+	list.Flags().StringVar(&opts.Status, "status", "", "only list accounts with this status")
+	list.Flags().IntVar(&opts.Offset, "offset", 0, "number of accounts to skip")
+	list.Flags().IntVar(&opts.Limit, "limit", 0, "maximum number of accounts to list")
+
+	cmd.AddCommand(get, list)
+	return cmd
+}
+
+func accountTable(accounts ...api.Account) table {
+	t := table{header: []string{"ID", "COMPANY", "EMAIL", "STATUS", "CHILD"}}
+	for _, acc := range accounts {
+		t.rows = append(t.rows, []string{acc.ID, acc.CompanyName, acc.Email, acc.Status, strconv.FormatBool(acc.IsChild)})
+	}
+	return t
+}
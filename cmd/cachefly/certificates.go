@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func (a *app) certificatesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "certificates",
+		Aliases: []string{"certificate", "certs"},
+		Short:   "List and inspect TLS certificates",
+	}
+
+	var opts api.ListCertificatesOptions
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List certificates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			resp, err := client.Certificates.List(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			return a.print(resp, func() table { return certificateTable(resp.Certificates...) })
+		},
+	}
+	list.Flags().StringVar(&opts.Search, "search", "", "only list certificates matching this text")
+	list.Flags().IntVar(&opts.Offset, "offset", 0, "number of certificates to skip")
+	list.Flags().IntVar(&opts.Limit, "limit", 0, "maximum number of certificates to list")
+
+	get := &cobra.Command{
+		Use:   "get CERTIFICATE_ID",
+		Short: "Show a certificate",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			cert, err := client.Certificates.GetByID(cmd.Context(), args[0], "")
+			if err != nil {
+				return err
+			}
+			return a.print(cert, func() table { return certificateTable(*cert) })
+		},
+	}
+
+	cmd.AddCommand(list, get)
+	return cmd
+}
+
+func certificateTable(certs ...api.Certificate) table {
+	t := table{header: []string{"ID", "COMMON NAME", "NOT AFTER", "EXPIRED", "IN USE"}}
+	for _, c := range certs {
+		t.rows = append(t.rows, []string{c.ID, c.SubjectCommonName, c.NotAfter, strconv.FormatBool(c.Expired), strconv.FormatBool(c.InUse)})
+	}
+	return t
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+// run executes the CLI against server and returns its output.
+func run(t *testing.T, server *httptest.Server, args ...string) (string, error) {
+	t.Helper()
+	env := map[string]string{
+		"CACHEFLY_API_TOKEN": "test-token",
+	}
+	var out bytes.Buffer
+	cmd := newRootCommand(&out, func(key string) string { return env[key] })
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(append([]string{"--base-url", server.URL, "--config", writeConfig(t, "")}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func servicesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected bearer token, got %s", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"count":1,"limit":10,"offset":0},"data":[{"_id":"srv-1","name":"Site","uniqueName":"site","status":"ACTIVE","autoSsl":true}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestServicesList_Table(t *testing.T) {
+	out, err := run(t, servicesServer(t), "services", "list")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected header and one row, got %q", out)
+	}
+	if !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "srv-1") || !strings.Contains(lines[1], "site") {
+		t.Errorf("Unexpected table output %q", out)
+	}
+}
+
+func TestServicesList_JSON(t *testing.T) {
+	out, err := run(t, servicesServer(t), "services", "list", "-o", "json")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var resp struct {
+		Data []struct {
+			ID string `json:"_id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("Expected JSON output, got %q: %v", out, err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != "srv-1" {
+		t.Errorf("Expected service srv-1, got %+v", resp.Data)
+	}
+}
+
+func TestServicesList_YAML(t *testing.T) {
+	out, err := run(t, servicesServer(t), "services", "list", "--output", "yaml")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out, "- _id: srv-1\n") || !strings.Contains(out, "uniqueName: site\n") {
+		t.Errorf("Unexpected YAML output %q", out)
+	}
+	if !strings.Contains(out, "configurationMode: \"\"\n") {
+		t.Errorf("Expected empty strings to stay quoted, got %q", out)
+	}
+	if strings.Index(out, "_id") > strings.Index(out, "uniqueName") {
+		t.Errorf("Expected JSON field order to be kept, got %q", out)
+	}
+}
+
+func TestUnknownOutput(t *testing.T) {
+	_, err := run(t, servicesServer(t), "services", "list", "-o", "xml")
+	if err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Errorf("Expected unknown format error, got %v", err)
+	}
+}
+
+func TestOptionsUpdate(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/options/metadata"):
+			w.Write([]byte(`{"meta":{},"data":[
+				{"name":"cors","type":"standard","property":{"type":"boolean"}},
+				{"name":"ttl","type":"standard","property":{"type":"integer"}}
+			]}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"cors":false,"ttl":3600}`))
+		case r.Method == http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.Write([]byte(`{"cors":true,"ttl":3600}`))
+		}
+	}))
+	defer server.Close()
+
+	out, err := run(t, server, "options", "update", "srv-1", "cors=true", "ttl=3600")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(sent) != 1 || sent["cors"] != true {
+		t.Errorf("Expected only cors to be sent, got %v", sent)
+	}
+	if !strings.Contains(out, "cors") || !strings.Contains(out, "true") {
+		t.Errorf("Expected the change to be printed, got %q", out)
+	}
+}
+
+func TestServicesDelete_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method != http.MethodGet:
+			t.Errorf("Expected no changes, got %s %s", r.Method, r.URL.Path)
+		case r.URL.Path == "/services/srv-1":
+			w.Write([]byte(`{"_id":"srv-1","name":"Site","status":"ACTIVE"}`))
+		case r.URL.Path == "/services/srv-1/options":
+			w.Write([]byte(`{}`))
+		case r.URL.Path == "/services/srv-1/domains":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"dom-1","name":"www.example.com"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	out, err := run(t, server, "services", "delete", "srv-1", "--dry-run", "--force")
+	if err == nil || !strings.Contains(err.Error(), "DetachDomains") {
+		t.Errorf("Expected the domains to block the delete, got %v", err)
+	}
+	for _, want := range []string{"deactivate", "www.example.com", "delete service", "blocked"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got %q", want, out)
+		}
+	}
+}
+
+func TestDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/services":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"srv-1","name":"Site","edgeCompute":true}]}`))
+		case "/accounts/me":
+			w.Write([]byte(`{"_id":"acc-1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	out, err := run(t, server, "drift")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(out, "data[].edgeCompute") || !strings.Contains(out, "v2_5.Service") {
+		t.Errorf("Expected the dropped service field, got %q", out)
+	}
+	if strings.Contains(out, "acc-1") || !strings.Contains(out, "skipped") {
+		t.Errorf("Expected only dropped fields and skipped endpoints, got %q", out)
+	}
+}
+
+func TestParseAssignments(t *testing.T) {
+	options, err := parseAssignments([]string{"ttl=60", "cors=true", "name=plain text", `proxy={"enabled":false}`})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if options["ttl"] != float64(60) || options["cors"] != true || options["name"] != "plain text" {
+		t.Errorf("Unexpected values %v", options)
+	}
+	if proxy, ok := options["proxy"].(map[string]interface{}); !ok || proxy["enabled"] != false {
+		t.Errorf("Expected object value, got %v", options["proxy"])
+	}
+
+	if _, err := parseAssignments([]string{"cors"}); err == nil {
+		t.Error("Expected error for missing value")
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	path := writeConfig(t, "default:\n  token: file-token\n  base_url: https://example.com/api/2.5\nstaging:\n  token_env: STAGING_TOKEN\n")
+	configOf := func(a *app) (*cachefly.ClientConfig, error) {
+		opts, err := a.clientOptions()
+		if err != nil {
+			return nil, err
+		}
+		cfg := &cachefly.ClientConfig{}
+		for _, opt := range opts {
+			opt(cfg)
+		}
+		return cfg, nil
+	}
+	noEnv := func(string) string { return "" }
+
+	cfg, err := configOf(&app{configPath: path, getenv: noEnv})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.Token != "file-token" || cfg.BaseURL != "https://example.com/api/2.5" {
+		t.Errorf("Expected the default profile, got %+v", cfg)
+	}
+
+	env := map[string]string{"CACHEFLY_API_TOKEN": "env-token", "CACHEFLY_CONFIG_FILE": path, "CACHEFLY_PROFILE": "staging"}
+	cfg, err = configOf(&app{baseURL: "https://flag.example.com", getenv: func(key string) string { return env[key] }})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cfg.Token != "env-token" || cfg.Credentials != nil || cfg.BaseURL != "https://flag.example.com" {
+		t.Errorf("Expected the environment token and flag base URL to take precedence, got %+v", cfg)
+	}
+
+	if _, err := configOf(&app{configPath: filepath.Join(t.TempDir(), "missing"), getenv: noEnv}); err == nil {
+		t.Error("Expected error for missing explicit config file")
+	}
+	if _, err := configOf(&app{configPath: path, profile: "prod", getenv: noEnv}); !errors.Is(err, cachefly.ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound for a missing explicit profile, got %v", err)
+	}
+	if _, err := configOf(&app{configPath: writeConfig(t, ""), getenv: noEnv}); err == nil || !strings.Contains(err.Error(), "no API token") {
+		t.Errorf("Expected an error without a token, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+// clientOptions returns the client options of the selected profile of the
+// shared config file, see cachefly.LoadProfile. The file is the one named by
+// --config, CACHEFLY_CONFIG_FILE or ~/.cachefly/config, and the profile the
+// one named by --profile, CACHEFLY_PROFILE or "default". A missing file or
+// profile is only an error when it was named explicitly.
+// CACHEFLY_API_TOKEN overrides the token of the profile.
+func (a *app) clientOptions() ([]cachefly.Option, error) {
+	path := a.configPath
+	if path == "" {
+		path = a.getenv(cachefly.ConfigFileEnvVar)
+	}
+	explicitPath := path != ""
+	if path == "" {
+		path, _ = cachefly.DefaultConfigFile()
+	}
+	name := a.profile
+	if name == "" {
+		name = a.getenv(cachefly.ProfileEnvVar)
+	}
+	explicitName := name != ""
+	if name == "" {
+		name = cachefly.DefaultProfile
+	}
+
+	profile := &cachefly.Profile{Name: name}
+	if path != "" {
+		loaded, err := cachefly.LoadProfile(path, name)
+		switch {
+		case err == nil:
+			profile = loaded
+		case errors.Is(err, fs.ErrNotExist) && !explicitPath:
+		case errors.Is(err, cachefly.ErrProfileNotFound) && !explicitName:
+		default:
+			return nil, err
+		}
+	}
+
+	opts := profile.Options()
+	if token := strings.TrimSpace(a.getenv("CACHEFLY_API_TOKEN")); token != "" {
+		opts = append(opts, cachefly.WithToken(token), cachefly.WithCredentials(nil))
+	} else if profile.Token == "" && profile.TokenFile == "" && profile.TokenEnv == "" {
+		return nil, fmt.Errorf("no API token: set CACHEFLY_API_TOKEN or a token in profile %q of the config file", name)
+	}
+	if a.baseURL != "" {
+		opts = append(opts, cachefly.WithBaseURL(a.baseURL))
+	}
+	return opts, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/drift"
+)
+
+func (a *app) driftCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "drift [SERVICE_ID]",
+		Short: "Report response fields the SDK does not model",
+		Long: `Report response fields the SDK does not model.
+
+The read-only endpoints of the account, and of the service when one is given,
+are fetched and compared with the SDK's types. Fields the API returns that a
+decoded value would drop are listed; endpoints that cannot be fetched are
+listed with their error.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			var serviceID string
+			if len(args) == 1 {
+				serviceID = args[0]
+			}
+			report, err := drift.Check(cmd.Context(), client, serviceID)
+			if err != nil {
+				return err
+			}
+			return a.print(report, func() table { return driftTable(report) })
+		},
+	}
+}
+
+func driftTable(report *drift.Report) table {
+	t := table{header: []string{"ENDPOINT", "FIELD", "TYPE", "SAMPLE"}}
+	for _, res := range report.Results {
+		if res.Error != "" {
+			t.rows = append(t.rows, []string{res.Endpoint, "-", "-", "skipped: " + res.Error})
+			continue
+		}
+		for _, f := range res.Dropped {
+			t.rows = append(t.rows, []string{res.Endpoint, f.Path, f.Type, f.Sample})
+		}
+	}
+	return t
+}
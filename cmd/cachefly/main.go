@@ -0,0 +1,38 @@
+// Command cachefly is a command-line client for the CacheFly API built on
+// this SDK. Every command maps to one or two SDK calls, so its source doubles
+// as a set of usage examples.
+//
+// The API token is read from CACHEFLY_API_TOKEN or, when unset, from a
+// profile of the SDK's shared config file (~/.cachefly/config by default, or
+// the file named by --config or CACHEFLY_CONFIG_FILE). The profile is named
+// by --profile or CACHEFLY_PROFILE and defaults to "default":
+//
+//	default:
+//	  token: your-token
+//	staging:
+//	  token_env: CACHEFLY_STAGING_TOKEN
+//	  base_url: https://staging-api.cachefly.com/api/2.5
+//
+// Usage:
+//
+//	cachefly services list
+//	cachefly services get srv_123 -o json
+//	cachefly options get srv_123 cors
+//	cachefly options update srv_123 cors=true ttl=3600
+//	cachefly purge srv_123 /index.html /images/logo.png
+//	cachefly accounts get
+//	cachefly certificates list -o yaml
+//
+// Output defaults to a table; --output json and --output yaml print the full
+// API objects.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	if err := newRootCommand(os.Stdout, os.Getenv).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func (a *app) optionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "options",
+		Aliases: []string{"option"},
+		Short:   "Read and update service options",
+	}
+
+	get := &cobra.Command{
+		Use:   "get SERVICE_ID [NAME...]",
+		Short: "Show the options of a service, or only the named ones",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			options, err := client.ServiceOptions.GetOptions(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if names := args[1:]; len(names) > 0 {
+				selected := make(api.ServiceOptions, len(names))
+				for _, name := range names {
+					value, ok := options[name]
+					if !ok {
+						return fmt.Errorf("service %s has no option %q", args[0], name)
+					}
+					selected[name] = value
+				}
+				options = selected
+			}
+			return a.print(options, func() table { return optionsTable(options) })
+		},
+	}
+
+	var dryRun bool
+	update := &cobra.Command{
+		Use:   "update SERVICE_ID NAME=VALUE...",
+		Short: "Change service options",
+		Long: `Change service options, sending only the options whose value differs.
+
+Values are parsed as JSON, so numbers, booleans, objects and arrays keep their
+type; anything that is not valid JSON is sent as a string:
+
+  cachefly options update srv_123 cors=true ttl=3600 'reverseProxy={"enabled":false}'`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			desired, err := parseAssignments(args[1:])
+			if err != nil {
+				return err
+			}
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			result, err := client.ServiceOptions.Apply(cmd.Context(), args[0], desired, api.ApplyOptions{DryRun: dryRun})
+			if err != nil {
+				return err
+			}
+			return a.print(result, func() table { return changesTable(result.Changes) })
+		},
+	}
+	update.Flags().BoolVar(&dryRun, "dry-run", false, "show the changes without applying them")
+
+	cmd.AddCommand(get, update)
+	return cmd
+}
+
+// parseAssignments parses NAME=VALUE arguments into options.
+func parseAssignments(args []string) (api.ServiceOptions, error) {
+	options := make(api.ServiceOptions, len(args))
+	for _, arg := range args {
+		name, raw, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid option %q, expected NAME=VALUE", arg)
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		options[name] = value
+	}
+	return options, nil
+}
+
+func optionsTable(options api.ServiceOptions) table {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	t := table{header: []string{"NAME", "VALUE"}}
+	for _, name := range names {
+		t.rows = append(t.rows, []string{name, cell(options[name])})
+	}
+	return t
+}
+
+func changesTable(changes []api.OptionChange) table {
+	t := table{header: []string{"NAME", "CURRENT", "DESIRED"}}
+	for _, c := range changes {
+		t.rows = append(t.rows, []string{c.Name, cell(c.Current), cell(c.Desired)})
+	}
+	return t
+}
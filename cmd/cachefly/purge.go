@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func (a *app) purgeCommand() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "purge SERVICE_ID [PATH...]",
+		Short: "Purge paths from the cache of a service",
+		Long: `Purge paths from the cache of a service.
+
+Paths are taken from the arguments and, with --file, from a file holding one
+path per line ("-" reads standard input). Large lists are sent in chunks.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths := args[1:]
+			if file != "" {
+				more, err := readPaths(file)
+				if err != nil {
+					return err
+				}
+				paths = append(paths, more...)
+			}
+			if len(paths) == 0 {
+				return fmt.Errorf("no paths to purge")
+			}
+
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			report, err := client.Purge.Paths(cmd.Context(), args[0], paths)
+			if err != nil {
+				return err
+			}
+			if err := a.print(report, func() table { return purgeTable(report) }); err != nil {
+				return err
+			}
+			if report.Failed > 0 {
+				return fmt.Errorf("%d of %d paths failed to purge", report.Failed, report.Requested)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file with one path per line, - for standard input")
+	return cmd
+}
+
+// readPaths reads the non-empty lines of name.
+func readPaths(name string) ([]string, error) {
+	f := os.Stdin
+	if name != "-" {
+		var err error
+		if f, err = os.Open(name); err != nil {
+			return nil, err
+		}
+		defer f.Close()
+	}
+
+	var paths []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, scanner.Err()
+}
+
+func purgeTable(report *api.PurgeReport) table {
+	t := table{header: []string{"CHUNK", "PATHS", "RESULT"}}
+	for _, chunk := range report.Chunks {
+		result := "purged"
+		if chunk.Error != "" {
+			result = chunk.Error
+		}
+		t.rows = append(t.rows, []string{strconv.Itoa(chunk.Index), strconv.Itoa(len(chunk.Paths)), result})
+	}
+	return t
+}
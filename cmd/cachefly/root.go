@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+// app holds the global flags and the client shared by all commands.
+type app struct {
+	out        io.Writer
+	getenv     func(string) string
+	output     string
+	configPath string
+	profile    string
+	baseURL    string
+
+	client *cachefly.Client
+}
+
+// newRootCommand builds the command tree. getenv is os.Getenv outside tests.
+func newRootCommand(out io.Writer, getenv func(string) string) *cobra.Command {
+	a := &app{out: out, getenv: getenv}
+
+	root := &cobra.Command{
+		Use:          "cachefly",
+		Short:        "Command-line client for the CacheFly API",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			switch a.output {
+			case outputTable, outputJSON, outputYAML:
+				return nil
+			default:
+				return fmt.Errorf("unknown output format %q, expected table, json or yaml", a.output)
+			}
+		},
+	}
+	root.SetOut(out)
+
+	flags := root.PersistentFlags()
+	flags.StringVarP(&a.output, "output", "o", outputTable, "output format: table, json or yaml")
+	flags.StringVar(&a.configPath, "config", "", "config file (default $CACHEFLY_CONFIG_FILE or ~/.cachefly/config)")
+	flags.StringVar(&a.profile, "profile", "", "profile of the config file (default $CACHEFLY_PROFILE or default)")
+	flags.StringVar(&a.baseURL, "base-url", "", "API base URL, including the version path")
+
+	root.AddCommand(
+		a.servicesCommand(),
+		a.optionsCommand(),
+		a.purgeCommand(),
+		a.accountsCommand(),
+		a.certificatesCommand(),
+		a.driftCommand(),
+	)
+	return root
+}
+
+// sdk returns the API client, creating it from the config on first use.
+func (a *app) sdk() (*cachefly.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	opts, err := a.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+	a.client = cachefly.NewClient(opts...)
+	return a.client, nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func (a *app) servicesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "services",
+		Aliases: []string{"service"},
+		Short:   "List and inspect services",
+	}
+
+	var opts api.ListOptions
+	var createdAfter string
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List services",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if createdAfter != "" {
+				t, err := time.Parse(time.RFC3339, createdAfter)
+				if err != nil {
+					return fmt.Errorf("invalid --created-after: %w", err)
+				}
+				opts.CreatedAfter = t
+			}
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			resp, err := client.Services.List(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			return a.print(resp, func() table { return serviceTable(resp.Services...) })
+		},
+	}
+	list.Flags().StringVar(&opts.Status, "status", "", "only list services with this status")
+	list.Flags().StringVar(&opts.Search, "search", "", "only list services whose name contains this string")
+	list.Flags().StringVar(&opts.UniqueName, "unique-name", "", "only list the service with this unique name")
+	list.Flags().StringVar(&createdAfter, "created-after", "", "only list services created after this RFC 3339 time")
+	list.Flags().StringSliceVar(&opts.SortBy, "sort", nil, "sort by these fields, prefixed with - for descending order")
+	list.Flags().IntVar(&opts.Offset, "offset", 0, "number of services to skip")
+	list.Flags().IntVar(&opts.Limit, "limit", 0, "maximum number of services to list")
+
+	get := &cobra.Command{
+		Use:   "get SERVICE_ID",
+		Short: "Show a service",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			service, err := client.Services.GetByID(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return a.print(service, func() table { return serviceTable(*service) })
+		},
+	}
+
+	var deleteOpts api.DeleteOptions
+	var dryRun bool
+	del := &cobra.Command{
+		Use:   "delete SERVICE_ID",
+		Short: "Delete a service",
+		Long: `Delete a service.
+
+Active services are only deleted with --force, which deactivates them first,
+and services with domains only with --detach-domains, which deletes the
+domains first. With --dry-run the steps are printed without changing
+anything.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdk()
+			if err != nil {
+				return err
+			}
+			var plan *api.DeletePlan
+			if dryRun {
+				plan, err = client.Services.PlanDelete(cmd.Context(), args[0], deleteOpts)
+			} else {
+				plan, err = client.Services.Delete(cmd.Context(), args[0], deleteOpts)
+			}
+			if plan != nil {
+				if perr := a.print(plan, func() table { return deletePlanTable(plan) }); perr != nil {
+					return perr
+				}
+			}
+			if err == nil && dryRun && len(plan.Blockers) > 0 {
+				err = &api.DeleteBlockedError{ServiceID: plan.ServiceID, Reasons: plan.Blockers}
+			}
+			return err
+		},
+	}
+	del.Flags().BoolVar(&deleteOpts.Force, "force", false, "deactivate the service first if it is active")
+	del.Flags().BoolVar(&deleteOpts.DetachDomains, "detach-domains", false, "delete the domains of the service first")
+	del.Flags().BoolVar(&dryRun, "dry-run", false, "print the steps without changing anything")
+
+	cmd.AddCommand(list, get, del)
+	return cmd
+}
+
+func serviceTable(services ...api.Service) table {
+	t := table{header: []string{"ID", "NAME", "UNIQUE NAME", "STATUS", "AUTO SSL"}}
+	for _, s := range services {
+		t.rows = append(t.rows, []string{s.ID, s.Name, s.UniqueName, s.Status, strconv.FormatBool(s.AutoSSL)})
+	}
+	return t
+}
+
+func deletePlanTable(plan *api.DeletePlan) table {
+	t := table{header: []string{"STEP", "TARGET", "DONE"}}
+	add := func(step, target string, done bool) {
+		t.rows = append(t.rows, []string{step, target, strconv.FormatBool(done)})
+	}
+	if plan.Deactivate {
+		add("deactivate", plan.ServiceID, plan.Deactivated)
+	}
+	detached := make(map[string]bool, len(plan.DetachedDomains))
+	for _, id := range plan.DetachedDomains {
+		detached[id] = true
+	}
+	for _, d := range plan.Domains {
+		add("delete domain", d.Name, detached[d.ID])
+	}
+	for _, id := range plan.Certificates {
+		add("release certificate", id, len(plan.DetachedDomains) == len(plan.Domains))
+	}
+	add("delete service", plan.ServiceID, plan.Deleted)
+	for _, reason := range plan.Blockers {
+		t.rows = append(t.rows, []string{"blocked", reason, "-"})
+	}
+	return t
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// GenerateConfig controls the generated file.
+type GenerateConfig struct {
+	Package  string
+	TypeName string
+
+	// ConstantsOnly emits only the option name and enum constants, without
+	// the struct, so the file has no imports
+	ConstantsOnly bool
+}
+
+// option is a single option prepared for rendering.
+type option struct {
+	Name     string // API field name
+	GoName   string // exported Go identifier
+	GoType   string
+	Title    string
+	ReadOnly bool
+	Enum     []api.EnumValue
+	EnumType string
+}
+
+// Generate renders gofmt-ed Go source for the options in metadata.
+func Generate(metadata *api.ServiceOptionsMetadata, cfg GenerateConfig) ([]byte, error) {
+	if cfg.Package == "" {
+		cfg.Package = "options"
+	}
+	if cfg.TypeName == "" {
+		cfg.TypeName = "Options"
+	}
+
+	options := collectOptions(metadata, cfg.TypeName)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by optiongen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", cfg.Package)
+	if !cfg.ConstantsOnly {
+		b.WriteString("import (\n\t\"encoding/json\"\n\n\tapi \"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5\"\n)\n\n")
+	}
+
+	b.WriteString("// Option names.\nconst (\n")
+	for _, o := range options {
+		fmt.Fprintf(&b, "\tOption%s = %q\n", o.GoName, o.Name)
+	}
+	b.WriteString(")\n\n")
+
+	for _, o := range options {
+		if o.EnumType == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "// %s is a value of the %s option.\n", o.EnumType, o.Name)
+		fmt.Fprintf(&b, "type %s string\n\n", o.EnumType)
+		fmt.Fprintf(&b, "// %s values.\nconst (\n", o.EnumType)
+		for _, ev := range o.Enum {
+			fmt.Fprintf(&b, "\t%s%s %s = %q\n", o.EnumType, identifier(ev.Value), o.EnumType, ev.Value)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	if cfg.ConstantsOnly {
+		return formatSource(b.Bytes())
+	}
+
+	fmt.Fprintf(&b, "// %s holds typed values for service options. Nil fields are omitted.\n", cfg.TypeName)
+	fmt.Fprintf(&b, "type %s struct {\n", cfg.TypeName)
+	for _, o := range options {
+		comment := o.Title
+		if o.ReadOnly {
+			comment += " (read-only)"
+		}
+		if comment = strings.Join(strings.Fields(comment), " "); comment != "" {
+			fmt.Fprintf(&b, "\t// %s\n", comment)
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:\"%s,omitempty\"`\n", o.GoName, o.GoType, o.Name)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "// ServiceOptions converts o to the map accepted by ServiceOptions.UpdateOptions and Apply.\n")
+	fmt.Fprintf(&b, "func (o %s) ServiceOptions() (api.ServiceOptions, error) {\n", cfg.TypeName)
+	b.WriteString("\tdata, err := json.Marshal(o)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	b.WriteString("\tvar options api.ServiceOptions\n\tif err := json.Unmarshal(data, &options); err != nil {\n\t\treturn nil, err\n\t}\n\treturn options, nil\n}\n")
+
+	return formatSource(b.Bytes())
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// collectOptions converts metadata into sorted, de-duplicated options.
+func collectOptions(metadata *api.ServiceOptionsMetadata, typeName string) []option {
+	seen := make(map[string]bool)
+	var options []option
+
+	for _, m := range metadata.Data {
+		o := option{Title: m.Title, ReadOnly: m.ReadOnly}
+		if o.Title == "" {
+			o.Title = m.Description
+		}
+
+		o.Name = m.FieldName()
+		if m.Type == "dynamic" && m.Property != nil {
+			o.GoName = identifier(o.Name)
+			o.GoType = goType(m.Property)
+			if m.Property.Type == "enum" && len(m.Property.EnumValues) > 0 {
+				o.EnumType = typeName + o.GoName
+				o.Enum = m.Property.EnumValues
+				o.GoType = "*" + o.EnumType
+			}
+		} else {
+			// Standard options carry structured values defined by the API
+			o.GoName = identifier(o.Name)
+			o.GoType = "interface{}"
+		}
+
+		if o.Name == "" || seen[o.Name] {
+			continue
+		}
+		seen[o.Name] = true
+		options = append(options, o)
+	}
+
+	sort.Slice(options, func(i, j int) bool { return options[i].Name < options[j].Name })
+	return options
+}
+
+func goType(prop *api.OptionProperty) string {
+	switch prop.Type {
+	case "boolean":
+		return "*bool"
+	case "integer":
+		return "*int"
+	case "string", "enum":
+		return "*string"
+	case "bitfield":
+		return "map[string]bool"
+	case "strings":
+		return "[]string"
+	}
+	return "interface{}"
+}
+
+// identifier turns an option name or enum value into an exported Go identifier.
+func identifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	id := b.String()
+	if id == "" || unicode.IsDigit(rune(id[0])) {
+		id = "V" + id
+	}
+	return id
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+const testMetadata = `{"meta":{"count":5},"data":[
+	{"name":"CORS Override","type":"standard","title":"CORS"},
+	{"name":"ttl","type":"dynamic","title":"Cache TTL","property":{"name":"ttl","type":"integer"}},
+	{"name":"compression","type":"dynamic","title":"Compression\nmode","property":{"name":"compression","type":"enum","enumValues":[{"value":"GZIP"},{"value":"brotli-11"}]}},
+	{"name":"allowedMethods","type":"dynamic","property":{"name":"allowedMethods","type":"bitfield"}},
+	{"name":"sharedShield","type":"dynamic","readOnly":true,"property":{"name":"sharedShield","type":"boolean"}}
+]}`
+
+func TestGenerate(t *testing.T) {
+	var metadata api.ServiceOptionsMetadata
+	if err := json.Unmarshal([]byte(testMetadata), &metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := Generate(&metadata, GenerateConfig{Package: "options", TypeName: "Options"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "options_gen.go", src, 0); err != nil {
+		t.Fatalf("Expected valid Go source, got %v\n%s", err, src)
+	}
+
+	// gofmt aligns declarations, so compare with whitespace collapsed
+	code := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		"// Code generated by optiongen. DO NOT EDIT.",
+		`OptionCors = "cors"`,
+		`OptionTtl = "ttl"`,
+		"type OptionsCompression string",
+		`OptionsCompressionBrotli11 OptionsCompression = "brotli-11"`,
+		"Compression *OptionsCompression `json:\"compression,omitempty\"`",
+		"AllowedMethods map[string]bool `json:\"allowedMethods,omitempty\"`",
+		"// Compression mode",
+		"// (read-only)",
+		"func (o Options) ServiceOptions() (api.ServiceOptions, error)",
+	} {
+		if !strings.Contains(code, strings.Join(strings.Fields(want), " ")) {
+			t.Errorf("Expected generated code to contain %q\n%s", want, code)
+		}
+	}
+}
+
+func TestGenerate_ConstantsOnly(t *testing.T) {
+	var metadata api.ServiceOptionsMetadata
+	if err := json.Unmarshal([]byte(testMetadata), &metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := Generate(&metadata, GenerateConfig{Package: "apispec", TypeName: "Options", ConstantsOnly: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	code := strings.Join(strings.Fields(string(src)), " ")
+	if !strings.Contains(code, `OptionCors = "cors"`) || !strings.Contains(code, "type OptionsCompression string") {
+		t.Errorf("Expected option and enum constants\n%s", code)
+	}
+	if strings.Contains(code, "import") || strings.Contains(code, "type Options struct") {
+		t.Errorf("Expected no imports or struct\n%s", code)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"reverseProxy": "ReverseProxy",
+		"brotli-11":    "Brotli11",
+		"max_age":      "MaxAge",
+		"1080p":        "V1080p",
+	}
+	for in, want := range tests {
+		if got := identifier(in); got != want {
+			t.Errorf("identifier(%q): expected %s, got %s", in, want, got)
+		}
+	}
+}
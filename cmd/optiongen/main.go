@@ -0,0 +1,93 @@
+// Command optiongen generates typed Go structs and constants for CacheFly
+// service options from the options metadata endpoint.
+//
+// The generated file contains a constant per option name, a string type and
+// constants for every enum option, and a struct with one typed field per
+// option that converts to api.ServiceOptions for use with UpdateOptions or
+// Apply. Regenerating after CacheFly adds or changes options turns catalog
+// drift into compile errors.
+//
+// Usage:
+//
+//	export CACHEFLY_API_TOKEN="your-token"
+//	optiongen -service srv_123456789 -package options -out options_gen.go
+//
+// With -constants only the option name and enum constants are generated,
+// as in the apispec package. Metadata can also be read from a file saved
+// earlier, which keeps go:generate runs offline:
+//
+//	//go:generate go run github.com/cachefly/cachefly-go-sdk/cmd/optiongen -input metadata.json -package options -out options_gen.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func main() {
+	var (
+		serviceID = flag.String("service", "", "service ID whose options metadata is fetched")
+		input     = flag.String("input", "", "read options metadata JSON from this file instead of the API")
+		pkg       = flag.String("package", "options", "package name of the generated file")
+		typeName  = flag.String("type", "Options", "name of the generated struct")
+		out       = flag.String("out", "", "output file (default stdout)")
+		constants = flag.Bool("constants", false, "only generate option name and enum constants")
+		baseURL   = flag.String("base-url", "", "API base URL (default "+cachefly.DefaultAPIHost+"/api/"+cachefly.DefaultAPIVersion+")")
+	)
+	flag.Parse()
+
+	metadata, err := loadMetadata(*input, *serviceID, *baseURL)
+	if err != nil {
+		log.Fatalf("optiongen: %v", err)
+	}
+
+	src, err := Generate(metadata, GenerateConfig{Package: *pkg, TypeName: *typeName, ConstantsOnly: *constants})
+	if err != nil {
+		log.Fatalf("optiongen: %v", err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("optiongen: %v", err)
+	}
+}
+
+func loadMetadata(input, serviceID, baseURL string) (*api.ServiceOptionsMetadata, error) {
+	if input != "" {
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return nil, err
+		}
+		var metadata api.ServiceOptionsMetadata
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", input, err)
+		}
+		return &metadata, nil
+	}
+
+	if serviceID == "" {
+		return nil, fmt.Errorf("either -service or -input is required")
+	}
+	token := strings.TrimSpace(os.Getenv("CACHEFLY_API_TOKEN"))
+	if token == "" {
+		return nil, fmt.Errorf("CACHEFLY_API_TOKEN environment variable is required")
+	}
+
+	opts := []cachefly.Option{cachefly.WithToken(token)}
+	if baseURL != "" {
+		opts = append(opts, cachefly.WithBaseURL(baseURL))
+	}
+	client := cachefly.NewClient(opts...)
+	return client.ServiceOptions.GetOptionsMetadata(context.Background(), serviceID)
+}
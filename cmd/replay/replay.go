@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/har"
+)
+
+// Config controls a replay.
+type Config struct {
+	// BaseURL replaces the recorded API base URL
+	BaseURL string
+
+	// Token replaces the recorded bearer token when set
+	Token string
+
+	// Methods limits the replay to these HTTP methods; empty replays all
+	Methods []string
+
+	// Match limits the replay to requests whose path contains it
+	Match string
+
+	// HTTPClient sends the requests; nil uses http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// Result is the outcome of replaying a single entry.
+type Result struct {
+	Index          int
+	Method         string
+	URL            string
+	RecordedStatus int
+	Status         int
+	Err            error
+}
+
+// Match reports whether the replayed response has the recorded status.
+func (r Result) Match() bool {
+	return r.Err == nil && r.Status == r.RecordedStatus
+}
+
+func (r Result) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("#%d %s %s: %v", r.Index, r.Method, r.URL, r.Err)
+	}
+	mark := "ok"
+	if !r.Match() {
+		mark = "MISMATCH"
+	}
+	return fmt.Sprintf("#%d %s %s: %d (recorded %d) %s", r.Index, r.Method, r.URL, r.Status, r.RecordedStatus, mark)
+}
+
+// skippedHeaders are recorded headers that are not replayed.
+var skippedHeaders = map[string]bool{
+	"host":           true,
+	"content-length": true,
+	"cookie":         true,
+}
+
+// apiPrefix matches the API version path of recorded URLs.
+var apiPrefix = regexp.MustCompile(`^/api/[0-9][0-9.]*`)
+
+// Replay sends the recorded requests one after another, in recorded order.
+// A failed request is recorded in its Result and does not stop the replay;
+// an error is returned only for an invalid configuration or when ctx ends.
+func Replay(ctx context.Context, entries []har.Entry, cfg Config) ([]Result, error) {
+	base, err := url.Parse(strings.TrimRight(cfg.BaseURL, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", cfg.BaseURL)
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var results []Result
+	for i, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		target, err := rebase(base, entry.Request.URL)
+		if err != nil {
+			results = append(results, Result{Index: i, Method: entry.Request.Method, URL: entry.Request.URL, Err: err})
+			continue
+		}
+		if !cfg.selected(entry.Request.Method, target) {
+			continue
+		}
+
+		result := Result{
+			Index:          i,
+			Method:         entry.Request.Method,
+			URL:            target.String(),
+			RecordedStatus: entry.Response.Status,
+		}
+		result.Status, result.Err = send(ctx, client, entry.Request, target, cfg.Token)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (cfg Config) selected(method string, target *url.URL) bool {
+	if len(cfg.Methods) > 0 {
+		found := false
+		for _, m := range cfg.Methods {
+			if strings.EqualFold(strings.TrimSpace(m), method) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return cfg.Match == "" || strings.Contains(target.Path, cfg.Match)
+}
+
+// rebase moves a recorded URL onto base, dropping the recorded API prefix.
+func rebase(base *url.URL, recorded string) (*url.URL, error) {
+	u, err := url.Parse(recorded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorded URL: %w", err)
+	}
+	rebased := *base
+	rebased.Path = base.Path + apiPrefix.ReplaceAllString(u.Path, "")
+	rebased.RawQuery = u.RawQuery
+	return &rebased, nil
+}
+
+func send(ctx context.Context, client *http.Client, recorded har.Request, target *url.URL, token string) (int, error) {
+	var body io.Reader
+	if recorded.PostData != nil {
+		body = strings.NewReader(recorded.PostData.Text)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, recorded.Method, target.String(), body)
+	if err != nil {
+		return 0, err
+	}
+	for _, h := range recorded.Headers {
+		if skippedHeaders[strings.ToLower(h.Name)] {
+			continue
+		}
+		req.Header.Add(h.Name, h.Value)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if recorded.PostData != nil && req.Header.Get("Content-Type") == "" && recorded.PostData.MimeType != "" {
+		req.Header.Set("Content-Type", recorded.PostData.MimeType)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
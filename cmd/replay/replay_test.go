@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/har"
+)
+
+const session = `{
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "cachefly-go-sdk", "version": "1.0"},
+    "entries": [
+      {
+        "request": {
+          "method": "GET",
+          "url": "https://api.cachefly.com/api/2.5/services/svc-1?responseType=full",
+          "headers": [{"name": "Authorization", "value": "[REDACTED]"}, {"name": "Accept", "value": "application/json"}]
+        },
+        "response": {"status": 200}
+      },
+      {
+        "request": {
+          "method": "PUT",
+          "url": "https://api.cachefly.com/api/2.5/services/svc-1",
+          "headers": [{"name": "Content-Type", "value": "application/json"}],
+          "postData": {"mimeType": "application/json", "text": "{\"description\":\"cdn\"}"}
+        },
+        "response": {"status": 200}
+      },
+      {
+        "request": {"method": "DELETE", "url": "https://api.cachefly.com/api/2.5/services/svc-2"},
+        "response": {"status": 204}
+      }
+    ]
+  }
+}`
+
+func TestReplay(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		seen = append(seen, r.Method+" "+r.URL.RequestURI()+" "+string(body))
+
+		if r.Header.Get("Authorization") != "Bearer replay-token" {
+			t.Errorf("Expected replaced token, got %s", r.Header.Get("Authorization"))
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	h, err := har.Read(strings.NewReader(session))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	results, err := Replay(context.Background(), h.Log.Entries, Config{BaseURL: server.URL + "/api/2.5", Token: "replay-token"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(seen) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(seen))
+	}
+	if seen[0] != "GET /api/2.5/services/svc-1?responseType=full " {
+		t.Errorf("Expected rebased GET, got %s", seen[0])
+	}
+	if seen[1] != `PUT /api/2.5/services/svc-1 {"description":"cdn"}` {
+		t.Errorf("Expected replayed body, got %s", seen[1])
+	}
+	if !results[0].Match() || !results[1].Match() {
+		t.Errorf("Expected first two requests to match, got %v", results[:2])
+	}
+	if results[2].Match() || results[2].Status != http.StatusNotFound {
+		t.Errorf("Expected DELETE mismatch, got %v", results[2])
+	}
+}
+
+func TestReplay_Filters(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected only GET requests, got %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	h, _ := har.Read(strings.NewReader(session))
+	results, err := Replay(context.Background(), h.Log.Entries, Config{BaseURL: server.URL, Methods: []string{"get"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 1 || len(results) != 1 {
+		t.Errorf("Expected 1 replayed request, got %d", requests)
+	}
+}
+
+func TestReplay_InvalidBaseURL(t *testing.T) {
+	if _, err := Replay(context.Background(), nil, Config{BaseURL: "localhost"}); err == nil {
+		t.Errorf("Expected error for base URL without scheme")
+	}
+}
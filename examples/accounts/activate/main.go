@@ -20,21 +20,9 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables from .env file if available
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️  Warning: unable to load .env file: %v", err)
-	}
-
-	// Get API token from environment
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Validate command line arguments
 	if len(os.Args) < 2 {
 		log.Println("⚠️  Usage: go run main.go <account_id>")
@@ -45,9 +33,10 @@ func main() {
 	accountID := os.Args[1]
 
 	// Initialize the CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	fmt.Printf("🔄 Activating account: %s\n", accountID)
 
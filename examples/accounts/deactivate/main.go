@@ -20,29 +20,19 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	if len(os.Args) < 2 {
 		log.Println("⚠️ Usage: go run main.go <account_id>")
 		return
 	}
 	serviceID := os.Args[1]
 
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	resp, err := client.Accounts.DeactivateAccountByID(context.Background(), serviceID)
 	if err != nil {
@@ -16,28 +16,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables from .env file in project root
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token from environment
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("⚠️ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Initialize CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Enable 2FA for the current account
 	updatedAccount, err := client.Accounts.Enable2FAForCurrentAccount(context.Background())
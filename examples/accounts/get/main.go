@@ -13,40 +13,19 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log"
-	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/quickstart"
 )
 
 func main() {
-
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
-
-	resp, err := client.Accounts.Get(context.Background(), "")
+	client, err := cachefly.NewClientFromEnv()
 	if err != nil {
-		log.Fatalf("❌ Failed to get account: %v", err)
+		log.Fatalf("❌ Failed to create client: %v", err)
 	}
 
-	listJSON, err := json.MarshalIndent(resp, "", "  ")
-	if err != nil {
-		log.Fatalf("Error formatting MarshalIndent [account]: %v", err)
+	if err := quickstart.PrintAccount(context.Background(), client); err != nil {
+		log.Fatalf("❌ %v", err)
 	}
-
-	fmt.Println("\n ✅ Current Account:")
-	fmt.Println(string(listJSON))
 }
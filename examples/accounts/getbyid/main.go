@@ -25,20 +25,9 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	if len(os.Args) < 2 {
 		log.Println("⚠️ Usage: go run main.go <account_id> [responseType]")
 		return
@@ -49,9 +38,10 @@ func main() {
 		responseType = os.Args[2]
 	}
 
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	account, err := client.Accounts.GetByID(context.Background(), accountID, responseType)
 	if err != nil {
@@ -23,21 +23,9 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables from .env file in project root
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token from environment
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("⚠️ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	if len(os.Args) < 2 {
 		log.Println("⚠️ Usage: go run main.go <CACHEFLY_CHILD_ID> [responseType]")
 		return
@@ -48,9 +36,10 @@ func main() {
 	}
 
 	// Initialize CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Call Get Child Account Auth Token endpoint
 	authResp, err := client.Accounts.GetChildAccountAuthToken(context.Background(), childID)
@@ -18,29 +18,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
 	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables from .env file in project root
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token from environment
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("⚠️ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Initialize CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Prepare list options
 	opts := api.ListAccountsOptions{
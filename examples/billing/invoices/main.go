@@ -0,0 +1,64 @@
+// Example demonstrates listing and downloading CacheFly invoices.
+//
+// This example shows:
+// - Client initialization with API token
+// - Listing the account's invoices
+// - Saving the PDF of an invoice to a file
+//
+// Usage:
+//
+//	export CACHEFLY_API_TOKEN="your-token"
+//	go run main.go [invoice_id]
+//
+// Example:
+//
+//	go run main.go inv_123456789
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func main() {
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
+
+	if len(os.Args) < 2 {
+		resp, err := client.Billing.ListInvoices(context.Background(), api.ListInvoicesOptions{Limit: 12})
+		if err != nil {
+			log.Fatalf("❌ Failed to list invoices: %v", err)
+		}
+		for _, invoice := range resp.Invoices {
+			fmt.Printf("%s  %-10s %-8s %.2f %s\n", invoice.ID, invoice.Number, invoice.Status, float64(invoice.Total)/100, invoice.Currency)
+		}
+		return
+	}
+
+	invoiceID := os.Args[1]
+	pdf, err := client.Billing.DownloadInvoice(context.Background(), invoiceID)
+	if err != nil {
+		log.Fatalf("❌ Failed to download invoice: %v", err)
+	}
+	defer pdf.Close()
+
+	file, err := os.Create(invoiceID + ".pdf")
+	if err != nil {
+		log.Fatalf("❌ Failed to create file: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, pdf); err != nil {
+		log.Fatalf("❌ Failed to save invoice: %v", err)
+	}
+	log.Printf("✅ Saved invoice to %s.pdf", invoiceID)
+}
@@ -0,0 +1,45 @@
+// Example demonstrates reading the usage of a CacheFly account.
+//
+// This example shows:
+// - Client initialization with API token
+// - Fetching bandwidth, request and storage usage for the current period
+// - Comparing usage with the plan limits and printing any overage
+//
+// Usage:
+//
+//	export CACHEFLY_API_TOKEN="your-token"
+//	go run main.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func main() {
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
+
+	usage, err := client.Billing.Usage(context.Background(), api.UsageOptions{})
+	if err != nil {
+		log.Fatalf("❌ Failed to get usage: %v", err)
+	}
+
+	fmt.Printf("Period %s - %s, plan %s\n", usage.PeriodStart, usage.PeriodEnd, usage.Plan.Name)
+	fmt.Printf("Bandwidth: %d of %d bytes\n", usage.BandwidthBytes, usage.Plan.BandwidthBytes)
+	fmt.Printf("Requests:  %d of %d\n", usage.Requests, usage.Plan.Requests)
+	fmt.Printf("Storage:   %d of %d bytes\n", usage.StorageBytes, usage.Plan.StorageBytes)
+
+	if usage.Overage.Amount > 0 {
+		log.Printf("⚠️ Overage of %.2f %s this period", float64(usage.Overage.Amount)/100, usage.Overage.Currency)
+	} else {
+		log.Println("✅ No overage this period")
+	}
+}
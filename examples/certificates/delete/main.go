@@ -23,21 +23,9 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables from .env file
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Read Certificate ID argument
 	if len(os.Args) < 2 {
 		log.Fatalf("⚠️ Usage: go run main.go <certificate_id>")
@@ -45,9 +33,10 @@ func main() {
 	certID := os.Args[1]
 
 	// Initialize CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Delete certificate by ID (DELETE /certificates/{id})
 	if err := client.Certificates.Delete(context.Background(), certID); err != nil {
@@ -7,21 +7,10 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
 	// Load environment variables (optional)
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Read service ID argument
 	if len(os.Args) < 2 {
 		log.Fatalf("⚠️ Usage: go run main.go <service_id>")
@@ -29,7 +18,10 @@ func main() {
 	serviceID := os.Args[1]
 
 	// Initialize CacheFly client
-	client := cachefly.NewClient(cachefly.WithToken(token))
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Activate image optimization configuration for the service
 	if err := client.ServiceImageOptimization.ActivateConfiguration(context.Background(), serviceID); err != nil {
@@ -9,21 +9,9 @@ import (
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
 	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables from .env file
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Read service ID argument
 	if len(os.Args) < 2 {
 		log.Fatalf("⚠️ Usage: go run main.go <service_id>")
@@ -31,7 +19,10 @@ func main() {
 	serviceID := os.Args[1]
 
 	// Initialize CacheFly client
-	client := cachefly.NewClient(cachefly.WithToken(token))
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Prepare payload for creating image optimization configuration
 	opts := api.CreateImageOptimizationOptions{
@@ -24,21 +24,9 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load .env variables (optional)
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	// Ensure API token is set
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Read service ID and rule ID arguments
 	if len(os.Args) < 3 {
 		log.Fatalf("⚠️ Usage: go run main.go <service_id> <rule_id>")
@@ -47,7 +35,10 @@ func main() {
 	ruleID := os.Args[2]
 
 	// Initialize CacheFly client
-	client := cachefly.NewClient(cachefly.WithToken(token))
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Fetch the specific referer rule by ID using GetByID
 	rule, err := client.ServiceOptionsRefererRules.GetByID(context.Background(), serviceID, ruleID)
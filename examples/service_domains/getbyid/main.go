@@ -24,21 +24,10 @@ import (
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
 )
 
 func main() {
 	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	// Read token
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Read service ID and domain ID arguments
 	if len(os.Args) < 3 {
 		log.Println("⚠️ Usage: go run main.go <service_id> <domain_id>")
@@ -48,9 +37,10 @@ func main() {
 	domainID := os.Args[2]
 
 	// Initialize CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
 
 	// Call GetByID endpoint for service domains
 	domain, err := client.ServiceDomains.GetByID(context.Background(), serviceID, domainID, "")
@@ -8,27 +8,14 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log"
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/quickstart"
 )
 
 func main() {
-	// Load environment variables from .env file
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️  Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Read Service ID argument
 	if len(os.Args) < 2 {
 		log.Println("⚠️  Usage: go run main.go <service_id>")
@@ -37,22 +24,13 @@ func main() {
 	serviceID := os.Args[1]
 
 	// Initialize CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
-
-	// Get basic service options
-	options, err := client.ServiceOptions.GetOptions(context.Background(), serviceID)
+	client, err := cachefly.NewClientFromEnv()
 	if err != nil {
-		log.Fatalf("❌ Failed to get basic service options for %s: %v", serviceID, err)
+		log.Fatalf("❌ Failed to create client: %v", err)
 	}
 
-	// Pretty-print the options
-	out, err := json.MarshalIndent(options, "", "  ")
-	if err != nil {
-		log.Fatalf("❌ Error formatting options JSON: %v", err)
+	// Get and print the service options
+	if err := quickstart.DumpServiceOptions(context.Background(), client, serviceID); err != nil {
+		log.Fatalf("❌ %v", err)
 	}
-
-	fmt.Println("✅ Basic service options retrieved successfully:")
-	fmt.Println(string(out))
 }
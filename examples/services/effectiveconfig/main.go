@@ -0,0 +1,52 @@
+// Example demonstrates resolving the effective configuration of a CacheFly service.
+//
+// This example shows:
+// - Client initialization with API token
+// - Resolving settings from the service, account defaults and option defaults
+// - Printing each setting with where its value comes from
+//
+// Usage:
+//
+//	export CACHEFLY_API_TOKEN="your-token"
+//	go run main.go <service_id>
+//
+// Example:
+//
+//	go run main.go srv_123456789
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Println("⚠️ Usage: go run main.go <service_id>")
+		return
+	}
+	serviceID := os.Args[1]
+
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
+
+	config, err := client.Services.EffectiveConfig(context.Background(), serviceID)
+	if err != nil {
+		log.Fatalf("❌ Failed to resolve effective config: %v", err)
+	}
+
+	fmt.Printf("Service %s (%s)\n\n", config.ServiceID, config.Status)
+	for _, name := range config.Names() {
+		setting := config.Settings[name]
+		fmt.Printf("%-30s %-8s %v\n", name, setting.Source, setting.Value)
+	}
+
+	fmt.Printf("\n%d script configs, %d rules\n", len(config.ScriptConfigs), len(config.Rules))
+}
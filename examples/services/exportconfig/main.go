@@ -0,0 +1,52 @@
+// Example demonstrates exporting a CacheFly service configuration.
+//
+// This example shows:
+// - Client initialization with API token
+// - Exporting basic config, options, domains, origins and rules
+// - Printing the exported document as JSON
+//
+// Usage:
+//
+//	export CACHEFLY_API_TOKEN="your-token"
+//	go run main.go <service_id>
+//
+// Example:
+//
+//	go run main.go srv_123456789 > service.json
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Println("⚠️ Usage: go run main.go <service_id>")
+		return
+	}
+	serviceID := os.Args[1]
+
+	client, err := cachefly.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("❌ Failed to create client: %v", err)
+	}
+
+	config, err := client.Services.ExportConfig(context.Background(), serviceID)
+	if err != nil {
+		log.Fatalf("❌ Failed to export service config: %v", err)
+	}
+
+	out, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		log.Fatalf("❌ Error formatting service config JSON: %v", err)
+	}
+
+	fmt.Println(string(out))
+}
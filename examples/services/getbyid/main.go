@@ -18,46 +18,26 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log"
 	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/quickstart"
 )
 
 func main() {
-
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	if len(os.Args) < 2 {
 		log.Println("⚠️ Usage: go run main.go <service_id>")
 		return
 	}
 	serviceID := os.Args[1]
 
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
-
-	service, err := client.Services.GetByID(context.Background(), serviceID)
+	client, err := cachefly.NewClientFromEnv()
 	if err != nil {
-		log.Fatalf("❌ Failed to get service by ID: %v", err)
+		log.Fatalf("❌ Failed to create client: %v", err)
 	}
 
-	out, err := json.MarshalIndent(service, "", "  ")
-	if err != nil {
-		log.Fatalf("❌ Error formatting service JSON: %v", err)
+	if err := quickstart.PrintService(context.Background(), client, serviceID); err != nil {
+		log.Fatalf("❌ %v", err)
 	}
-
-	fmt.Println("\n✅ Service fetched successfully:")
-	fmt.Println(string(out))
 }
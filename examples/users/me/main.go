@@ -14,44 +14,21 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log"
-	"os"
 
 	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
-	"github.com/joho/godotenv"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/quickstart"
 )
 
 func main() {
-	// Load environment variables from .env file
-	if err := godotenv.Load(); err != nil {
-		log.Printf("⚠️ Warning: unable to load .env file: %v", err)
-	}
-
-	// Read API token
-	token := os.Getenv("CACHEFLY_API_TOKEN")
-	if token == "" {
-		log.Fatal("❌ CACHEFLY_API_TOKEN environment variable is required")
-	}
-
 	// Initialize CacheFly client
-	client := cachefly.NewClient(
-		cachefly.WithToken(token),
-	)
-
-	// Fetch the current authenticated user (GET /account/users/me)
-	user, err := client.Users.GetCurrentUser(context.Background())
+	client, err := cachefly.NewClientFromEnv()
 	if err != nil {
-		log.Fatalf("❌ Failed to fetch current user: %v", err)
+		log.Fatalf("❌ Failed to create client: %v", err)
 	}
 
-	// Pretty-print the user details
-	out, err := json.MarshalIndent(user, "", "  ")
-	if err != nil {
-		log.Fatalf("❌ Error formatting user JSON: %v", err)
+	// Fetch and print the current authenticated user (GET /users/me)
+	if err := quickstart.PrintCurrentUser(context.Background(), client); err != nil {
+		log.Fatalf("❌ %v", err)
 	}
-
-	fmt.Println("\n✅ Current user retrieved successfully:")
-	fmt.Println(string(out))
 }
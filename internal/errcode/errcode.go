@@ -0,0 +1,30 @@
+// Package errcode defines the error codes of SDK errors. They are exported
+// to users as the ErrorCode constants of pkg/cachefly/apispec.
+package errcode
+
+// Validation
+const (
+	Required          = "required"
+	InvalidID         = "invalid_id"
+	InvalidHostname   = "invalid_hostname"
+	InvalidUniqueName = "invalid_unique_name"
+	InvalidCIDR       = "invalid_cidr"
+	InvalidPEM        = "invalid_pem"
+	InvalidEnum       = "invalid_enum"
+	OutOfRange        = "out_of_range"
+)
+
+// Requests
+const (
+	APIError       = "api_error"
+	APIMaintenance = "api_maintenance"
+	RetryExhausted = "retry_exhausted"
+	LimitExceeded  = "limit_exceeded"
+	CircuitOpen    = "circuit_open"
+)
+
+// Environments
+const EnvironmentMismatch = "environment_mismatch"
+
+// Features
+const FeatureUnavailable = "feature_unavailable"
@@ -0,0 +1,252 @@
+// Package fakeapi is an in-memory fake of the CacheFly API for benchmarks,
+// soak tests and unit tests. It serves the endpoints of the high-volume
+// operations: listing services, reading and updating service options, and
+// purging. Other endpoints are answered from fixed routes, which is what
+// unit tests of read-only helpers use:
+//
+//	server := fakeapi.Serve(t, map[string]string{
+//		"/api/2.5/services":      `{"data":[{"_id":"svc-1"}]}`,
+//		"POST /api/2.5/services": `{"_id":"svc-2"}`,
+//	})
+//	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+//
+// Responses have the shape of the real API but no validation beyond what the
+// SDK needs to run; it is not a substitute for the API in functional tests.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Config sizes the fake account.
+type Config struct {
+	// Services is the number of services; zero uses 1000
+	Services int
+
+	// Options is the number of dynamic boolean options of every service,
+	// named option0, option1 and so on; zero uses 50
+	Options int
+
+	// Latency is added to every response
+	Latency time.Duration
+
+	// Routes are fixed response bodies, keyed by "METHOD /path" or, for
+	// any method, by "/path". They take precedence over the built-in
+	// endpoints; requests matching neither get a 404.
+	Routes map[string]string
+}
+
+// Server is a running fake API. Its URL is the base URL for the client.
+type Server struct {
+	*httptest.Server
+
+	cfg      Config
+	services []byte
+	metadata []byte
+
+	mu      sync.Mutex
+	options map[string]map[string]interface{}
+	log     []string
+
+	requests atomic.Int64
+	purged   atomic.Int64
+}
+
+// New starts a fake API server. Close it when done.
+func New(cfg Config) *Server {
+	if cfg.Services <= 0 {
+		cfg.Services = 1000
+	}
+	if cfg.Options <= 0 {
+		cfg.Options = 50
+	}
+
+	s := &Server{cfg: cfg, options: make(map[string]map[string]interface{})}
+	s.services = s.renderServices()
+	s.metadata = s.renderMetadata()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Serve starts a fake API server answering from routes, see Config.Routes,
+// and closes it when the test ends.
+func Serve(tb testing.TB, routes map[string]string) *Server {
+	if routes == nil {
+		routes = map[string]string{}
+	}
+	s := New(Config{Services: 1, Options: 1, Routes: routes})
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Log returns the "METHOD /path" of every request served, in order, when
+// the server has routes.
+func (s *Server) Log() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.log...)
+}
+
+// Requests returns the number of requests served.
+func (s *Server) Requests() int64 {
+	return s.requests.Load()
+}
+
+// Purged returns the number of paths purged.
+func (s *Server) Purged() int64 {
+	return s.purged.Load()
+}
+
+// ServiceID returns the ID of the i-th service.
+func ServiceID(i int) string {
+	return fmt.Sprintf("srv-%06d", i)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	if s.cfg.Latency > 0 {
+		time.Sleep(s.cfg.Latency)
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	// Only servers with routes keep a log, so soak tests do not grow it
+	if s.cfg.Routes != nil {
+		route := r.Method + " " + r.URL.Path
+		s.mu.Lock()
+		s.log = append(s.log, route)
+		s.mu.Unlock()
+		if body, ok := s.cfg.Routes[route]; ok {
+			w.Write([]byte(body))
+			return
+		}
+		if body, ok := s.cfg.Routes[r.URL.Path]; ok {
+			w.Write([]byte(body))
+			return
+		}
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1 && parts[0] == "services":
+		s.listServices(w, r)
+	case len(parts) == 3 && parts[0] == "services" && parts[2] == "options":
+		s.serviceOptions(w, r, parts[1])
+	case r.Method == http.MethodGet && len(parts) == 4 && parts[0] == "services" && parts[2] == "options" && parts[3] == "metadata":
+		w.Write(s.metadata)
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[0] == "services" && parts[2] == "purge":
+		s.purge(w, r)
+	default:
+		http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+	}
+}
+
+// listServices serves a page of the pre-rendered service list.
+func (s *Server) listServices(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if offset < 0 || offset > s.cfg.Services {
+		offset = s.cfg.Services
+	}
+	if limit <= 0 || offset+limit > s.cfg.Services {
+		limit = s.cfg.Services - offset
+	}
+
+	fmt.Fprintf(w, `{"meta":{"limit":%d,"offset":%d,"count":%d},"data":[`, limit, offset, s.cfg.Services)
+	for i := offset; i < offset+limit; i++ {
+		if i > offset {
+			w.Write([]byte{','})
+		}
+		w.Write(s.service(i))
+	}
+	w.Write([]byte("]}"))
+}
+
+func (s *Server) serviceOptions(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.options[id]
+	if !ok {
+		current = make(map[string]interface{}, s.cfg.Options)
+		for i := 0; i < s.cfg.Options; i++ {
+			current[fmt.Sprintf("option%d", i)] = false
+		}
+		s.options[id] = current
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var update map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, `{"message":"invalid body"}`, http.StatusBadRequest)
+			return
+		}
+		for name, value := range update {
+			current[name] = value
+		}
+	default:
+		http.Error(w, `{"message":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	json.NewEncoder(w).Encode(current)
+}
+
+func (s *Server) purge(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Paths []string `json:"paths"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"message":"invalid body"}`, http.StatusBadRequest)
+		return
+	}
+	s.purged.Add(int64(len(req.Paths)))
+	w.Write([]byte(`{}`))
+}
+
+// service returns the JSON of the i-th service, a slice of s.services.
+func (s *Server) service(i int) []byte {
+	size := len(s.services) / s.cfg.Services
+	return s.services[i*size : (i+1)*size]
+}
+
+// renderServices renders every service with the same length so service can
+// slice them without an index.
+func (s *Server) renderServices() []byte {
+	var b strings.Builder
+	for i := 0; i < s.cfg.Services; i++ {
+		id := ServiceID(i)
+		fmt.Fprintf(&b, `{"_id":%q,"name":"Service %s","uniqueName":"service-%s","status":"ACTIVE","autoSsl":true,"configurationMode":"API_RULES_AND_OPTIONS","createdAt":"2025-01-01T00:00:00.000Z","updateAt":"2025-01-01T00:00:00.000Z","tlsProfile":"tls-default","deliveryRegion":"global"}`, id, id[4:], id[4:])
+	}
+	return []byte(b.String())
+}
+
+func (s *Server) renderMetadata() []byte {
+	type property struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	type option struct {
+		Name     string   `json:"name"`
+		Title    string   `json:"title"`
+		Group    string   `json:"group"`
+		Type     string   `json:"type"`
+		Property property `json:"property"`
+	}
+	data := make([]option, s.cfg.Options)
+	for i := range data {
+		name := fmt.Sprintf("option%d", i)
+		data[i] = option{Name: name, Title: name, Group: "Bench", Type: "dynamic", Property: property{Name: name, Type: "boolean"}}
+	}
+	body, _ := json.Marshal(map[string]interface{}{"meta": map[string]int{"count": len(data)}, "data": data})
+	return body
+}
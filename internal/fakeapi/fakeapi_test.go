@@ -0,0 +1,97 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestServer_ListServicesPages(t *testing.T) {
+	server := New(Config{Services: 25})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/services?offset=20&limit=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var page struct {
+		Meta struct{ Count, Limit, Offset int }
+		Data []struct {
+			ID string `json:"_id"`
+		}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if page.Meta.Count != 25 || len(page.Data) != 5 {
+		t.Fatalf("Expected the last 5 of 25 services, got %+v", page)
+	}
+	if page.Data[0].ID != ServiceID(20) || page.Data[4].ID != ServiceID(24) {
+		t.Errorf("Unexpected services %v", page.Data)
+	}
+}
+
+func TestServer_OptionsAndPurge(t *testing.T) {
+	server := New(Config{Services: 1, Options: 3})
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/services/srv-000000/options", strings.NewReader(`{"option1":true}`))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var options map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&options)
+	resp.Body.Close()
+	if len(options) != 3 || options["option1"] != true || options["option0"] != false {
+		t.Errorf("Unexpected options %v", options)
+	}
+
+	resp, err = http.Post(server.URL+"/services/srv-000000/purge", "application/json", strings.NewReader(`{"paths":["/a","/b"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if server.Purged() != 2 || server.Requests() != 2 {
+		t.Errorf("Expected 2 requests and 2 purged paths, got %d and %d", server.Requests(), server.Purged())
+	}
+}
+
+func TestServe_Routes(t *testing.T) {
+	server := Serve(t, map[string]string{
+		"/api/2.5/accounts/me":      `{"_id":"acc-1"}`,
+		"POST /api/2.5/accounts/me": `{"_id":"acc-2"}`,
+	})
+
+	get := func(method, path string) (int, string) {
+		req, _ := http.NewRequest(method, server.URL+path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var body struct {
+			ID string `json:"_id"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body.ID
+	}
+
+	if status, id := get(http.MethodGet, "/api/2.5/accounts/me"); status != http.StatusOK || id != "acc-1" {
+		t.Errorf("Expected the path route, got %d %q", status, id)
+	}
+	if status, id := get(http.MethodPost, "/api/2.5/accounts/me"); status != http.StatusOK || id != "acc-2" {
+		t.Errorf("Expected the method route, got %d %q", status, id)
+	}
+	if status, _ := get(http.MethodGet, "/api/2.5/users"); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown route, got %d", status)
+	}
+
+	expected := []string{"GET /api/2.5/accounts/me", "POST /api/2.5/accounts/me", "GET /api/2.5/users"}
+	if log := server.Log(); strings.Join(log, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, log)
+	}
+}
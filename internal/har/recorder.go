@@ -0,0 +1,290 @@
+package har
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces secrets in recorded requests and responses.
+const Redacted = "[REDACTED]"
+
+// Omitted replaces bodies that cannot be redacted: bodies that are not JSON,
+// including JSON bodies cut off at the capture limit.
+const Omitted = "[OMITTED: truncated or non-JSON body, not redacted]"
+
+// maxBodyCapture is the largest request or response body recorded in full.
+// Larger bodies are omitted from the recording but passed through unchanged.
+const maxBodyCapture = 1 << 20
+
+// redactedHeaders are headers whose values are never recorded.
+var redactedHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+}
+
+// Recorder is an http.RoundTripper that records every request it sends and
+// rewrites the HAR file at path after each response body is closed, so the
+// file is complete at any point of the session. Credentials and secret
+// fields are redacted before they are recorded. Failures to write the file
+// never fail the request.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu  sync.Mutex
+	har HAR
+}
+
+// NewRecorder returns a Recorder writing to path that sends requests with
+// next, or http.DefaultTransport when next is nil.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{
+		path: path,
+		next: next,
+		har: HAR{Log: Log{
+			Version: Version,
+			Creator: Creator{Name: "cachefly-go-sdk", Version: Version},
+			Entries: []Entry{},
+		}},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	entry := Entry{
+		StartedDateTime: start.UTC().Format(time.RFC3339Nano),
+		Request:         recordRequest(req),
+		Timings:         Timings{Send: -1, Receive: -1},
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxBodyCapture))
+			body.Close()
+			entry.Request.BodySize = len(data)
+			entry.Request.PostData = &PostData{
+				MimeType: req.Header.Get("Content-Type"),
+				Text:     RedactBody(data),
+			}
+		}
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		entry.Time = milliseconds(time.Since(start))
+		entry.Timings.Wait = entry.Time
+		entry.Comment = err.Error()
+		r.add(entry)
+		return nil, err
+	}
+
+	entry.Response = Response{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Cookies:     []Cookie{},
+		Headers:     recordHeaders(resp.Header),
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		Content:     Content{MimeType: resp.Header.Get("Content-Type")},
+	}
+	entry.Time = milliseconds(time.Since(start))
+	entry.Timings.Wait = entry.Time
+
+	resp.Body = &recordingBody{ReadCloser: resp.Body, recorder: r, entry: entry}
+	return resp, nil
+}
+
+// recordingBody captures a response body as it is read and adds the entry
+// when it is closed.
+type recordingBody struct {
+	io.ReadCloser
+	recorder *Recorder
+	entry    Entry
+	buf      bytes.Buffer
+	size     int
+	once     sync.Once
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += n
+	if room := maxBodyCapture - b.buf.Len(); room > 0 {
+		if n < room {
+			room = n
+		}
+		b.buf.Write(p[:room])
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.entry.Response.BodySize = b.size
+		b.entry.Response.Content.Size = b.size
+		b.entry.Response.Content.Text = RedactBody(b.buf.Bytes())
+		b.recorder.add(b.entry)
+	})
+	return err
+}
+
+// HAR returns a copy of the session recorded so far.
+func (r *Recorder) HAR() HAR {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h := r.har
+	h.Log.Entries = append([]Entry(nil), r.har.Log.Entries...)
+	return h
+}
+
+func (r *Recorder) add(entry Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.har.Log.Entries = append(r.har.Log.Entries, entry)
+	if r.path != "" {
+		writeFile(r.path, &r.har)
+	}
+}
+
+// Write encodes h to w as indented JSON.
+func Write(w io.Writer, h *HAR) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(h)
+}
+
+// writeFile replaces the file at path with h, so readers never see a
+// partially written file.
+func writeFile(path string, h *HAR) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if err := Write(tmp, h); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func recordRequest(req *http.Request) Request {
+	recorded := Request{
+		Method:      req.Method,
+		URL:         redactURL(req.URL),
+		HTTPVersion: req.Proto,
+		Cookies:     []Cookie{},
+		Headers:     recordHeaders(req.Header),
+		QueryString: []NameValue{},
+		HeadersSize: -1,
+	}
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			if sensitiveKey(name) {
+				v = Redacted
+			}
+			recorded.QueryString = append(recorded.QueryString, NameValue{Name: name, Value: v})
+		}
+	}
+	return recorded
+}
+
+func recordHeaders(header http.Header) []NameValue {
+	headers := []NameValue{}
+	for name, values := range header {
+		for _, v := range values {
+			if redactedHeaders[strings.ToLower(name)] {
+				v = Redacted
+			}
+			headers = append(headers, NameValue{Name: name, Value: v})
+		}
+	}
+	return headers
+}
+
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	changed := false
+	for name := range query {
+		if sensitiveKey(name) {
+			query.Set(name, Redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return u.String()
+	}
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// sensitiveKey reports whether a JSON field or query parameter holds a
+// secret, such as a token, password, API key or certificate key.
+func sensitiveKey(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "token") ||
+		strings.Contains(name, "password") ||
+		strings.Contains(name, "secret") ||
+		strings.HasSuffix(name, "key")
+}
+
+// RedactBody replaces secret fields of a JSON body. Bodies that do not
+// parse as JSON, such as truncated ones, are replaced by Omitted since their
+// secrets cannot be found.
+func RedactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return Omitted
+	}
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return Omitted
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, isString := value.(string); isString && sensitiveKey(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
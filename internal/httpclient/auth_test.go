@@ -0,0 +1,60 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHMACAuth(t *testing.T) {
+	secret := []byte("shared-secret")
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		if hash := hex.EncodeToString(sum[:]); r.Header.Get(HeaderHMACContentSHA256) != hash {
+			t.Errorf("Expected content hash %s, got %s", hash, r.Header.Get(HeaderHMACContentSHA256))
+		}
+		got = r.Header.Get("Authorization")
+		want := fmt.Sprintf("%s KeyId=key-1, Signature=%s", HMACAlgorithm,
+			HMACSignature(secret, r.Method, r.URL.RequestURI(), r.Header.Get(HeaderHMACDate), r.Header.Get(HeaderHMACContentSHA256)))
+		if got != want {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	client := New(Config{
+		BaseURL:    server.URL + "/api/2.5",
+		AuthScheme: &HMACAuth{KeyID: "key-1", Secret: secret, Now: func() time.Time { return now }},
+	})
+
+	if err := client.Post(context.Background(), "/services/svc-1/purge?async=true", map[string]string{"path": "/a"}, nil); err != nil {
+		t.Fatalf("Expected the signed request to verify, got %v (%s)", err, got)
+	}
+	if err := client.Get(context.Background(), "/accounts/me", nil); err != nil {
+		t.Fatalf("Expected the signed request to verify, got %v (%s)", err, got)
+	}
+
+	unsigned := New(Config{BaseURL: server.URL, AuthScheme: &HMACAuth{KeyID: "key-1"}})
+	if err := unsigned.Get(context.Background(), "/accounts/me", nil); err == nil {
+		t.Error("Expected an error without a secret")
+	}
+}
+
+func TestHMACSignature(t *testing.T) {
+	a := HMACSignature([]byte("s"), "GET", "/api/2.5/accounts/me", "2024-05-01T10:00:00Z", "e3b0")
+	b := HMACSignature([]byte("s"), "GET", "/api/2.5/accounts/me", "2024-05-01T10:00:01Z", "e3b0")
+	if a == b || len(a) != 64 {
+		t.Errorf("Expected distinct hex signatures, got %s and %s", a, b)
+	}
+}
@@ -0,0 +1,178 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/errcode"
+)
+
+// ErrCircuitOpen is matched by errors.Is for requests rejected without being
+// sent because the circuit breaker is open.
+var ErrCircuitOpen = errors.New("cachefly: circuit breaker is open")
+
+// CircuitOpenError is returned instead of sending a request while the
+// circuit breaker is open.
+type CircuitOpenError struct {
+	// Until is when the breaker lets a trial request through again
+	Until time.Time
+
+	// Err is the failure that opened the breaker
+	Err error
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%v until %s after repeated failures: %v", ErrCircuitOpen, e.Until.Format(time.RFC3339), e.Err)
+}
+
+// MessageKey returns "circuit_open" for message catalogs.
+func (e *CircuitOpenError) MessageKey() string {
+	return errcode.CircuitOpen
+}
+
+// MessageParams returns the time the breaker half-opens and the failure
+// that opened it for message catalogs.
+func (e *CircuitOpenError) MessageParams() map[string]string {
+	params := map[string]string{"until": e.Until.Format(time.RFC3339), "error": ""}
+	if e.Err != nil {
+		params["error"] = e.Err.Error()
+	}
+	return params
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every request until the cooldown has passed
+	CircuitOpen
+	// CircuitHalfOpen lets a single trial request through
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker stops sending requests after repeated failures.
+//
+// The breaker opens after a number of consecutive attempts failed with a
+// network error, a client timeout or a 5xx response other than maintenance,
+// and rejects requests with a CircuitOpenError for a cooldown. Then it lets
+// one trial request through: if it succeeds the breaker closes, if it fails
+// the breaker opens for another cooldown. Any other response, 4xx included,
+// shows the API is up and resets the count. A CircuitBreaker is safe for
+// concurrent use and may be shared between clients.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	lastErr  error
+}
+
+// NewCircuitBreaker returns a CircuitBreaker that opens after threshold
+// consecutive failures and half-opens after cooldown. A threshold below one
+// opens it on the first failure.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow returns a CircuitOpenError when a request may not be sent. Every
+// allowed request must be followed by a call to record.
+func (b *CircuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cooldown {
+		b.state = CircuitHalfOpen
+	}
+	switch {
+	case b.state == CircuitOpen, b.state == CircuitHalfOpen && b.probing:
+		return &CircuitOpenError{Until: b.openedAt.Add(b.cooldown), Err: b.lastErr}
+	case b.state == CircuitHalfOpen:
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of an allowed request.
+// Requests ended by the caller's context count neither way.
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	probe := b.probing
+	b.probing = false
+
+	switch {
+	case ctx.Err() != nil:
+		return
+	case !breakerFailure(err):
+		b.state = CircuitClosed
+		b.failures = 0
+		b.lastErr = nil
+		return
+	}
+
+	b.failures++
+	b.lastErr = err
+	if probe || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// breakerFailure reports whether err indicates that the API is unavailable.
+// err must not be caused by the caller's context. Local errors are not.
+func breakerFailure(err error) bool {
+	if err == nil || errors.Is(err, ErrAPIMaintenance) || isLocal(err) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	// Network errors and client timeouts
+	return true
+}
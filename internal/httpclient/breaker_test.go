@@ -0,0 +1,119 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAndHalfOpens(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(2, 50*time.Millisecond)
+	client := New(Config{BaseURL: server.URL, CircuitBreaker: breaker})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		var apiErr *APIError
+		if err := client.Get(ctx, "/services", nil); !errors.As(err, &apiErr) {
+			t.Fatalf("Expected APIError, got %v", err)
+		}
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected open breaker, got %s", breaker.State())
+	}
+
+	err := client.Get(ctx, "/services", nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || openErr.Until.IsZero() || openErr.Err == nil {
+		t.Errorf("Expected CircuitOpenError with the opening failure, got %+v", openErr)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected no request while open, got %d requests", requests.Load())
+	}
+
+	// A failed trial request reopens the breaker
+	time.Sleep(60 * time.Millisecond)
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("Expected half-open breaker, got %s", breaker.State())
+	}
+	if err := client.Get(ctx, "/services", nil); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected trial request to be sent, got %v", err)
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("Expected breaker to reopen, got %s", breaker.State())
+	}
+
+	// A successful trial request closes it
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	if err := client.Get(ctx, "/services", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected closed breaker, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(1, time.Minute)
+	client := New(Config{BaseURL: server.URL, CircuitBreaker: breaker})
+	for i := 0; i < 3; i++ {
+		client.Get(context.Background(), "/services/missing", nil)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected 404s to keep the breaker closed, got %s", breaker.State())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Get(ctx, "/services", nil)
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected canceled requests not to count, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_StopsRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := New(Config{
+		BaseURL:        server.URL,
+		Retry:          &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		CircuitBreaker: NewCircuitBreaker(2, time.Minute),
+	})
+
+	err := client.Get(context.Background(), "/services", nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected retries to stop once the breaker opened, got %d requests", requests.Load())
+	}
+}
@@ -0,0 +1,214 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache caches successful GET responses by URL.
+//
+// Entries are served without a request while younger than the TTL. Older
+// entries that carried an ETag or Last-Modified header are revalidated with
+// If-None-Match/If-Modified-Since, and a 304 response renews them. Writes
+// through the client invalidate the written path, everything below it and
+// its parent collection. A ResponseCache is safe for concurrent use and may
+// be shared between clients.
+//
+// Entries are kept in a CacheStore, in memory unless another store is
+// given to NewResponseCacheWithStore. Since freshness is computed from the
+// time an entry was stored, workers sharing a store share the TTL too.
+type ResponseCache struct {
+	ttl      time.Duration
+	patterns []EndpointPolicy
+	store    CacheStore
+}
+
+// CacheEntry is a cached response.
+type CacheEntry struct {
+	// Path is the endpoint path without query, used for invalidation
+	Path         string    `json:"path"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+}
+
+// CacheStore persists the entries of a ResponseCache, keyed by URL.
+// Implementations must be safe for concurrent use. Errors are treated as
+// cache misses, so a failing store degrades to uncached requests. A store
+// may drop entries at any time, for example when they expire; keeping them
+// somewhat longer than the cache TTL lets stale entries be revalidated.
+type CacheStore interface {
+	// Get returns the entry for key, or nil when there is none
+	Get(ctx context.Context, key string) (*CacheEntry, error)
+
+	// Set stores entry under key, replacing any previous entry
+	Set(ctx context.Context, key string, entry *CacheEntry) error
+
+	// DeleteFunc removes the entries whose path satisfies match
+	DeleteFunc(ctx context.Context, match func(path string) bool) error
+
+	// Len returns the number of entries
+	Len(ctx context.Context) (int, error)
+}
+
+// NewResponseCache returns an in-memory cache with the given TTL. When
+// patterns are given, only GET endpoints matching one of them are cached;
+// patterns use the syntax of EndpointPolicy.Pattern.
+func NewResponseCache(ttl time.Duration, patterns ...string) *ResponseCache {
+	return NewResponseCacheWithStore(NewMemoryCacheStore(), ttl, patterns...)
+}
+
+// NewResponseCacheWithStore returns a cache with the given TTL that keeps
+// its entries in store. Patterns work as for NewResponseCache.
+func NewResponseCacheWithStore(store CacheStore, ttl time.Duration, patterns ...string) *ResponseCache {
+	c := &ResponseCache{ttl: ttl, store: store}
+	for _, p := range patterns {
+		c.patterns = append(c.patterns, EndpointPolicy{Pattern: p})
+	}
+	return c
+}
+
+// Invalidate drops every entry whose endpoint path equals prefix or lies
+// below it, e.g. "/services" drops the service list and every service.
+func (c *ResponseCache) Invalidate(prefix string) {
+	c.invalidate(context.Background(), prefix)
+}
+
+func (c *ResponseCache) invalidate(ctx context.Context, prefix string) {
+	prefix = path.Clean("/" + prefix)
+	below := strings.TrimSuffix(prefix, "/") + "/"
+	_ = c.store.DeleteFunc(ctx, func(p string) bool {
+		return p == prefix || strings.HasPrefix(p, below)
+	})
+}
+
+// Purge drops every entry.
+func (c *ResponseCache) Purge() {
+	_ = c.store.DeleteFunc(context.Background(), func(string) bool { return true })
+}
+
+// Len returns the number of cached entries, or 0 when the store fails.
+func (c *ResponseCache) Len() int {
+	n, _ := c.store.Len(context.Background())
+	return n
+}
+
+func (c *ResponseCache) cacheable(endpoint string) bool {
+	if len(c.patterns) == 0 {
+		return true
+	}
+	for i := range c.patterns {
+		if c.patterns[i].matches(http.MethodGet, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup returns the entry for key and whether it is still fresh.
+func (c *ResponseCache) lookup(ctx context.Context, key string) (*CacheEntry, bool) {
+	e, err := c.store.Get(ctx, key)
+	if err != nil || e == nil {
+		return nil, false
+	}
+	return e, time.Since(e.StoredAt) < c.ttl
+}
+
+func (c *ResponseCache) save(ctx context.Context, key, endpoint string, body []byte, header http.Header) {
+	_ = c.store.Set(ctx, key, &CacheEntry{
+		Path:         endpointPath(endpoint),
+		Body:         body,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		StoredAt:     time.Now(),
+	})
+}
+
+// renew marks a revalidated entry as fresh again.
+func (c *ResponseCache) renew(ctx context.Context, key string, e *CacheEntry) {
+	renewed := *e
+	renewed.StoredAt = time.Now()
+	_ = c.store.Set(ctx, key, &renewed)
+}
+
+// invalidateWrite drops entries affected by a write to endpoint.
+func (c *ResponseCache) invalidateWrite(ctx context.Context, endpoint string) {
+	p := endpointPath(endpoint)
+	c.invalidate(ctx, p)
+
+	parent := path.Dir(p)
+	_ = c.store.DeleteFunc(ctx, func(entryPath string) bool { return entryPath == parent })
+}
+
+// conditionalHeaders returns the revalidation headers for e, or nil.
+func (e *CacheEntry) conditionalHeaders() http.Header {
+	if e.ETag == "" && e.LastModified == "" {
+		return nil
+	}
+	h := http.Header{}
+	if e.ETag != "" {
+		h.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		h.Set("If-Modified-Since", e.LastModified)
+	}
+	return h
+}
+
+// endpointPath strips the query from endpoint and cleans it.
+func endpointPath(endpoint string) string {
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	return path.Clean("/" + endpoint)
+}
+
+// MemoryCacheStore is a CacheStore keeping entries in process memory.
+type MemoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]*CacheEntry
+}
+
+// NewMemoryCacheStore returns an empty MemoryCacheStore.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{entries: make(map[string]*CacheEntry)}
+}
+
+// Get implements CacheStore.
+func (s *MemoryCacheStore) Get(_ context.Context, key string) (*CacheEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[key], nil
+}
+
+// Set implements CacheStore.
+func (s *MemoryCacheStore) Set(_ context.Context, key string, entry *CacheEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = entry
+	return nil
+}
+
+// DeleteFunc implements CacheStore.
+func (s *MemoryCacheStore) DeleteFunc(_ context.Context, match func(path string) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, e := range s.entries {
+		if match(e.Path) {
+			delete(s.entries, key)
+		}
+	}
+	return nil
+}
+
+// Len implements CacheStore.
+func (s *MemoryCacheStore) Len(context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries), nil
+}
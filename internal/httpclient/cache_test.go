@@ -0,0 +1,223 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_CacheServesFreshEntries(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"name":"cached"}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Cache: NewResponseCache(time.Minute)})
+
+	for i := 0; i < 3; i++ {
+		var out struct{ Name string }
+		if err := client.Get(context.Background(), "/accounts/me", &out); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if out.Name != "cached" {
+			t.Errorf("Expected cached body, got %s", out.Name)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request, got %d", requests)
+	}
+}
+
+func TestClient_CacheBypassesLimitsAndBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"name":"cached"}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(1, time.Hour)
+	client := New(Config{
+		BaseURL:        server.URL,
+		Cache:          NewResponseCache(time.Minute, "/accounts/me"),
+		RateLimiter:    NewRateLimiter(1, 2),
+		CircuitBreaker: breaker,
+	})
+
+	if err := client.Get(context.Background(), "/accounts/me", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := client.Get(context.Background(), "/services", nil); err == nil {
+		t.Fatal("Expected the failure to open the breaker")
+	}
+
+	// The rate limiter has no tokens left and the breaker is open
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		var out struct{ Name string }
+		if err := client.Get(ctx, "/accounts/me", &out); err != nil || out.Name != "cached" {
+			t.Fatalf("Expected the cached body, got %q and %v", out.Name, err)
+		}
+	}
+	if breaker.State() != CircuitOpen {
+		t.Errorf("Expected cache hits not to close the breaker, got %s", breaker.State())
+	}
+}
+
+func TestClient_CacheRevalidatesWithETag(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name":"v1"}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Cache: NewResponseCache(0)})
+
+	for i := 0; i < 2; i++ {
+		var out struct{ Name string }
+		if err := client.Get(context.Background(), "/services/svc-1/options/metadata", &out); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if out.Name != "v1" {
+			t.Errorf("Expected v1, got %s", out.Name)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("Expected a conditional second request, got %d requests and %d not-modified", requests, notModified)
+	}
+}
+
+func TestClient_CacheInvalidation(t *testing.T) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cache := NewResponseCache(time.Minute, "/services", "/services/*")
+	client := New(Config{BaseURL: server.URL, Cache: cache})
+	ctx := context.Background()
+
+	client.Get(ctx, "/services?limit=10", nil)
+	client.Get(ctx, "/services/svc-1", nil)
+	client.Get(ctx, "/accounts/me", nil)
+	if cache.Len() != 2 {
+		t.Fatalf("Expected only matching endpoints to be cached, got %d entries", cache.Len())
+	}
+
+	// Updating a service drops it and the service list
+	client.Put(ctx, "/services/svc-1", struct{}{}, nil)
+	if cache.Len() != 0 {
+		t.Errorf("Expected write to invalidate the service and its list, got %d entries", cache.Len())
+	}
+
+	client.Get(ctx, "/services/svc-1", nil)
+	cache.Invalidate("/services")
+	if cache.Len() != 0 {
+		t.Errorf("Expected manual invalidation to drop entries, got %d", cache.Len())
+	}
+	if gets != 4 {
+		t.Errorf("Expected 4 GET requests, got %d", gets)
+	}
+}
+
+func TestClient_CacheDoesNotServeCanceledRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name":"cached"}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Cache: NewResponseCache(time.Minute)})
+	if err := client.Get(context.Background(), "/accounts/me", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.Get(ctx, "/accounts/me", nil); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_CacheSharedStore(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			requests++
+		}
+		w.Write([]byte(`{"name":"shared"}`))
+	}))
+	defer server.Close()
+
+	// Two workers with their own caches backed by one store
+	store := NewMemoryCacheStore()
+	first := New(Config{BaseURL: server.URL, Cache: NewResponseCacheWithStore(store, time.Minute)})
+	second := New(Config{BaseURL: server.URL, Cache: NewResponseCacheWithStore(store, time.Minute)})
+
+	var out struct{ Name string }
+	if err := first.Get(context.Background(), "/services/svc-1", &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := second.Get(context.Background(), "/services/svc-1", &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 1 || out.Name != "shared" {
+		t.Errorf("Expected the second worker to be served from the store, got %d requests", requests)
+	}
+
+	if err := second.Put(context.Background(), "/services/svc-1", struct{}{}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n, _ := store.Len(context.Background()); n != 0 {
+		t.Errorf("Expected the write to invalidate the shared entry, got %d entries", n)
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) (*CacheEntry, error) {
+	return nil, errors.New("unavailable")
+}
+func (failingStore) Set(context.Context, string, *CacheEntry) error { return errors.New("unavailable") }
+func (failingStore) DeleteFunc(context.Context, func(string) bool) error {
+	return errors.New("unavailable")
+}
+func (failingStore) Len(context.Context) (int, error) { return 0, errors.New("unavailable") }
+
+func TestClient_CacheFailingStore(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"name":"live"}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Cache: NewResponseCacheWithStore(failingStore{}, time.Minute)})
+	for i := 0; i < 2; i++ {
+		var out struct{ Name string }
+		if err := client.Get(context.Background(), "/accounts/me", &out); err != nil {
+			t.Fatalf("Expected store failures to be ignored, got %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected uncached requests, got %d", requests)
+	}
+}
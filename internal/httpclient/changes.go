@@ -0,0 +1,85 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// ChangeAction classifies a successful mutating request by its method.
+type ChangeAction string
+
+const (
+	ChangeCreate ChangeAction = "create" // POST
+	ChangeUpdate ChangeAction = "update" // PUT
+	ChangeDelete ChangeAction = "delete" // DELETE
+)
+
+// ChangeEvent describes a mutating request that succeeded.
+type ChangeEvent struct {
+	Action ChangeAction
+	Method string
+
+	// Endpoint is the request path relative to the base URL, including the query
+	Endpoint string
+
+	// Request is the JSON payload that was sent, if any
+	Request json.RawMessage
+
+	// Before is the cached GET response of Endpoint from before the change.
+	// It is only known when a response cache is configured and held the
+	// resource at the time of the request.
+	Before json.RawMessage
+
+	// After is the JSON response of the request, if the API returned one
+	After json.RawMessage
+}
+
+func changeAction(method string) ChangeAction {
+	switch method {
+	case http.MethodPut:
+		return ChangeUpdate
+	case http.MethodDelete:
+		return ChangeDelete
+	default:
+		return ChangeCreate
+	}
+}
+
+// before returns the cached representation of endpoint for a change event.
+func (c *Client) before(ctx context.Context, method, endpoint string) json.RawMessage {
+	if c.onChange == nil || c.cache == nil || method == http.MethodPost {
+		return nil
+	}
+	if entry, _ := c.cache.lookup(ctx, c.fullURL(endpoint)); entry != nil {
+		return entry.Body
+	}
+	return nil
+}
+
+// changed buffers the response of a successful mutating request, decodes it
+// into out and reports the change to the listener.
+func (c *Client) changed(method, endpoint string, payload, before json.RawMessage, body io.Reader, out interface{}) error {
+	after, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if len(after) > 0 {
+		if err := c.decodeBody(after, out); err != nil {
+			return err
+		}
+	} else {
+		after = nil
+	}
+
+	c.onChange(ChangeEvent{
+		Action:   changeAction(method),
+		Method:   method,
+		Endpoint: endpoint,
+		Request:  payload,
+		Before:   before,
+		After:    after,
+	})
+	return nil
+}
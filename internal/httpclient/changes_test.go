@@ -0,0 +1,96 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_OnChange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id":"svc-1","name":"old"}`))
+		case http.MethodPut:
+			w.Write([]byte(`{"id":"svc-1","name":"new"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	var events []ChangeEvent
+	client := New(Config{
+		BaseURL:  server.URL,
+		Cache:    NewResponseCache(time.Minute),
+		OnChange: func(ev ChangeEvent) { events = append(events, ev) },
+	})
+	ctx := context.Background()
+
+	var svc struct{ Name string }
+	if err := client.Get(ctx, "/services/svc-1", &svc); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Expected no event for GET, got %d", len(events))
+	}
+
+	if err := client.Put(ctx, "/services/svc-1", map[string]string{"name": "new"}, &svc); err != nil {
+		t.Fatal(err)
+	}
+	if svc.Name != "new" {
+		t.Errorf("Expected response decoded into out, got %s", svc.Name)
+	}
+	if err := client.Delete(ctx, "/services/svc-1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	update := events[0]
+	if update.Action != ChangeUpdate || update.Method != http.MethodPut || update.Endpoint != "/services/svc-1" {
+		t.Errorf("Unexpected update event %+v", update)
+	}
+	if string(update.Request) != `{"name":"new"}` {
+		t.Errorf("Expected request payload, got %s", update.Request)
+	}
+	if string(update.Before) != `{"id":"svc-1","name":"old"}` {
+		t.Errorf("Expected cached representation as before, got %s", update.Before)
+	}
+	if string(update.After) != `{"id":"svc-1","name":"new"}` {
+		t.Errorf("Expected response as after, got %s", update.After)
+	}
+
+	// The update invalidated the cache, so nothing is known before the delete
+	del := events[1]
+	if del.Action != ChangeDelete || del.Before != nil || del.After != nil {
+		t.Errorf("Unexpected delete event %+v", del)
+	}
+}
+
+func TestClient_OnChangeSkipsFailuresAndDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	var events int
+	client := New(Config{
+		BaseURL:   server.URL,
+		DryRunLog: func(DryRunRequest) {},
+		OnChange:  func(ChangeEvent) { events++ },
+	})
+
+	if err := client.Post(context.Background(), "/services", map[string]string{}, nil); err == nil {
+		t.Fatal("Expected error for 400 response")
+	}
+	if err := client.Post(WithDryRun(context.Background()), "/services", map[string]string{}, nil); err != nil {
+		t.Fatalf("Expected no error in dry-run mode, got %v", err)
+	}
+	if events != 0 {
+		t.Errorf("Expected no events, got %d", events)
+	}
+}
@@ -0,0 +1,42 @@
+package httpclient
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// debugTransport logs the method, URL, status, duration and request ID of
+// every request. Headers and bodies are never logged, so tokens stay out of logs.
+type debugTransport struct {
+	next http.RoundTripper
+	logf func(format string, args ...interface{})
+}
+
+// NewDebugTransport wraps next, or http.DefaultTransport when next is nil,
+// to log every request with logf; nil logf uses the log package.
+func NewDebugTransport(next http.RoundTripper, logf func(format string, args ...interface{})) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if logf == nil {
+		logf = log.Printf
+	}
+	return &debugTransport{next: next, logf: logf}
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	id := ""
+	if rid := req.Header.Get(HeaderRequestID); rid != "" {
+		id = " (request ID " + rid + ")"
+	}
+	if err != nil {
+		t.logf("cachefly: %s %s failed after %s%s: %v", req.Method, req.URL.Redacted(), elapsed, id, err)
+		return nil, err
+	}
+	t.logf("cachefly: %s %s -> %d in %s%s", req.Method, req.URL.Redacted(), resp.StatusCode, elapsed, id)
+	return resp, nil
+}
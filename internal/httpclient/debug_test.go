@@ -0,0 +1,30 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var lines []string
+	logf := func(format string, args ...interface{}) { lines = append(lines, fmt.Sprintf(format, args...)) }
+	c := New(Config{BaseURL: server.URL, AuthToken: "secret-token", Transport: NewDebugTransport(nil, logf)})
+
+	c.Get(context.Background(), "/services/svc-1", nil)
+
+	if len(lines) != 1 || !strings.Contains(lines[0], "GET "+server.URL+"/services/svc-1 -> 404") {
+		t.Fatalf("Expected one GET line with status 404, got %q", lines)
+	}
+	if strings.Contains(lines[0], "secret-token") {
+		t.Errorf("Expected the token to stay out of the log, got %q", lines[0])
+	}
+}
@@ -0,0 +1,347 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// maxPooledBuffer is the largest buffer returned to the pool, so that one
+// huge response does not pin its memory for the life of the process.
+const maxPooledBuffer = 4 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// decode decodes a JSON response body into out.
+//
+// By default the body is read into a pooled buffer and unmarshaled, which
+// avoids growing a new buffer for every response. In low-memory mode the
+// body is never buffered whole; see streamDecode.
+func (c *Client) decode(body io.Reader, out interface{}) error {
+	if c.lowMemory {
+		err := unknownField(streamDecode(body, out, c.strict))
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		if errors.Is(err, ErrUnknownField) || errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+			// Read errors are left to the network error handling
+			return local(err)
+		}
+		return err
+	}
+
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			bufferPool.Put(buf)
+		}
+	}()
+
+	if _, err := buf.ReadFrom(body); err != nil {
+		return err
+	}
+	return c.decodeBody(buf.Bytes(), out)
+}
+
+// decodeBody decodes a buffered JSON body into out when out is non-nil.
+func (c *Client) decodeBody(body []byte, out interface{}) error {
+	if out == nil {
+		return nil
+	}
+	return local(unknownField(unmarshal(body, out, c.strict)))
+}
+
+// DecodeElement decodes the next value of dec, such as a list element
+// passed to a GetEach callback, into v like the client decodes responses.
+func (c *Client) DecodeElement(dec *json.Decoder, v interface{}) error {
+	return unknownField(decodeNext(dec, v, c.strict))
+}
+
+// unmarshal decodes data into v and fills its Raw fields. When strict,
+// fields v does not model are an error.
+func unmarshal(data []byte, v interface{}, strict bool) error {
+	if strict {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(v); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	FillRaw(data, v)
+	return nil
+}
+
+// decodeNext decodes the next value of dec into v, buffering it only when
+// v has Raw fields to fill.
+func decodeNext(dec *json.Decoder, v interface{}, strict bool) error {
+	if !hasRaw(reflect.TypeOf(v)) {
+		return dec.Decode(v)
+	}
+	var data json.RawMessage
+	if err := dec.Decode(&data); err != nil {
+		return err
+	}
+	return unmarshal(data, v, strict)
+}
+
+// streamDecode decodes a JSON object into the struct out points to, reading
+// the elements of slice fields one at a time, so that memory use is bounded
+// by the largest element rather than the whole response. Other values are
+// decoded as usual. When strict, fields out does not model are an error.
+func streamDecode(r io.Reader, out interface{}, strict bool) error {
+	dec := json.NewDecoder(r)
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return dec.Decode(out)
+	}
+	target := rv.Elem()
+	fields := jsonFields(target.Type())
+	if fields == nil {
+		return dec.Decode(out)
+	}
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	rawIndex := rawField(target.Type())
+	unknown := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+
+		index, ok := fields[key]
+		if !ok {
+			index, ok = fields[strings.ToLower(key)]
+		}
+		if !ok {
+			if strict {
+				return fmt.Errorf("json: unknown field %q", key)
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			if rawIndex != nil {
+				unknown[key] = skip
+			}
+			continue
+		}
+
+		field := target.FieldByIndex(index)
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+			if err := streamSlice(dec, field, strict); err != nil {
+				return fmt.Errorf("failed to decode %s: %w", key, err)
+			}
+			continue
+		}
+		if err := decodeNext(dec, field.Addr().Interface(), strict); err != nil {
+			return err
+		}
+	}
+	if rawIndex != nil {
+		var raw json.RawMessage
+		if len(unknown) > 0 {
+			raw, _ = json.Marshal(unknown)
+		}
+		target.FieldByIndex(rawIndex).Set(reflect.ValueOf(raw))
+	}
+	return expectDelim(dec, '}')
+}
+
+// streamSlice decodes a JSON array or null into the slice field.
+func streamSlice(dec *json.Decoder, field reflect.Value, strict bool) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+
+	slice := reflect.MakeSlice(field.Type(), 0, 0)
+	elem := reflect.New(field.Type().Elem())
+	for dec.More() {
+		elem.Elem().Set(reflect.Zero(elem.Elem().Type()))
+		if err := decodeNext(dec, elem.Interface(), strict); err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, elem.Elem())
+	}
+	field.Set(slice)
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %v, got %v", want, tok)
+	}
+	return nil
+}
+
+var fieldCache sync.Map // reflect.Type -> map[string][]int
+
+// jsonFields maps the JSON names of a struct's fields, and their lower-case
+// forms for case-insensitive matching, to the field indexes. Fields of
+// embedded structs are included unless shadowed by an outer field. It
+// returns nil for structs embedding pointers, which streamDecode leaves to
+// encoding/json.
+func jsonFields(t reflect.Type) map[string][]int {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	fields := make(map[string][]int)
+	supported := true
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		var embedded []int
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+
+			if f.Anonymous && name == "" {
+				if f.Type.Kind() != reflect.Struct {
+					supported = false
+					continue
+				}
+				// Walked after the fields of this level, which shadow them
+				embedded = append(embedded, i)
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			fieldIndex := append(append([]int(nil), index...), i)
+			if _, ok := fields[name]; !ok {
+				fields[name] = fieldIndex
+			}
+			if lower := strings.ToLower(name); lower != name {
+				if _, ok := fields[lower]; !ok {
+					fields[lower] = fieldIndex
+				}
+			}
+		}
+		for _, i := range embedded {
+			walk(t.Field(i).Type, append(append([]int(nil), index...), i))
+		}
+	}
+	walk(t, nil)
+
+	if !supported {
+		fields = nil
+	}
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// GetEach performs a GET request for a list response and calls fn with the
+// decoder positioned at each element of its "data" array, so that elements
+// can be decoded one at a time. It returns the number of elements. Like
+// GetStream, the response is never cached.
+func (c *Client) GetEach(ctx context.Context, endpoint string, fn func(dec *json.Decoder) error) (int, error) {
+	n, _, err := c.GetEachPage(ctx, endpoint, fn)
+	return n, err
+}
+
+// GetEachPage is like GetEach but also returns the page size the API
+// reported in the "meta" object of the response, or 0 if it reported none,
+// so callers can tell a page the API capped from the last one.
+func (c *Client) GetEachPage(ctx context.Context, endpoint string, fn func(dec *json.Decoder) error) (int, int, error) {
+	body, err := c.GetStream(ctx, endpoint, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	if c.strict {
+		dec.DisallowUnknownFields()
+	}
+	var page pageMeta
+	n, err := eachElement(dec, &page, fn)
+	if meta := responseMeta(ctx); meta != nil {
+		meta.setPagination(page)
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return n, page.Limit, ctxErr
+		}
+		return n, page.Limit, unknownField(err)
+	}
+	return n, page.Limit, nil
+}
+
+func eachElement(dec *json.Decoder, page *pageMeta, fn func(dec *json.Decoder) error) (int, error) {
+	n := 0
+	if err := expectDelim(dec, '{'); err != nil {
+		return n, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return n, err
+		}
+		if key, _ := tok.(string); key != "data" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return n, err
+			}
+			if key == "meta" {
+				json.Unmarshal(skip, page)
+			}
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return n, err
+		}
+		if tok == nil {
+			continue
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return n, fmt.Errorf("expected array, got %v", tok)
+		}
+		for dec.More() {
+			if err := fn(dec); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return n, err
+		}
+	}
+	return n, expectDelim(dec, '}')
+}
@@ -0,0 +1,150 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type decodeItem struct {
+	ID   string   `json:"_id"`
+	Tags []string `json:"tags"`
+}
+
+type decodeMeta struct {
+	Count int `json:"count"`
+}
+
+type decodeList struct {
+	decodeMeta `json:"-"`
+	Meta       decodeMeta   `json:"meta"`
+	Items      []decodeItem `json:"data"`
+	Raw        []byte       `json:"raw"`
+	Name       string
+}
+
+func TestStreamDecode_MatchesEncodingJSON(t *testing.T) {
+	for _, body := range []string{
+		`{"meta":{"count":2},"data":[{"_id":"a","tags":["x"]},{"_id":"b"}],"extra":{"nested":[1,2]},"NAME":"list","raw":"aGk="}`,
+		`{"data":null,"meta":{"count":0}}`,
+		`{"data":[]}`,
+		`{}`,
+	} {
+		var want, got decodeList
+		if err := json.Unmarshal([]byte(body), &want); err != nil {
+			t.Fatal(err)
+		}
+		if err := streamDecode(strings.NewReader(body), &got, false); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", body, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("For %s expected %+v, got %+v", body, want, got)
+		}
+	}
+}
+
+func TestStreamDecode_NonStruct(t *testing.T) {
+	var got map[string]interface{}
+	if err := streamDecode(strings.NewReader(`{"cors":true}`), &got, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got["cors"] != true {
+		t.Errorf("Expected cors true, got %v", got)
+	}
+
+	var list decodeList
+	if err := streamDecode(strings.NewReader(`{"data":{"_id":"a"}}`), &list, false); err == nil {
+		t.Error("Expected error for an object where an array is expected")
+	}
+}
+
+func TestClient_LowMemoryDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"count":2},"data":[{"_id":"a"},{"_id":"b"}]}`))
+	}))
+	defer server.Close()
+
+	for _, lowMemory := range []bool{false, true} {
+		client := New(Config{BaseURL: server.URL, LowMemoryDecoding: lowMemory})
+		var out decodeList
+		if err := client.Get(context.Background(), "/services", &out); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if out.Meta.Count != 2 || len(out.Items) != 2 || out.Items[1].ID != "b" {
+			t.Errorf("Low memory %v: unexpected result %+v", lowMemory, out)
+		}
+	}
+}
+
+func TestClient_GetEach(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"meta":{"count":3},"data":[{"_id":"a"},{"_id":"b"},{"_id":"c"}]}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+
+	var ids []string
+	n, err := client.GetEach(context.Background(), "/services", func(dec *json.Decoder) error {
+		var item decodeItem
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		ids = append(ids, item.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 3 || strings.Join(ids, ",") != "a,b,c" {
+		t.Errorf("Expected 3 items a,b,c, got %d %v", n, ids)
+	}
+
+	stop := errors.New("stop")
+	n, err = client.GetEach(context.Background(), "/services", func(dec *json.Decoder) error {
+		var item decodeItem
+		dec.Decode(&item)
+		return stop
+	})
+	if err != stop || n != 0 {
+		t.Errorf("Expected the callback error after 0 items, got %v after %d", err, n)
+	}
+}
+
+func TestStreamDecode_EmbeddedFields(t *testing.T) {
+	type extended struct {
+		decodeItem
+		ID    string `json:"_id"`
+		Owner string `json:"owner"`
+	}
+	type list struct {
+		Data []extended `json:"data"`
+	}
+
+	body := `{"data":[{"_id":"a","tags":["x"],"owner":"me"}]}`
+	var want, got list
+	json.Unmarshal([]byte(body), &want)
+	if err := streamDecode(strings.NewReader(body), &got, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	type outer struct {
+		decodeItem
+		Count int `json:"count"`
+	}
+	var o outer
+	if err := streamDecode(strings.NewReader(`{"_id":"x","tags":["a","b"],"count":2}`), &o, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if o.ID != "x" || len(o.Tags) != 2 || o.Count != 2 {
+		t.Errorf("Expected promoted fields to be decoded, got %+v", o)
+	}
+}
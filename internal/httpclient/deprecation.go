@@ -0,0 +1,116 @@
+package httpclient
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeprecationWarning reports an endpoint the API announced as deprecated
+// with the Deprecation (RFC 9745) or Sunset (RFC 8594) response header.
+type DeprecationWarning struct {
+	Method string `json:"method"`
+
+	// Endpoint is the request path with resource IDs replaced by "*"
+	Endpoint string `json:"endpoint"`
+
+	// DeprecatedAt is when the endpoint was or will be deprecated; zero when
+	// the API only flags it as deprecated
+	DeprecatedAt time.Time `json:"deprecatedAt,omitempty"`
+
+	// Sunset is when the endpoint will stop working; zero when not announced
+	Sunset time.Time `json:"sunset,omitempty"`
+
+	// Link points to documentation of the deprecation, when provided
+	Link string `json:"link,omitempty"`
+}
+
+func (w DeprecationWarning) String() string {
+	var b strings.Builder
+	b.WriteString("cachefly: deprecated endpoint " + w.Method + " " + w.Endpoint)
+	if !w.Sunset.IsZero() {
+		b.WriteString(", sunset " + w.Sunset.UTC().Format(time.RFC3339))
+	}
+	if w.Link != "" {
+		b.WriteString(", see " + w.Link)
+	}
+	return b.String()
+}
+
+// checkDeprecation reports the deprecation headers of resp, once per method
+// and endpoint for the lifetime of the client.
+func (c *Client) checkDeprecation(method, endpoint string, resp *http.Response) {
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return
+	}
+
+	w := DeprecationWarning{Method: method, Endpoint: endpointPattern(endpoint), Link: deprecationLink(resp.Header)}
+	if _, seen := c.deprecations.LoadOrStore(w.Method+" "+w.Endpoint, true); seen {
+		return
+	}
+	w.DeprecatedAt = parseDeprecation(deprecation)
+	if t, err := http.ParseTime(sunset); err == nil {
+		w.Sunset = t
+	}
+
+	if c.deprecationLog != nil {
+		c.deprecationLog(w)
+	} else {
+		log.Print(w.String())
+	}
+}
+
+// parseDeprecation parses a Deprecation header: a structured date such as
+// "@1688169599", or an HTTP date or "true" as sent by older servers.
+func parseDeprecation(value string) time.Time {
+	if strings.HasPrefix(value, "@") {
+		if secs, err := strconv.ParseInt(value[1:], 10, 64); err == nil {
+			return time.Unix(secs, 0)
+		}
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// deprecationLink returns the target of the Link header with
+// rel="deprecation", falling back to rel="sunset".
+func deprecationLink(header http.Header) string {
+	var sunset string
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, params, _ := strings.Cut(strings.TrimSpace(link), ";")
+			target = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(target), "<"), ">")
+			rel := strings.ToLower(strings.ReplaceAll(params, " ", ""))
+			switch {
+			case strings.Contains(rel, `rel="deprecation"`) || strings.Contains(rel, "rel=deprecation"):
+				return target
+			case strings.Contains(rel, `rel="sunset"`) || strings.Contains(rel, "rel=sunset"):
+				sunset = target
+			}
+		}
+	}
+	return sunset
+}
+
+// endpointPattern strips the query from endpoint and replaces segments that
+// look like resource IDs, long and containing a digit, with "*", so that a
+// deprecated endpoint is reported once however many resources it is used
+// for.
+func endpointPattern(endpoint string) string {
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	segments := strings.Split(endpoint, "/")
+	for i, s := range segments {
+		if len(s) >= 16 && strings.ContainsAny(s, "0123456789") {
+			segments[i] = "*"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_DeprecationWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services" {
+			w.Header().Set("Deprecation", "@1735689600")
+			w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+			w.Header().Add("Link", `<https://docs.example.com/migrate>; rel="deprecation"; type="text/html"`)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var warnings []DeprecationWarning
+	client := New(Config{BaseURL: server.URL, DeprecationLog: func(w DeprecationWarning) {
+		warnings = append(warnings, w)
+	}})
+
+	ctx := context.Background()
+	for _, endpoint := range []string{
+		"/services",
+		"/services/5f1a2b3c4d5e6f7a8b9c0d1e/options/legacy",
+		"/services/6a1a2b3c4d5e6f7a8b9c0d1f/options/legacy?hide=true",
+	} {
+		if err := client.Get(ctx, endpoint, nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if err := client.Put(ctx, "/services/5f1a2b3c4d5e6f7a8b9c0d1e/options/legacy", struct{}{}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(warnings) != 2 {
+		t.Fatalf("Expected one warning per method and endpoint, got %+v", warnings)
+	}
+	w := warnings[0]
+	if w.Method != "GET" || w.Endpoint != "/services/*/options/legacy" {
+		t.Errorf("Unexpected endpoint %s %s", w.Method, w.Endpoint)
+	}
+	if !w.DeprecatedAt.Equal(time.Unix(1735689600, 0)) || !w.Sunset.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected dates %v, %v", w.DeprecatedAt, w.Sunset)
+	}
+	if w.Link != "https://docs.example.com/migrate" {
+		t.Errorf("Expected deprecation link, got %s", w.Link)
+	}
+	if warnings[1].Method != "PUT" {
+		t.Errorf("Expected PUT warning, got %s", warnings[1].Method)
+	}
+}
+
+func TestEndpointPattern(t *testing.T) {
+	tests := map[string]string{
+		"/services/5f1a2b3c4d5e6f7a8b9c0d1e/imageopt4": "/services/*/imageopt4",
+		"/accounts/me/enable2FA":                       "/accounts/me/enable2FA",
+		"/certificates?offset=0":                       "/certificates",
+	}
+	for endpoint, expected := range tests {
+		if got := endpointPattern(endpoint); got != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, endpoint, got)
+		}
+	}
+}
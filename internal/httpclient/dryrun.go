@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/har"
+)
+
+// DryRunRequest is a mutating request that was not sent because the client
+// or the call was in dry-run mode.
+type DryRunRequest struct {
+	Method string
+	URL    string
+
+	// Body is the JSON payload, nil when the request has none
+	Body []byte
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context that puts calls made with it in dry-run mode.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether ctx was returned by WithDryRun.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// dryRun reports whether a request must be skipped rather than sent. Only
+// mutating requests are skipped; reads are still sent so that validation
+// against current state keeps working.
+func (c *Client) dryRun(ctx context.Context, method string) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return false
+	}
+	return c.dryRunAll || IsDryRun(ctx)
+}
+
+// DryRun reports whether mutating requests made with ctx are skipped,
+// because the client or ctx is in dry-run mode.
+func (c *Client) DryRun(ctx context.Context) bool {
+	return c.dryRun(ctx, http.MethodPost)
+}
+
+// skip reports a request that was not sent and fills out with the request
+// payload, so callers see the state they asked for. The standard logger gets
+// the payload with its secrets redacted; a DryRunLog gets it as sent.
+func (c *Client) skip(method, endpoint string, payload []byte, out interface{}) error {
+	req := DryRunRequest{Method: method, URL: c.fullURL(endpoint), Body: payload}
+	if c.dryRunLog != nil {
+		c.dryRunLog(req)
+	} else {
+		log.Printf("cachefly: dry run: %s %s %s", req.Method, req.URL, har.RedactBody(req.Body))
+	}
+
+	if out != nil && payload != nil {
+		// The payload rarely has every field of the response; what it has is
+		// decoded and the rest stays zero
+		_ = json.Unmarshal(payload, out)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/errcode"
+)
+
+// ErrAPIMaintenance is matched by errors.Is for responses indicating that
+// the API is down for maintenance.
+var ErrAPIMaintenance = errors.New("cachefly: API is in maintenance mode")
+
+// localError is a failure on the client's side of a request, such as
+// getting credentials or decoding a response the API sent successfully.
+// Sending the request again would not help, and the API is not at fault, so
+// local errors are neither retried nor counted by the circuit breaker.
+type localError struct {
+	err error
+}
+
+func (e *localError) Error() string { return e.err.Error() }
+
+func (e *localError) Unwrap() error { return e.err }
+
+// local marks err, if any, as a localError.
+func local(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &localError{err: err}
+}
+
+// isLocal reports whether err is a localError.
+func isLocal(err error) bool {
+	var l *localError
+	return errors.As(err, &l)
+}
+
+// APIError is returned for responses with a status code of 400 or above.
+type APIError struct {
+	StatusCode int
+	Body       string
+	Header     http.Header
+
+	// RequestID is the X-Request-ID the request was sent with
+	RequestID string
+
+	// ServerRequestID is the correlation ID the API reported, if any
+	ServerRequestID string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Body) + e.requestIDs()
+}
+
+// requestIDs returns the request IDs for the end of an error message, or
+// "" when there are none.
+func (e *APIError) requestIDs() string {
+	switch {
+	case e.ServerRequestID != "" && e.ServerRequestID != e.RequestID:
+		return fmt.Sprintf(" (request ID %s, server request ID %s)", e.RequestID, e.ServerRequestID)
+	case e.RequestID != "":
+		return fmt.Sprintf(" (request ID %s)", e.RequestID)
+	}
+	return ""
+}
+
+// MessageKey returns "api_error." followed by the status code, for message
+// catalogs.
+func (e *APIError) MessageKey() string {
+	return errcode.APIError + "." + strconv.Itoa(e.StatusCode)
+}
+
+// MessageParams returns the status, body and request ID for message
+// catalogs.
+func (e *APIError) MessageParams() map[string]string {
+	return map[string]string{"status": strconv.Itoa(e.StatusCode), "body": e.Body, "requestId": e.RequestID}
+}
+
+// MaintenanceError is returned when the API responds with 503 Service
+// Unavailable because of scheduled maintenance.
+type MaintenanceError struct {
+	*APIError
+
+	// EstimatedEnd is when the API expects maintenance to end; zero if unknown
+	EstimatedEnd time.Time
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.EstimatedEnd.IsZero() {
+		return fmt.Sprintf("API maintenance: %s", e.Body) + e.requestIDs()
+	}
+	return fmt.Sprintf("API maintenance until %s: %s", e.EstimatedEnd.Format(time.RFC3339), e.Body) + e.requestIDs()
+}
+
+// MessageKey returns "api_maintenance" for message catalogs.
+func (e *MaintenanceError) MessageKey() string {
+	return errcode.APIMaintenance
+}
+
+// MessageParams returns the body, the estimated end, in RFC 3339 or
+// empty, and the request ID for message catalogs.
+func (e *MaintenanceError) MessageParams() map[string]string {
+	params := map[string]string{"body": e.Body, "until": "", "requestId": e.RequestID}
+	if !e.EstimatedEnd.IsZero() {
+		params["until"] = e.EstimatedEnd.Format(time.RFC3339)
+	}
+	return params
+}
+
+// Is reports whether target is ErrAPIMaintenance.
+func (e *MaintenanceError) Is(target error) bool {
+	return target == ErrAPIMaintenance
+}
+
+// Unwrap returns the underlying APIError.
+func (e *MaintenanceError) Unwrap() error {
+	return e.APIError
+}
+
+// HeaderMaintenance is set by the API on responses served during maintenance.
+const HeaderMaintenance = "X-CF-Maintenance"
+
+// maintenanceBody is the subset of a maintenance response body inspected.
+type maintenanceBody struct {
+	Maintenance      bool   `json:"maintenance"`
+	Message          string `json:"message"`
+	EstimatedEndTime string `json:"estimatedEndTime"`
+}
+
+// newAPIError builds the error for a failed response, detecting maintenance.
+//
+// A 503 is treated as maintenance when it carries a HeaderMaintenance header
+// other than "false", a JSON body with "maintenance": true or an
+// estimatedEndTime, or a message mentioning maintenance. Retry-After alone
+// does not mark maintenance, as overloaded servers send it too. The
+// estimated end comes from estimatedEndTime (RFC 3339) or Retry-After
+// (seconds or HTTP date).
+func newAPIError(resp *http.Response, body []byte, now time.Time) error {
+	apiErr := &APIError{
+		StatusCode:      resp.StatusCode,
+		Body:            string(body),
+		Header:          resp.Header,
+		RequestID:       sentRequestID(resp),
+		ServerRequestID: serverRequestID(resp),
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		return apiErr
+	}
+
+	var parsed maintenanceBody
+	_ = json.Unmarshal(body, &parsed)
+
+	marker := strings.TrimSpace(resp.Header.Get(HeaderMaintenance))
+	isMaintenance := parsed.Maintenance || parsed.EstimatedEndTime != "" ||
+		(marker != "" && !strings.EqualFold(marker, "false")) ||
+		strings.Contains(strings.ToLower(string(body)), "maintenance")
+	if !isMaintenance {
+		return apiErr
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+
+	maintErr := &MaintenanceError{APIError: apiErr}
+	if end, err := time.Parse(time.RFC3339, parsed.EstimatedEndTime); err == nil {
+		maintErr.EstimatedEnd = end
+	} else if secs, err := strconv.Atoi(retryAfter); err == nil {
+		maintErr.EstimatedEnd = now.Add(time.Duration(secs) * time.Second)
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		maintErr.EstimatedEnd = date
+	}
+	return maintErr
+}
@@ -0,0 +1,168 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_MaintenanceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"maintenance":true,"message":"scheduled upgrade","estimatedEndTime":"2030-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+
+	err := client.Get(context.Background(), "/services", nil)
+	if !errors.Is(err, ErrAPIMaintenance) {
+		t.Fatalf("Expected ErrAPIMaintenance, got %v", err)
+	}
+	var maintErr *MaintenanceError
+	if !errors.As(err, &maintErr) {
+		t.Fatalf("Expected MaintenanceError, got %T", err)
+	}
+	if want := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC); !maintErr.EstimatedEnd.Equal(want) {
+		t.Errorf("Expected estimated end %s, got %s", want, maintErr.EstimatedEnd)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected wrapped APIError with status 503, got %v", apiErr)
+	}
+}
+
+func TestMaintenanceError_RequestID(t *testing.T) {
+	err := &MaintenanceError{
+		APIError:     &APIError{StatusCode: http.StatusServiceUnavailable, Body: "scheduled upgrade", RequestID: "req-1", ServerRequestID: "srv-1"},
+		EstimatedEnd: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	want := "API maintenance until 2030-01-01T00:00:00Z: scheduled upgrade (request ID req-1, server request ID srv-1)"
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+	if got := err.MessageParams()["requestId"]; got != "req-1" {
+		t.Errorf("Expected the request ID in the message params, got %q", got)
+	}
+}
+
+func TestClient_PlainServiceUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`upstream overloaded`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+
+	err := client.Get(context.Background(), "/services", nil)
+	if errors.Is(err, ErrAPIMaintenance) {
+		t.Errorf("Expected a plain API error, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected APIError with status 503, got %v", err)
+	}
+}
+
+func TestNewAPIError_MaintenanceMarkers(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+		want   bool
+	}{
+		{"retry-after only", http.Header{"Retry-After": {"120"}}, `{"message":"upstream overloaded"}`, false},
+		{"header marker", http.Header{"Retry-After": {"120"}, HeaderMaintenance: {"true"}}, ``, true},
+		{"header marker false", http.Header{HeaderMaintenance: {"false"}}, ``, false},
+		{"body flag", http.Header{"Retry-After": {"120"}}, `{"maintenance":true}`, true},
+		{"body message", nil, `Down for Maintenance`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+			for key, values := range tt.header {
+				resp.Header[http.CanonicalHeaderKey(key)] = values
+			}
+			err := newAPIError(resp, []byte(tt.body), now)
+			if got := errors.Is(err, ErrAPIMaintenance); got != tt.want {
+				t.Fatalf("Expected maintenance %v, got %v", tt.want, err)
+			}
+			var maintErr *MaintenanceError
+			if tt.want && tt.header.Get("Retry-After") != "" && errors.As(err, &maintErr) && !maintErr.EstimatedEnd.Equal(now.Add(2*time.Minute)) {
+				t.Errorf("Expected the estimated end from Retry-After, got %s", maintErr.EstimatedEnd)
+			}
+		})
+	}
+}
+
+func TestClient_WaitsForMaintenance(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`maintenance`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, MaintenanceWait: 5 * time.Second})
+
+	if err := client.Get(context.Background(), "/services", nil); err != nil {
+		t.Fatalf("Expected request to resume after maintenance, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestClient_MaintenanceWaitExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.Header().Set(HeaderMaintenance, "true")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, MaintenanceWait: time.Minute})
+
+	start := time.Now()
+	err := client.Get(context.Background(), "/services", nil)
+	if !errors.Is(err, ErrAPIMaintenance) {
+		t.Errorf("Expected ErrAPIMaintenance, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Expected to give up immediately when maintenance outlasts the wait budget")
+	}
+}
+
+func TestClient_CanceledDuringMaintenanceWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`maintenance`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, MaintenanceWait: 2 * time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := client.Get(ctx, "/services", nil)
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected to return promptly, took %s", elapsed)
+	}
+}
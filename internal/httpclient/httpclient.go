@@ -4,155 +4,579 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"path"
+	"sync"
 	"time"
 )
 
+// DefaultTimeout bounds a request when neither the client nor the caller's
+// context sets a shorter deadline.
+const DefaultTimeout = 35 * time.Second
+
+// CredentialsProvider supplies the bearer token used to authenticate a request.
+// It is called for every request, so implementations can rotate tokens.
+type CredentialsProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// RefreshableCredentials is implemented by providers that can obtain a new
+// token after the API rejects the current one with 401 Unauthorized.
+type RefreshableCredentials interface {
+	CredentialsProvider
+
+	// Refresh returns a token other than stale. When several requests fail
+	// with the same stale token concurrently, only the first one should
+	// obtain a new token; the others receive it once it is available.
+	Refresh(ctx context.Context, stale string) (string, error)
+}
+
+// staticToken is the CredentialsProvider used for Config.AuthToken.
+type staticToken string
+
+func (t staticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
 type Config struct {
 	BaseURL   string
 	AuthToken string
+
+	// Credentials supplies the token per request and takes precedence over AuthToken
+	Credentials CredentialsProvider
+
+	// AuthScheme authenticates each request; nil uses BearerAuth
+	AuthScheme AuthScheme
+
+	// UserAgent is sent in the User-Agent header; empty uses Go's default
+	UserAgent string
+
+	// Timeout bounds each request. Zero uses DefaultTimeout, negative disables it.
+	Timeout time.Duration
+
+	// MaintenanceWait is the longest a request waits in total for API
+	// maintenance to end before returning a MaintenanceError. Zero disables waiting.
+	MaintenanceWait time.Duration
+
+	// Retry enables retries of failed requests; nil disables them
+	Retry *RetryPolicy
+
+	// EndpointPolicies override Timeout and Retry for matching endpoints
+	EndpointPolicies []EndpointPolicy
+
+	// Cache enables client-side caching of GET responses; nil disables it
+	Cache *ResponseCache
+
+	// Transport sends the HTTP requests; nil uses http.DefaultTransport
+	Transport http.RoundTripper
+
+	// Scheduler limits concurrent requests across clients sharing it, with
+	// requests interleaved fairly by Account; nil disables it
+	Scheduler *Scheduler
+	Account   string
+
+	// DryRun skips every mutating request, see WithDryRun
+	DryRun bool
+
+	// DryRunLog receives the skipped requests; nil logs them with the log package
+	DryRunLog func(DryRunRequest)
+
+	// RateLimiter limits the rate of request attempts; nil disables it
+	RateLimiter *RateLimiter
+
+	// Limiter is consulted before every request attempt, in addition to
+	// RateLimiter; nil disables it
+	Limiter Limiter
+
+	// CircuitBreaker fails requests fast after repeated failures; nil disables it
+	CircuitBreaker *CircuitBreaker
+
+	// LowMemoryDecoding decodes the elements of list responses one at a
+	// time instead of buffering whole responses
+	LowMemoryDecoding bool
+
+	// StrictDecoding fails responses with fields the SDK does not model
+	// with an ErrUnknownField error, instead of ignoring them
+	StrictDecoding bool
+
+	// ReadAfterWrite makes ReadBack re-read resources after writes
+	ReadAfterWrite bool
+
+	// OnChange is called after every successful POST, PUT and DELETE, from
+	// the goroutine that made the request; nil disables it
+	OnChange func(ChangeEvent)
+
+	// DeprecationLog receives a warning the first time a deprecated endpoint
+	// is used; nil logs them with the log package
+	DeprecationLog func(DeprecationWarning)
+
+	// RefuseWrites is returned by every mutating request instead of sending
+	// it; nil allows writes. Dry-run requests are still reported.
+	RefuseWrites error
+
+	// AllowBetaOptions lets service option updates set beta options
+	AllowBetaOptions bool
+
+	// AllowReadOnlyOptions lets service option updates set options the
+	// metadata marks as read-only
+	AllowReadOnlyOptions bool
 }
 
 type Client struct {
-	http    *http.Client
-	baseURL string
-	token   string
+	http            *http.Client
+	baseURL         string
+	credentials     CredentialsProvider
+	auth            AuthScheme
+	userAgent       string
+	timeout         time.Duration
+	maintenanceWait time.Duration
+	retry           *RetryPolicy
+	policies        []EndpointPolicy
+	cache           *ResponseCache
+	scheduler       *Scheduler
+	account         string
+	dryRunAll       bool
+	dryRunLog       func(DryRunRequest)
+	lowMemory       bool
+	strict          bool
+	rateLimiter     *RateLimiter
+	limiter         Limiter
+	breaker         *CircuitBreaker
+	onChange        func(ChangeEvent)
+	readAfterWrite  bool
+	deprecationLog  func(DeprecationWarning)
+	deprecations    sync.Map
+	refuseWrites    error
+	allowBeta       bool
+	allowReadOnly   bool
 }
 
 func New(cfg Config) *Client {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	credentials := cfg.Credentials
+	if credentials == nil {
+		credentials = staticToken(cfg.AuthToken)
+	}
+	auth := cfg.AuthScheme
+	if auth == nil {
+		auth = BearerAuth{}
+	}
 	return &Client{
-		http: &http.Client{
-			Timeout: 35 * time.Second,
-		},
-		baseURL: cfg.BaseURL,
-		token:   cfg.AuthToken,
+		http:            &http.Client{Transport: cfg.Transport},
+		baseURL:         cfg.BaseURL,
+		credentials:     credentials,
+		auth:            auth,
+		userAgent:       cfg.UserAgent,
+		timeout:         timeout,
+		maintenanceWait: cfg.MaintenanceWait,
+		retry:           cfg.Retry,
+		policies:        cfg.EndpointPolicies,
+		cache:           cfg.Cache,
+		scheduler:       cfg.Scheduler,
+		account:         cfg.Account,
+		dryRunAll:       cfg.DryRun,
+		dryRunLog:       cfg.DryRunLog,
+		lowMemory:       cfg.LowMemoryDecoding,
+		strict:          cfg.StrictDecoding,
+		rateLimiter:     cfg.RateLimiter,
+		limiter:         cfg.Limiter,
+		breaker:         cfg.CircuitBreaker,
+		onChange:        cfg.OnChange,
+		readAfterWrite:  cfg.ReadAfterWrite,
+		deprecationLog:  cfg.DeprecationLog,
+		refuseWrites:    cfg.RefuseWrites,
+		allowBeta:       cfg.AllowBetaOptions,
+		allowReadOnly:   cfg.AllowReadOnlyOptions,
 	}
 }
 
+// AllowsBetaOptions reports whether the client may set beta service options.
+func (c *Client) AllowsBetaOptions() bool {
+	return c.allowBeta
+}
+
+// AllowsReadOnlyOptions reports whether the client may set service options
+// marked as read-only.
+func (c *Client) AllowsReadOnlyOptions() bool {
+	return c.allowReadOnly
+}
+
 // Post performs a POST request with a JSON payload and decodes the JSON response.
 func (c *Client) Post(ctx context.Context, endpoint string, body interface{}, out interface{}) error {
 	payload, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request body: %w", err)
 	}
+	return c.do(ctx, http.MethodPost, endpoint, payload, true, out)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.fullURL(endpoint), bytes.NewReader(payload))
-	if err != nil {
-		return err
+// Get performs a GET request and decodes the JSON response.
+func (c *Client) Get(ctx context.Context, endpoint string, out interface{}) error {
+	return c.do(ctx, http.MethodGet, endpoint, nil, false, out)
+}
+
+// Put performs a PUT request with an optional JSON payload and decodes the JSON response if out is provided.
+func (c *Client) Put(ctx context.Context, endpoint string, body interface{}, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
 	}
+	return c.do(ctx, http.MethodPut, endpoint, payload, true, out)
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+// Delete performs a DELETE request with no body and decodes the JSON response into out.
+func (c *Client) Delete(ctx context.Context, endpoint string, out interface{}) error {
+	return c.do(ctx, http.MethodDelete, endpoint, nil, false, out)
+}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
+// maintenancePoll is how long to wait between attempts during maintenance
+// when the API gives no estimated end.
+const maintenancePoll = 30 * time.Second
+
+// do sends a request and decodes the JSON response into out when out is non-nil.
+//
+// When MaintenanceWait is set and the API reports maintenance, the request
+// is paused until the estimated end and then replayed, for at most
+// MaintenanceWait in total. Other failures are retried according to the
+// retry policy; once it is exhausted a RetryExhaustedError is returned.
+// Timeout and retry policy may be overridden per endpoint.
+//
+// Once ctx ends, no further attempt is started and every wait, whether for the
+// rate limiter, a scheduler slot, a retry backoff or maintenance, returns immediately with
+// ctx.Err() unwrapped.
+//
+// While the circuit breaker is open, requests fail with a CircuitOpenError
+// without being sent. GETs with a fresh cache entry are served from it
+// before the rate limiters, scheduler and breaker are consulted.
+//
+// In dry-run mode mutating requests are reported instead of sent and
+// succeed with the request payload decoded into out. Successful mutating
+// requests that were sent are reported to the OnChange listener. Otherwise,
+// when writes are refused, mutating requests fail with that error.
+func (c *Client) do(ctx context.Context, method, endpoint string, payload []byte, jsonBody bool, out interface{}) error {
+	if c.dryRun(ctx, method) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return c.skip(method, endpoint, payload, out)
+	}
+	if c.refuseWrites != nil && method != http.MethodGet && method != http.MethodHead {
+		return c.refuseWrites
+	}
+	if err := ctx.Err(); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	// Fresh cache hits say nothing about the API, so they are served
+	// without rate tokens, scheduler slots or the breaker
+	if entry := c.fresh(ctx, method, endpoint); entry != nil {
+		return c.serveCached(ctx, entry, out)
 	}
+	ctx = ensureRequestID(ctx)
+	return c.attempt(ctx, method, endpoint, func(timeout time.Duration) error {
+		return c.doOnce(ctx, timeout, method, endpoint, payload, jsonBody, out)
+	})
+}
+
+// attempt calls once with the endpoint's timeout until it succeeds, waiting
+// out maintenance and retrying failures as described for do.
+func (c *Client) attempt(ctx context.Context, method, endpoint string, once func(timeout time.Duration) error) error {
+	timeout, retry := c.policyFor(method, endpoint)
+
+	var waited time.Duration
+	var attempts []Attempt
+	for {
+		// Cached responses and free scheduler slots would otherwise let a
+		// canceled request succeed
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// The shared limiter is consulted before the breaker, so that its
+		// failures do not count as API failures
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				return fmt.Errorf("rate limiter: %w", err)
+			}
+		}
+
+		if err := c.breaker.allow(); err != nil {
+			return err
+		}
 
-	return json.NewDecoder(resp.Body).Decode(out)
+		// Wait for the rate limit before taking a scheduler slot, so that
+		// throttled requests do not hold slots other accounts could use
+		var start time.Time
+		err := c.rateLimiter.Wait(ctx)
+		if err == nil {
+			start = time.Now()
+			err = c.scheduled(ctx, func() error { return once(timeout) })
+		}
+		c.breaker.record(ctx, err)
+		if err == nil {
+			return nil
+		}
+
+		var maintErr *MaintenanceError
+		if errors.As(err, &maintErr) && c.maintenanceWait > 0 {
+			wait := maintenancePoll
+			if !maintErr.EstimatedEnd.IsZero() {
+				wait = time.Until(maintErr.EstimatedEnd)
+			}
+			if wait <= 0 {
+				wait = time.Second
+			}
+			if waited+wait > c.maintenanceWait {
+				return err
+			}
+			if err := sleep(ctx, wait); err != nil {
+				return err
+			}
+			waited += wait
+			continue
+		}
+
+		if retry == nil || retry.MaxAttempts <= 1 || !retry.retryable(method, err) {
+			return err
+		}
+
+		attempt := Attempt{Time: start, StatusCode: statusCode(err), Err: err}
+		if len(attempts)+1 >= retry.MaxAttempts {
+			attempts = append(attempts, attempt)
+			return &RetryExhaustedError{Method: method, Endpoint: endpoint, Attempts: attempts}
+		}
+		attempt.Wait = retry.delay(len(attempts), err)
+		attempts = append(attempts, attempt)
+
+		if err := sleep(ctx, attempt.Wait); err != nil {
+			return err
+		}
+	}
 }
 
-// Get performs a GET request and decodes the JSON response.
-func (c *Client) Get(ctx context.Context, endpoint string, out interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.fullURL(endpoint), nil)
-	if err != nil {
+// scheduled runs fn in a slot of the client's scheduler, if any. Waits
+// between attempts do not hold a slot.
+func (c *Client) scheduled(ctx context.Context, fn func() error) error {
+	if c.scheduler == nil {
+		return fn()
+	}
+	if err := c.scheduler.acquire(ctx, c.accountFor(ctx)); err != nil {
 		return err
 	}
+	defer c.scheduler.release()
+	return fn()
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return err
+// sleep waits for d or until ctx ends, returning ctx.Err() in that case.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	defer resp.Body.Close()
+}
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+// fresh returns the fresh cache entry of a GET, or nil when there is none.
+func (c *Client) fresh(ctx context.Context, method, endpoint string) *CacheEntry {
+	if c.cache == nil || method != http.MethodGet || !c.cache.cacheable(endpoint) {
+		return nil
+	}
+	if _, own := c.credentialsFor(ctx); !own {
+		return nil
+	}
+	if entry, fresh := c.cache.lookup(ctx, c.fullURL(endpoint)); fresh {
+		return entry
 	}
+	return nil
+}
 
-	return json.NewDecoder(resp.Body).Decode(out)
+// serveCached decodes a fresh cache entry into out as the response.
+func (c *Client) serveCached(ctx context.Context, entry *CacheEntry, out interface{}) error {
+	if meta := responseMeta(ctx); meta != nil {
+		*meta = ResponseMeta{StatusCode: http.StatusOK, Cached: true}
+		meta.recordPagination(entry.Body)
+	}
+	return c.decodeBody(entry.Body, out)
 }
 
-func (c *Client) Put(ctx context.Context, endpoint string, body interface{}, out interface{}) error {
-	// 1. Marshal the request body
-	var reader io.Reader
-	if body != nil {
-		payload, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+// doOnce performs a single request attempt.
+//
+// The client-level timeout never extends the caller's deadline. When the
+// request fails because a context ended, context.Canceled or
+// context.DeadlineExceeded is returned unwrapped.
+func (c *Client) doOnce(ctx context.Context, timeout time.Duration, method, endpoint string, payload []byte, jsonBody bool, out interface{}) error {
+	reqCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	credentials, own := c.credentialsFor(ctx)
+
+	// Serve GETs from the cache while fresh, otherwise revalidate
+	var cacheKey string
+	var cached *CacheEntry
+	var header http.Header
+	if c.cache != nil && own && method == http.MethodGet && c.cache.cacheable(endpoint) {
+		cacheKey = c.fullURL(endpoint)
+		entry, fresh := c.cache.lookup(ctx, cacheKey)
+		if fresh {
+			return c.serveCached(ctx, entry, out)
+		}
+		if entry != nil {
+			cached, header = entry, entry.conditionalHeaders()
 		}
-		reader = bytes.NewReader(payload)
 	}
 
-	// 2. Create the request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.fullURL(endpoint), reader)
+	token, err := credentials.Token(reqCtx)
 	if err != nil {
-		return err
+		return contextError(ctx, reqCtx, local(fmt.Errorf("failed to get credentials: %w", err)))
 	}
 
-	// 3. Set headers
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	// 4. Do the call
-	resp, err := c.http.Do(req)
+	resp, err := c.send(reqCtx, method, endpoint, payload, jsonBody, token, header)
 	if err != nil {
-		return err
+		return contextError(ctx, reqCtx, err)
+	}
+
+	// A rejected token may have been rotated; refresh once and replay
+	if rc, ok := credentials.(RefreshableCredentials); ok && resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+
+		token, err = rc.Refresh(reqCtx, token)
+		if err != nil {
+			return contextError(ctx, reqCtx, local(fmt.Errorf("failed to refresh credentials: %w", err)))
+		}
+		resp, err = c.send(reqCtx, method, endpoint, payload, jsonBody, token, header)
+		if err != nil {
+			return contextError(ctx, reqCtx, err)
+		}
 	}
 	defer resp.Body.Close()
 
-	// 5. Check for error status
+	meta := observe(ctx, resp)
+	c.checkDeprecation(method, endpoint, resp)
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		c.cache.renew(ctx, cacheKey, cached)
+		if meta != nil {
+			meta.recordPagination(cached.Body)
+		}
+		return c.decodeBody(cached.Body, out)
+	}
+
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, body, time.Now())
+	}
+
+	// Buffer the body to read its pagination metadata before decoding
+	if meta != nil && method == http.MethodGet {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return contextError(ctx, reqCtx, err)
+		}
+		meta.recordPagination(body)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var before json.RawMessage
+	if method != http.MethodGet {
+		before = c.before(ctx, method, endpoint)
+		if c.cache != nil {
+			c.cache.invalidateWrite(ctx, endpoint)
+		}
+	}
+
+	if cacheKey != "" && resp.StatusCode == http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return contextError(ctx, reqCtx, err)
+		}
+		c.cache.save(ctx, cacheKey, endpoint, body, resp.Header)
+		return c.decodeBody(body, out)
+	}
+
+	if c.onChange != nil && method != http.MethodGet && method != http.MethodHead {
+		if err := c.changed(method, endpoint, payload, before, resp.Body, out); err != nil {
+			return contextError(ctx, reqCtx, err)
+		}
+		return nil
 	}
 
-	// 6. Decode if out is provided
 	if out != nil {
-		return json.NewDecoder(resp.Body).Decode(out)
+		if err := c.decode(resp.Body, out); err != nil {
+			return contextError(ctx, reqCtx, err)
+		}
 	}
 	return nil
 }
 
-// Delete performs a DELETE request with no body and decodes the JSON response into out.
-func (c *Client) Delete(ctx context.Context, endpoint string, out interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.fullURL(endpoint), nil)
-	if err != nil {
-		return err
+// send performs a single HTTP request authenticated with token.
+func (c *Client) send(ctx context.Context, method, endpoint string, payload []byte, jsonBody bool, token string, header http.Header) (*http.Response, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
 	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.http.Do(req)
+	req, err := http.NewRequestWithContext(ctx, method, c.fullURL(endpoint), reader)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+	applyOverrides(ctx, req)
+	if err := c.auth.Authenticate(req, token, payload); err != nil {
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
 	}
-	if out != nil {
-		return json.NewDecoder(resp.Body).Decode(out)
+	if jsonBody {
+		req.Header.Set("Content-Type", "application/json")
 	}
-	return nil
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if id := RequestIDFromContext(ctx); id != "" {
+		req.Header.Set(HeaderRequestID, id)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	return c.http.Do(req)
+}
+
+// contextError returns the bare context error when err was caused by the
+// caller's context or the client timeout ending, and err otherwise.
+func contextError(ctx, reqCtx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if reqErr := reqCtx.Err(); reqErr != nil {
+		return reqErr
+	}
+	return err
 }
 
 func (c *Client) fullURL(endpoint string) string {
 	return c.baseURL + path.Clean("/"+endpoint)
 }
+
+// BaseURL returns the base URL all endpoints are resolved against.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
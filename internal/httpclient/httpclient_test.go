@@ -0,0 +1,127 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Timeout: 20 * time.Millisecond})
+
+	err := client.Get(context.Background(), "/slow", nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_CallerDeadlineWins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Timeout: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.Get(ctx, "/slow", nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("Expected request to return at the caller deadline, took %v", time.Since(start))
+	}
+}
+
+func TestClient_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err := client.Get(ctx, "/hang", nil)
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+// rotatingCredentials hands out "old" until refreshed, then "new".
+type rotatingCredentials struct {
+	token     string
+	refreshes int
+}
+
+func (r *rotatingCredentials) Token(ctx context.Context) (string, error) {
+	return r.token, nil
+}
+
+func (r *rotatingCredentials) Refresh(ctx context.Context, stale string) (string, error) {
+	r.refreshes++
+	r.token = "new"
+	return r.token, nil
+}
+
+func TestClient_RefreshesOnUnauthorized(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	creds := &rotatingCredentials{token: "old"}
+	client := New(Config{BaseURL: server.URL, Credentials: creds})
+
+	if err := client.Put(context.Background(), "/resource", map[string]string{"a": "b"}, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if creds.refreshes != 1 || requests != 2 {
+		t.Errorf("Expected 1 refresh and 2 requests, got %d and %d", creds.refreshes, requests)
+	}
+}
+
+func TestClient_NoRefreshForStaticToken(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, AuthToken: "static"})
+
+	if err := client.Get(context.Background(), "/resource", nil); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request, got %d", requests)
+	}
+}
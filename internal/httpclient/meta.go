@@ -0,0 +1,115 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta describes the last response received for a call made with a
+// context returned by WithResponseMeta.
+type ResponseMeta struct {
+	StatusCode int `json:"statusCode"`
+
+	// Cached is set when the response was served from the response cache
+	// without contacting the API
+	Cached bool `json:"cached"`
+
+	// Count, Offset and Limit are the pagination metadata of list
+	// responses; they are zero for other responses
+	Count  int `json:"count"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+
+	RateLimit RateLimitState `json:"rateLimit"`
+
+	// RequestID is the X-Request-ID the request was sent with, and
+	// ServerRequestID the correlation ID the API reported, if any
+	RequestID       string `json:"requestId,omitempty"`
+	ServerRequestID string `json:"serverRequestId,omitempty"`
+}
+
+// RateLimitState is the API rate limit reported in the X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset response headers. Fields are
+// zero when the API did not report them.
+type RateLimitState struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a context that records the metadata of the
+// responses received for calls made with it in meta. When a call sends
+// several requests, such as paging or read-back, meta describes the last
+// one. meta must not be shared by concurrent calls.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// responseMeta returns the ResponseMeta registered with ctx, or nil.
+func responseMeta(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	return meta
+}
+
+type captureResponseKey struct{}
+
+// WithCaptureResponse returns a context that stores the last HTTP response
+// received for calls made with it in *resp. The stored response has the
+// status and headers of the original; its body is read by the client and
+// replaced by http.NoBody. Responses served from the response cache
+// without contacting the API are not stored.
+func WithCaptureResponse(ctx context.Context, resp **http.Response) context.Context {
+	return context.WithValue(ctx, captureResponseKey{}, resp)
+}
+
+// observe stores resp for WithCaptureResponse and records it in the
+// ResponseMeta of ctx, which it returns.
+func observe(ctx context.Context, resp *http.Response) *ResponseMeta {
+	if dst, _ := ctx.Value(captureResponseKey{}).(**http.Response); dst != nil {
+		captured := *resp
+		captured.Body = http.NoBody
+		*dst = &captured
+	}
+	meta := responseMeta(ctx)
+	if meta != nil {
+		meta.recordResponse(resp)
+	}
+	return meta
+}
+
+// recordResponse resets meta to the status and rate limit of resp.
+func (m *ResponseMeta) recordResponse(resp *http.Response) {
+	*m = ResponseMeta{StatusCode: resp.StatusCode, RequestID: sentRequestID(resp), ServerRequestID: serverRequestID(resp)}
+	m.RateLimit.Limit, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	m.RateLimit.Remaining, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && reset > 0 {
+		m.RateLimit.Reset = time.Unix(reset, 0)
+	}
+}
+
+// recordPagination sets the pagination fields from the "meta" object of a
+// list response body. Bodies without one leave them zero.
+func (m *ResponseMeta) recordPagination(body []byte) {
+	var page struct {
+		Meta *pageMeta `json:"meta"`
+	}
+	if json.Unmarshal(body, &page) == nil && page.Meta != nil {
+		m.setPagination(*page.Meta)
+	}
+}
+
+func (m *ResponseMeta) setPagination(p pageMeta) {
+	m.Count, m.Offset, m.Limit = p.Count, p.Offset, p.Limit
+}
+
+// pageMeta is the "meta" object of list responses.
+type pageMeta struct {
+	Count  int `json:"count"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
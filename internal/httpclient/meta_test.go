@@ -0,0 +1,96 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_ResponseMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.Write([]byte(`{"meta":{"count":250,"offset":100,"limit":50},"data":[{"id":"a"},{"id":"b"}]}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+
+	var meta ResponseMeta
+	var out struct {
+		Data []struct{ ID string } `json:"data"`
+	}
+	if err := client.Get(WithResponseMeta(context.Background(), &meta), "/services", &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(out.Data) != 2 {
+		t.Errorf("Expected the body to be decoded, got %+v", out)
+	}
+	if meta.StatusCode != 200 || meta.Count != 250 || meta.Offset != 100 || meta.Limit != 50 {
+		t.Errorf("Unexpected meta %+v", meta)
+	}
+	if meta.RateLimit.Limit != 100 || meta.RateLimit.Remaining != 42 || !meta.RateLimit.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Unexpected rate limit %+v", meta.RateLimit)
+	}
+
+	var each ResponseMeta
+	n, err := client.GetEach(WithResponseMeta(context.Background(), &each), "/services", func(dec *json.Decoder) error {
+		var v json.RawMessage
+		return dec.Decode(&v)
+	})
+	if err != nil || n != 2 {
+		t.Fatalf("Expected 2 elements, got %d, %v", n, err)
+	}
+	if each.Count != 250 || each.RateLimit.Remaining != 42 {
+		t.Errorf("Unexpected meta from GetEach %+v", each)
+	}
+}
+
+func TestClient_ResponseMetaError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message":"slow down"}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+
+	meta := ResponseMeta{Count: 10}
+	if err := client.Get(WithResponseMeta(context.Background(), &meta), "/services", nil); err == nil {
+		t.Fatal("Expected error")
+	}
+	if meta.StatusCode != http.StatusTooManyRequests || meta.RateLimit.Remaining != 0 || meta.Count != 0 {
+		t.Errorf("Unexpected meta %+v", meta)
+	}
+}
+
+func TestClient_CaptureResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"svc-1"}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+
+	var resp *http.Response
+	var out struct{ ID string }
+	if err := client.Post(WithCaptureResponse(context.Background(), &resp), "/services", map[string]string{}, &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if out.ID != "svc-1" {
+		t.Errorf("Expected the body to be decoded, got %+v", out)
+	}
+	if resp == nil || resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Request-Id") != "req-123" {
+		t.Fatalf("Unexpected captured response %+v", resp)
+	}
+	if resp.Body != http.NoBody {
+		t.Error("Expected the captured body to be http.NoBody")
+	}
+}
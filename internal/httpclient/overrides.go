@@ -0,0 +1,98 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+type headersKey struct{}
+
+type baggageKey struct{}
+
+type accountKey struct{}
+
+// accountOverride is the account set with WithAccount.
+type accountOverride struct {
+	id          string
+	credentials CredentialsProvider
+}
+
+// WithHeaders returns a context whose requests carry the given headers in
+// addition to those of ctx. Headers the client sets itself, such as
+// Authorization, Content-Type, User-Agent and X-Request-ID, take precedence.
+func WithHeaders(ctx context.Context, header http.Header) context.Context {
+	merged := http.Header{}
+	if prev, ok := ctx.Value(headersKey{}).(http.Header); ok {
+		for name, values := range prev {
+			merged[name] = values
+		}
+	}
+	for name, values := range header {
+		merged[http.CanonicalHeaderKey(name)] = append([]string{}, values...)
+	}
+	return context.WithValue(ctx, headersKey{}, merged)
+}
+
+// WithBaggage returns a context whose requests carry the members in a W3C
+// baggage header, in addition to the members of ctx.
+func WithBaggage(ctx context.Context, members map[string]string) context.Context {
+	merged := make(map[string]string)
+	if prev, ok := ctx.Value(baggageKey{}).(map[string]string); ok {
+		for k, v := range prev {
+			merged[k] = v
+		}
+	}
+	for k, v := range members {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, baggageKey{}, merged)
+}
+
+// WithAccount returns a context whose requests are scheduled as account id
+// and, when credentials is non-nil, authenticated with credentials instead
+// of the client's. Responses of requests with their own credentials are
+// neither served from nor stored in the response cache.
+func WithAccount(ctx context.Context, id string, credentials CredentialsProvider) context.Context {
+	return context.WithValue(ctx, accountKey{}, accountOverride{id: id, credentials: credentials})
+}
+
+// accountFor returns the scheduler account of requests made with ctx.
+func (c *Client) accountFor(ctx context.Context) string {
+	if o, ok := ctx.Value(accountKey{}).(accountOverride); ok && o.id != "" {
+		return o.id
+	}
+	return c.account
+}
+
+// credentialsFor returns the credentials of requests made with ctx, and
+// whether they are the client's own.
+func (c *Client) credentialsFor(ctx context.Context) (CredentialsProvider, bool) {
+	if o, ok := ctx.Value(accountKey{}).(accountOverride); ok && o.credentials != nil {
+		return o.credentials, false
+	}
+	return c.credentials, true
+}
+
+// applyOverrides sets the headers and baggage of ctx on req.
+func applyOverrides(ctx context.Context, req *http.Request) {
+	if header, ok := ctx.Value(headersKey{}).(http.Header); ok {
+		for name, values := range header {
+			req.Header[name] = append([]string{}, values...)
+		}
+	}
+	if members, ok := ctx.Value(baggageKey{}).(map[string]string); ok && len(members) > 0 {
+		keys := make([]string, 0, len(members))
+		for k := range members {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, len(keys))
+		for i, k := range keys {
+			pairs[i] = k + "=" + url.PathEscape(members[k])
+		}
+		req.Header.Set("Baggage", strings.Join(pairs, ","))
+	}
+}
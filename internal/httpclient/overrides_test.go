@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestContextOverrides(t *testing.T) {
+	var got []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := New(Config{BaseURL: server.URL, AuthToken: "client-token", Cache: NewResponseCache(time.Minute)})
+
+	ctx := WithHeaders(context.Background(), http.Header{"x-tenant": {"t1"}, "Authorization": {"Bearer injected"}})
+	ctx = WithHeaders(ctx, http.Header{"X-Feature": {"beta"}})
+	ctx = WithBaggage(ctx, map[string]string{"tenant": "t1"})
+	ctx = WithBaggage(ctx, map[string]string{"user": "a b"})
+	if err := c.Get(ctx, "/services", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	r := got[0]
+	if r.Header.Get("X-Tenant") != "t1" || r.Header.Get("X-Feature") != "beta" {
+		t.Errorf("Expected the context headers, got %v", r.Header)
+	}
+	if r.Header.Get("Authorization") != "Bearer client-token" {
+		t.Errorf("Expected the client's Authorization to win, got %q", r.Header.Get("Authorization"))
+	}
+	if r.Header.Get("Baggage") != "tenant=t1,user=a%20b" {
+		t.Errorf("Expected the merged baggage, got %q", r.Header.Get("Baggage"))
+	}
+
+	// The cached response of the client's account is not served to another
+	ctx = WithAccount(context.Background(), "child", staticToken("child-token"))
+	if err := c.Get(ctx, "/services", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got) != 2 || got[1].Header.Get("Authorization") != "Bearer child-token" {
+		t.Errorf("Expected a request with the account's credentials, got %d requests", len(got))
+	}
+	if err := c.Get(context.Background(), "/services", nil); err != nil || len(got) != 2 {
+		t.Errorf("Expected the client's own cache entry to be kept, got %d requests, %v", len(got), err)
+	}
+}
@@ -0,0 +1,93 @@
+package httpclient
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// EndpointPolicy overrides the client's timeout and retry policy for
+// requests matching Pattern.
+//
+// Pattern is an endpoint path relative to the base URL, optionally prefixed
+// by an HTTP method ("POST /certificates"). Segments are matched with
+// path.Match, and a trailing "/*" also matches everything below the prefix,
+// so "/reports/*" covers "/reports/usage/daily".
+type EndpointPolicy struct {
+	Pattern string
+
+	// Timeout overrides the client timeout. Zero keeps it, negative disables it.
+	Timeout time.Duration
+
+	// Retry overrides the client retry policy
+	Retry *RetryPolicy
+
+	// NoRetry disables retries for matching requests
+	NoRetry bool
+}
+
+// matches reports whether the policy applies to method and endpoint.
+func (p *EndpointPolicy) matches(method, endpoint string) bool {
+	pattern := strings.TrimSpace(p.Pattern)
+	if i := strings.IndexByte(pattern, ' '); i > 0 {
+		if !strings.EqualFold(pattern[:i], method) {
+			return false
+		}
+		pattern = strings.TrimSpace(pattern[i+1:])
+	}
+
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		endpoint = endpoint[:i]
+	}
+	want := splitPath(pattern)
+	got := splitPath(endpoint)
+
+	// A trailing "*" segment matches one or more remaining segments
+	if n := len(want); n > 0 && want[n-1] == "*" {
+		if len(got) < n {
+			return false
+		}
+		got = got[:n-1]
+		want = want[:n-1]
+	}
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if ok, _ := path.Match(want[i], got[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// policyFor returns the timeout and retry policy for a request.
+// The first matching endpoint policy wins.
+func (c *Client) policyFor(method, endpoint string) (time.Duration, *RetryPolicy) {
+	timeout, retry := c.timeout, c.retry
+	for i := range c.policies {
+		p := &c.policies[i]
+		if !p.matches(method, endpoint) {
+			continue
+		}
+		if p.Timeout != 0 {
+			timeout = p.Timeout
+		}
+		if p.Retry != nil {
+			retry = p.Retry
+		}
+		if p.NoRetry {
+			retry = nil
+		}
+		break
+	}
+	return timeout, retry
+}
@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEndpointPolicy_Matches(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		method   string
+		endpoint string
+		want     bool
+	}{
+		{"/reports/*", "GET", "/reports/usage", true},
+		{"/reports/*", "GET", "/reports/usage/daily?from=1", true},
+		{"/reports/*", "GET", "/reports", false},
+		{"/services/*/options", "GET", "/services/svc-1/options", true},
+		{"/services/*/options", "GET", "/services/svc-1/options/metadata", false},
+		{"POST /certificates", "POST", "/certificates", true},
+		{"POST /certificates", "DELETE", "/certificates", false},
+		{"certificates", "GET", "/certificates?limit=10", true},
+	}
+
+	for _, tt := range tests {
+		p := EndpointPolicy{Pattern: tt.pattern}
+		if got := p.matches(tt.method, tt.endpoint); got != tt.want {
+			t.Errorf("%q matches %s %s: expected %v, got %v", tt.pattern, tt.method, tt.endpoint, tt.want, got)
+		}
+	}
+}
+
+func TestClient_EndpointPolicyOverrides(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path]++
+		if r.URL.Path == "/reports/usage" {
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New(Config{
+		BaseURL: server.URL,
+		Timeout: 20 * time.Millisecond,
+		Retry:   &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryPost: true},
+		EndpointPolicies: []EndpointPolicy{
+			{Pattern: "/reports/*", Timeout: time.Second},
+			{Pattern: "POST /certificates", NoRetry: true},
+		},
+	})
+
+	if err := client.Get(context.Background(), "/reports/usage", nil); err != nil {
+		t.Errorf("Expected longer timeout for reports, got %v", err)
+	}
+
+	client.Post(context.Background(), "/certificates", struct{}{}, nil)
+	client.Put(context.Background(), "/certificates/cert-1", struct{}{}, nil)
+
+	if got := requests["POST /certificates"]; got != 1 {
+		t.Errorf("Expected certificate upload not to be retried, got %d attempts", got)
+	}
+	if got := requests["PUT /certificates/cert-1"]; got != 3 {
+		t.Errorf("Expected other requests to use the client retry policy, got %d attempts", got)
+	}
+}
@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ProxyURL returns a TransportOptions.Proxy function sending requests
+// through proxyURL, which may use the http, https, socks5 or socks5h
+// scheme. Requests to hosts matching noProxy are sent directly; when
+// noProxy is empty, the NO_PROXY environment variable is used instead.
+//
+// noProxy entries are host names, which also match their subdomains, IP
+// addresses, CIDR ranges or "*" for every host, each optionally with a
+// port. An invalid proxyURL fails every request.
+func ProxyURL(proxyURL string, noProxy []string) func(*http.Request) (*url.URL, error) {
+	proxy, err := url.Parse(proxyURL)
+	if err == nil && (proxy.Scheme == "" || proxy.Host == "") {
+		err = fmt.Errorf("missing scheme or host")
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+		return func(*http.Request) (*url.URL, error) { return nil, err }
+	}
+
+	if len(noProxy) == 0 {
+		env := os.Getenv("NO_PROXY")
+		if env == "" {
+			env = os.Getenv("no_proxy")
+		}
+		noProxy = strings.Split(env, ",")
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+		return proxy, nil
+	}
+}
+
+// bypassProxy reports whether u matches one of the noProxy entries.
+func bypassProxy(u *url.URL, noProxy []string) bool {
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+	ip := net.ParseIP(host)
+
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			if p != port {
+				continue
+			}
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, "*")
+		if ip != nil {
+			if entryIP := net.ParseIP(entry); entryIP != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter decides when a request attempt may start. Implementations can
+// coordinate several processes, for example through Redis or a token
+// service, to keep a fleet of workers under an account-wide limit.
+type Limiter interface {
+	// Wait blocks until the attempt may start or ctx ends. An error fails
+	// the request without sending it.
+	Wait(ctx context.Context) error
+}
+
+// LimiterFunc adapts a function to the Limiter interface.
+type LimiterFunc func(ctx context.Context) error
+
+// Wait calls f(ctx).
+func (f LimiterFunc) Wait(ctx context.Context) error {
+	return f(ctx)
+}
+
+// RateLimiter is a token bucket limiting the rate at which requests are
+// started. Tokens are added at the configured rate up to the burst size, and
+// every attempt of a request, retries included, takes one.
+type RateLimiter struct {
+	rate  float64 // tokens per second
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rps requests per second on
+// average and bursts of up to burst requests. The bucket starts full. A
+// burst below one allows one request at a time.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{rate: rps, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Wait blocks until a token is available or ctx ends. A limiter with a
+// non-positive rate never blocks.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+	// Take the token now, possibly going into debt, so that waiters are
+	// served in order without polling
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		// Give the unused token back
+		l.mu.Lock()
+		l.refill(time.Now())
+		l.tokens++
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// refill adds the tokens accumulated since the last call. It must be called
+// with mu held.
+func (l *RateLimiter) refill(now time.Time) {
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+}
@@ -0,0 +1,131 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_BurstThenRate(t *testing.T) {
+	l := NewRateLimiter(100, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("Expected the burst to pass without waiting, took %s", elapsed)
+	}
+
+	// 5 more at 100/s take about 50ms
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Errorf("Expected about 50ms for requests over the burst, took %s", elapsed)
+	}
+}
+
+func TestRateLimiter_CanceledWaitReturnsToken(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.1 {
+		t.Errorf("Expected the canceled wait to return its token, bucket at %f", tokens)
+	}
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	var l *RateLimiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("Expected nil limiter not to block, got %v", err)
+	}
+	if err := NewRateLimiter(0, 0).Wait(context.Background()); err != nil {
+		t.Errorf("Expected zero rate not to block, got %v", err)
+	}
+}
+
+func TestClient_RateLimitsRetries(t *testing.T) {
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		if len(times) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{
+		BaseURL:     server.URL,
+		Retry:       &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Microsecond, MaxDelay: time.Microsecond},
+		RateLimiter: NewRateLimiter(20, 1),
+	})
+
+	if err := client.Get(context.Background(), "/services", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(times) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(times))
+	}
+	if gap := times[2].Sub(times[0]); gap < 80*time.Millisecond {
+		t.Errorf("Expected attempts spaced by the rate limit, 3 attempts took %s", gap)
+	}
+}
+
+func TestClient_Limiter(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var acquired int
+	var fail error
+	limiter := LimiterFunc(func(ctx context.Context) error {
+		acquired++
+		return fail
+	})
+	breaker := NewCircuitBreaker(1, time.Minute)
+	client := New(Config{BaseURL: server.URL, Limiter: limiter, CircuitBreaker: breaker})
+
+	if err := client.Get(context.Background(), "/services", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if acquired != 1 || requests != 1 {
+		t.Errorf("Expected one acquisition and request, got %d and %d", acquired, requests)
+	}
+
+	fail = errors.New("token service unavailable")
+	err := client.Get(context.Background(), "/services", nil)
+	if !errors.Is(err, fail) {
+		t.Fatalf("Expected the limiter error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected no request without a token, got %d", requests)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Error("Expected limiter failures not to open the circuit breaker")
+	}
+}
@@ -0,0 +1,51 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// ReadBackError is returned when a write succeeded but reading the resource
+// back failed. The write has been applied; retrying it may not be safe.
+type ReadBackError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *ReadBackError) Error() string {
+	return fmt.Sprintf("write succeeded but reading back %s failed: %v", e.Endpoint, e.Err)
+}
+
+// Unwrap returns the error of the read-back request.
+func (e *ReadBackError) Unwrap() error {
+	return e.Err
+}
+
+// ReadBack replaces the value out points to with the resource at endpoint
+// after a successful write, when read-after-write is enabled. It does
+// nothing otherwise, in dry-run mode and for endpoints with an empty path
+// segment, as built from a write response without an ID.
+func (c *Client) ReadBack(ctx context.Context, endpoint string, out interface{}) error {
+	if !c.readAfterWrite || c.dryRun(ctx, http.MethodPut) {
+		return nil
+	}
+	p := endpoint
+	if i := strings.IndexByte(p, '?'); i >= 0 {
+		p = p[:i]
+	}
+	if strings.HasSuffix(p, "/") || strings.Contains(p, "//") {
+		return nil
+	}
+
+	// Decode into a fresh value so that fields missing from the GET
+	// response do not survive from the write response
+	fresh := reflect.New(reflect.TypeOf(out).Elem())
+	if err := c.Get(ctx, endpoint, fresh.Interface()); err != nil {
+		return &ReadBackError{Endpoint: endpoint, Err: err}
+	}
+	reflect.ValueOf(out).Elem().Set(fresh.Elem())
+	return nil
+}
@@ -0,0 +1,61 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ReadBack(t *testing.T) {
+	var gets int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if r.URL.Path == "/services/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"name":"server"}`))
+	}))
+	defer server.Close()
+
+	type resource struct {
+		Name  string `json:"name"`
+		Extra string `json:"extra"`
+	}
+	ctx := context.Background()
+
+	disabled := New(Config{BaseURL: server.URL})
+	out := resource{Name: "written"}
+	if err := disabled.ReadBack(ctx, "/services/svc-1", &out); err != nil || out.Name != "written" || gets != 0 {
+		t.Errorf("Expected no read-back when disabled, got %+v after %d requests", out, gets)
+	}
+
+	client := New(Config{BaseURL: server.URL, ReadAfterWrite: true})
+	out = resource{Name: "written", Extra: "stale"}
+	if err := client.ReadBack(ctx, "/services/svc-1", &out); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if out.Name != "server" || out.Extra != "" {
+		t.Errorf("Expected the server state only, got %+v", out)
+	}
+
+	gets = 0
+	if err := client.ReadBack(ctx, "/services/", &out); err != nil || gets != 0 {
+		t.Errorf("Expected endpoints without an ID to be skipped, got %v after %d requests", err, gets)
+	}
+	if err := client.ReadBack(WithDryRun(ctx), "/services/svc-1", &out); err != nil || gets != 0 {
+		t.Errorf("Expected no read-back in dry-run mode, got %v after %d requests", err, gets)
+	}
+
+	err := client.ReadBack(ctx, "/services/broken", &out)
+	var rbErr *ReadBackError
+	if !errors.As(err, &rbErr) || rbErr.Endpoint != "/services/broken" {
+		t.Fatalf("Expected ReadBackError, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the read error to be wrapped, got %v", err)
+	}
+}
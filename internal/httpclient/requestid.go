@@ -0,0 +1,58 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// HeaderRequestID carries the ID of each request, so it can be quoted in
+// support tickets and matched with the API's logs.
+const HeaderRequestID = "X-Request-ID"
+
+// serverRequestIDHeaders are the response headers the API may report its
+// own correlation ID in, in order of preference.
+var serverRequestIDHeaders = []string{"X-Correlation-ID", "X-Amzn-RequestId", HeaderRequestID}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose requests are sent with id in the
+// X-Request-ID header instead of a generated one.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set with WithRequestID, or
+// empty.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ensureRequestID returns ctx with a generated request ID unless it has
+// one, so all attempts of a call share the ID.
+func ensureRequestID(ctx context.Context) context.Context {
+	if RequestIDFromContext(ctx) != "" {
+		return ctx
+	}
+	return WithRequestID(ctx, uuid.NewString())
+}
+
+// serverRequestID returns the correlation ID the API reported in resp.
+func serverRequestID(resp *http.Response) string {
+	for _, name := range serverRequestIDHeaders {
+		if id := resp.Header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// sentRequestID returns the request ID sent with the request of resp.
+func sentRequestID(resp *http.Response) string {
+	if resp.Request == nil {
+		return ""
+	}
+	return resp.Request.Header.Get(HeaderRequestID)
+}
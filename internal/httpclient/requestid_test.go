@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(HeaderRequestID))
+		w.Header().Set("X-Correlation-ID", "srv-1")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := New(Config{BaseURL: server.URL, AuthToken: "token", Retry: &RetryPolicy{MaxAttempts: 2}})
+
+	var meta ResponseMeta
+	err := c.Get(WithResponseMeta(context.Background(), &meta), "/services", nil)
+	if len(ids) != 2 || ids[0] == "" || ids[0] != ids[1] {
+		t.Fatalf("Expected both attempts to share a generated ID, got %q", ids)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != ids[0] || apiErr.ServerRequestID != "srv-1" {
+		t.Fatalf("Expected the request IDs in the error, got %v", err)
+	}
+	if !strings.Contains(apiErr.Error(), ids[0]) || !strings.Contains(apiErr.Error(), "srv-1") {
+		t.Errorf("Expected the IDs in the message, got %q", apiErr.Error())
+	}
+	if meta.RequestID != ids[0] || meta.ServerRequestID != "srv-1" {
+		t.Errorf("Expected the IDs in the metadata, got %+v", meta)
+	}
+
+	ids = nil
+	c.Get(WithRequestID(context.Background(), "req-42"), "/services", nil)
+	c.Get(context.Background(), "/services", nil)
+	if ids[0] != "req-42" || ids[2] == "req-42" || ids[2] == "" {
+		t.Errorf("Expected the context ID to be used once, got %q", ids)
+	}
+}
@@ -0,0 +1,162 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/errcode"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry; later retries back off
+	// exponentially up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// RetryPost also retries POST requests, which are not idempotent
+	RetryPost bool
+}
+
+// DefaultRetryPolicy returns a policy of 3 attempts backing off from 500ms to 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second}
+}
+
+// Attempt records a single failed request attempt.
+type Attempt struct {
+	// Time is when the attempt started
+	Time time.Time
+
+	// StatusCode is the HTTP status, or 0 when no response was received
+	StatusCode int
+
+	// Err is the error of the attempt
+	Err error
+
+	// Wait is the delay before the next attempt; zero for the last one
+	Wait time.Duration
+}
+
+// RetryExhaustedError is returned when every attempt allowed by the retry
+// policy failed. It unwraps to the last attempt's error.
+type RetryExhaustedError struct {
+	Method   string
+	Endpoint string
+	Attempts []Attempt
+}
+
+func (e *RetryExhaustedError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s failed after %d attempts", e.Method, e.Endpoint, len(e.Attempts))
+	for i, a := range e.Attempts {
+		fmt.Fprintf(&b, "; #%d at %s", i+1, a.Time.Format(time.RFC3339Nano))
+		if a.StatusCode != 0 {
+			fmt.Fprintf(&b, " status %d", a.StatusCode)
+		} else {
+			fmt.Fprintf(&b, " %v", a.Err)
+		}
+		if a.Wait > 0 {
+			fmt.Fprintf(&b, ", waited %s", a.Wait)
+		}
+	}
+	return b.String()
+}
+
+// MessageKey returns "retry_exhausted" for message catalogs.
+func (e *RetryExhaustedError) MessageKey() string {
+	return errcode.RetryExhausted
+}
+
+// MessageParams returns the method, endpoint, number of attempts and the
+// last attempt's error for message catalogs.
+func (e *RetryExhaustedError) MessageParams() map[string]string {
+	params := map[string]string{
+		"method":   e.Method,
+		"endpoint": e.Endpoint,
+		"attempts": strconv.Itoa(len(e.Attempts)),
+		"error":    "",
+	}
+	if last := e.Unwrap(); last != nil {
+		params["error"] = last.Error()
+	}
+	return params
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryExhaustedError) Unwrap() error {
+	if len(e.Attempts) == 0 {
+		return nil
+	}
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+// retryable reports whether a request that failed with err may be retried.
+// Context errors and local errors, such as responses that fail to decode,
+// are never retried; network errors and 429, 502, 503 and 504 responses
+// are.
+func (p *RetryPolicy) retryable(method string, err error) bool {
+	if method == http.MethodPost && !p.RetryPost {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || isLocal(err) {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// Failed before a response was received
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns the wait before retry n (0-based), honouring a Retry-After
+// header on the failed response.
+func (p *RetryPolicy) delay(n int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Header != nil {
+		if secs, convErr := strconv.Atoi(apiErr.Header.Get("Retry-After")); convErr == nil {
+			return p.capDelay(time.Duration(secs) * time.Second)
+		}
+	}
+
+	d := p.BaseDelay << n
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	// Equal jitter keeps at least half the backoff while spreading retries
+	if d > 1 {
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+	return d
+}
+
+func (p *RetryPolicy) capDelay(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// statusCode returns the HTTP status carried by err, or 0.
+func statusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
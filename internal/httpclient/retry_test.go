@@ -0,0 +1,163 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_RetriesUntilSuccess(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}})
+
+	if err := client.Get(context.Background(), "/services", nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestClient_RetryExhausted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}})
+
+	err := client.Get(context.Background(), "/services", nil)
+
+	var exhausted *RetryExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected RetryExhaustedError, got %v", err)
+	}
+	if len(exhausted.Attempts) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(exhausted.Attempts))
+	}
+	for i, a := range exhausted.Attempts {
+		if a.StatusCode != http.StatusGatewayTimeout || a.Time.IsZero() {
+			t.Errorf("Attempt %d: unexpected record %+v", i, a)
+		}
+		if last := i == len(exhausted.Attempts)-1; last != (a.Wait == 0) {
+			t.Errorf("Attempt %d: unexpected wait %s", i, a.Wait)
+		}
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("Expected to unwrap to the last APIError, got %v", apiErr)
+	}
+	if !strings.Contains(err.Error(), "failed after 3 attempts") {
+		t.Errorf("Unexpected message %s", err)
+	}
+}
+
+func TestClient_NoRetryForClientErrorsOrPost(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Retry: &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}})
+
+	if err := client.Get(context.Background(), "/services", nil); err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if err := client.Post(context.Background(), "/services", struct{}{}, nil); err == nil {
+		t.Error("Expected error, got nil")
+	}
+	if requests != 2 {
+		t.Errorf("Expected a single attempt per request, got %d requests", requests)
+	}
+}
+
+// failingCredentials fails every token request.
+type failingCredentials struct{}
+
+func (failingCredentials) Token(ctx context.Context) (string, error) {
+	return "", errors.New("vault unavailable")
+}
+
+func TestClient_NoRetryForLocalErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"_id":"svc-1","edgeCompute":true}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(1, time.Minute)
+	retry := &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	client := New(Config{BaseURL: server.URL, StrictDecoding: true, Retry: retry, CircuitBreaker: breaker})
+
+	var out struct {
+		ID string `json:"_id"`
+	}
+	err := client.Put(context.Background(), "/services/svc-1", map[string]string{"description": "x"}, &out)
+	if !errors.Is(err, ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField, got %v", err)
+	}
+	var exhausted *RetryExhaustedError
+	if errors.As(err, &exhausted) || requests != 1 {
+		t.Errorf("Expected the write to be sent once, got %d requests and %v", requests, err)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected decode errors not to open the breaker, got %s", breaker.State())
+	}
+
+	client = New(Config{BaseURL: server.URL, Credentials: failingCredentials{}, Retry: retry, CircuitBreaker: breaker})
+	err = client.Get(context.Background(), "/services", nil)
+	if err == nil || !strings.Contains(err.Error(), "vault unavailable") || errors.As(err, &exhausted) {
+		t.Fatalf("Expected the credentials error without retries, got %v", err)
+	}
+	if requests != 1 || breaker.State() != CircuitClosed {
+		t.Errorf("Expected no requests and a closed breaker, got %d requests and %s", requests, breaker.State())
+	}
+}
+
+func TestClient_CanceledDuringBackoff(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Retry: &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.Get(ctx, "/services", nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected to return promptly, took %s", elapsed)
+	}
+	if requests != 1 {
+		t.Errorf("Expected a single request, got %d", requests)
+	}
+}
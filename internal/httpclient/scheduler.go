@@ -0,0 +1,129 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+)
+
+// Scheduler caps the number of concurrent requests across every client that
+// shares it, and hands free slots to waiting requests round-robin by account,
+// so a single busy account cannot starve the others.
+type Scheduler struct {
+	limit int
+
+	mu      sync.Mutex
+	active  int
+	waiting map[string][]chan struct{}
+	ring    []string // accounts with waiting requests, in round-robin order
+	next    int
+}
+
+// NewScheduler returns a Scheduler allowing limit concurrent requests.
+// A limit below one allows a single request at a time.
+func NewScheduler(limit int) *Scheduler {
+	if limit < 1 {
+		limit = 1
+	}
+	return &Scheduler{limit: limit, waiting: make(map[string][]chan struct{})}
+}
+
+// Active returns the number of requests currently holding a slot.
+func (s *Scheduler) Active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active
+}
+
+// Waiting returns the number of requests waiting for a slot, per account.
+func (s *Scheduler) Waiting() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	waiting := make(map[string]int, len(s.waiting))
+	for account, queue := range s.waiting {
+		waiting[account] = len(queue)
+	}
+	return waiting
+}
+
+// acquire blocks until the account may send a request or ctx ends.
+func (s *Scheduler) acquire(ctx context.Context, account string) error {
+	s.mu.Lock()
+	if s.active < s.limit && len(s.ring) == 0 {
+		s.active++
+		s.mu.Unlock()
+		return nil
+	}
+	granted := make(chan struct{})
+	if len(s.waiting[account]) == 0 {
+		s.ring = append(s.ring, account)
+	}
+	s.waiting[account] = append(s.waiting[account], granted)
+	s.mu.Unlock()
+
+	select {
+	case <-granted:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		removed := s.remove(account, granted)
+		s.mu.Unlock()
+		if !removed {
+			// The slot was granted while ctx ended; hand it on
+			s.release()
+		}
+		return ctx.Err()
+	}
+}
+
+// release frees a slot, passing it to the next account in turn.
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ring) == 0 {
+		s.active--
+		return
+	}
+
+	if s.next >= len(s.ring) {
+		s.next = 0
+	}
+	account := s.ring[s.next]
+	queue := s.waiting[account]
+	granted := queue[0]
+	if len(queue) == 1 {
+		delete(s.waiting, account)
+		s.ring = append(s.ring[:s.next], s.ring[s.next+1:]...)
+	} else {
+		s.waiting[account] = queue[1:]
+		s.next++
+	}
+	close(granted)
+}
+
+// remove drops a waiting request, reporting whether it was still waiting.
+func (s *Scheduler) remove(account string, granted chan struct{}) bool {
+	queue := s.waiting[account]
+	for i, ch := range queue {
+		if ch != granted {
+			continue
+		}
+		queue = append(queue[:i], queue[i+1:]...)
+		if len(queue) > 0 {
+			s.waiting[account] = queue
+			return true
+		}
+		delete(s.waiting, account)
+		for j, a := range s.ring {
+			if a == account {
+				s.ring = append(s.ring[:j], s.ring[j+1:]...)
+				if j < s.next {
+					s.next--
+				}
+				break
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_InterleavesAccounts(t *testing.T) {
+	s := NewScheduler(1)
+	ctx := context.Background()
+	if err := s.acquire(ctx, "big"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	enqueue := func(account string) {
+		queued := s.Waiting()[account]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.acquire(ctx, account)
+			mu.Lock()
+			order = append(order, account)
+			mu.Unlock()
+			s.release()
+		}()
+		// Wait until queued so the arrival order is deterministic
+		for s.Waiting()[account] == queued {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		enqueue("big")
+	}
+	enqueue("small")
+
+	s.release()
+	wg.Wait()
+
+	if len(order) != 4 || order[1] != "small" {
+		t.Errorf("Expected small account served second, got %v", order)
+	}
+	if s.Active() != 0 {
+		t.Errorf("Expected no active requests, got %d", s.Active())
+	}
+}
+
+func TestScheduler_CancelWhileWaiting(t *testing.T) {
+	s := NewScheduler(1)
+	s.acquire(context.Background(), "a")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.acquire(ctx, "b"); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if len(s.Waiting()) != 0 {
+		t.Errorf("Expected canceled request to leave the queue, got %v", s.Waiting())
+	}
+
+	s.release()
+	if s.Active() != 0 {
+		t.Errorf("Expected slot to be freed, got %d active", s.Active())
+	}
+}
+
+func TestClient_SchedulerCapsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	scheduler := NewScheduler(2)
+	clients := []*Client{
+		New(Config{BaseURL: server.URL, Scheduler: scheduler, Account: "a"}),
+		New(Config{BaseURL: server.URL, Scheduler: scheduler, Account: "b"}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			c.Get(context.Background(), "/", nil)
+		}(clients[i%2])
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
@@ -0,0 +1,111 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// GetStream performs a GET request and returns the response body unread,
+// for downloads too large to buffer. The caller must close the body.
+//
+// The client timeout bounds the wait for the response headers only, so the
+// download itself is limited by ctx alone. Failures before the body is
+// returned are retried like other requests; the response is never cached.
+func (c *Client) GetStream(ctx context.Context, endpoint string, header http.Header) (io.ReadCloser, error) {
+	ctx = ensureRequestID(ctx)
+	var body io.ReadCloser
+	err := c.attempt(ctx, http.MethodGet, endpoint, func(timeout time.Duration) error {
+		var err error
+		body, err = c.streamOnce(ctx, timeout, endpoint, header)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// streamOnce performs a single GetStream attempt.
+func (c *Client) streamOnce(ctx context.Context, timeout time.Duration, endpoint string, header http.Header) (io.ReadCloser, error) {
+	reqCtx, cancel := context.WithCancelCause(ctx)
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, func() { cancel(context.DeadlineExceeded) })
+	}
+	fail := func(err error) (io.ReadCloser, error) {
+		if timer != nil {
+			timer.Stop()
+		}
+		cancel(nil)
+		return nil, err
+	}
+
+	credentials, _ := c.credentialsFor(ctx)
+	token, err := credentials.Token(reqCtx)
+	if err != nil {
+		return fail(streamContextError(ctx, reqCtx, fmt.Errorf("failed to get credentials: %w", err)))
+	}
+
+	resp, err := c.send(reqCtx, http.MethodGet, endpoint, nil, false, token, header)
+	if err != nil {
+		return fail(streamContextError(ctx, reqCtx, err))
+	}
+
+	// A rejected token may have been rotated; refresh once and replay
+	if rc, ok := credentials.(RefreshableCredentials); ok && resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+
+		token, err = rc.Refresh(reqCtx, token)
+		if err != nil {
+			return fail(streamContextError(ctx, reqCtx, fmt.Errorf("failed to refresh credentials: %w", err)))
+		}
+		resp, err = c.send(reqCtx, http.MethodGet, endpoint, nil, false, token, header)
+		if err != nil {
+			return fail(streamContextError(ctx, reqCtx, err))
+		}
+	}
+
+	// The headers arrived; from here on only ctx bounds the download
+	if timer != nil && !timer.Stop() {
+		resp.Body.Close()
+		return fail(context.DeadlineExceeded)
+	}
+
+	observe(ctx, resp)
+	c.checkDeprecation(http.MethodGet, endpoint, resp)
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return fail(newAPIError(resp, body, time.Now()))
+	}
+
+	return &streamBody{ReadCloser: resp.Body, cancel: cancel}, nil
+}
+
+// streamBody releases the request context when the body is closed.
+type streamBody struct {
+	io.ReadCloser
+	cancel context.CancelCauseFunc
+}
+
+func (b *streamBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel(nil)
+	return err
+}
+
+// streamContextError is contextError for a request context canceled with a
+// cause, such as the header timeout.
+func streamContextError(ctx, reqCtx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if cause := context.Cause(reqCtx); cause != nil {
+		return cause
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_GetStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/gzip" {
+			t.Errorf("Expected Accept header override, got %s", r.Header.Get("Accept"))
+		}
+		w.Write([]byte("line 1\n"))
+		w.(http.Flusher).Flush()
+		// The body outlives the client timeout
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("line 2\n"))
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Timeout: 50 * time.Millisecond})
+	body, err := client.GetStream(context.Background(), "/logs", http.Header{"Accept": {"application/gzip"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Expected full body, got %v", err)
+	}
+	if string(data) != "line 1\nline 2\n" {
+		t.Errorf("Expected both lines, got %q", data)
+	}
+}
+
+func TestClient_GetStreamHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL, Timeout: 20 * time.Millisecond})
+	_, err := client.GetStream(context.Background(), "/logs", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestClient_GetStreamAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := New(Config{BaseURL: server.URL})
+	_, err := client.GetStream(context.Background(), "/logs", nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 APIError, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownField is matched by errors.Is when StrictDecoding rejects a
+// response with a field the SDK does not model.
+var ErrUnknownField = errors.New("unknown field in response")
+
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+var hasRawCache sync.Map // reflect.Type -> bool
+
+// unknownField wraps the unknown field errors of encoding/json in
+// ErrUnknownField and returns other errors unchanged.
+func unknownField(err error) error {
+	if err != nil && strings.Contains(err.Error(), "json: unknown field ") {
+		return fmt.Errorf("%w: %v", ErrUnknownField, err)
+	}
+	return err
+}
+
+// FillRaw sets the Raw fields of the structs in v, which data was decoded
+// into, to the members of their JSON object they have no field for, or to
+// nil when there are none. A Raw field is an exported json.RawMessage named
+// Raw and tagged `json:"-"`, directly in the struct or promoted from an
+// embedded one. Structs in map values are not filled.
+func FillRaw(data []byte, v interface{}) {
+	fillRaw(data, reflect.ValueOf(v))
+}
+
+func fillRaw(data []byte, v reflect.Value) {
+	if !v.IsValid() || !hasRaw(v.Type()) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			fillRaw(data, v.Elem())
+		}
+	case reflect.Struct:
+		fields := jsonFields(v.Type())
+		var members map[string]json.RawMessage
+		if fields == nil || json.Unmarshal(data, &members) != nil {
+			return
+		}
+		unknown := make(map[string]json.RawMessage)
+		for name, value := range members {
+			index, ok := fields[name]
+			if !ok {
+				index, ok = fields[strings.ToLower(name)]
+			}
+			if !ok {
+				unknown[name] = value
+				continue
+			}
+			fillRaw(value, v.FieldByIndex(index))
+		}
+		if index := rawField(v.Type()); index != nil && v.CanSet() {
+			var raw json.RawMessage
+			if len(unknown) > 0 {
+				raw, _ = json.Marshal(unknown)
+			}
+			v.FieldByIndex(index).Set(reflect.ValueOf(raw))
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			fillRaw(items[i], v.Index(i))
+		}
+	}
+}
+
+// rawField returns the index of the Raw field of the struct type t, or nil.
+func rawField(t reflect.Type) []int {
+	f, ok := t.FieldByName("Raw")
+	if !ok || f.Type != rawMessageType || f.Tag.Get("json") != "-" {
+		return nil
+	}
+	return f.Index
+}
+
+// hasRaw reports whether values of t can hold a Raw field.
+func hasRaw(t reflect.Type) bool {
+	if cached, ok := hasRawCache.Load(t); ok {
+		return cached.(bool)
+	}
+	// Results for the types met along the way may depend on types still
+	// being walked, so only the result for t is cached
+	found := walkRaw(t, make(map[reflect.Type]bool))
+	hasRawCache.Store(t, found)
+	return found
+}
+
+// walkRaw reports whether values of t can hold a Raw field. Types in seen
+// were walked already, or are being walked; a Raw field reachable from them
+// is found on that walk.
+func walkRaw(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if cached, ok := hasRawCache.Load(t); ok {
+		return cached.(bool)
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return walkRaw(t.Elem(), seen)
+	case reflect.Struct:
+		if rawField(t) != nil {
+			return true
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if f := t.Field(i); (f.IsExported() || f.Anonymous) && walkRaw(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type rawItem struct {
+	ID  string          `json:"_id"`
+	Raw json.RawMessage `json:"-"`
+}
+
+type rawList struct {
+	Data []rawItem `json:"data"`
+}
+
+func TestStrictDecoding(t *testing.T) {
+	body := `{"data":[{"_id":"a","newField":1},{"_id":"b"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	for _, lowMemory := range []bool{false, true} {
+		lenient := New(Config{BaseURL: server.URL, LowMemoryDecoding: lowMemory})
+		var list rawList
+		if err := lenient.Get(context.Background(), "/items", &list); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if string(list.Data[0].Raw) != `{"newField":1}` || list.Data[1].Raw != nil {
+			t.Errorf("Expected the unknown field in Raw (low memory %v), got %s and %s", lowMemory, list.Data[0].Raw, list.Data[1].Raw)
+		}
+
+		strict := New(Config{BaseURL: server.URL, LowMemoryDecoding: lowMemory, StrictDecoding: true})
+		if err := strict.Get(context.Background(), "/items", &rawList{}); !errors.Is(err, ErrUnknownField) {
+			t.Errorf("Expected ErrUnknownField (low memory %v), got %v", lowMemory, err)
+		}
+	}
+}
+
+func TestFillRaw_Embedded(t *testing.T) {
+	var v struct {
+		rawItem
+		Extra []string `json:"extra"`
+	}
+	data := []byte(`{"_id":"a","extra":["x"],"other":true}`)
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	FillRaw(data, &v)
+	if v.ID != "a" || len(v.Extra) != 1 || string(v.Raw) != `{"other":true}` {
+		t.Errorf("Expected the fields of the outer struct to be known, got %+v", v)
+	}
+}
+
+type rawTree struct {
+	Children []rawTree `json:"children"`
+	Items    []rawItem `json:"items"`
+}
+
+type rawGraph struct {
+	Next  *rawGraph `json:"next"`
+	Items []rawItem `json:"items"`
+}
+
+func TestHasRaw_RecursiveTypes(t *testing.T) {
+	if !hasRaw(reflect.TypeOf(rawTree{})) {
+		t.Error("Expected rawTree to hold Raw fields")
+	}
+	if !hasRaw(reflect.TypeOf([]rawTree{})) {
+		t.Error("Expected []rawTree met while walking rawTree to hold Raw fields")
+	}
+
+	var wg sync.WaitGroup
+	var missed atomic.Int32
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !hasRaw(reflect.TypeOf(rawGraph{})) {
+				missed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if missed.Load() != 0 {
+		t.Errorf("Expected concurrent first lookups to find the Raw fields, %d did not", missed.Load())
+	}
+}
@@ -0,0 +1,78 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportOptions tunes the connection pool of the HTTP transport. Zero
+// values keep the defaults of http.DefaultTransport.
+type TransportOptions struct {
+	// MaxIdleConns limits idle connections across all hosts
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost limits idle connections kept per host. Go's default
+	// of 2 makes most connections of a highly concurrent client short-lived.
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits connections per host, including active ones
+	MaxConnsPerHost int
+
+	// IdleConnTimeout closes connections idle for longer
+	IdleConnTimeout time.Duration
+
+	// KeepAlive is the interval of TCP keep-alive probes; negative disables them
+	KeepAlive time.Duration
+
+	// DisableKeepAlives opens a new connection for every request
+	DisableKeepAlives bool
+
+	// DisableHTTP2 restricts the transport to HTTP/1.1
+	DisableHTTP2 bool
+
+	// Proxy selects the proxy of each request, as http.Transport.Proxy;
+	// nil keeps http.ProxyFromEnvironment
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// TLSConfig configures TLS connections, e.g. custom root CAs, client
+	// certificates or the minimum version; nil uses the defaults
+	TLSConfig *tls.Config
+}
+
+// NewTransport returns a copy of http.DefaultTransport tuned with opts.
+func NewTransport(opts TransportOptions) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.MaxIdleConns > 0 {
+		t.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if opts.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = opts.MaxConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.KeepAlive != 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: opts.KeepAlive}
+		t.DialContext = dialer.DialContext
+	}
+	if opts.Proxy != nil {
+		t.Proxy = opts.Proxy
+	}
+	if opts.TLSConfig != nil {
+		t.TLSClientConfig = opts.TLSConfig.Clone()
+	}
+	t.DisableKeepAlives = opts.DisableKeepAlives
+	if opts.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto keeps HTTP/2 from being negotiated
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return t
+}
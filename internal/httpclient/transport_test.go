@@ -0,0 +1,43 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	def := http.DefaultTransport.(*http.Transport)
+
+	tr := NewTransport(TransportOptions{})
+	if tr == def {
+		t.Fatal("Expected a copy of the default transport")
+	}
+	if tr.MaxIdleConns != def.MaxIdleConns || !tr.ForceAttemptHTTP2 {
+		t.Errorf("Expected zero options to keep the defaults, got %+v", tr)
+	}
+
+	tr = NewTransport(TransportOptions{
+		MaxIdleConns:        200,
+		MaxIdleConnsPerHost: 50,
+		MaxConnsPerHost:     64,
+		IdleConnTimeout:     time.Minute,
+		KeepAlive:           -1,
+		DisableHTTP2:        true,
+	})
+	if tr.MaxIdleConns != 200 || tr.MaxIdleConnsPerHost != 50 || tr.MaxConnsPerHost != 64 || tr.IdleConnTimeout != time.Minute {
+		t.Errorf("Expected pool settings to be applied, got %+v", tr)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Error("Expected HTTP/2 to be disabled")
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS13}
+	tr = NewTransport(TransportOptions{TLSConfig: tlsConfig})
+	if tr.TLSClientConfig == tlsConfig || tr.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("Expected a copy of the TLS config, got %+v", tr.TLSClientConfig)
+	}
+	if def.MaxIdleConnsPerHost == 50 {
+		t.Error("Expected the default transport to be left untouched")
+	}
+}
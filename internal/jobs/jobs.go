@@ -0,0 +1,522 @@
+// Package jobs implements the in-process job queue the bulk helpers of the
+// API packages run their requests through. It is exported to users by
+// pkg/cachefly/jobs.
+package jobs
+
+import (
+	"container/heap"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when enqueueing onto a closed queue.
+var ErrClosed = errors.New("jobs: queue is closed")
+
+// Status is the state of a job.
+type Status string
+
+// Job states.
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Func is the work of a job.
+type Func func(ctx context.Context) error
+
+// Handler runs a named job from its payload.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Store persists jobs. Save is called with a snapshot of the job on every
+// state change; errors other than on enqueue are ignored, so the store never
+// stops the queue.
+type Store interface {
+	Save(ctx context.Context, job Job) error
+}
+
+// Job is a snapshot of a queued job.
+type Job struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name,omitempty"`
+	Priority    int       `json:"priority"`
+	Payload     []byte    `json:"payload,omitempty"`
+	MaxAttempts int       `json:"maxAttempts"`
+	Attempts    int       `json:"attempts"`
+	Status      Status    `json:"status"`
+	LastError   string    `json:"lastError,omitempty"`
+	EnqueuedAt  time.Time `json:"enqueuedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Options describes a job being enqueued.
+type Options struct {
+	// Name labels the job; for EnqueueNamed it selects the handler
+	Name string
+
+	// Priority orders pending jobs, highest first; equal priorities run in
+	// enqueue order
+	Priority int
+
+	// MaxAttempts overrides Config.MaxAttempts for this job
+	MaxAttempts int
+}
+
+// Config controls a Queue.
+type Config struct {
+	// Workers is the number of jobs run at once. Zero uses 4.
+	Workers int
+
+	// MaxAttempts is how often a failing job is run before it fails. Zero
+	// runs every job once.
+	MaxAttempts int
+
+	// RetryDelay is the wait before the first retry, doubled for each further
+	// retry. Zero uses one second.
+	RetryDelay time.Duration
+
+	// Retryable reports whether a failed job may be retried; nil retries
+	// every error except context cancellation
+	Retryable func(err error) bool
+
+	// Interval is the minimum time between starting two jobs; zero
+	// disables the rate limit
+	Interval time.Duration
+
+	// Store persists job state changes; nil keeps jobs in memory only
+	Store Store
+}
+
+// Queue runs jobs on a pool of workers.
+type Queue struct {
+	cfg Config
+
+	mu        sync.Mutex
+	handlers  map[string]Handler
+	pending   jobHeap
+	seq       int
+	closed    bool
+	ctx       context.Context
+	nextStart time.Time
+
+	notify  chan struct{}
+	closing chan struct{}
+	active  sync.WaitGroup // jobs not yet finished
+	workers sync.WaitGroup
+}
+
+// New returns a Queue. Jobs only run after Start.
+func New(cfg Config) *Queue {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 4
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = time.Second
+	}
+	return &Queue{
+		cfg:      cfg,
+		handlers: make(map[string]Handler),
+		notify:   make(chan struct{}, 1),
+		closing:  make(chan struct{}),
+	}
+}
+
+// Register makes handler available to EnqueueNamed and Restore under name.
+func (q *Queue) Register(name string, handler Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[name] = handler
+}
+
+// Start starts the workers. When ctx ends, running jobs see it canceled and
+// pending jobs are canceled.
+func (q *Queue) Start(ctx context.Context) {
+	q.mu.Lock()
+	q.ctx = ctx
+	q.mu.Unlock()
+
+	for i := 0; i < q.cfg.Workers; i++ {
+		q.workers.Add(1)
+		go q.work(ctx)
+	}
+}
+
+// Close stops accepting jobs, waits for every queued job to finish and
+// stops the workers. It must only be called after Start.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	q.active.Wait()
+	close(q.closing)
+	q.workers.Wait()
+}
+
+// Enqueue adds fn as a job.
+func (q *Queue) Enqueue(fn Func, opts Options) (*Handle, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("fn is required")
+	}
+	return q.enqueue(Job{Name: opts.Name, Priority: opts.Priority, MaxAttempts: opts.MaxAttempts}, fn)
+}
+
+// EnqueueNamed adds a job run by the handler registered under opts.Name
+// with payload. Unlike Enqueue, such jobs can be restored from a Store.
+func (q *Queue) EnqueueNamed(payload []byte, opts Options) (*Handle, error) {
+	fn, err := q.handlerFunc(opts.Name, payload)
+	if err != nil {
+		return nil, err
+	}
+	return q.enqueue(Job{Name: opts.Name, Priority: opts.Priority, MaxAttempts: opts.MaxAttempts, Payload: payload}, fn)
+}
+
+// Restore re-enqueues persisted named jobs that had not finished, keeping
+// their IDs and attempt counts. Finished jobs are skipped.
+func (q *Queue) Restore(jobs []Job) ([]*Handle, error) {
+	var handles []*Handle
+	for _, job := range jobs {
+		if job.Status != StatusPending && job.Status != StatusRunning {
+			continue
+		}
+		fn, err := q.handlerFunc(job.Name, job.Payload)
+		if err != nil {
+			return handles, fmt.Errorf("failed to restore job %s: %w", job.ID, err)
+		}
+		h, err := q.enqueue(job, fn)
+		if err != nil {
+			return handles, err
+		}
+		handles = append(handles, h)
+	}
+	return handles, nil
+}
+
+func (q *Queue) handlerFunc(name string, payload []byte) (Func, error) {
+	q.mu.Lock()
+	handler, ok := q.handlers[name]
+	q.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no handler registered for %q", name)
+	}
+	return func(ctx context.Context) error { return handler(ctx, payload) }, nil
+}
+
+func (q *Queue) enqueue(job Job, fn Func) (*Handle, error) {
+	now := time.Now()
+	if job.ID == "" {
+		job.ID = newID()
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = q.cfg.MaxAttempts
+	}
+	if job.EnqueuedAt.IsZero() {
+		job.EnqueuedAt = now
+	}
+	job.Status = StatusPending
+	job.UpdatedAt = now
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil, ErrClosed
+	}
+	q.mu.Unlock()
+
+	if q.cfg.Store != nil {
+		if err := q.cfg.Store.Save(context.Background(), job); err != nil {
+			return nil, fmt.Errorf("failed to persist job: %w", err)
+		}
+	}
+
+	// The job counts as active before the lock is released, so a Close
+	// that starts waiting afterwards waits for it
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		if q.cfg.Store != nil {
+			job.Status = StatusCanceled
+			job.UpdatedAt = time.Now()
+			_ = q.cfg.Store.Save(context.Background(), job)
+		}
+		return nil, ErrClosed
+	}
+	q.active.Add(1)
+	q.mu.Unlock()
+
+	item := &queued{job: job, fn: fn, done: make(chan struct{})}
+	q.push(item)
+	return &Handle{item: item, queue: q}, nil
+}
+
+func (q *Queue) push(item *queued) {
+	q.mu.Lock()
+	// Workers drain the queue when the start context ends; jobs arriving
+	// afterwards would never run
+	if q.ctx != nil && q.ctx.Err() != nil {
+		err := q.ctx.Err()
+		q.mu.Unlock()
+		q.finish(item, StatusCanceled, err)
+		return
+	}
+	q.seq++
+	item.seq = q.seq
+	heap.Push(&q.pending, item)
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *Queue) pop() *queued {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending.Len() == 0 {
+		return nil
+	}
+	return heap.Pop(&q.pending).(*queued)
+}
+
+func (q *Queue) work(ctx context.Context) {
+	defer q.workers.Done()
+	for {
+		if ctx.Err() != nil {
+			q.cancelPending(ctx.Err())
+			return
+		}
+
+		item := q.pop()
+		if item == nil {
+			select {
+			case <-ctx.Done():
+			case <-q.closing:
+				return
+			case <-q.notify:
+			}
+			continue
+		}
+
+		if err := q.waitTurn(ctx); err != nil {
+			q.finish(item, StatusCanceled, err)
+			continue
+		}
+		q.run(ctx, item)
+
+		// Let another idle worker pick up remaining jobs
+		select {
+		case q.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// waitTurn enforces the minimum interval between job starts.
+func (q *Queue) waitTurn(ctx context.Context) error {
+	if q.cfg.Interval <= 0 {
+		return nil
+	}
+	q.mu.Lock()
+	now := time.Now()
+	start := q.nextStart
+	if start.Before(now) {
+		start = now
+	}
+	q.nextStart = start.Add(q.cfg.Interval)
+	q.mu.Unlock()
+
+	wait := time.Until(start)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (q *Queue) run(ctx context.Context, item *queued) {
+	q.update(item, func(job *Job) {
+		job.Status = StatusRunning
+		job.Attempts++
+	})
+
+	err := item.fn(ctx)
+	if err == nil {
+		q.finish(item, StatusSucceeded, nil)
+		return
+	}
+	if ctx.Err() != nil {
+		q.finish(item, StatusCanceled, err)
+		return
+	}
+
+	job := item.snapshot()
+	if job.Attempts >= job.MaxAttempts || !q.retryable(err) {
+		q.finish(item, StatusFailed, err)
+		return
+	}
+
+	q.update(item, func(job *Job) {
+		job.Status = StatusPending
+		job.LastError = err.Error()
+	})
+	delay := q.cfg.RetryDelay << (job.Attempts - 1)
+	go func() {
+		// Cancel during the delay right away so Close does not wait it out
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			q.finish(item, StatusCanceled, ctx.Err())
+		case <-timer.C:
+			q.push(item)
+		}
+	}()
+}
+
+func (q *Queue) retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if q.cfg.Retryable != nil {
+		return q.cfg.Retryable(err)
+	}
+	return true
+}
+
+func (q *Queue) cancelPending(err error) {
+	for item := q.pop(); item != nil; item = q.pop() {
+		q.finish(item, StatusCanceled, err)
+	}
+}
+
+func (q *Queue) update(item *queued, fn func(job *Job)) {
+	item.mu.Lock()
+	fn(&item.job)
+	item.job.UpdatedAt = time.Now()
+	job := item.job
+	item.mu.Unlock()
+
+	if q.cfg.Store != nil {
+		q.cfg.Store.Save(context.Background(), job)
+	}
+}
+
+func (q *Queue) finish(item *queued, status Status, err error) {
+	q.update(item, func(job *Job) {
+		job.Status = status
+		if err != nil {
+			job.LastError = err.Error()
+		}
+	})
+	item.err = err
+	close(item.done)
+	q.active.Done()
+}
+
+// Handle tracks an enqueued job.
+type Handle struct {
+	item  *queued
+	queue *Queue
+}
+
+// ID returns the job ID.
+func (h *Handle) ID() string {
+	return h.item.snapshot().ID
+}
+
+// Job returns a snapshot of the job's current state.
+func (h *Handle) Job() Job {
+	return h.item.snapshot()
+}
+
+// Done is closed when the job has finished.
+func (h *Handle) Done() <-chan struct{} {
+	return h.item.done
+}
+
+// Wait blocks until the job finishes and returns its last error, or until
+// ctx ends.
+func (h *Handle) Wait(ctx context.Context) error {
+	select {
+	case <-h.item.done:
+		return h.item.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Err returns the job's last error once it has finished.
+func (h *Handle) Err() error {
+	select {
+	case <-h.item.done:
+		return h.item.err
+	default:
+		return nil
+	}
+}
+
+// queued is a job in the queue.
+type queued struct {
+	mu   sync.Mutex
+	job  Job
+	fn   Func
+	seq  int
+	done chan struct{}
+	err  error
+}
+
+func (item *queued) snapshot() Job {
+	item.mu.Lock()
+	defer item.mu.Unlock()
+	return item.job
+}
+
+// jobHeap orders pending jobs by priority, then by enqueue order.
+type jobHeap []*queued
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].job.Priority != h[j].job.Priority {
+		return h[i].job.Priority > h[j].job.Priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *jobHeap) Push(x interface{}) { *h = append(*h, x.(*queued)) }
+
+func (h *jobHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,268 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueue_RunsByPriority(t *testing.T) {
+	q := New(Config{Workers: 1})
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) Func {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	// Enqueued before Start, so all are pending when the worker begins
+	q.Enqueue(record("low"), Options{Priority: 1})
+	q.Enqueue(record("high"), Options{Priority: 10})
+	q.Enqueue(record("low2"), Options{Priority: 1})
+
+	q.Start(context.Background())
+	q.Close()
+
+	if len(order) != 3 || order[0] != "high" || order[1] != "low" || order[2] != "low2" {
+		t.Errorf("Expected high, low, low2, got %v", order)
+	}
+}
+
+func TestQueue_Retries(t *testing.T) {
+	q := New(Config{MaxAttempts: 3, RetryDelay: time.Millisecond})
+	q.Start(context.Background())
+	defer q.Close()
+
+	calls := 0
+	h, err := q.Enqueue(func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("temporary")
+		}
+		return nil
+	}, Options{Name: "flaky"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if err := h.Wait(context.Background()); err != nil {
+		t.Errorf("Expected success after retries, got %v", err)
+	}
+	job := h.Job()
+	if job.Attempts != 3 || job.Status != StatusSucceeded {
+		t.Errorf("Expected 3 attempts and success, got %d and %s", job.Attempts, job.Status)
+	}
+}
+
+func TestQueue_NonRetryableFails(t *testing.T) {
+	permanent := errors.New("permanent")
+	q := New(Config{MaxAttempts: 5, RetryDelay: time.Millisecond, Retryable: func(err error) bool {
+		return !errors.Is(err, permanent)
+	}})
+	q.Start(context.Background())
+	defer q.Close()
+
+	h, _ := q.Enqueue(func(ctx context.Context) error { return permanent }, Options{})
+	if err := h.Wait(context.Background()); !errors.Is(err, permanent) {
+		t.Errorf("Expected permanent error, got %v", err)
+	}
+	if job := h.Job(); job.Attempts != 1 || job.Status != StatusFailed {
+		t.Errorf("Expected single failed attempt, got %d and %s", job.Attempts, job.Status)
+	}
+}
+
+type memoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+func (s *memoryStore) Save(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func TestQueue_PersistAndRestore(t *testing.T) {
+	store := &memoryStore{jobs: make(map[string]Job)}
+
+	// A queue that never runs its job, as if the process stopped
+	q := New(Config{Store: store})
+	q.Register("purge", func(ctx context.Context, payload []byte) error { return nil })
+	h, err := q.EnqueueNamed([]byte(`{"path":"/a"}`), Options{Name: "purge"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if store.jobs[h.ID()].Status != StatusPending {
+		t.Errorf("Expected pending job to be persisted, got %+v", store.jobs[h.ID()])
+	}
+
+	// Simulate a restart: restore the persisted job on a new queue
+	persisted := store.jobs[h.ID()]
+
+	var got []byte
+	restored := New(Config{Store: store})
+	restored.Register("purge", func(ctx context.Context, payload []byte) error {
+		got = payload
+		return nil
+	})
+	handles, err := restored.Restore([]Job{persisted})
+	if err != nil || len(handles) != 1 {
+		t.Fatalf("Expected 1 restored job, got %d, %v", len(handles), err)
+	}
+	restored.Start(context.Background())
+	restored.Close()
+
+	if string(got) != `{"path":"/a"}` {
+		t.Errorf("Expected payload to be restored, got %s", got)
+	}
+	if handles[0].ID() != persisted.ID || store.jobs[persisted.ID].Status != StatusSucceeded {
+		t.Errorf("Expected restored job to keep its ID and succeed, got %+v", store.jobs[persisted.ID])
+	}
+}
+
+func TestQueue_CancelPending(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := New(Config{Workers: 1})
+
+	block := make(chan struct{})
+	q.Enqueue(func(ctx context.Context) error {
+		<-block
+		return nil
+	}, Options{})
+	pending, _ := q.Enqueue(func(ctx context.Context) error { return nil }, Options{})
+
+	q.Start(ctx)
+	cancel()
+	close(block)
+	q.Close()
+
+	if pending.Job().Status != StatusCanceled || !errors.Is(pending.Err(), context.Canceled) {
+		t.Errorf("Expected pending job to be canceled, got %s, %v", pending.Job().Status, pending.Err())
+	}
+}
+
+func TestQueue_CancelDuringRetryDelay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := New(Config{MaxAttempts: 3, RetryDelay: time.Hour})
+	q.Start(ctx)
+
+	h, _ := q.Enqueue(func(ctx context.Context) error { return errors.New("temporary") }, Options{})
+	for h.Job().Attempts == 0 || h.Job().Status != StatusPending {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+
+	closed := make(chan struct{})
+	go func() {
+		q.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Close to return once the context was canceled")
+	}
+	if h.Job().Status != StatusCanceled || !errors.Is(h.Err(), context.Canceled) {
+		t.Errorf("Expected job to be canceled, got %s, %v", h.Job().Status, h.Err())
+	}
+}
+
+func TestQueue_EnqueueAfterClose(t *testing.T) {
+	q := New(Config{})
+	q.Start(context.Background())
+	q.Close()
+
+	if _, err := q.Enqueue(func(ctx context.Context) error { return nil }, Options{}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := q.EnqueueNamed(nil, Options{Name: "unknown"}); err == nil {
+		t.Errorf("Expected error for unregistered handler")
+	}
+}
+
+func TestQueue_EnqueueDuringClose(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		q := New(Config{Workers: 2})
+		q.Start(context.Background())
+
+		var wg sync.WaitGroup
+		handles := make(chan *Handle, 20)
+		for j := 0; j < 20; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				h, err := q.Enqueue(func(ctx context.Context) error { return nil }, Options{})
+				if err == nil {
+					handles <- h
+				} else if !errors.Is(err, ErrClosed) {
+					t.Errorf("Expected ErrClosed, got %v", err)
+				}
+			}()
+		}
+		q.Close()
+		wg.Wait()
+		close(handles)
+
+		for h := range handles {
+			select {
+			case <-h.Done():
+			case <-time.After(time.Second):
+				t.Fatal("Expected every accepted job to run before Close returned")
+			}
+		}
+	}
+}
+
+// blockingStore blocks the first Save until release is closed.
+type blockingStore struct {
+	once    sync.Once
+	saving  chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStore) Save(ctx context.Context, job Job) error {
+	s.once.Do(func() {
+		close(s.saving)
+		<-s.release
+	})
+	return nil
+}
+
+func TestQueue_CloseWhileEnqueueing(t *testing.T) {
+	store := &blockingStore{saving: make(chan struct{}), release: make(chan struct{})}
+	q := New(Config{Store: store})
+	q.Start(context.Background())
+
+	type enqueued struct {
+		h   *Handle
+		err error
+	}
+	result := make(chan enqueued, 1)
+	go func() {
+		h, err := q.Enqueue(func(ctx context.Context) error { return nil }, Options{})
+		result <- enqueued{h, err}
+	}()
+
+	<-store.saving
+	q.Close()
+	close(store.release)
+
+	r := <-result
+	if r.err == nil {
+		select {
+		case <-r.h.Done():
+		case <-time.After(time.Second):
+			t.Fatal("Expected a job accepted by a closing queue to run")
+		}
+	} else if !errors.Is(r.err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", r.err)
+	}
+}
@@ -10,6 +10,7 @@
 // Currently supported API versions:
 //
 //   - v2_5: The latest stable API version with full feature support
+//   - v2_6: Compatibility layer aliasing the v2_5 types for API 2.6
 //
 // # Internal Architecture
 //
@@ -0,0 +1,101 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// LimitKind identifies a capacity limit of an account.
+type LimitKind string
+
+// Limits reported by Accounts.Limits.
+const (
+	LimitServices          LimitKind = "services"
+	LimitDomainsPerService LimitKind = "domainsPerService"
+	LimitCertificates      LimitKind = "certificates"
+	LimitStorageAccounts   LimitKind = "storageAccounts"
+)
+
+// AccountLimits describes the capacity limits of an account.
+// A zero maximum means the limit is not enforced or not reported.
+type AccountLimits struct {
+	MaxServices          int `json:"maxServices"`
+	MaxDomainsPerService int `json:"maxDomainsPerService"`
+	MaxCertificates      int `json:"maxCertificates"`
+	MaxStorageAccounts   int `json:"maxNumOfActiveStorageAccounts"`
+
+	// RateLimit is the API request rate allowed for the account
+	RateLimit APIRateLimit `json:"rateLimit"`
+}
+
+// APIRateLimit describes the number of API requests allowed per window.
+type APIRateLimit struct {
+	Requests      int `json:"requests"`
+	WindowSeconds int `json:"windowSeconds"`
+}
+
+// LimitExceededError is returned by AccountLimits.Check when an operation
+// would exceed a limit.
+type LimitExceededError struct {
+	Limit     LimitKind
+	Max       int
+	Current   int
+	Requested int
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s limit exceeded: %d in use, %d requested, maximum is %d", e.Limit, e.Current, e.Requested, e.Max)
+}
+
+// MessageKey returns "limit_exceeded." followed by the limit, for message
+// catalogs.
+func (e *LimitExceededError) MessageKey() string {
+	return apispec.ErrorCodeLimitExceeded + "." + string(e.Limit)
+}
+
+// MessageParams returns the limit and counts for message catalogs.
+func (e *LimitExceededError) MessageParams() map[string]string {
+	return map[string]string{
+		"limit":     string(e.Limit),
+		"max":       strconv.Itoa(e.Max),
+		"current":   strconv.Itoa(e.Current),
+		"requested": strconv.Itoa(e.Requested),
+	}
+}
+
+// Max returns the maximum for kind, or 0 when it is not enforced.
+func (l *AccountLimits) Max(kind LimitKind) int {
+	switch kind {
+	case LimitServices:
+		return l.MaxServices
+	case LimitDomainsPerService:
+		return l.MaxDomainsPerService
+	case LimitCertificates:
+		return l.MaxCertificates
+	case LimitStorageAccounts:
+		return l.MaxStorageAccounts
+	}
+	return 0
+}
+
+// Check reports whether adding requested resources to current in-use ones
+// stays within the limit for kind. It returns a *LimitExceededError if not.
+func (l *AccountLimits) Check(kind LimitKind, current, requested int) error {
+	max := l.Max(kind)
+	if max <= 0 || current+requested <= max {
+		return nil
+	}
+	return &LimitExceededError{Limit: kind, Max: max, Current: current, Requested: requested}
+}
+
+// Limits retrieves the capacity limits of the authenticated account.
+func (a *AccountsService) Limits(ctx context.Context) (*AccountLimits, error) {
+	var limits AccountLimits
+	if err := a.Client.Get(ctx, apispec.PathCurrentAccountLimits, &limits); err != nil {
+		return nil, err
+	}
+	return &limits, nil
+}
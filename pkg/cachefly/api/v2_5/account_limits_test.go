@@ -0,0 +1,52 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestAccountsService_Limits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/accounts/me/limits" {
+			t.Errorf("Expected path /api/2.5/accounts/me/limits, got %s", r.URL.Path)
+		}
+		if r.Method != "GET" {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"maxServices":10,"maxDomainsPerService":50,"maxCertificates":0,"rateLimit":{"requests":600,"windowSeconds":60}}`))
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &AccountsService{Client: httpclient.New(cfg)}
+
+	limits, err := svc.Limits(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if limits.MaxServices != 10 || limits.RateLimit.Requests != 600 {
+		t.Errorf("Unexpected limits %+v", limits)
+	}
+
+	if err := limits.Check(LimitServices, 9, 1); err != nil {
+		t.Errorf("Expected capacity for one more service, got %v", err)
+	}
+
+	var exceeded *LimitExceededError
+	if err := limits.Check(LimitServices, 9, 2); !errors.As(err, &exceeded) {
+		t.Errorf("Expected LimitExceededError, got %v", err)
+	} else if exceeded.Max != 10 {
+		t.Errorf("Expected max 10, got %d", exceeded.Max)
+	}
+
+	if err := limits.Check(LimitCertificates, 1000, 1); err != nil {
+		t.Errorf("Expected unenforced limit to pass, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// AccountSecurityService handles account security settings: two-factor
+// enforcement, SAML single sign-on and allowed login IP ranges.
+//
+// Every method takes the ID of the account to manage; an empty ID manages
+// the current account.
+type AccountSecurityService struct {
+	Client *httpclient.Client
+}
+
+// TwoFactorSettings controls two-factor authentication for an account's users.
+type TwoFactorSettings struct {
+	Enforced bool `json:"enforced"`
+
+	// GracePeriod is the number of days users have to enroll once enforced
+	GracePeriod int `json:"gracePeriod"`
+}
+
+// SAMLSettings describes the SAML identity provider of an account.
+type SAMLSettings struct {
+	Enabled                bool   `json:"enabled"`
+	EntityID               string `json:"entityId,omitempty"`
+	SSOURL                 string `json:"ssoUrl,omitempty"`
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty"`
+	MetadataUpdatedAt      string `json:"metadataUpdatedAt,omitempty"`
+
+	// EnforceSSO rejects password logins once SAML is enabled
+	EnforceSSO bool `json:"enforceSso"`
+}
+
+// AccountSecurity is the security configuration of an account.
+type AccountSecurity struct {
+	TwoFactor TwoFactorSettings `json:"twoFactor"`
+	SAML      SAMLSettings      `json:"saml"`
+
+	// AllowedIPRanges restricts logins and API access to these CIDR ranges;
+	// empty allows any address
+	AllowedIPRanges []string `json:"allowedIpRanges"`
+}
+
+// UpdateAccountSecurityRequest changes security settings. Nil fields are
+// left unchanged; a non-nil empty AllowedIPRanges removes the restriction.
+type UpdateAccountSecurityRequest struct {
+	TwoFactor       *TwoFactorSettings `json:"twoFactor,omitempty"`
+	EnforceSSO      *bool              `json:"enforceSso,omitempty"`
+	AllowedIPRanges *[]string          `json:"allowedIpRanges,omitempty"`
+}
+
+// uploadSAMLMetadataRequest is the payload for uploading IdP metadata.
+type uploadSAMLMetadataRequest struct {
+	Metadata string `json:"metadata"`
+}
+
+// Get retrieves the security settings of an account.
+func (s *AccountSecurityService) Get(ctx context.Context, accountID string) (*AccountSecurity, error) {
+	var security AccountSecurity
+	if err := s.Client.Get(ctx, accountPath(apispec.PathAccountSecurity, accountID), &security); err != nil {
+		return nil, err
+	}
+	return &security, nil
+}
+
+// Update changes the security settings of an account. IP ranges are
+// validated before the request is sent.
+func (s *AccountSecurityService) Update(ctx context.Context, accountID string, req UpdateAccountSecurityRequest) (*AccountSecurity, error) {
+	if req.AllowedIPRanges != nil {
+		ranges, err := normalizeIPRanges(*req.AllowedIPRanges)
+		if err != nil {
+			return nil, err
+		}
+		req.AllowedIPRanges = &ranges
+	}
+	if req.TwoFactor != nil {
+		if err := validate.Range("grace period", req.TwoFactor.GracePeriod, 0, math.MaxInt); err != nil {
+			return nil, err
+		}
+	}
+
+	var security AccountSecurity
+	if err := s.Client.Put(ctx, accountPath(apispec.PathAccountSecurity, accountID), req, &security); err != nil {
+		return nil, err
+	}
+	return &security, nil
+}
+
+// SetTwoFactor enforces or relaxes two-factor authentication for the users
+// of an account, with a grace period in days for enrollment.
+func (s *AccountSecurityService) SetTwoFactor(ctx context.Context, accountID string, enforced bool, gracePeriod int) (*AccountSecurity, error) {
+	return s.Update(ctx, accountID, UpdateAccountSecurityRequest{
+		TwoFactor: &TwoFactorSettings{Enforced: enforced, GracePeriod: gracePeriod},
+	})
+}
+
+// SetAllowedIPRanges restricts access to the given CIDR ranges. Bare IP
+// addresses are accepted as single-address ranges; nil or empty removes the
+// restriction.
+func (s *AccountSecurityService) SetAllowedIPRanges(ctx context.Context, accountID string, cidrs []string) (*AccountSecurity, error) {
+	if cidrs == nil {
+		cidrs = []string{}
+	}
+	return s.Update(ctx, accountID, UpdateAccountSecurityRequest{AllowedIPRanges: &cidrs})
+}
+
+// SetEnforceSSO enables or disables rejecting password logins in favour of
+// SAML single sign-on.
+func (s *AccountSecurityService) SetEnforceSSO(ctx context.Context, accountID string, enforce bool) (*AccountSecurity, error) {
+	return s.Update(ctx, accountID, UpdateAccountSecurityRequest{EnforceSSO: &enforce})
+}
+
+// UploadSAMLMetadata configures SAML single sign-on from the identity
+// provider's metadata XML and enables it. The metadata is checked to be an
+// EntityDescriptor with an entityID before it is sent.
+func (s *AccountSecurityService) UploadSAMLMetadata(ctx context.Context, accountID string, metadata []byte) (*SAMLSettings, error) {
+	var descriptor struct {
+		XMLName  xml.Name
+		EntityID string `xml:"entityID,attr"`
+	}
+	if err := xml.Unmarshal(metadata, &descriptor); err != nil {
+		return nil, fmt.Errorf("invalid SAML metadata: %w", err)
+	}
+	if descriptor.XMLName.Local != "EntityDescriptor" || descriptor.EntityID == "" {
+		return nil, fmt.Errorf("invalid SAML metadata: expected an EntityDescriptor with an entityID")
+	}
+
+	endpoint := accountPath(apispec.PathAccountSecuritySAML, accountID)
+	var saml SAMLSettings
+	if err := s.Client.Post(ctx, endpoint, uploadSAMLMetadataRequest{Metadata: string(metadata)}, &saml); err != nil {
+		return nil, err
+	}
+	return &saml, nil
+}
+
+// DisableSAML removes the SAML configuration of an account.
+func (s *AccountSecurityService) DisableSAML(ctx context.Context, accountID string) error {
+	return s.Client.Delete(ctx, accountPath(apispec.PathAccountSecuritySAML, accountID), nil)
+}
+
+// accountPath fills in the account ID of template, defaulting to the
+// current account.
+func accountPath(template, accountID string) string {
+	if accountID == "" {
+		accountID = "me"
+	}
+	return fmt.Sprintf(template, accountID)
+}
+
+// normalizeIPRanges validates CIDR ranges and turns bare addresses into
+// single-address ranges.
+func normalizeIPRanges(ranges []string) ([]string, error) {
+	normalized := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		r = strings.TrimSpace(r)
+		if err := validate.IPOrCIDR("IP range", r); err != nil {
+			return nil, err
+		}
+		if ip := net.ParseIP(r); ip != nil {
+			if ip.To4() != nil {
+				r += "/32"
+			} else {
+				r += "/128"
+			}
+		}
+		_, network, _ := net.ParseCIDR(r)
+		normalized = append(normalized, network.String())
+	}
+	return normalized, nil
+}
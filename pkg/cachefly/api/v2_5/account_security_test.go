@@ -0,0 +1,94 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestAccountSecurityService_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/me/security" {
+			t.Errorf("Expected path /accounts/me/security, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"twoFactor":{"enforced":true,"gracePeriod":7},"saml":{"enabled":true,"entityId":"https://idp.example.com"},"allowedIpRanges":["10.0.0.0/8"]}`))
+	}))
+	defer server.Close()
+
+	svc := &AccountSecurityService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	security, err := svc.Get(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !security.TwoFactor.Enforced || security.TwoFactor.GracePeriod != 7 {
+		t.Errorf("Expected enforced 2FA with 7 day grace period, got %+v", security.TwoFactor)
+	}
+	if !security.SAML.Enabled || len(security.AllowedIPRanges) != 1 {
+		t.Errorf("Unexpected security settings %+v", security)
+	}
+}
+
+func TestAccountSecurityService_SetAllowedIPRanges(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/accounts/acc-2/security" {
+			t.Errorf("Expected PUT /accounts/acc-2/security, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		ranges := body["allowedIpRanges"].([]interface{})
+		if len(ranges) != 2 || ranges[0] != "192.168.0.0/16" || ranges[1] != "203.0.113.7/32" {
+			t.Errorf("Expected normalized ranges, got %v", ranges)
+		}
+		if _, ok := body["twoFactor"]; ok {
+			t.Errorf("Expected unchanged settings to be omitted, got %v", body)
+		}
+		w.Write([]byte(`{"allowedIpRanges":["192.168.0.0/16","203.0.113.7/32"]}`))
+	}))
+	defer server.Close()
+
+	svc := &AccountSecurityService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	if _, err := svc.SetAllowedIPRanges(context.Background(), "acc-2", []string{"192.168.1.0/16", "203.0.113.7"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := svc.SetAllowedIPRanges(context.Background(), "acc-2", []string{"not-a-range"}); err == nil {
+		t.Error("Expected error for invalid range")
+	}
+}
+
+func TestAccountSecurityService_UploadSAMLMetadata(t *testing.T) {
+	metadata := `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/saml">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol"/>
+</md:EntityDescriptor>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/accounts/me/security/saml" {
+			t.Errorf("Expected POST /accounts/me/security/saml, got %s %s", r.Method, r.URL.Path)
+		}
+		var req uploadSAMLMetadataRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Metadata != metadata {
+			t.Errorf("Expected metadata to be sent unchanged")
+		}
+		w.Write([]byte(`{"enabled":true,"entityId":"https://idp.example.com/saml"}`))
+	}))
+	defer server.Close()
+
+	svc := &AccountSecurityService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	saml, err := svc.UploadSAMLMetadata(context.Background(), "", []byte(metadata))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !saml.Enabled || saml.EntityID != "https://idp.example.com/saml" {
+		t.Errorf("Expected enabled SAML, got %+v", saml)
+	}
+
+	if _, err := svc.UploadSAMLMetadata(context.Background(), "", []byte(`<html></html>`)); err == nil {
+		t.Error("Expected error for metadata that is not an EntityDescriptor")
+	}
+}
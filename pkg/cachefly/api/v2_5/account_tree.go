@@ -0,0 +1,109 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AccountNode is an account in the account hierarchy.
+type AccountNode struct {
+	Account  Account        `json:"account"`
+	Parent   *AccountNode   `json:"-"`
+	Children []*AccountNode `json:"children,omitempty"`
+}
+
+// Tree builds the hierarchy below the authenticated account. Child accounts
+// are attached to their parent; accounts whose parent is not visible to the
+// authenticated account are attached to the root. Children are ordered as
+// listed by the API.
+func (a *AccountsService) Tree(ctx context.Context) (*AccountNode, error) {
+	current, err := a.Get(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current account: %w", err)
+	}
+	root := &AccountNode{Account: *current}
+
+	var children []Account
+	for offset := 0; ; offset += listAllPageSize {
+		page, err := a.List(ctx, ListAccountsOptions{IsChild: true, Offset: offset, Limit: listAllPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list child accounts: %w", err)
+		}
+		children = append(children, page.Accounts...)
+		if len(page.Accounts) < listAllPageSize {
+			break
+		}
+	}
+
+	nodes := map[string]*AccountNode{root.Account.ID: root}
+	for _, account := range children {
+		if _, ok := nodes[account.ID]; !ok {
+			nodes[account.ID] = &AccountNode{Account: account}
+		}
+	}
+
+	// Attach each node to its parent, falling back to the root for unknown
+	// parents and for parent chains that loop instead of reaching the root
+	for _, account := range children {
+		node := nodes[account.ID]
+		if node == root || node.Parent != nil {
+			continue
+		}
+		parent := root
+		if account.Parent != nil {
+			if p, ok := nodes[*account.Parent]; ok && !p.descendsFrom(node) {
+				parent = p
+			}
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+	return root, nil
+}
+
+// descendsFrom reports whether ancestor is n or one of its parents.
+func (n *AccountNode) descendsFrom(ancestor *AccountNode) bool {
+	for p := n; p != nil; p = p.Parent {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Walk calls fn for n and every account below it, parents before their
+// children. depth is 0 for n. An error returned by fn stops the walk and is
+// returned as is.
+func (n *AccountNode) Walk(fn func(node *AccountNode, depth int) error) error {
+	return n.walk(fn, 0)
+}
+
+func (n *AccountNode) walk(fn func(*AccountNode, int) error, depth int) error {
+	if err := fn(n, depth); err != nil {
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.walk(fn, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByName returns the first account in walk order whose company name
+// matches name, ignoring case.
+func (n *AccountNode) FindByName(name string) (*AccountNode, bool) {
+	var found *AccountNode
+	n.Walk(func(node *AccountNode, _ int) error {
+		if strings.EqualFold(node.Account.CompanyName, name) {
+			found = node
+			return errStopWalk
+		}
+		return nil
+	})
+	return found, found != nil
+}
+
+var errStopWalk = errors.New("stop walk")
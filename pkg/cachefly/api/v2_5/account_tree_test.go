@@ -0,0 +1,90 @@
+package v2_5
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// READ - Test Tree links child accounts to their parents
+func TestAccountsService_Tree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/2.5/accounts/me":
+			w.Write([]byte(`{"_id":"root","companyName":"Reseller","isParent":true}`))
+		case "/api/2.5/accounts":
+			if r.URL.Query().Get("isChild") != "true" {
+				t.Errorf("Expected isChild=true, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"meta":{"count":4},"data":[
+				{"_id":"grandchild","companyName":"Shop","parent":"child-a","isChild":true},
+				{"_id":"child-a","companyName":"Agency","parent":"root","isChild":true,"isParent":true},
+				{"_id":"child-b","companyName":"Blog","parent":"root","isChild":true},
+				{"_id":"orphan","companyName":"Other","parent":"elsewhere","isChild":true}
+			]}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &AccountsService{Client: httpclient.New(cfg)}
+
+	root, err := svc.Tree(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if root.Account.ID != "root" || len(root.Children) != 3 {
+		t.Fatalf("Expected root with 3 children, got %+v", root)
+	}
+
+	var visited []string
+	var depths []int
+	root.Walk(func(node *AccountNode, depth int) error {
+		visited = append(visited, node.Account.ID)
+		depths = append(depths, depth)
+		return nil
+	})
+	if len(visited) != 5 || visited[1] != "child-a" || visited[2] != "grandchild" || depths[2] != 2 {
+		t.Errorf("Unexpected walk order %v with depths %v", visited, depths)
+	}
+
+	shop, ok := root.FindByName("shop")
+	if !ok || shop.Account.ID != "grandchild" || shop.Parent.Account.ID != "child-a" {
+		t.Errorf("Expected to find grandchild below child-a, got %+v", shop)
+	}
+	if _, ok := root.FindByName("missing"); ok {
+		t.Error("Expected no account named missing")
+	}
+}
+
+// READ - Test Tree breaks parent loops
+func TestAccountsService_TreeLoop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/2.5/accounts/me" {
+			w.Write([]byte(`{"_id":"root"}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"_id":"a","parent":"b"},{"_id":"b","parent":"a"}]}`))
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &AccountsService{Client: httpclient.New(cfg)}
+
+	root, err := svc.Tree(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	count := 0
+	root.Walk(func(*AccountNode, int) error { count++; return nil })
+	if count != 3 || len(root.Children) != 1 {
+		t.Errorf("Expected 3 accounts with one attached to the root, got %d", count)
+	}
+}
@@ -2,11 +2,14 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // AccountsService handles account-related API operations.
@@ -77,6 +80,9 @@ type Account struct {
 	Users                   []string `json:"users"`
 	Services                []string `json:"services"`
 	V1Account               bool     `json:"v1Account"`
+
+	// Raw holds the response fields Account does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // CreateChildAccountRequest contains the required fields for creating a child account.
@@ -114,6 +120,13 @@ type ListAccountsOptions struct {
 	ResponseType string
 }
 
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListAccountsOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
 // UpdateAccountRequest contains fields for updating an existing account.
 type UpdateAccountRequest struct {
 	CompanyName              string `json:"companyName"`
@@ -138,7 +151,7 @@ type ChildAccountAuthResponse struct {
 
 // Get retrieves the current authenticated account.
 func (a *AccountsService) Get(ctx context.Context, responseType string) (*Account, error) {
-	endpoint := "/accounts/me"
+	endpoint := apispec.PathCurrentAccount
 
 	params := url.Values{}
 	if responseType != "" {
@@ -158,7 +171,7 @@ func (a *AccountsService) Get(ctx context.Context, responseType string) (*Accoun
 
 // List retrieves accounts with optional filtering and pagination.
 func (a *AccountsService) List(ctx context.Context, opts ListAccountsOptions) (*ListAccountsResponse, error) {
-	endpoint := "/accounts"
+	endpoint := apispec.PathAccounts
 	params := url.Values{}
 
 	params.Set("isChild", strconv.FormatBool(opts.IsChild))
@@ -167,20 +180,12 @@ func (a *AccountsService) List(ctx context.Context, opts ListAccountsOptions) (*
 	if opts.Status != "" {
 		params.Set("status", opts.Status)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
 	if opts.ResponseType != "" {
 		params.Set("responseType", opts.ResponseType)
 	}
 
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
-
 	var result ListAccountsResponse
-	err := a.Client.Get(ctx, fullURL, &result)
+	err := getList(ctx, a.Client, endpoint, params, opts.Offset, opts.Limit, &result.Meta, &result.Accounts)
 	if err != nil {
 		return nil, err
 	}
@@ -190,81 +195,101 @@ func (a *AccountsService) List(ctx context.Context, opts ListAccountsOptions) (*
 
 // GetByID retrieves an account by its ID.
 func (a *AccountsService) GetByID(ctx context.Context, id string, responseType string) (*Account, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	fullURL, err := accountURL(id, responseType)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Account
+	if err := a.Client.Get(ctx, fullURL, &result); err != nil {
+		return nil, err
 	}
+	return &result, nil
+}
 
-	endpoint := fmt.Sprintf("/accounts/%s", url.PathEscape(id))
+// accountURL returns the endpoint of the account with the given ID.
+func accountURL(id, responseType string) (string, error) {
+	if err := validate.ID("id", id); err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf(apispec.PathAccount, url.PathEscape(id))
 
 	params := url.Values{}
 	if responseType != "" {
 		params.Set("responseType", responseType)
 	}
 
-	fullURL := endpoint
 	if len(params) > 0 {
-		fullURL = fmt.Sprintf("%s?%s", endpoint, params.Encode())
+		return fmt.Sprintf("%s?%s", endpoint, params.Encode()), nil
 	}
-
-	var result Account
-	if err := a.Client.Get(ctx, fullURL, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
+	return endpoint, nil
 }
 
 // UpdateCurrentAccount updates the authenticated account.
 func (a *AccountsService) UpdateCurrentAccount(ctx context.Context, req UpdateAccountRequest) (*Account, error) {
-	endpoint := "/accounts/me"
+	endpoint := apispec.PathCurrentAccount
 
 	var updated Account
 	if err := a.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := a.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // UpdateAccountByID updates an existing account by ID.
 func (a *AccountsService) UpdateAccountByID(ctx context.Context, id string, req UpdateAccountRequest) (*Account, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/accounts/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathAccount, id)
 
 	var updated Account
 	if err := a.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := a.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 
 	return &updated, nil
 }
 
 // ActivateAccountByID activates an account.
 func (a *AccountsService) ActivateAccountByID(ctx context.Context, id string) (*Account, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/accounts/%s/activate", id)
+	endpoint := fmt.Sprintf(apispec.PathAccountActivate, id)
 
 	var updated Account
 	if err := a.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := a.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathAccount, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // DeactivateAccountByID deactivates an account.
 func (a *AccountsService) DeactivateAccountByID(ctx context.Context, id string) (*Account, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/accounts/%s/deactivate", id)
+	endpoint := fmt.Sprintf(apispec.PathAccountDeactivate, id)
 
 	var updated Account
 	if err := a.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := a.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathAccount, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
@@ -276,7 +301,10 @@ func (a *AccountsService) CreateChildAccount(ctx context.Context, req CreateChil
 	}
 
 	var created Account
-	if err := a.Client.Post(ctx, "/accounts", req, &created); err != nil {
+	if err := a.Client.Post(ctx, apispec.PathAccounts, req, &created); err != nil {
+		return nil, err
+	}
+	if err := a.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathAccount, created.ID), &created); err != nil {
 		return nil, err
 	}
 	return &created, nil
@@ -285,11 +313,11 @@ func (a *AccountsService) CreateChildAccount(ctx context.Context, req CreateChil
 // GetChildAccountAuthToken generates an authentication token for a child account.
 // Parent accounts can use this token to manage child account services.
 func (a *AccountsService) GetChildAccountAuthToken(ctx context.Context, id string) (*ChildAccountAuthResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/accounts/%s/auth", url.PathEscape(id))
+	endpoint := fmt.Sprintf(apispec.PathAccountAuth, url.PathEscape(id))
 
 	var resp ChildAccountAuthResponse
 	if err := a.Client.Post(ctx, endpoint, struct{}{}, &resp); err != nil {
@@ -301,22 +329,28 @@ func (a *AccountsService) GetChildAccountAuthToken(ctx context.Context, id strin
 
 // Enable2FAForCurrentAccount enables two-factor authentication for the current account.
 func (a *AccountsService) Enable2FAForCurrentAccount(ctx context.Context) (*Account, error) {
-	endpoint := "/accounts/me/enable2FA"
+	endpoint := apispec.PathCurrentAccountEnable2FA
 
 	var updated Account
 	if err := a.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := a.Client.ReadBack(ctx, apispec.PathCurrentAccount, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // Disable2FAForCurrentAccount disables two-factor authentication for the current account.
 func (a *AccountsService) Disable2FAForCurrentAccount(ctx context.Context) (*Account, error) {
-	endpoint := "/accounts/me/disable2FA"
+	endpoint := apispec.PathCurrentAccountDisable2FA
 
 	var updated Account
 	if err := a.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := a.Client.ReadBack(ctx, apispec.PathCurrentAccount, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
@@ -0,0 +1,309 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// AlertMetric identifies the traffic metric an alert rule watches.
+type AlertMetric string
+
+// Alert metrics.
+const (
+	AlertMetricBandwidth       AlertMetric = "bandwidth"
+	AlertMetricRequests        AlertMetric = "requests"
+	AlertMetricErrorRate       AlertMetric = "errorRate"
+	AlertMetricOriginErrorRate AlertMetric = "originErrorRate"
+	AlertMetricCacheHitRatio   AlertMetric = "cacheHitRatio"
+)
+
+// AlertUnit is the unit of an alert threshold.
+type AlertUnit string
+
+// Alert threshold units.
+const (
+	AlertUnitBitsPerSecond     AlertUnit = "bps"
+	AlertUnitMegabitsPerSecond AlertUnit = "Mbps"
+	AlertUnitGigabitsPerSecond AlertUnit = "Gbps"
+	AlertUnitRequestsPerSecond AlertUnit = "rps"
+	AlertUnitPercent           AlertUnit = "percent"
+)
+
+// alertUnits lists the units each metric can be measured in.
+var alertUnits = map[AlertMetric][]AlertUnit{
+	AlertMetricBandwidth:       {AlertUnitBitsPerSecond, AlertUnitMegabitsPerSecond, AlertUnitGigabitsPerSecond},
+	AlertMetricRequests:        {AlertUnitRequestsPerSecond},
+	AlertMetricErrorRate:       {AlertUnitPercent},
+	AlertMetricOriginErrorRate: {AlertUnitPercent},
+	AlertMetricCacheHitRatio:   {AlertUnitPercent},
+}
+
+// AlertOperator compares a metric with the threshold.
+type AlertOperator string
+
+// Alert operators.
+const (
+	AlertOperatorAbove AlertOperator = "gt"
+	AlertOperatorBelow AlertOperator = "lt"
+)
+
+// AlertChannelType identifies how an alert notification is delivered.
+type AlertChannelType string
+
+// Alert channel types.
+const (
+	AlertChannelEmail   AlertChannelType = "email"
+	AlertChannelWebhook AlertChannelType = "webhook"
+	AlertChannelSlack   AlertChannelType = "slack"
+)
+
+// Limits of AlertCondition.Window, in seconds.
+const (
+	MinAlertWindow = 60
+	MaxAlertWindow = 86400
+)
+
+// AlertsService handles alert rule operations.
+type AlertsService struct {
+	Client *httpclient.Client
+}
+
+// AlertCondition describes when an alert rule triggers: when Metric stays
+// above or below Threshold, measured in Unit, for Window seconds.
+type AlertCondition struct {
+	Metric    AlertMetric   `json:"metric"`
+	Operator  AlertOperator `json:"operator"`
+	Threshold float64       `json:"threshold"`
+	Unit      AlertUnit     `json:"unit"`
+	Window    int           `json:"window"`
+}
+
+// AlertChannel is a notification target of an alert rule. Target is an
+// email address, the ID of a webhook or a Slack incoming webhook URL.
+type AlertChannel struct {
+	Type   AlertChannelType `json:"type"`
+	Target string           `json:"target"`
+}
+
+// AlertRule represents an alert rule in CacheFly.
+type AlertRule struct {
+	ID              string         `json:"_id"`
+	UpdatedAt       string         `json:"updateAt"`
+	CreatedAt       string         `json:"createdAt"`
+	Name            string         `json:"name"`
+	Condition       AlertCondition `json:"condition"`
+	Channels        []AlertChannel `json:"channels"`
+	Services        []string       `json:"services,omitempty"`
+	Enabled         bool           `json:"enabled"`
+	LastTriggeredAt string         `json:"lastTriggeredAt,omitempty"`
+}
+
+// ListAlertRulesResponse contains paginated alert rule results.
+type ListAlertRulesResponse struct {
+	Meta  MetaInfo    `json:"meta"`
+	Rules []AlertRule `json:"data"`
+}
+
+// ListAlertRulesOptions specifies filters and pagination for listing alert
+// rules.
+type ListAlertRulesOptions struct {
+	ServiceID string
+	Metric    AlertMetric
+	Offset    int
+	Limit     int
+}
+
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListAlertRulesOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
+// CreateAlertRuleRequest is the payload for creating an alert rule. Empty
+// Services scopes the rule to the whole account.
+type CreateAlertRuleRequest struct {
+	Name      string         `json:"name"`
+	Condition AlertCondition `json:"condition"`
+	Channels  []AlertChannel `json:"channels"`
+	Services  []string       `json:"services,omitempty"`
+	Enabled   bool           `json:"enabled"`
+}
+
+// UpdateAlertRuleRequest is the payload for updating an alert rule. Nil and
+// empty fields are left unchanged.
+type UpdateAlertRuleRequest struct {
+	Name      string          `json:"name,omitempty"`
+	Condition *AlertCondition `json:"condition,omitempty"`
+	Channels  []AlertChannel  `json:"channels,omitempty"`
+	Services  []string        `json:"services,omitempty"`
+	Enabled   *bool           `json:"enabled,omitempty"`
+}
+
+// Validate checks the metric, operator and window of the condition, that
+// the unit fits the metric and that the threshold is valid for the unit:
+// percentages must lie within [0, 100] and rates must not be negative.
+func (c AlertCondition) Validate() error {
+	units, ok := alertUnits[c.Metric]
+	if !ok {
+		return validate.OneOf("condition.metric", string(c.Metric), string(AlertMetricBandwidth), string(AlertMetricRequests),
+			string(AlertMetricErrorRate), string(AlertMetricOriginErrorRate), string(AlertMetricCacheHitRatio))
+	}
+	if err := validate.OneOf("condition.operator", string(c.Operator), string(AlertOperatorAbove), string(AlertOperatorBelow)); err != nil {
+		return err
+	}
+	allowed := make([]string, len(units))
+	for i, u := range units {
+		allowed[i] = string(u)
+	}
+	if err := validate.OneOf("condition.unit", string(c.Unit), allowed...); err != nil {
+		return err
+	}
+	max := math.Inf(1)
+	if c.Unit == AlertUnitPercent {
+		max = 100
+	}
+	if err := validate.FloatRange("condition.threshold", c.Threshold, 0, max); err != nil {
+		return err
+	}
+	return validate.Range("condition.window", c.Window, MinAlertWindow, MaxAlertWindow)
+}
+
+// Validate checks the channel type and that the target fits it.
+func (ch AlertChannel) Validate() error {
+	switch ch.Type {
+	case AlertChannelEmail:
+		if err := validate.Required("channel.target", ch.Target); err != nil {
+			return err
+		}
+		if at := strings.LastIndex(ch.Target, "@"); at < 1 || at == len(ch.Target)-1 {
+			return fmt.Errorf("channel.target %q is not a valid email address", ch.Target)
+		}
+		return nil
+	case AlertChannelWebhook:
+		return validate.ID("channel.target", ch.Target)
+	case AlertChannelSlack:
+		if err := validate.Required("channel.target", ch.Target); err != nil {
+			return err
+		}
+		if u, err := url.Parse(ch.Target); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("channel.target %q is not a valid https URL", ch.Target)
+		}
+		return nil
+	default:
+		return validate.OneOf("channel.type", string(ch.Type), string(AlertChannelEmail), string(AlertChannelWebhook), string(AlertChannelSlack))
+	}
+}
+
+// validateAlertScope checks the channels and service IDs of a rule.
+func validateAlertScope(channels []AlertChannel, services []string) error {
+	for _, ch := range channels {
+		if err := ch.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, id := range services {
+		if err := validate.ID("services", id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List retrieves alert rules with optional filters.
+func (s *AlertsService) List(ctx context.Context, opts ListAlertRulesOptions) (*ListAlertRulesResponse, error) {
+	params := url.Values{}
+	if opts.ServiceID != "" {
+		params.Set("serviceId", opts.ServiceID)
+	}
+	if opts.Metric != "" {
+		params.Set("metric", string(opts.Metric))
+	}
+
+	var resp ListAlertRulesResponse
+	if err := getList(ctx, s.Client, apispec.PathAlerts, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Rules); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Create adds a new alert rule. The condition, channels and services are
+// validated before the request is sent.
+func (s *AlertsService) Create(ctx context.Context, req CreateAlertRuleRequest) (*AlertRule, error) {
+	if err := validate.Required("name", req.Name); err != nil {
+		return nil, err
+	}
+	if err := req.Condition.Validate(); err != nil {
+		return nil, err
+	}
+	if len(req.Channels) == 0 {
+		return nil, fmt.Errorf("at least one channel is required")
+	}
+	if err := validateAlertScope(req.Channels, req.Services); err != nil {
+		return nil, err
+	}
+
+	var created AlertRule
+	if err := s.Client.Post(ctx, apispec.PathAlerts, req, &created); err != nil {
+		return nil, err
+	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathAlert, created.ID), &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetByID fetches a single alert rule by its ID.
+func (s *AlertsService) GetByID(ctx context.Context, id string) (*AlertRule, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(apispec.PathAlert, id)
+	var rule AlertRule
+	if err := s.Client.Get(ctx, endpoint, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// UpdateByID modifies an existing alert rule. A new condition, channels and
+// services are validated before the request is sent.
+func (s *AlertsService) UpdateByID(ctx context.Context, id string, req UpdateAlertRuleRequest) (*AlertRule, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	if req.Condition != nil {
+		if err := req.Condition.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateAlertScope(req.Channels, req.Services); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf(apispec.PathAlert, id)
+	var updated AlertRule
+	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
+		return nil, err
+	}
+	if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes an alert rule by ID.
+func (s *AlertsService) Delete(ctx context.Context, id string) error {
+	if err := validate.ID("id", id); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf(apispec.PathAlert, id)
+	return s.Client.Delete(ctx, endpoint, nil)
+}
@@ -0,0 +1,146 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+func validAlertRule() CreateAlertRuleRequest {
+	return CreateAlertRuleRequest{
+		Name: "High error rate",
+		Condition: AlertCondition{
+			Metric:    AlertMetricErrorRate,
+			Operator:  AlertOperatorAbove,
+			Threshold: 5,
+			Unit:      AlertUnitPercent,
+			Window:    300,
+		},
+		Channels: []AlertChannel{
+			{Type: AlertChannelEmail, Target: "ops@example.com"},
+			{Type: AlertChannelSlack, Target: "https://hooks.slack.com/services/T000/B000/XXX"},
+		},
+		Services: []string{"svc-1"},
+		Enabled:  true,
+	}
+}
+
+func TestAlertsService_Create(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts" {
+			t.Errorf("Expected path /alerts, got %s", r.URL.Path)
+		}
+		if r.Method != "POST" {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		var req CreateAlertRuleRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Condition.Metric != AlertMetricErrorRate || req.Condition.Window != 300 || len(req.Channels) != 2 {
+			t.Errorf("Unexpected request %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"alert-1","name":"High error rate","condition":{"metric":"errorRate","operator":"gt","threshold":5,"unit":"percent","window":300},"enabled":true}`))
+	}))
+	defer server.Close()
+
+	svc := &AlertsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	rule, err := svc.Create(context.Background(), validAlertRule())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rule.ID != "alert-1" || rule.Condition.Threshold != 5 {
+		t.Errorf("Expected alert-1 with threshold 5, got %+v", rule)
+	}
+}
+
+func TestAlertsService_CreateValidation(t *testing.T) {
+	svc := &AlertsService{Client: httpclient.New(httpclient.Config{BaseURL: "http://127.0.0.1:0"})}
+
+	tests := []struct {
+		name   string
+		modify func(*CreateAlertRuleRequest)
+		code   validate.Code
+		text   string
+	}{
+		{"percent above 100", func(r *CreateAlertRuleRequest) { r.Condition.Threshold = 150 }, validate.CodeOutOfRange, "condition.threshold"},
+		{"negative rate", func(r *CreateAlertRuleRequest) {
+			r.Condition = AlertCondition{Metric: AlertMetricRequests, Operator: AlertOperatorAbove, Threshold: -1, Unit: AlertUnitRequestsPerSecond, Window: 60}
+		}, validate.CodeOutOfRange, "at least 0"},
+		{"unit of another metric", func(r *CreateAlertRuleRequest) { r.Condition.Unit = AlertUnitGigabitsPerSecond }, validate.CodeInvalidEnum, "condition.unit"},
+		{"unknown metric", func(r *CreateAlertRuleRequest) { r.Condition.Metric = "latency" }, validate.CodeInvalidEnum, "condition.metric"},
+		{"short window", func(r *CreateAlertRuleRequest) { r.Condition.Window = 10 }, validate.CodeOutOfRange, "condition.window"},
+		{"bad email", func(r *CreateAlertRuleRequest) { r.Channels[0].Target = "ops" }, "", "email address"},
+		{"plain http slack", func(r *CreateAlertRuleRequest) { r.Channels[1].Target = "http://hooks.slack.com/x" }, "", "https URL"},
+		{"bad service ID", func(r *CreateAlertRuleRequest) { r.Services = []string{"svc/1"} }, validate.CodeInvalidID, "services"},
+		{"no channels", func(r *CreateAlertRuleRequest) { r.Channels = nil }, "", "channel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validAlertRule()
+			tt.modify(&req)
+			_, err := svc.Create(context.Background(), req)
+			if err == nil {
+				t.Fatal("Expected a validation error")
+			}
+			if validate.CodeOf(err) != tt.code || !strings.Contains(err.Error(), tt.text) {
+				t.Errorf("Expected %q error containing %q, got %v", tt.code, tt.text, err)
+			}
+		})
+	}
+}
+
+func TestAlertsService_UpdateByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts/alert-1" {
+			t.Errorf("Expected path /alerts/alert-1, got %s", r.URL.Path)
+		}
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT method, got %s", r.Method)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body) != 1 || body["enabled"] != false {
+			t.Errorf("Expected only enabled=false, got %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"alert-1","enabled":false}`))
+	}))
+	defer server.Close()
+
+	svc := &AlertsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	enabled := false
+	rule, err := svc.UpdateByID(context.Background(), "alert-1", UpdateAlertRuleRequest{Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rule.Enabled {
+		t.Error("Expected the rule to be disabled")
+	}
+}
+
+func TestAlertsService_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("serviceId") != "svc-1" || r.URL.Query().Get("metric") != "bandwidth" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"limit":10,"offset":0,"count":1},"data":[{"_id":"alert-2","condition":{"metric":"bandwidth","unit":"Gbps","threshold":10}}]}`))
+	}))
+	defer server.Close()
+
+	svc := &AlertsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	resp, err := svc.List(context.Background(), ListAlertRulesOptions{ServiceID: "svc-1", Metric: AlertMetricBandwidth})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Rules) != 1 || resp.Rules[0].Condition.Unit != AlertUnitGigabitsPerSecond {
+		t.Errorf("Expected one Gbps rule, got %+v", resp.Rules)
+	}
+}
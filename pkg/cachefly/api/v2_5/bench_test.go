@@ -0,0 +1,144 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// Benchmarks of the high-volume operations against internal/fakeapi. Run
+// them with -benchmem and compare with benchstat before and after a change:
+//
+//	go test ./pkg/cachefly/api/v2_5 -run '^$' -bench . -benchmem -count 10
+
+func benchClient(b *testing.B, cfg fakeapi.Config) (*httpclient.Client, *fakeapi.Server) {
+	b.Helper()
+	server := fakeapi.New(cfg)
+	b.Cleanup(server.Close)
+	return httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "bench-token"}), server
+}
+
+func BenchmarkServicesList(b *testing.B) {
+	for _, size := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("page=%d", size), func(b *testing.B) {
+			client, _ := benchClient(b, fakeapi.Config{Services: size})
+			svc := &ServicesService{Client: client}
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				resp, err := svc.List(ctx, ListOptions{Limit: size})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(resp.Services) != size {
+					b.Fatalf("Expected %d services, got %d", size, len(resp.Services))
+				}
+			}
+			b.ReportMetric(float64(size*b.N)/b.Elapsed().Seconds(), "services/s")
+		})
+	}
+}
+
+func BenchmarkServicesListLowMemory(b *testing.B) {
+	const size = 1000
+	server := fakeapi.New(fakeapi.Config{Services: size})
+	b.Cleanup(server.Close)
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "bench-token", LowMemoryDecoding: true})}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := svc.List(ctx, ListOptions{Limit: size})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(resp.Services) != size {
+			b.Fatalf("Expected %d services, got %d", size, len(resp.Services))
+		}
+	}
+}
+
+func BenchmarkServicesListEach(b *testing.B) {
+	const size = 1000
+	client, _ := benchClient(b, fakeapi.Config{Services: size})
+	svc := &ServicesService{Client: client}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := 0
+		err := svc.ListEach(ctx, ListOptions{Limit: size}, func(*Service) error {
+			n++
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if n != size {
+			b.Fatalf("Expected %d services, got %d", size, n)
+		}
+	}
+}
+
+func BenchmarkServiceOptionsApply(b *testing.B) {
+	const options = 50
+	client, _ := benchClient(b, fakeapi.Config{Services: 1, Options: options})
+	svc := &ServiceOptionsService{Client: client}
+	ctx := context.Background()
+
+	// Flip every option on each iteration so every key is sent
+	desired := [2]ServiceOptions{make(ServiceOptions, options), make(ServiceOptions, options)}
+	for i := 0; i < options; i++ {
+		desired[0][fmt.Sprintf("option%d", i)] = true
+		desired[1][fmt.Sprintf("option%d", i)] = false
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := svc.Apply(ctx, fakeapi.ServiceID(0), desired[i%2], ApplyOptions{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(result.Changes) != options {
+			b.Fatalf("Expected %d changes, got %d", options, len(result.Changes))
+		}
+	}
+}
+
+func BenchmarkPurgePaths(b *testing.B) {
+	const count = 10000
+	client, server := benchClient(b, fakeapi.Config{Services: 1})
+	svc := &PurgeService{Client: client}
+	ctx := context.Background()
+
+	paths := make([]string, count)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("/assets/%d/image.png", i)
+	}
+	opts := PurgeOptions{Concurrency: 8, Interval: -1}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		report, err := svc.PathsWithOptions(ctx, fakeapi.ServiceID(0), paths, opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if report.Purged != count {
+			b.Fatalf("Expected %d paths purged, got %d", count, report.Purged)
+		}
+	}
+	b.StopTimer()
+	if got := server.Purged(); got != int64(count*b.N) {
+		b.Errorf("Expected the server to see %d paths, got %d", count*b.N, got)
+	}
+	b.ReportMetric(float64(count*b.N)/b.Elapsed().Seconds(), "paths/s")
+}
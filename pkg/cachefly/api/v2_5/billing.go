@@ -0,0 +1,210 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// Invoice statuses.
+const (
+	InvoiceStatusOpen    = "OPEN"
+	InvoiceStatusPaid    = "PAID"
+	InvoiceStatusVoid    = "VOID"
+	InvoiceStatusOverdue = "OVERDUE"
+)
+
+// BillingService handles usage and billing operations.
+type BillingService struct {
+	Client *httpclient.Client
+}
+
+// Usage reports the usage of an account during a billing period, as totals
+// for the period.
+type Usage struct {
+	PeriodStart string `json:"periodStart"`
+	PeriodEnd   string `json:"periodEnd"`
+
+	BandwidthBytes int64 `json:"bandwidthBytes"`
+	Requests       int64 `json:"requests"`
+	StorageBytes   int64 `json:"storageBytes"`
+
+	// Plan describes the commitments of the account's plan
+	Plan PlanLimits `json:"plan"`
+
+	// Overage is the usage beyond the plan during the period
+	Overage Overage `json:"overage"`
+}
+
+// PlanLimits describes the commitments included in an account's plan. A
+// zero value means the plan has no commitment for that metric.
+type PlanLimits struct {
+	Name           string `json:"name"`
+	BandwidthBytes int64  `json:"bandwidthBytes"`
+	Requests       int64  `json:"requests"`
+	StorageBytes   int64  `json:"storageBytes"`
+}
+
+// Overage is the usage beyond the plan and its cost. Amount is in the
+// smallest unit of Currency, e.g. cents.
+type Overage struct {
+	BandwidthBytes int64  `json:"bandwidthBytes"`
+	Requests       int64  `json:"requests"`
+	StorageBytes   int64  `json:"storageBytes"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+}
+
+// ServiceUsage is the usage of a single service during a billing period.
+type ServiceUsage struct {
+	ServiceID      string `json:"serviceId"`
+	ServiceName    string `json:"serviceName"`
+	BandwidthBytes int64  `json:"bandwidthBytes"`
+	Requests       int64  `json:"requests"`
+}
+
+// UsageOptions selects the period of a usage report. Zero values select
+// the current billing period.
+type UsageOptions struct {
+	From time.Time
+	To   time.Time
+}
+
+// Invoice represents an invoice of the account. Amounts are in the
+// smallest unit of Currency, e.g. cents.
+type Invoice struct {
+	ID          string `json:"_id"`
+	Number      string `json:"number"`
+	Status      string `json:"status"`
+	PeriodStart string `json:"periodStart"`
+	PeriodEnd   string `json:"periodEnd"`
+	IssuedAt    string `json:"issuedAt"`
+	DueAt       string `json:"dueAt,omitempty"`
+	PaidAt      string `json:"paidAt,omitempty"`
+	Subtotal    int64  `json:"subtotal"`
+	Tax         int64  `json:"tax"`
+	Total       int64  `json:"total"`
+	Currency    string `json:"currency"`
+}
+
+// ListInvoicesResponse contains paginated invoice results.
+type ListInvoicesResponse struct {
+	Meta     MetaInfo  `json:"meta"`
+	Invoices []Invoice `json:"data"`
+}
+
+// ListInvoicesOptions specifies filters and pagination for listing invoices.
+type ListInvoicesOptions struct {
+	Status string
+	From   time.Time
+	To     time.Time
+	Offset int
+	Limit  int
+}
+
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListInvoicesOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
+// Usage retrieves the bandwidth, request and storage usage of the
+// authenticated account together with its plan limits and overage. Accounts
+// without usage reports get a FeatureUnavailableError.
+func (s *BillingService) Usage(ctx context.Context, opts UsageOptions) (*Usage, error) {
+	endpoint := apispec.PathBillingUsage
+	if params := usageParams(opts); len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	var usage Usage
+	if err := s.Client.Get(ctx, endpoint, &usage); err != nil {
+		return nil, featureUnavailable(FeatureReports, err)
+	}
+	return &usage, nil
+}
+
+// ServicesUsage retrieves the usage of every service of the authenticated
+// account, ordered by bandwidth with the largest first. Accounts without
+// usage reports get a FeatureUnavailableError.
+func (s *BillingService) ServicesUsage(ctx context.Context, opts UsageOptions) ([]ServiceUsage, error) {
+	endpoint := apispec.PathBillingServicesUsage
+	if params := usageParams(opts); len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	var resp struct {
+		Services []ServiceUsage `json:"data"`
+	}
+	if err := s.Client.Get(ctx, endpoint, &resp); err != nil {
+		return nil, featureUnavailable(FeatureReports, err)
+	}
+	sort.SliceStable(resp.Services, func(i, j int) bool {
+		return resp.Services[i].BandwidthBytes > resp.Services[j].BandwidthBytes
+	})
+	return resp.Services, nil
+}
+
+// ListInvoices retrieves the invoices of the authenticated account.
+func (s *BillingService) ListInvoices(ctx context.Context, opts ListInvoicesOptions) (*ListInvoicesResponse, error) {
+	params := url.Values{}
+	if opts.Status != "" {
+		params.Set("status", opts.Status)
+	}
+	if !opts.From.IsZero() {
+		params.Set("from", opts.From.UTC().Format(time.RFC3339))
+	}
+	if !opts.To.IsZero() {
+		params.Set("to", opts.To.UTC().Format(time.RFC3339))
+	}
+
+	var resp ListInvoicesResponse
+	if err := getList(ctx, s.Client, apispec.PathInvoices, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Invoices); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetInvoice fetches a single invoice by its ID.
+func (s *BillingService) GetInvoice(ctx context.Context, id string) (*Invoice, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(apispec.PathInvoice, id)
+	var invoice Invoice
+	if err := s.Client.Get(ctx, endpoint, &invoice); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// DownloadInvoice streams the PDF document of an invoice. The caller must
+// close the returned reader.
+func (s *BillingService) DownloadInvoice(ctx context.Context, id string) (io.ReadCloser, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(apispec.PathInvoicePDF, id)
+	return s.Client.GetStream(ctx, endpoint, http.Header{"Accept": {"application/pdf"}})
+}
+
+// usageParams encodes the period of a usage report.
+func usageParams(opts UsageOptions) url.Values {
+	params := url.Values{}
+	if !opts.From.IsZero() {
+		params.Set("from", opts.From.UTC().Format(time.RFC3339))
+	}
+	if !opts.To.IsZero() {
+		params.Set("to", opts.To.UTC().Format(time.RFC3339))
+	}
+	return params
+}
@@ -0,0 +1,112 @@
+package v2_5
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestBillingService_Usage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing/usage" {
+			t.Errorf("Expected path /billing/usage, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("from") != "2025-06-01T00:00:00Z" {
+			t.Errorf("Expected from 2025-06-01T00:00:00Z, got %s", r.URL.Query().Get("from"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"periodStart":"2025-06-01T00:00:00Z","periodEnd":"2025-06-30T23:59:59Z","bandwidthBytes":1500,"requests":20,
+			"plan":{"name":"Pro","bandwidthBytes":1000},"overage":{"bandwidthBytes":500,"amount":1250,"currency":"USD"}}`))
+	}))
+	defer server.Close()
+
+	svc := &BillingService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	usage, err := svc.Usage(context.Background(), UsageOptions{From: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if usage.BandwidthBytes != 1500 || usage.Plan.BandwidthBytes != 1000 || usage.Plan.Name != "Pro" {
+		t.Errorf("Expected usage 1500 of plan Pro 1000, got %+v", usage)
+	}
+	if usage.Overage.BandwidthBytes != 500 || usage.Overage.Amount != 1250 || usage.Overage.Currency != "USD" {
+		t.Errorf("Expected overage of 500 bytes for 1250 USD, got %+v", usage.Overage)
+	}
+}
+
+func TestBillingService_ServicesUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing/usage/services" {
+			t.Errorf("Expected path /billing/usage/services, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"serviceId":"svc-1","bandwidthBytes":10},{"serviceId":"svc-2","bandwidthBytes":30}]}`))
+	}))
+	defer server.Close()
+
+	svc := &BillingService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	services, err := svc.ServicesUsage(context.Background(), UsageOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(services) != 2 || services[0].ServiceID != "svc-2" {
+		t.Errorf("Expected svc-2 first, got %+v", services)
+	}
+}
+
+func TestBillingService_ListInvoices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing/invoices" {
+			t.Errorf("Expected path /billing/invoices, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("status") != InvoiceStatusOpen || r.URL.Query().Get("limit") != "5" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"limit":5,"offset":0,"count":1},"data":[{"_id":"inv-1","number":"2025-0001","status":"OPEN","total":9900,"currency":"USD"}]}`))
+	}))
+	defer server.Close()
+
+	svc := &BillingService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	resp, err := svc.ListInvoices(context.Background(), ListInvoicesOptions{Status: InvoiceStatusOpen, Limit: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Invoices) != 1 || resp.Invoices[0].ID != "inv-1" || resp.Invoices[0].Total != 9900 {
+		t.Errorf("Expected invoice inv-1 of 9900, got %+v", resp.Invoices)
+	}
+}
+
+func TestBillingService_DownloadInvoice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing/invoices/inv-1/pdf" {
+			t.Errorf("Expected path /billing/invoices/inv-1/pdf, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Accept") != "application/pdf" {
+			t.Errorf("Expected Accept application/pdf, got %s", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.7 invoice"))
+	}))
+	defer server.Close()
+
+	svc := &BillingService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	pdf, err := svc.DownloadInvoice(context.Background(), "inv-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer pdf.Close()
+
+	data, _ := io.ReadAll(pdf)
+	if string(data) != "%PDF-1.7 invoice" {
+		t.Errorf("Expected PDF body, got %q", data)
+	}
+
+	if _, err := svc.DownloadInvoice(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty invoice ID")
+	}
+}
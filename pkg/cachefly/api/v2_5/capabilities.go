@@ -0,0 +1,115 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// Capabilities reported by Accounts.GetCapabilities and
+// Services.GetFeatures. The API may report others.
+const (
+	CapabilityImageOptimization = "imageOptimization"
+	CapabilityWebSockets        = "webSockets"
+	CapabilityChinaDelivery     = "chinaDelivery"
+	CapabilityScriptConfigs     = "scriptConfigs"
+	CapabilityRawLogs           = "rawLogs"
+	CapabilityReports           = "reports"
+	CapabilityFTP               = "ftp"
+)
+
+// FeatureSet maps capabilities to whether they are enabled.
+type FeatureSet map[string]bool
+
+// Enabled reports whether capability is enabled. Capabilities the API did
+// not report are not.
+func (f FeatureSet) Enabled(capability string) bool {
+	return f[capability]
+}
+
+// Require returns a *FeatureUnavailableError for capability unless it is
+// enabled, so callers can skip an operation instead of failing on the API's
+// 403 or 404 response.
+func (f FeatureSet) Require(capability string) error {
+	if f.Enabled(capability) {
+		return nil
+	}
+	return &FeatureUnavailableError{Feature: capability, Err: fmt.Errorf("%s is not enabled", capability)}
+}
+
+// List returns the enabled capabilities, sorted.
+func (f FeatureSet) List() []string {
+	var enabled []string
+	for capability, on := range f {
+		if on {
+			enabled = append(enabled, capability)
+		}
+	}
+	sort.Strings(enabled)
+	return enabled
+}
+
+// AccountCapabilities describes the plan of an account and the
+// capabilities it includes.
+type AccountCapabilities struct {
+	Plan     string     `json:"plan"`
+	Features FeatureSet `json:"features"`
+}
+
+// ServiceFeatures describes the capabilities enabled for a service, which
+// are those of the account's plan the service can use.
+type ServiceFeatures struct {
+	ServiceID string     `json:"serviceId"`
+	Features  FeatureSet `json:"features"`
+}
+
+// GetCapabilities retrieves the plan of the authenticated account and the
+// capabilities it includes.
+//
+// Example:
+//
+//	caps, err := client.Accounts.GetCapabilities(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	if caps.Features.Enabled(api.CapabilityImageOptimization) {
+//		// configure image optimization
+//	}
+func (a *AccountsService) GetCapabilities(ctx context.Context) (*AccountCapabilities, error) {
+	var caps AccountCapabilities
+	if err := a.Client.Get(ctx, apispec.PathCurrentAccountFeatures, &caps); err != nil {
+		return nil, err
+	}
+	return &caps, nil
+}
+
+// GetFeatures retrieves the capabilities enabled for the service identified
+// by id.
+//
+// Example:
+//
+//	features, err := client.Services.GetFeatures(ctx, serviceID)
+//	if err != nil {
+//		return err
+//	}
+//	if err := features.Features.Require(api.CapabilityWebSockets); err != nil {
+//		log.Printf("skipping WebSocket setup: %v", err)
+//	}
+func (s *ServicesService) GetFeatures(ctx context.Context, id string) (*ServiceFeatures, error) {
+	if err := validate.ID("service ID", id); err != nil {
+		return nil, err
+	}
+
+	var features ServiceFeatures
+	if err := s.Client.Get(ctx, fmt.Sprintf(apispec.PathServiceFeatures, url.PathEscape(id)), &features); err != nil {
+		return nil, err
+	}
+	if features.ServiceID == "" {
+		features.ServiceID = id
+	}
+	return &features, nil
+}
@@ -0,0 +1,72 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestAccountsService_GetCapabilities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/accounts/me/features" {
+			t.Errorf("Expected path /api/2.5/accounts/me/features, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"plan":"business","features":{"imageOptimization":true,"webSockets":true,"chinaDelivery":false}}`))
+	}))
+	defer server.Close()
+
+	svc := &AccountsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	caps, err := svc.GetCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if caps.Plan != "business" || !caps.Features.Enabled(CapabilityImageOptimization) {
+		t.Errorf("Unexpected capabilities %+v", caps)
+	}
+	if got := caps.Features.List(); !reflect.DeepEqual(got, []string{"imageOptimization", "webSockets"}) {
+		t.Errorf("Expected the enabled capabilities, got %v", got)
+	}
+
+	if err := caps.Features.Require(CapabilityWebSockets); err != nil {
+		t.Errorf("Expected WebSockets to be enabled, got %v", err)
+	}
+	for _, capability := range []string{CapabilityChinaDelivery, CapabilityFTP} {
+		var unavailable *FeatureUnavailableError
+		err := caps.Features.Require(capability)
+		if !errors.Is(err, ErrFeatureUnavailable) || !errors.As(err, &unavailable) || unavailable.Feature != capability {
+			t.Errorf("Expected FeatureUnavailableError for %s, got %v", capability, err)
+		}
+	}
+}
+
+func TestServicesService_GetFeatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/services/svc-1/features" {
+			t.Errorf("Expected path /api/2.5/services/svc-1/features, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"features":{"webSockets":true}}`))
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	features, err := svc.GetFeatures(context.Background(), "svc-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if features.ServiceID != "svc-1" || !features.Features.Enabled(CapabilityWebSockets) || features.Features.Enabled(CapabilityImageOptimization) {
+		t.Errorf("Unexpected features %+v", features)
+	}
+
+	if _, err := svc.GetFeatures(context.Background(), ""); err == nil {
+		t.Error("Expected an error for an empty service ID")
+	}
+}
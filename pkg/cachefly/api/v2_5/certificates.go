@@ -2,11 +2,14 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
+	"time"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // CertificatesService handles TLS/SSL certificate operations.
@@ -28,6 +31,9 @@ type Certificate struct {
 	Domains           []string `json:"domains"`
 	NotBefore         string   `json:"notBefore"`
 	NotAfter          string   `json:"notAfter"`
+
+	// Raw holds the response fields Certificate does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // ListCertificatesResponse contains paginated certificate results.
@@ -44,6 +50,13 @@ type ListCertificatesOptions struct {
 	Limit        int
 }
 
+// Apply sets the offset, limit and search of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListCertificatesOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit, search: &o.Search}.apply(opts)
+}
+
 // CreateCertificateRequest contains the required fields for uploading a certificate.
 type CreateCertificateRequest struct {
 	Certificate    string `json:"certificate"`        // required, PEM-encoded certificate
@@ -53,7 +66,7 @@ type CreateCertificateRequest struct {
 
 // List retrieves certificates with optional filtering and pagination.
 func (s *CertificatesService) List(ctx context.Context, opts ListCertificatesOptions) (*ListCertificatesResponse, error) {
-	endpoint := "/certificates"
+	endpoint := apispec.PathCertificates
 	params := url.Values{}
 
 	if opts.ResponseType != "" {
@@ -62,17 +75,9 @@ func (s *CertificatesService) List(ctx context.Context, opts ListCertificatesOpt
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
-
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
 	var resp ListCertificatesResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Certificates); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -84,7 +89,7 @@ func (s *CertificatesService) Create(ctx context.Context, req CreateCertificateR
 		return nil, fmt.Errorf("certificate and certificateKey are required")
 	}
 
-	endpoint := "/certificates"
+	endpoint := apispec.PathCertificates
 
 	var created Certificate
 	if err := s.Client.Post(ctx, endpoint, req, &created); err != nil {
@@ -95,18 +100,11 @@ func (s *CertificatesService) Create(ctx context.Context, req CreateCertificateR
 
 // GetByID retrieves a certificate by its ID.
 func (s *CertificatesService) GetByID(ctx context.Context, id, responseType string) (*Certificate, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
-	}
-
-	endpoint := fmt.Sprintf("/certificates/%s", id)
-	params := url.Values{}
-	if responseType != "" {
-		params.Set("responseType", responseType)
+	fullURL, err := certificateURL(id, responseType)
+	if err != nil {
+		return nil, err
 	}
 
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
-
 	var cert Certificate
 	if err := s.Client.Get(ctx, fullURL, &cert); err != nil {
 		return nil, err
@@ -114,12 +112,269 @@ func (s *CertificatesService) GetByID(ctx context.Context, id, responseType stri
 	return &cert, nil
 }
 
+// certificateURL returns the endpoint of the certificate with the given ID.
+func certificateURL(id, responseType string) (string, error) {
+	if err := validate.ID("id", id); err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf(apispec.PathCertificate, id)
+	params := url.Values{}
+	if responseType != "" {
+		params.Set("responseType", responseType)
+	}
+	return fmt.Sprintf("%s?%s", endpoint, params.Encode()), nil
+}
+
 // Delete removes a certificate by ID.
 func (s *CertificatesService) Delete(ctx context.Context, id string) error {
-	if id == "" {
-		return fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return err
 	}
 
-	endpoint := fmt.Sprintf("/certificates/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathCertificate, id)
 	return s.Client.Delete(ctx, endpoint, nil)
 }
+
+// CertificateIssuer issues a replacement for an expiring certificate, e.g.
+// through ACME or an internal CA, and returns the upload payload.
+type CertificateIssuer func(ctx context.Context, expiring Certificate) (*CreateCertificateRequest, error)
+
+// RenewalStatus is the outcome of renewing a single certificate.
+type RenewalStatus string
+
+// Renewal outcomes reported in CertificateRenewal.
+const (
+	RenewalRenewed RenewalStatus = "renewed"
+	RenewalSkipped RenewalStatus = "skipped"
+	RenewalFailed  RenewalStatus = "failed"
+
+	// RenewalPartial means rebinding failed and could not be fully rolled
+	// back: NewCertificateID was not deleted and ReboundDomains still use it
+	RenewalPartial RenewalStatus = "partial"
+)
+
+// CertificateRenewal reports what happened to one expiring certificate.
+type CertificateRenewal struct {
+	OldCertificateID  string        `json:"oldCertificateId"`
+	NewCertificateID  string        `json:"newCertificateId,omitempty"`
+	SubjectCommonName string        `json:"subjectCommonName"`
+	NotAfter          string        `json:"notAfter"`
+	Status            RenewalStatus `json:"status"`
+	ReboundDomains    []string      `json:"reboundDomains,omitempty"`
+	OldDeleted        bool          `json:"oldDeleted"`
+	Reason            string        `json:"reason,omitempty"`
+}
+
+// CertificateRenewalReport aggregates the per-certificate results of RenewExpiring.
+type CertificateRenewalReport struct {
+	Renewals []CertificateRenewal `json:"renewals"`
+	Renewed  int                  `json:"renewed"`
+	Skipped  int                  `json:"skipped"`
+
+	// Failed counts the failed and the partial renewals
+	Failed int `json:"failed"`
+}
+
+// RenewExpiring renews every uploaded certificate that expires within the
+// given window.
+//
+// For each expiring certificate the issuer is called for a replacement,
+// which is uploaded and bound to every domain that used the old certificate.
+// When a domain cannot be rebound, the domains already rebound are put back
+// on the old certificate and the replacement is deleted. The old certificate
+// is deleted only after domains were rebound and the API no longer reports
+// it bound to any service or domain; otherwise it is kept and the reason
+// recorded. CacheFly-managed certificates are skipped.
+//
+// Failures are recorded per certificate and do not stop the run. An error
+// is returned when the certificates cannot be listed, or with the report so
+// far when ctx ends.
+func (s *CertificatesService) RenewExpiring(ctx context.Context, within time.Duration, issuer CertificateIssuer) (*CertificateRenewalReport, error) {
+	if issuer == nil {
+		return nil, fmt.Errorf("issuer is required")
+	}
+
+	var certs []Certificate
+	for offset := 0; ; offset += listAllPageSize {
+		page, err := s.List(ctx, ListCertificatesOptions{Offset: offset, Limit: listAllPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list certificates: %w", err)
+		}
+		certs = append(certs, page.Certificates...)
+		if len(page.Certificates) < listAllPageSize {
+			break
+		}
+	}
+
+	report := &CertificateRenewalReport{}
+	deadline := time.Now().Add(within)
+
+	for _, cert := range certs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if !certificateExpiresBefore(cert, deadline) {
+			continue
+		}
+
+		renewal := CertificateRenewal{
+			OldCertificateID:  cert.ID,
+			SubjectCommonName: cert.SubjectCommonName,
+			NotAfter:          cert.NotAfter,
+		}
+		if cert.Managed {
+			renewal.Status = RenewalSkipped
+			renewal.Reason = "certificate is managed by CacheFly"
+		} else {
+			s.renewCertificate(ctx, cert, issuer, &renewal)
+		}
+
+		switch renewal.Status {
+		case RenewalRenewed:
+			report.Renewed++
+		case RenewalSkipped:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+		report.Renewals = append(report.Renewals, renewal)
+	}
+
+	return report, nil
+}
+
+// reboundDomain is a domain renewCertificate moved to the replacement.
+type reboundDomain struct {
+	serviceID    string
+	domain       ServiceDomain
+	certificates []string
+}
+
+// renewCertificate issues, uploads and rebinds a single certificate.
+func (s *CertificatesService) renewCertificate(ctx context.Context, cert Certificate, issuer CertificateIssuer, renewal *CertificateRenewal) {
+	fail := func(format string, args ...interface{}) {
+		renewal.Status = RenewalFailed
+		renewal.Reason = fmt.Sprintf(format, args...)
+	}
+
+	req, err := issuer(ctx, cert)
+	if err != nil {
+		fail("issuer failed: %v", err)
+		return
+	}
+	if req == nil {
+		fail("issuer returned no certificate")
+		return
+	}
+
+	// List responses may leave out the services of a certificate
+	if len(cert.Services) == 0 {
+		full, err := s.GetByID(ctx, cert.ID, "")
+		if err != nil {
+			fail("failed to get certificate: %v", err)
+			return
+		}
+		cert.Services = full.Services
+	}
+
+	created, err := s.Create(ctx, *req)
+	if err != nil {
+		fail("failed to upload replacement: %v", err)
+		return
+	}
+	renewal.NewCertificateID = created.ID
+
+	var rebound []reboundDomain
+	domains := &ServiceDomainsService{Client: s.Client}
+	for _, serviceID := range cert.Services {
+		for offset := 0; ; offset += listAllPageSize {
+			page, err := domains.List(ctx, serviceID, ListServiceDomainsOptions{Offset: offset, Limit: listAllPageSize})
+			if err != nil {
+				s.undoRenewal(ctx, rebound, renewal, fmt.Sprintf("failed to list domains of service %s: %v", serviceID, err))
+				return
+			}
+			for _, d := range page.Domains {
+				bound := make([]string, 0, len(d.Certificates))
+				uses := false
+				for _, id := range d.Certificates {
+					if id == cert.ID {
+						uses = true
+						id = created.ID
+					}
+					bound = append(bound, id)
+				}
+				if !uses {
+					continue
+				}
+				if _, err := domains.UpdateByID(ctx, serviceID, d.ID, UpdateServiceDomainRequest{Certificates: bound}); err != nil {
+					s.undoRenewal(ctx, rebound, renewal, fmt.Sprintf("failed to rebind domain %s: %v", d.Name, err))
+					return
+				}
+				rebound = append(rebound, reboundDomain{serviceID: serviceID, domain: d, certificates: d.Certificates})
+				renewal.ReboundDomains = append(renewal.ReboundDomains, d.Name)
+			}
+			if len(page.Domains) < listAllPageSize {
+				break
+			}
+		}
+	}
+	renewal.Status = RenewalRenewed
+
+	if len(rebound) == 0 {
+		renewal.Reason = "no domain used the old certificate; it was kept"
+		return
+	}
+	current, err := s.GetByID(ctx, cert.ID, "")
+	if err != nil {
+		renewal.Reason = fmt.Sprintf("old certificate kept: failed to check that it is unused: %v", err)
+		return
+	}
+	if len(current.Services) > 0 || len(current.Domains) > 0 {
+		renewal.Reason = fmt.Sprintf("old certificate kept: still bound to services %v and domains %v", current.Services, current.Domains)
+		return
+	}
+	if err := s.Delete(ctx, cert.ID); err != nil {
+		fail("replacement bound but failed to delete old certificate: %v", err)
+		return
+	}
+	renewal.OldDeleted = true
+}
+
+// undoRenewal puts the rebound domains back on the old certificate and
+// deletes the replacement after rebinding failed with reason. Whatever it
+// cannot undo is left in renewal as a partial renewal.
+func (s *CertificatesService) undoRenewal(ctx context.Context, rebound []reboundDomain, renewal *CertificateRenewal, reason string) {
+	ctx, cancel := compensationContext(ctx)
+	defer cancel()
+
+	renewal.Status = RenewalPartial
+	domains := &ServiceDomainsService{Client: s.Client}
+	for i := len(rebound) - 1; i >= 0; i-- {
+		r := rebound[i]
+		if _, err := domains.UpdateByID(ctx, r.serviceID, r.domain.ID, UpdateServiceDomainRequest{Certificates: r.certificates}); err != nil {
+			renewal.Reason = fmt.Sprintf("%s; failed to rebind domain %s to the old certificate: %v", reason, r.domain.Name, err)
+			return
+		}
+		renewal.ReboundDomains = renewal.ReboundDomains[:i]
+	}
+	renewal.ReboundDomains = nil
+
+	if err := s.Delete(ctx, renewal.NewCertificateID); err != nil {
+		renewal.Reason = fmt.Sprintf("%s; failed to delete the replacement: %v", reason, err)
+		return
+	}
+	renewal.NewCertificateID = ""
+	renewal.Status = RenewalFailed
+	renewal.Reason = reason + "; rolled back"
+}
+
+// certificateExpiresBefore reports whether cert expires before deadline,
+// falling back to the API's expiring flag when NotAfter cannot be parsed.
+func certificateExpiresBefore(cert Certificate, deadline time.Time) bool {
+	notAfter, err := time.Parse(time.RFC3339, cert.NotAfter)
+	if err != nil {
+		return cert.Expiring || cert.Expired
+	}
+	return notAfter.Before(deadline)
+}
@@ -2,9 +2,15 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
 )
@@ -158,3 +164,221 @@ func TestCertificatesService_ErrorHandling(t *testing.T) {
 		t.Errorf("Expected 'id is required' error, got %s", err.Error())
 	}
 }
+
+// UPDATE - Test RenewExpiring method
+func TestCertificatesService_RenewExpiring(t *testing.T) {
+	soon := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	later := time.Now().Add(90 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	var deleted string
+	var rebound []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/certificates":
+			w.Write([]byte(`{"meta":{"count":3},"data":[
+				{"_id":"cert-old","subjectCommonName":"example.com","notAfter":"` + soon + `","services":["svc-1"]},
+				{"_id":"cert-managed","subjectCommonName":"auto.example.com","notAfter":"` + soon + `","managed":true},
+				{"_id":"cert-fresh","subjectCommonName":"fresh.example.com","notAfter":"` + later + `"}
+			]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/certificates":
+			w.Write([]byte(`{"_id":"cert-new","subjectCommonName":"example.com"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/svc-1/domains":
+			w.Write([]byte(`{"meta":{"count":2},"data":[
+				{"_id":"dom-1","name":"www.example.com","certificates":["cert-old"]},
+				{"_id":"dom-2","name":"other.example.com","certificates":["cert-other"]}
+			]}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/svc-1/domains/dom-1":
+			body, _ := io.ReadAll(r.Body)
+			var req UpdateServiceDomainRequest
+			json.Unmarshal(body, &req)
+			rebound = req.Certificates
+			w.Write([]byte(`{"_id":"dom-1","name":"www.example.com"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/certificates/cert-old":
+			w.Write([]byte(`{"_id":"cert-old","services":[],"domains":[]}`))
+		case r.Method == "DELETE" && r.URL.Path == "/api/2.5/certificates/cert-old":
+			deleted = "cert-old"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	client := httpclient.New(cfg)
+	svc := &CertificatesService{Client: client}
+
+	issuer := func(ctx context.Context, expiring Certificate) (*CreateCertificateRequest, error) {
+		return &CreateCertificateRequest{Certificate: "new-cert", CertificateKey: "new-key"}, nil
+	}
+	report, err := svc.RenewExpiring(context.Background(), 30*24*time.Hour, issuer)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Renewed != 1 || report.Skipped != 1 || report.Failed != 0 {
+		t.Errorf("Expected 1 renewed and 1 skipped, got %+v", report)
+	}
+	if len(rebound) != 1 || rebound[0] != "cert-new" {
+		t.Errorf("Expected domain rebound to cert-new, got %v", rebound)
+	}
+	if deleted != "cert-old" {
+		t.Error("Expected old certificate to be deleted")
+	}
+	if report.Renewals[0].NewCertificateID != "cert-new" || len(report.Renewals[0].ReboundDomains) != 1 {
+		t.Errorf("Expected detailed renewal entry, got %+v", report.Renewals[0])
+	}
+}
+
+// renewalServer fakes the endpoints RenewExpiring uses for an expiring
+// certificate cert-old bound to domains dom-1 and dom-2 of svc-1. The list
+// response leaves out the services, as shallow responses do. Changing
+// requests are recorded as "METHOD path body" and fail when fail says so.
+func renewalServer(t *testing.T, fail func(request string) bool, requests *[]string) *httptest.Server {
+	t.Helper()
+	soon := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != "GET" {
+			body, _ := io.ReadAll(r.Body)
+			request := strings.TrimSpace(r.Method + " " + r.URL.Path + " " + string(body))
+			*requests = append(*requests, request)
+			if fail != nil && fail(request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"message":"boom"}`))
+				return
+			}
+		}
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/certificates":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"cert-old","subjectCommonName":"example.com","notAfter":"` + soon + `"}]}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/certificates/cert-old":
+			w.Write([]byte(`{"_id":"cert-old","services":["svc-1"],"domains":["dom-1","dom-2"]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/certificates":
+			w.Write([]byte(`{"_id":"cert-new"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/svc-1/domains":
+			w.Write([]byte(`{"meta":{"count":2},"data":[
+				{"_id":"dom-1","name":"www.example.com","certificates":["cert-old"]},
+				{"_id":"dom-2","name":"api.example.com","certificates":["cert-old"]}
+			]}`))
+		case r.Method == "PUT":
+			w.Write([]byte(`{}`))
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func renewWith(t *testing.T, server *httptest.Server) CertificateRenewal {
+	t.Helper()
+	svc := &CertificatesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+	issuer := func(ctx context.Context, expiring Certificate) (*CreateCertificateRequest, error) {
+		return &CreateCertificateRequest{Certificate: "new-cert", CertificateKey: "new-key"}, nil
+	}
+	report, err := svc.RenewExpiring(context.Background(), 30*24*time.Hour, issuer)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Renewals) != 1 {
+		t.Fatalf("Expected 1 renewal, got %+v", report.Renewals)
+	}
+	return report.Renewals[0]
+}
+
+func TestCertificatesService_RenewExpiring_RollsBack(t *testing.T) {
+	var requests []string
+	renewal := renewWith(t, renewalServer(t, func(request string) bool {
+		return strings.HasPrefix(request, "PUT /api/2.5/services/svc-1/domains/dom-2 ")
+	}, &requests))
+
+	if renewal.Status != RenewalFailed || renewal.NewCertificateID != "" || len(renewal.ReboundDomains) != 0 {
+		t.Errorf("Expected a rolled back failure, got %+v", renewal)
+	}
+	if !strings.Contains(renewal.Reason, "api.example.com") || !strings.HasSuffix(renewal.Reason, "rolled back") {
+		t.Errorf("Unexpected reason %q", renewal.Reason)
+	}
+
+	expected := []string{
+		`POST /api/2.5/certificates {"certificate":"new-cert","certificateKey":"new-key"}`,
+		`PUT /api/2.5/services/svc-1/domains/dom-1 {"certificates":["cert-new"]}`,
+		`PUT /api/2.5/services/svc-1/domains/dom-2 {"certificates":["cert-new"]}`,
+		`PUT /api/2.5/services/svc-1/domains/dom-1 {"certificates":["cert-old"]}`,
+		`DELETE /api/2.5/certificates/cert-new`,
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestCertificatesService_RenewExpiring_Partial(t *testing.T) {
+	var requests []string
+	renewal := renewWith(t, renewalServer(t, func(request string) bool {
+		return strings.HasPrefix(request, "PUT /api/2.5/services/svc-1/domains/dom-2 ") ||
+			request == `PUT /api/2.5/services/svc-1/domains/dom-1 {"certificates":["cert-old"]}`
+	}, &requests))
+
+	if renewal.Status != RenewalPartial || renewal.NewCertificateID != "cert-new" {
+		t.Errorf("Expected a partial renewal, got %+v", renewal)
+	}
+	if !reflect.DeepEqual(renewal.ReboundDomains, []string{"www.example.com"}) {
+		t.Errorf("Expected www.example.com left on the replacement, got %v", renewal.ReboundDomains)
+	}
+	for _, r := range requests {
+		if strings.HasPrefix(r, "DELETE") {
+			t.Errorf("Expected no certificate to be deleted, got %s", r)
+		}
+	}
+}
+
+func TestCertificatesService_RenewExpiring_KeepsReferencedCertificate(t *testing.T) {
+	var requests []string
+	renewal := renewWith(t, renewalServer(t, nil, &requests))
+
+	if renewal.Status != RenewalRenewed || renewal.OldDeleted || !strings.Contains(renewal.Reason, "still bound") {
+		t.Errorf("Expected the old certificate to be kept while the API reports it bound, got %+v", renewal)
+	}
+	if len(renewal.ReboundDomains) != 2 {
+		t.Errorf("Expected both domains rebound, got %v", renewal.ReboundDomains)
+	}
+	for _, r := range requests {
+		if strings.HasPrefix(r, "DELETE") {
+			t.Errorf("Expected no certificate to be deleted, got %s", r)
+		}
+	}
+}
+
+func TestCertificatesService_RenewExpiring_Canceled(t *testing.T) {
+	soon := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"count":2},"data":[
+			{"_id":"cert-1","notAfter":"` + soon + `","services":["svc-1"]},
+			{"_id":"cert-2","notAfter":"` + soon + `","services":["svc-1"]}
+		]}`))
+	}))
+	defer server.Close()
+	svc := &CertificatesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	issued := 0
+	issuer := func(ctx context.Context, expiring Certificate) (*CreateCertificateRequest, error) {
+		issued++
+		cancel()
+		return nil, ctx.Err()
+	}
+	report, err := svc.RenewExpiring(ctx, 30*24*time.Hour, issuer)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if issued != 1 || report == nil || len(report.Renewals) != 1 {
+		t.Errorf("Expected the run to stop after the first certificate, got %d issued and report %+v", issued, report)
+	}
+}
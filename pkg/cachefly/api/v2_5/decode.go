@@ -0,0 +1,93 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// The functions below send the same requests as the matching service
+// methods, with the same authentication, retries and errors, but decode the
+// response into a caller-provided type. They are meant for reading fields the
+// SDK types do not have yet:
+//
+//	type serviceWithFlags struct {
+//		api.Service
+//		BetaFlags []string `json:"betaFlags"`
+//	}
+//
+//	svc, err := api.GetServiceAs[serviceWithFlags](ctx, client.Services, "srv_123")
+
+// ListResponse is a page of list results decoded into a caller-provided type.
+type ListResponse[T any] struct {
+	Meta MetaInfo `json:"meta"`
+	Data []T      `json:"data"`
+}
+
+// GetServiceAs retrieves a service like ServicesService.GetByID, decoded into T.
+func GetServiceAs[T any](ctx context.Context, s *ServicesService, id string) (*T, error) {
+	if err := validate.ID("service ID", id); err != nil {
+		return nil, err
+	}
+	return getAs[T](ctx, s.Client, fmt.Sprintf(apispec.PathService, url.PathEscape(id)))
+}
+
+// ListServicesAs lists services like ServicesService.List, decoding each
+// service into T.
+func ListServicesAs[T any](ctx context.Context, s *ServicesService, opts ListOptions) (*ListResponse[T], error) {
+	if err := checkSort(opts.SortBy, opts.Order); err != nil {
+		return nil, err
+	}
+	return getAs[ListResponse[T]](ctx, s.Client, listServicesURL(opts))
+}
+
+// GetOptionsAs retrieves the options of a service like
+// ServiceOptionsService.GetOptions, decoded into T. T is typically a struct
+// with a field per option of interest, such as one generated by optiongen.
+func GetOptionsAs[T any](ctx context.Context, s *ServiceOptionsService, id string) (*T, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	return getAs[T](ctx, s.Client, fmt.Sprintf(apispec.PathServiceOptions, id))
+}
+
+// GetAccountAs retrieves an account like AccountsService.GetByID, decoded
+// into T. An empty id retrieves the current account like AccountsService.Get.
+func GetAccountAs[T any](ctx context.Context, a *AccountsService, id, responseType string) (*T, error) {
+	if id == "" {
+		params := url.Values{}
+		if responseType != "" {
+			params.Set("responseType", responseType)
+		}
+		return getAs[T](ctx, a.Client, fmt.Sprintf("%s?%s", apispec.PathCurrentAccount, params.Encode()))
+	}
+
+	fullURL, err := accountURL(id, responseType)
+	if err != nil {
+		return nil, err
+	}
+	return getAs[T](ctx, a.Client, fullURL)
+}
+
+// GetCertificateAs retrieves a certificate like CertificatesService.GetByID,
+// decoded into T.
+func GetCertificateAs[T any](ctx context.Context, s *CertificatesService, id, responseType string) (*T, error) {
+	fullURL, err := certificateURL(id, responseType)
+	if err != nil {
+		return nil, err
+	}
+	return getAs[T](ctx, s.Client, fullURL)
+}
+
+// getAs performs a GET request and decodes the JSON response into a new T.
+func getAs[T any](ctx context.Context, c *httpclient.Client, endpoint string) (*T, error) {
+	var out T
+	if err := c.Get(ctx, endpoint, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
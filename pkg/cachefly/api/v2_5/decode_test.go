@@ -0,0 +1,143 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+type serviceWithFlags struct {
+	Service
+	BetaFlags []string `json:"betaFlags"`
+}
+
+func TestGetServiceAs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/services/srv-1" {
+			t.Errorf("Expected path /api/2.5/services/srv-1, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected bearer token, got %s", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"srv-1","name":"Site","betaFlags":["edge-compute"]}`))
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+	result, err := GetServiceAs[serviceWithFlags](context.Background(), svc, "srv-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.ID != "srv-1" || result.Name != "Site" {
+		t.Errorf("Expected embedded service fields, got %+v", result.Service)
+	}
+	if len(result.BetaFlags) != 1 || result.BetaFlags[0] != "edge-compute" {
+		t.Errorf("Expected extra field to be decoded, got %v", result.BetaFlags)
+	}
+}
+
+func TestGetServiceAs_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+	_, err := GetServiceAs[serviceWithFlags](context.Background(), svc, "srv-1")
+
+	var apiErr *httpclient.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected APIError with status 404, got %v", err)
+	}
+
+	if _, err := GetServiceAs[serviceWithFlags](context.Background(), svc, ""); err == nil {
+		t.Error("Expected error for empty ID")
+	}
+}
+
+func TestListServicesAs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("status") != "ACTIVE" {
+			t.Errorf("Expected status filter ACTIVE, got %s", r.URL.Query().Get("status"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"count":2,"limit":10,"offset":0},"data":[{"_id":"a","betaFlags":["x"]},{"_id":"b"}]}`))
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+	result, err := ListServicesAs[serviceWithFlags](context.Background(), svc, ListOptions{Status: "ACTIVE"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Meta.Count != 2 || len(result.Data) != 2 {
+		t.Fatalf("Expected 2 services, got %+v", result)
+	}
+	if result.Data[0].ID != "a" || len(result.Data[0].BetaFlags) != 1 {
+		t.Errorf("Unexpected first service %+v", result.Data[0])
+	}
+}
+
+func TestGetOptionsAs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/srv-1/options" {
+			t.Errorf("Expected path /services/srv-1/options, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"cors":true,"ttl":3600,"reverseProxy":{"enabled":true,"hostname":"origin.example.com"}}`))
+	}))
+	defer server.Close()
+
+	type options struct {
+		Cors         bool `json:"cors"`
+		TTL          int  `json:"ttl"`
+		ReverseProxy struct {
+			Enabled  bool   `json:"enabled"`
+			Hostname string `json:"hostname"`
+		} `json:"reverseProxy"`
+	}
+
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+	result, err := GetOptionsAs[options](context.Background(), svc, "srv-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Cors || result.TTL != 3600 || result.ReverseProxy.Hostname != "origin.example.com" {
+		t.Errorf("Unexpected options %+v", result)
+	}
+}
+
+func TestGetAccountAs(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"acc-1","plan":"enterprise"}`))
+	}))
+	defer server.Close()
+
+	type account struct {
+		ID   string `json:"_id"`
+		Plan string `json:"plan"`
+	}
+
+	svc := &AccountsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+	for _, id := range []string{"", "acc-1"} {
+		result, err := GetAccountAs[account](context.Background(), svc, id, "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Plan != "enterprise" {
+			t.Errorf("Expected plan enterprise, got %s", result.Plan)
+		}
+	}
+	if len(paths) != 2 || paths[0] != "/accounts/me" || paths[1] != "/accounts/acc-1" {
+		t.Errorf("Unexpected paths %v", paths)
+	}
+}
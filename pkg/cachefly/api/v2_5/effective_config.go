@@ -0,0 +1,190 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// SettingSource tells where the value of an effective setting comes from.
+type SettingSource string
+
+const (
+	// SourceService is a basic setting of the service itself
+	SourceService SettingSource = "service"
+	// SourceAccount is an account default the service inherits
+	SourceAccount SettingSource = "account"
+	// SourceOption is an option set on the service
+	SourceOption SettingSource = "option"
+	// SourceDefault is the default of an option the service does not set
+	SourceDefault SettingSource = "default"
+)
+
+// EffectiveSetting is the value a setting has at the edge.
+type EffectiveSetting struct {
+	// Value is converted to the Go type of the option, see OptionValue
+	Value interface{} `json:"value"`
+
+	// Enabled is set for options using the enabled/value structure
+	Enabled *bool `json:"enabled,omitempty"`
+
+	Source SettingSource `json:"source"`
+}
+
+// EffectiveConfig is a normalized view of how a service behaves at the edge.
+type EffectiveConfig struct {
+	ServiceID string `json:"serviceId"`
+	Status    string `json:"status"`
+
+	// Settings holds the basic settings tlsProfile, deliveryRegion, autoSsl
+	// and configurationMode, and every option available to the service,
+	// keyed by name
+	Settings map[string]EffectiveSetting `json:"settings"`
+
+	// ScriptConfigs are the active script configs bound to the service
+	ScriptConfigs []ScriptConfig `json:"scriptConfigs"`
+
+	// Rules are the service rules in evaluation order
+	Rules []map[string]interface{} `json:"rules"`
+}
+
+// Names returns the setting names in sorted order.
+func (c *EffectiveConfig) Names() []string {
+	names := make([]string, 0, len(c.Settings))
+	for name := range c.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Enabled reports whether the named setting is set and enabled, and returns
+// its value. Options use either a plain value, the enabled/value structure,
+// or an object with an enabled field next to its settings; for the latter
+// the whole object is returned. A non-boolean value without an enabled flag
+// counts as enabled.
+func (c *EffectiveConfig) Enabled(name string) (bool, interface{}) {
+	s, ok := c.Settings[name]
+	if !ok || s.Value == nil {
+		return false, nil
+	}
+	if s.Enabled != nil {
+		return *s.Enabled, s.Value
+	}
+	switch v := s.Value.(type) {
+	case bool:
+		return v, v
+	case map[string]interface{}:
+		enabled, hasEnabled := v["enabled"].(bool)
+		if !hasEnabled {
+			return true, v
+		}
+		if inner, ok := v["value"]; ok {
+			return enabled, inner
+		}
+		return enabled, v
+	}
+	return true, s.Value
+}
+
+// EffectiveConfig resolves the configuration a service runs with: its basic
+// settings, falling back to the defaults of the current account, every
+// option available to it, falling back to the option defaults from the
+// metadata, and the active script configs and rules that apply to it.
+func (s *ServicesService) EffectiveConfig(ctx context.Context, id string) (*EffectiveConfig, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+
+	svc, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	account, err := (&AccountsService{Client: s.Client}).Get(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	cfg := &EffectiveConfig{
+		ServiceID: svc.ID,
+		Status:    svc.Status,
+		Settings: map[string]EffectiveSetting{
+			"autoSsl":           {Value: svc.AutoSSL, Source: SourceService},
+			"configurationMode": {Value: svc.ConfigurationMode, Source: SourceService},
+			"tlsProfile":        inherited(svc.TLSProfile, account.DefaultTlsProfile),
+			"deliveryRegion":    inherited(svc.DeliveryRegion, account.DefaultDeliveryRegion),
+		},
+	}
+
+	optionsService := &ServiceOptionsService{Client: s.Client}
+	metadata, err := optionsService.GetOptionsMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get options metadata: %w", err)
+	}
+	options, err := optionsService.GetOptions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service options: %w", err)
+	}
+	for name, raw := range options {
+		opt, _ := metadata.Find(name)
+		cfg.Settings[name] = effectiveOption(opt, raw, SourceOption)
+	}
+	for i := range metadata.Data {
+		opt := &metadata.Data[i]
+		name := opt.FieldName()
+		if _, ok := cfg.Settings[name]; ok || name == "" || opt.Property == nil || opt.Property.Default == nil {
+			continue
+		}
+		cfg.Settings[name] = effectiveOption(opt, opt.Property.Default, SourceDefault)
+	}
+
+	scriptConfigs := &ScriptConfigsService{Client: s.Client}
+	for offset := 0; ; offset += listAllPageSize {
+		page, err := scriptConfigs.List(ctx, ListScriptConfigsOptions{Status: "ACTIVE", Offset: offset, Limit: listAllPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list script configs: %w", err)
+		}
+		for _, sc := range page.Configs {
+			for _, sid := range sc.Services {
+				if sid == id {
+					cfg.ScriptConfigs = append(cfg.ScriptConfigs, sc)
+					break
+				}
+			}
+		}
+		if len(page.Configs) < listAllPageSize {
+			break
+		}
+	}
+
+	if cfg.Rules, err = s.listRawRules(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// effectiveOption converts an option value like GetOption does. Values
+// that do not match the metadata are kept as returned by the API, so one odd
+// option does not hide the rest of the configuration.
+func effectiveOption(opt *OptionMetadata, raw interface{}, source SettingSource) EffectiveSetting {
+	setting := EffectiveSetting{Value: raw, Source: source}
+	if opt == nil {
+		return setting
+	}
+	if value, enabled, err := optionValue(opt, raw); err == nil {
+		setting.Value, setting.Enabled = value, enabled
+	}
+	return setting
+}
+
+// inherited returns the service value of a basic setting, or the account
+// default when the service does not set one.
+func inherited(value, accountDefault string) EffectiveSetting {
+	if value == "" && accountDefault != "" {
+		return EffectiveSetting{Value: accountDefault, Source: SourceAccount}
+	}
+	return EffectiveSetting{Value: value, Source: SourceService}
+}
@@ -0,0 +1,109 @@
+package v2_5
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestServicesService_EffectiveConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/services/svc-123":
+			w.Write([]byte(`{"_id":"svc-123","status":"ACTIVE","autoSsl":true,"deliveryRegion":"eu"}`))
+		case "/accounts/me":
+			w.Write([]byte(`{"_id":"acc-1","defaultTlsProfile":"modern","defaultDeliveryRegion":"global"}`))
+		case "/services/svc-123/options/metadata":
+			w.Write([]byte(`{"meta":{"count":2},"data":[
+				{"name":"ttl","type":"dynamic","property":{"name":"ttl","type":"integer","default":86400}},
+				{"name":"cors","type":"dynamic","property":{"name":"cors","type":"boolean","default":false}}
+			]}`))
+		case "/services/svc-123/options":
+			w.Write([]byte(`{"ttl":{"enabled":true,"value":"3600"}}`))
+		case "/scriptConfigs":
+			if r.URL.Query().Get("status") != "ACTIVE" {
+				t.Errorf("Expected active script configs to be listed, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"meta":{"count":2},"data":[
+				{"_id":"sc-1","name":"Bound","services":["svc-999","svc-123"]},
+				{"_id":"sc-2","name":"Other","services":["svc-999"]}
+			]}`))
+		case "/services/svc-123/rules":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"rule-1","directory":"/img"}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+
+	cfg, err := svc.EffectiveConfig(context.Background(), "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := cfg.Settings["tlsProfile"]; got.Value != "modern" || got.Source != SourceAccount {
+		t.Errorf("Expected tlsProfile inherited from the account, got %+v", got)
+	}
+	if got := cfg.Settings["deliveryRegion"]; got.Value != "eu" || got.Source != SourceService {
+		t.Errorf("Expected deliveryRegion from the service, got %+v", got)
+	}
+	if got := cfg.Settings["ttl"]; got.Value != 3600 || got.Source != SourceOption || got.Enabled == nil || !*got.Enabled {
+		t.Errorf("Expected enabled ttl option of 3600, got %+v", got)
+	}
+	if got := cfg.Settings["cors"]; got.Value != false || got.Source != SourceDefault {
+		t.Errorf("Expected cors default, got %+v", got)
+	}
+	if len(cfg.ScriptConfigs) != 1 || cfg.ScriptConfigs[0].ID != "sc-1" {
+		t.Errorf("Expected only the bound script config, got %+v", cfg.ScriptConfigs)
+	}
+	if len(cfg.Rules) != 1 || cfg.Rules[0]["directory"] != "/img" {
+		t.Errorf("Expected rules, got %+v", cfg.Rules)
+	}
+	if names := cfg.Names(); len(names) != 6 || names[0] != "autoSsl" {
+		t.Errorf("Expected 6 sorted setting names, got %v", names)
+	}
+}
+
+func TestServicesService_EffectiveConfigValidation(t *testing.T) {
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: "http://unused"})}
+	if _, err := svc.EffectiveConfig(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty id")
+	}
+}
+
+func TestEffectiveConfig_Enabled(t *testing.T) {
+	on := true
+	cfg := &EffectiveConfig{Settings: map[string]EffectiveSetting{
+		"ttl":          {Value: 3600, Enabled: &on},
+		"cors":         {Value: false},
+		"expiry":       {Value: map[string]interface{}{"enabled": true, "value": []interface{}{}}},
+		"reverseProxy": {Value: map[string]interface{}{"enabled": false, "hostname": "origin"}},
+		"hsts":         {Value: 300},
+	}}
+
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{"ttl", true},
+		{"cors", false},
+		{"expiry", true},
+		{"reverseProxy", false},
+		{"hsts", true},
+		{"missing", false},
+	}
+	for _, tt := range tests {
+		if got, _ := cfg.Enabled(tt.name); got != tt.enabled {
+			t.Errorf("Expected %s enabled=%v, got %v", tt.name, tt.enabled, got)
+		}
+	}
+	if _, v := cfg.Enabled("reverseProxy"); v.(map[string]interface{})["hostname"] != "origin" {
+		t.Errorf("Expected the whole object for options without a value field, got %v", v)
+	}
+}
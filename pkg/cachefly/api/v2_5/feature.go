@@ -0,0 +1,67 @@
+package v2_5
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// ErrFeatureUnavailable is matched by errors.Is when an endpoint is not
+// available for the account, usually because its plan does not include the
+// feature. Use errors.As with *FeatureUnavailableError to read the feature.
+var ErrFeatureUnavailable = errors.New("feature not available for this account")
+
+// Optional features, as reported in FeatureUnavailableError.Feature.
+const (
+	FeatureReports       = "reports"
+	FeatureRawLogs       = "raw logs"
+	FeatureScriptConfigs = "script configs"
+	FeatureFTP           = "FTP"
+)
+
+// FeatureUnavailableError is returned instead of a 404 response by
+// endpoints that are not offered on every plan.
+type FeatureUnavailableError struct {
+	Feature string
+
+	// Err is the API error
+	Err error
+}
+
+func (e *FeatureUnavailableError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Feature, ErrFeatureUnavailable)
+}
+
+// Is reports whether target is ErrFeatureUnavailable.
+func (e *FeatureUnavailableError) Is(target error) bool {
+	return target == ErrFeatureUnavailable
+}
+
+// Unwrap returns the API error.
+func (e *FeatureUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// MessageKey returns "feature_unavailable" for message catalogs.
+func (e *FeatureUnavailableError) MessageKey() string {
+	return apispec.ErrorCodeFeatureUnavailable
+}
+
+// MessageParams returns the feature for message catalogs.
+func (e *FeatureUnavailableError) MessageParams() map[string]string {
+	return map[string]string{"feature": e.Feature}
+}
+
+// featureUnavailable turns a 404 response of an optional endpoint into a
+// FeatureUnavailableError and returns other errors unchanged. It is only
+// used for endpoints that answer 404 when the feature is not enabled.
+func featureUnavailable(feature string, err error) error {
+	var apiErr *httpclient.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return &FeatureUnavailableError{Feature: feature, Err: err}
+	}
+	return err
+}
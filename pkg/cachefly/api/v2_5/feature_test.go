@@ -0,0 +1,65 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestFeatureUnavailable_OptionalEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	client := httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})
+	ctx := context.Background()
+
+	tests := []struct {
+		feature string
+		call    func() error
+	}{
+		{FeatureReports, func() error {
+			_, err := (&BillingService{Client: client}).Usage(ctx, UsageOptions{})
+			return err
+		}},
+		{FeatureScriptConfigs, func() error {
+			_, err := (&ScriptConfigsService{Client: client}).List(ctx, ListScriptConfigsOptions{})
+			return err
+		}},
+		{FeatureRawLogs, func() error {
+			_, err := (&LogsService{Client: client}).Download(ctx, "svc-123", DownloadLogsOptions{})
+			return err
+		}},
+		{FeatureFTP, func() error {
+			_, err := (&ServiceOptionsService{Client: client}).GetFTPSettings(ctx, "svc-123", true)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		err := tt.call()
+		if !errors.Is(err, ErrFeatureUnavailable) {
+			t.Errorf("%s: expected ErrFeatureUnavailable, got %v", tt.feature, err)
+			continue
+		}
+		var featureErr *FeatureUnavailableError
+		if !errors.As(err, &featureErr) || featureErr.Feature != tt.feature {
+			t.Errorf("%s: expected the feature to be reported, got %v", tt.feature, err)
+		}
+		var apiErr *httpclient.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: expected the API error to be wrapped, got %v", tt.feature, err)
+		}
+	}
+
+	// Required endpoints keep the plain API error
+	_, err := (&CertificatesService{Client: client}).GetByID(ctx, "cert-1", "")
+	if err == nil || errors.Is(err, ErrFeatureUnavailable) {
+		t.Errorf("Expected a plain API error, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// SortOrder is the direction results are sorted in.
+type SortOrder string
+
+// Sort orders.
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// ListOption sets one of the listing fields shared by all List methods:
+// Offset, Limit, SortBy, Order and Search.
+//
+// The options apply to ListOptions with NewListOptions or Apply, and to the
+// options of the other services with their Apply method, which returns an
+// ErrUnsupportedListOption error for options the endpoint does not support
+// rather than listing unfiltered results.
+//
+// Example:
+//
+//	page := []api.ListOption{api.WithLimit(50), api.WithSortBy("name"), api.WithOrder(api.SortDescending)}
+//	services, err := client.Services.List(ctx, api.NewListOptions(page...))
+//
+//	certs := api.ListCertificatesOptions{ResponseType: "shallow"}
+//	if err := certs.Apply(api.WithLimit(50), api.WithSearch("example.com")); err != nil {
+//		return err
+//	}
+//	certificates, err := client.Certificates.List(ctx, certs)
+type ListOption func(*ListOptions)
+
+// WithOffset skips the first n results.
+func WithOffset(n int) ListOption {
+	return func(o *ListOptions) { o.Offset = n }
+}
+
+// WithLimit returns at most n results per page.
+func WithLimit(n int) ListOption {
+	return func(o *ListOptions) { o.Limit = n }
+}
+
+// WithSortBy sorts the results by fields, in order of precedence.
+func WithSortBy(fields ...string) ListOption {
+	return func(o *ListOptions) { o.SortBy = append([]string(nil), fields...) }
+}
+
+// WithOrder sorts the results in order. It requires WithSortBy; List
+// methods return an ErrUnsupportedListOption error for an order alone.
+func WithOrder(order SortOrder) ListOption {
+	return func(o *ListOptions) { o.Order = order }
+}
+
+// WithSearch matches the results whose name contains s.
+func WithSearch(s string) ListOption {
+	return func(o *ListOptions) { o.Search = s }
+}
+
+// NewListOptions returns the ListOptions set by opts.
+func NewListOptions(opts ...ListOption) ListOptions {
+	var o ListOptions
+	o.Apply(opts...)
+	return o
+}
+
+// Apply sets the fields of o from opts.
+func (o *ListOptions) Apply(opts ...ListOption) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// ErrUnsupportedListOption is returned by the Apply methods of list options
+// when an option sets a field the endpoint does not support.
+var ErrUnsupportedListOption = errors.New("list option not supported by the endpoint")
+
+// listFields points at the shared listing fields of a service's list
+// options; nil fields are not supported by the endpoint.
+type listFields struct {
+	offset *int
+	limit  *int
+	sortBy *[]string
+	order  *SortOrder
+	search *string
+}
+
+// apply sets the supported fields from opts, or none of them when opts set
+// an unsupported one.
+func (f listFields) apply(opts []ListOption) error {
+	var o ListOptions
+	if f.offset != nil {
+		o.Offset = *f.offset
+	}
+	if f.limit != nil {
+		o.Limit = *f.limit
+	}
+	if f.sortBy != nil {
+		o.SortBy = *f.sortBy
+	}
+	if f.order != nil {
+		o.Order = *f.order
+	}
+	if f.search != nil {
+		o.Search = *f.search
+	}
+
+	o.Apply(opts...)
+
+	var unsupported []string
+	for _, u := range []struct {
+		name      string
+		supported bool
+		set       bool
+	}{
+		{"offset", f.offset != nil, o.Offset != 0},
+		{"limit", f.limit != nil, o.Limit != 0},
+		{"sortBy", f.sortBy != nil, len(o.SortBy) > 0},
+		{"order", f.order != nil, o.Order != ""},
+		{"search", f.search != nil, o.Search != ""},
+	} {
+		if u.set && !u.supported {
+			unsupported = append(unsupported, u.name)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnsupportedListOption, strings.Join(unsupported, ", "))
+	}
+	if err := checkSort(o.SortBy, o.Order); err != nil {
+		return err
+	}
+
+	if f.offset != nil {
+		*f.offset = o.Offset
+	}
+	if f.limit != nil {
+		*f.limit = o.Limit
+	}
+	if f.sortBy != nil {
+		*f.sortBy = o.SortBy
+	}
+	if f.order != nil {
+		*f.order = o.Order
+	}
+	if f.search != nil {
+		*f.search = o.Search
+	}
+	return nil
+}
+
+// getList GETs the page of limit items from offset of the list at endpoint,
+// with the filters in params, into meta and items.
+//
+// The API may cap the page size below limit, which it reports as the limit
+// in the page's meta. getList then fetches the following pages until limit
+// items are listed or the list ends, rather than returning fewer items
+// than asked for; meta then describes the combined page.
+func getList[T any](ctx context.Context, client *httpclient.Client, endpoint string, params url.Values, offset, limit int, meta *MetaInfo, items *[]T) error {
+	requested := limit
+	for first := true; ; first = false {
+		var page struct {
+			Meta MetaInfo `json:"meta"`
+			Data []T      `json:"data"`
+		}
+		setPage(params, offset, limit)
+		if err := client.Get(ctx, endpoint+"?"+params.Encode(), &page); err != nil {
+			return err
+		}
+		if first {
+			*meta = page.Meta
+		} else {
+			meta.Count = page.Meta.Count
+			meta.Limit = requested
+		}
+		*items = append(*items, page.Data...)
+
+		n := len(page.Data)
+		capped := page.Meta.Limit > 0 && page.Meta.Limit < limit
+		if !capped || n < page.Meta.Limit || n >= limit {
+			return nil
+		}
+		if offset < 0 {
+			offset = 0
+		}
+		offset += n
+		limit -= n
+	}
+}
+
+// setPage sets the offset and limit query parameters. The offset is always
+// sent unless negative, the limit only when positive.
+func setPage(params url.Values, offset, limit int) {
+	if offset >= 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+// checkSort returns an ErrUnsupportedListOption error when order is set
+// without fields to sort by, since the API has no order of its own and the
+// results would come back unsorted.
+func checkSort(fields []string, order SortOrder) error {
+	if order != "" && len(fields) == 0 {
+		return fmt.Errorf("%w: order without sortBy", ErrUnsupportedListOption)
+	}
+	return nil
+}
+
+// addSort adds a sortBy query parameter per field. The API sorts fields
+// prefixed with "-" in descending order, so SortDescending prefixes the
+// fields that have no prefix yet.
+func addSort(params url.Values, fields []string, order SortOrder) {
+	for _, field := range fields {
+		if order == SortDescending && !strings.HasPrefix(field, "-") {
+			field = "-" + field
+		}
+		params.Add("sortBy", field)
+	}
+}
@@ -0,0 +1,178 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestNewListOptions(t *testing.T) {
+	opts := NewListOptions(WithOffset(20), WithLimit(10), WithSortBy("name", "-createdAt"), WithOrder(SortDescending), WithSearch("shop"))
+	want := ListOptions{Offset: 20, Limit: 10, SortBy: []string{"name", "-createdAt"}, Order: SortDescending, Search: "shop"}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("Expected %+v, got %+v", want, opts)
+	}
+
+	certs := ListCertificatesOptions{ResponseType: "shallow", Limit: 5}
+	if err := certs.Apply(WithOffset(10), WithSearch("example")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if certs != (ListCertificatesOptions{ResponseType: "shallow", Search: "example", Offset: 10, Limit: 5}) {
+		t.Errorf("Expected the supported fields to be set, got %+v", certs)
+	}
+
+	err := certs.Apply(WithOffset(20), WithSortBy("name"), WithOrder(SortDescending))
+	if !errors.Is(err, ErrUnsupportedListOption) || !strings.Contains(err.Error(), "sortBy, order") {
+		t.Errorf("Expected the unsupported options in an ErrUnsupportedListOption, got %v", err)
+	}
+	if certs.Offset != 10 {
+		t.Errorf("Expected no field to be set on error, got %+v", certs)
+	}
+
+	origins := ListOriginsOptions{}
+	if err := origins.Apply(WithSearch("example")); !errors.Is(err, ErrUnsupportedListOption) {
+		t.Errorf("Expected search to be unsupported on origins, got %v", err)
+	}
+}
+
+func TestListOptions_Query(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.Query().Encode())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})
+	page := []ListOption{WithLimit(10), WithSortBy("name", "-createdAt"), WithOrder(SortDescending)}
+
+	if _, err := (&ServicesService{Client: client}).List(context.Background(), NewListOptions(page...)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	opts := ListScriptConfigsOptions{Status: "ACTIVE"}
+	if err := opts.Apply(page...); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := (&ScriptConfigsService{Client: client}).List(context.Background(), opts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := []string{
+		"includeFeatures=false&limit=10&offset=0&sortBy=-name&sortBy=-createdAt",
+		"includeFeatures=false&includeHidden=false&limit=10&offset=0&sortBy=-name&sortBy=-createdAt&status=ACTIVE",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected queries %v, got %v", want, got)
+	}
+}
+
+func TestListOptions_OrderWithoutSortBy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request, got %s", r.URL)
+	}))
+	defer server.Close()
+	client := httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})
+	ctx := context.Background()
+
+	if _, err := (&ServicesService{Client: client}).List(ctx, NewListOptions(WithOrder(SortDescending))); !errors.Is(err, ErrUnsupportedListOption) {
+		t.Errorf("Expected services to reject an order without sortBy, got %v", err)
+	}
+
+	var profiles ListTLSProfilesOptions
+	if err := profiles.Apply(WithOrder(SortDescending)); !errors.Is(err, ErrUnsupportedListOption) {
+		t.Errorf("Expected TLS profiles to reject an order without sortBy, got %v", err)
+	}
+	if _, err := (&TLSProfilesService{Client: client}).List(ctx, ListTLSProfilesOptions{Order: SortDescending}); !errors.Is(err, ErrUnsupportedListOption) {
+		t.Errorf("Expected listing TLS profiles to reject an order without sortBy, got %v", err)
+	}
+
+	var configs ListScriptConfigsOptions
+	if err := configs.Apply(WithOrder(SortDescending)); !errors.Is(err, ErrUnsupportedListOption) {
+		t.Errorf("Expected script configs to reject an order without sortBy, got %v", err)
+	}
+	if _, err := (&ScriptConfigsService{Client: client}).List(ctx, ListScriptConfigsOptions{Order: SortDescending}); !errors.Is(err, ErrUnsupportedListOption) {
+		t.Errorf("Expected listing script configs to reject an order without sortBy, got %v", err)
+	}
+}
+
+// cappedServer serves the services a to e, capping pages at 2 items.
+func cappedServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	ids := []string{"a", "b", "c", "d", "e"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.Query().Get("offset")+"/"+r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit > 2 {
+			limit = 2
+		}
+		end := offset + limit
+		if end > len(ids) {
+			end = len(ids)
+		}
+		var data []string
+		for _, id := range ids[offset:end] {
+			data = append(data, `{"_id":"`+id+`"}`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"meta":{"offset":%d,"limit":2,"count":5},"data":[%s]}`, offset, strings.Join(data, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestList_CappedPageSize(t *testing.T) {
+	var requests []string
+	server := cappedServer(t, &requests)
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+
+	result, err := svc.List(context.Background(), ListOptions{Offset: 1, Limit: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Services) != 3 || result.Services[0].ID != "b" || result.Services[2].ID != "d" {
+		t.Errorf("Expected services b to d, got %+v", result.Services)
+	}
+	if result.Meta != (MetaInfo{Offset: 1, Limit: 3, Count: 5}) {
+		t.Errorf("Expected the meta of the combined page, got %+v", result.Meta)
+	}
+	if !reflect.DeepEqual(requests, []string{"1/3", "3/1"}) {
+		t.Errorf("Expected the rest of the page to be fetched, got %v", requests)
+	}
+
+	requests = nil
+	result, err = svc.List(context.Background(), ListOptions{Offset: 2, Limit: 10})
+	if err != nil || len(result.Services) != 3 {
+		t.Errorf("Expected the last 3 services, got %+v, %v", result, err)
+	}
+	if !reflect.DeepEqual(requests, []string{"2/10", "4/8"}) {
+		t.Errorf("Expected fetching to stop at the end of the list, got %v", requests)
+	}
+}
+
+func TestListEach_CappedPageSize(t *testing.T) {
+	var requests []string
+	server := cappedServer(t, &requests)
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+
+	var ids []string
+	err := svc.ListEach(context.Background(), ListOptions{Limit: 10}, func(s *Service) error {
+		ids = append(ids, s.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(ids, "") != "abcde" {
+		t.Errorf("Expected all services, got %v", ids)
+	}
+}
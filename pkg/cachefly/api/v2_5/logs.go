@@ -0,0 +1,95 @@
+package v2_5
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// LogType selects which raw logs of a service are downloaded.
+type LogType string
+
+// Raw log types.
+const (
+	LogTypeAccess LogType = "access"
+	LogTypeOrigin LogType = "origin"
+)
+
+// DownloadLogsOptions specifies the logs to download.
+type DownloadLogsOptions struct {
+	// Type selects access or origin logs; empty means access logs
+	Type LogType
+
+	// From and To bound the period of the logs; zero values are omitted
+	From time.Time
+	To   time.Time
+
+	// Decompress returns the uncompressed log lines instead of the gzip stream
+	Decompress bool
+}
+
+// LogsService handles raw log downloads.
+type LogsService struct {
+	Client *httpclient.Client
+}
+
+// Download streams the gzip-compressed raw logs of a service. The logs are
+// never buffered in memory, so arbitrarily large periods can be downloaded;
+// the caller must close the returned reader.
+//
+// With opts.Decompress set, the reader yields the uncompressed log lines,
+// ready for NewLogScanner or the logparse package. Services without raw logs get a
+// FeatureUnavailableError.
+func (s *LogsService) Download(ctx context.Context, serviceID string, opts DownloadLogsOptions) (io.ReadCloser, error) {
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	logType := opts.Type
+	if logType == "" {
+		logType = LogTypeAccess
+	}
+	params.Set("type", string(logType))
+	if !opts.From.IsZero() {
+		params.Set("from", opts.From.UTC().Format(time.RFC3339))
+	}
+	if !opts.To.IsZero() {
+		params.Set("to", opts.To.UTC().Format(time.RFC3339))
+	}
+
+	endpoint := fmt.Sprintf(apispec.PathServiceLogsDownload, serviceID) + "?" + params.Encode()
+	body, err := s.Client.GetStream(ctx, endpoint, http.Header{"Accept": {"application/gzip"}})
+	if err != nil {
+		return nil, featureUnavailable(FeatureRawLogs, err)
+	}
+	if !opts.Decompress {
+		return body, nil
+	}
+
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		body.Close()
+		return nil, fmt.Errorf("failed to read gzip stream: %w", err)
+	}
+	return &gzipBody{Reader: zr, body: body}, nil
+}
+
+// gzipBody closes both the gzip reader and the underlying response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,197 @@
+package v2_5
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogTimeLayout is the layout of timestamps in CacheFly raw logs.
+const LogTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// LogEntry is a single request from a CacheFly raw log.
+//
+// CacheFly raw logs use the NCSA combined log format, optionally followed by
+// additional fields, which are kept in Extra in order.
+type LogEntry struct {
+	RemoteAddr string
+	User       string
+	Time       time.Time
+	Method     string
+	Path       string
+	Protocol   string
+	Status     int
+	Bytes      int64
+	Referer    string
+	UserAgent  string
+	Extra      []string
+
+	// Raw is the unparsed line
+	Raw string
+}
+
+// ParseLogLine parses a single raw log line. Fields logged as "-" are
+// returned empty or zero.
+func ParseLogLine(line string) (*LogEntry, error) {
+	fields, err := SplitLogFields(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 9 {
+		return nil, fmt.Errorf("expected at least 9 fields, got %d", len(fields))
+	}
+
+	entry := &LogEntry{
+		RemoteAddr: dash(fields[0]),
+		User:       dash(fields[2]),
+		Referer:    dash(fields[7]),
+		UserAgent:  dash(fields[8]),
+		Extra:      fields[9:],
+		Raw:        line,
+	}
+
+	entry.Time, err = time.Parse(LogTimeLayout, fields[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q: %w", fields[3], err)
+	}
+
+	request := strings.SplitN(fields[4], " ", 3)
+	if len(request) >= 2 {
+		entry.Method, entry.Path = request[0], request[1]
+		if len(request) == 3 {
+			entry.Protocol = request[2]
+		}
+	} else {
+		entry.Path = dash(fields[4])
+	}
+
+	if entry.Status, err = strconv.Atoi(fields[5]); err != nil {
+		return nil, fmt.Errorf("invalid status %q", fields[5])
+	}
+	if fields[6] != "-" {
+		if entry.Bytes, err = strconv.ParseInt(fields[6], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid size %q", fields[6])
+		}
+	}
+	return entry, nil
+}
+
+// SplitLogFields splits a raw log line into fields separated by spaces or
+// tabs, keeping [bracketed] and "quoted" fields together without their
+// delimiters. Backslash escapes inside quotes are resolved.
+func SplitLogFields(line string) ([]string, error) {
+	var fields []string
+	for i := 0; i < len(line); {
+		switch line[i] {
+		case ' ', '\t':
+			i++
+		case '[':
+			end := strings.IndexByte(line[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [ at offset %d", i)
+			}
+			fields = append(fields, line[i+1:i+end])
+			i += end + 1
+		case '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(line) && line[j] != '"'; j++ {
+				if line[j] == '\\' && j+1 < len(line) {
+					j++
+				}
+				b.WriteByte(line[j])
+			}
+			if j >= len(line) {
+				return nil, fmt.Errorf("unterminated quote at offset %d", i)
+			}
+			fields = append(fields, b.String())
+			i = j + 1
+		default:
+			end := strings.IndexAny(line[i:], " \t")
+			if end < 0 {
+				end = len(line) - i
+			}
+			fields = append(fields, line[i:i+end])
+			i += end
+		}
+	}
+	return fields, nil
+}
+
+func dash(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
+
+// LogScanner reads LogEntry values line by line from an uncompressed log
+// stream, such as the one returned by LogsService.Download with Decompress
+// set. Blank lines and lines starting with # are skipped. The logparse
+// package builds on it to also read W3C logs and type CacheFly's extra
+// fields.
+//
+//	scanner := v2_5.NewLogScanner(logs)
+//	for scanner.Scan() {
+//		entry := scanner.Entry()
+//		...
+//	}
+//	if err := scanner.Err(); err != nil {
+//		...
+//	}
+type LogScanner struct {
+	scanner *bufio.Scanner
+	entry   *LogEntry
+	line    int
+	err     error
+
+	// SkipInvalid skips lines that fail to parse instead of stopping
+	SkipInvalid bool
+}
+
+// NewLogScanner returns a LogScanner reading from r.
+func NewLogScanner(r io.Reader) *LogScanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &LogScanner{scanner: scanner}
+}
+
+// Scan advances to the next entry, returning false at the end of the input
+// or on an error.
+func (s *LogScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.scanner.Scan() {
+		s.line++
+		line := strings.TrimRight(s.scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, err := ParseLogLine(line)
+		if err != nil {
+			if s.SkipInvalid {
+				continue
+			}
+			s.err = fmt.Errorf("line %d: %w", s.line, err)
+			return false
+		}
+		s.entry = entry
+		return true
+	}
+	s.err = s.scanner.Err()
+	return false
+}
+
+// Entry returns the entry read by the last call to Scan.
+func (s *LogScanner) Entry() *LogEntry {
+	return s.entry
+}
+
+// Err returns the first error encountered by Scan.
+func (s *LogScanner) Err() error {
+	return s.err
+}
@@ -0,0 +1,124 @@
+package v2_5
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+const sampleLog = `# CacheFly access log
+203.0.113.7 - - [10/Jun/2025:13:55:36 +0000] "GET /img/logo.png HTTP/1.1" 200 2326 "https://example.com/" "Mozilla/5.0 (X11; Linux)" "cdn.example.com" HIT
+
+198.51.100.2 - - [10/Jun/2025:13:55:37 +0000] "HEAD /index.html HTTP/2.0" 304 - "-" "curl/8.5.0"
+`
+
+func TestLogsDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/services/svc-1/logs/download" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("type") != "origin" || r.URL.Query().Get("from") != "2025-06-10T00:00:00Z" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(sampleLog))
+		zw.Close()
+	}))
+	defer server.Close()
+
+	svc := &LogsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	logs, err := svc.Download(context.Background(), "svc-1", DownloadLogsOptions{
+		Type:       LogTypeOrigin,
+		From:       time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC),
+		Decompress: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer logs.Close()
+
+	data, _ := io.ReadAll(logs)
+	if string(data) != sampleLog {
+		t.Errorf("Expected decompressed log, got %q", data)
+	}
+}
+
+func TestLogScanner(t *testing.T) {
+	scanner := NewLogScanner(strings.NewReader(sampleLog))
+
+	var entries []*LogEntry
+	for scanner.Scan() {
+		entries = append(entries, scanner.Entry())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	first := entries[0]
+	if first.RemoteAddr != "203.0.113.7" || first.Method != "GET" || first.Path != "/img/logo.png" || first.Protocol != "HTTP/1.1" {
+		t.Errorf("Unexpected request fields %+v", first)
+	}
+	if first.Status != 200 || first.Bytes != 2326 {
+		t.Errorf("Expected 200 and 2326 bytes, got %d and %d", first.Status, first.Bytes)
+	}
+	if first.UserAgent != "Mozilla/5.0 (X11; Linux)" || first.Referer != "https://example.com/" {
+		t.Errorf("Unexpected referer or user agent %+v", first)
+	}
+	if len(first.Extra) != 2 || first.Extra[0] != "cdn.example.com" || first.Extra[1] != "HIT" {
+		t.Errorf("Expected extra fields, got %v", first.Extra)
+	}
+	if !first.Time.Equal(time.Date(2025, 6, 10, 13, 55, 36, 0, time.UTC)) {
+		t.Errorf("Unexpected time %s", first.Time)
+	}
+
+	if entries[1].Bytes != 0 || entries[1].Referer != "" {
+		t.Errorf("Expected dash fields to be empty, got %+v", entries[1])
+	}
+}
+
+func TestLogScanner_InvalidLine(t *testing.T) {
+	input := "garbage\n" + sampleLog
+
+	scanner := NewLogScanner(strings.NewReader(input))
+	if scanner.Scan() {
+		t.Fatalf("Expected scan to stop on invalid line")
+	}
+	if scanner.Err() == nil || !strings.Contains(scanner.Err().Error(), "line 1") {
+		t.Errorf("Expected error for line 1, got %v", scanner.Err())
+	}
+
+	scanner = NewLogScanner(strings.NewReader(input))
+	scanner.SkipInvalid = true
+	n := 0
+	for scanner.Scan() {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 entries when skipping invalid lines, got %d", n)
+	}
+}
+
+func TestSplitLogFields(t *testing.T) {
+	fields, err := SplitLogFields("a\t[b c]  \"d \\\"e\\\"\"\tf")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{"a", "b c", `d "e"`, "f"}
+	if strings.Join(fields, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %q, got %q", expected, fields)
+	}
+	if _, err := SplitLogFields(`a "b`); err == nil {
+		t.Error("Expected an error for an unterminated quote")
+	}
+}
@@ -0,0 +1,51 @@
+package v2_5
+
+import (
+	"context"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// POP statuses.
+const (
+	POPStatusActive      = "ACTIVE"
+	POPStatusMaintenance = "MAINTENANCE"
+	POPStatusPlanned     = "PLANNED"
+)
+
+// NetworkService handles CacheFly network inventory operations.
+type NetworkService struct {
+	Client *httpclient.Client
+}
+
+// POP is a CacheFly edge location.
+type POP struct {
+	// Code identifies the POP, such as "ams1"
+	Code string `json:"code"`
+
+	Name string `json:"name"`
+	City string `json:"city"`
+
+	// Country is the ISO 3166-1 alpha-2 country code, such as "NL"
+	Country string `json:"country"`
+
+	// Region is the delivery region the POP serves, such as "EU"; it uses
+	// the codes of Service.DeliveryRegion
+	Region string `json:"region"`
+
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Status    string  `json:"status"`
+}
+
+// ListPOPs retrieves every CacheFly edge location.
+func (s *NetworkService) ListPOPs(ctx context.Context) ([]POP, error) {
+	var resp struct {
+		POPs []POP `json:"data"`
+	}
+	if err := s.Client.Get(ctx, apispec.PathNetworkPOPs, &resp); err != nil {
+		return nil, err
+	}
+	return resp.POPs, nil
+}
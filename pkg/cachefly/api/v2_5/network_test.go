@@ -0,0 +1,41 @@
+package v2_5
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestNetworkService_ListPOPs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/network/pops" {
+			t.Errorf("Expected path /network/pops, got %s", r.URL.Path)
+		}
+		if r.Method != "GET" {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[
+			{"code":"ams1","name":"Amsterdam","city":"Amsterdam","country":"NL","region":"EU","latitude":52.37,"longitude":4.9,"status":"ACTIVE"},
+			{"code":"nyc1","name":"New York","city":"New York","country":"US","region":"NA","latitude":40.71,"longitude":-74.01,"status":"MAINTENANCE"}]}`))
+	}))
+	defer server.Close()
+
+	svc := &NetworkService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	pops, err := svc.ListPOPs(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pops) != 2 {
+		t.Fatalf("Expected 2 POPs, got %d", len(pops))
+	}
+	if pops[0].Code != "ams1" || pops[0].Region != "EU" || pops[0].Latitude != 52.37 {
+		t.Errorf("Unexpected POP %+v", pops[0])
+	}
+	if pops[1].Status != POPStatusMaintenance || pops[1].Longitude != -74.01 {
+		t.Errorf("Unexpected POP %+v", pops[1])
+	}
+}
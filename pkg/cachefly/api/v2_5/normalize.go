@@ -0,0 +1,41 @@
+package v2_5
+
+import "encoding/json"
+
+// NormalizeValue round-trips v through JSON, so Go values compare equal to
+// the values decoded from API responses, e.g. int 3600 to float64 3600.
+// Values that cannot be round-tripped are returned unchanged.
+func NormalizeValue(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// IsServerField reports whether name is a field the API assigns to rules
+// and other stored objects, such as "_id" or "createdAt". Such fields are
+// left out when objects are copied or compared.
+func IsServerField(name string) bool {
+	switch name {
+	case "_id", "createdAt", "updateAt", "updatedAt":
+		return true
+	}
+	return false
+}
+
+// StripServerFields returns a copy of obj without the fields IsServerField
+// reports.
+func StripServerFields(obj map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if !IsServerField(k) {
+			copied[k] = v
+		}
+	}
+	return copied
+}
@@ -0,0 +1,31 @@
+package v2_5
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeValue(t *testing.T) {
+	got := NormalizeValue(map[string]interface{}{"ttl": 3600, "paths": []string{"/a"}})
+	want := map[string]interface{}{"ttl": float64(3600), "paths": []interface{}{"/a"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	ch := make(chan int)
+	if got := NormalizeValue(ch); got != interface{}(ch) {
+		t.Errorf("Expected the unmarshalable value back, got %v", got)
+	}
+}
+
+func TestStripServerFields(t *testing.T) {
+	rule := map[string]interface{}{"_id": "r1", "createdAt": "x", "updateAt": "y", "updatedAt": "z", "path": "/a"}
+	got := StripServerFields(rule)
+	want := map[string]interface{}{"path": "/a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if _, ok := rule["_id"]; !ok {
+		t.Error("Expected the input rule to be left unchanged")
+	}
+}
@@ -2,11 +2,13 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // OriginsService handles origin configuration operations.
@@ -33,6 +35,9 @@ type Origin struct {
 	SecretKey              string `json:"secretKey,omitempty"`
 	Region                 string `json:"region,omitempty"`
 	SignatureVersion       string `json:"signatureVersion,omitempty"`
+
+	// Raw holds the response fields Origin does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // ListOriginsResponse wraps paginated origin list.
@@ -49,6 +54,13 @@ type ListOriginsOptions struct {
 	ResponseType string
 }
 
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListOriginsOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
 // CreateOriginRequest is the payload for creating a new origin.
 type CreateOriginRequest struct {
 	Type                   string `json:"type"`
@@ -87,24 +99,17 @@ type UpdateOriginRequest struct {
 
 // List retrieves all origins with optional filters.
 func (s *OriginsService) List(ctx context.Context, opts ListOriginsOptions) (*ListOriginsResponse, error) {
-	endpoint := "/origins"
+	endpoint := apispec.PathOrigins
 	params := url.Values{}
 	if opts.Type != "" {
 		params.Set("type", opts.Type)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
 	if opts.ResponseType != "" {
 		params.Set("responseType", opts.ResponseType)
 	}
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
 	var resp ListOriginsResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Origins); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -112,20 +117,23 @@ func (s *OriginsService) List(ctx context.Context, opts ListOriginsOptions) (*Li
 
 // Create adds a new origin.
 func (s *OriginsService) Create(ctx context.Context, req CreateOriginRequest) (*Origin, error) {
-	endpoint := "/origins"
+	endpoint := apispec.PathOrigins
 	var created Origin
 	if err := s.Client.Post(ctx, endpoint, req, &created); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathOrigin, created.ID), &created); err != nil {
+		return nil, err
+	}
 	return &created, nil
 }
 
 // GetByID fetches a single origin by its ID.
 func (s *OriginsService) GetByID(ctx context.Context, id, responseType string) (*Origin, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/origins/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathOrigin, id)
 	params := url.Values{}
 	if responseType != "" {
 		params.Set("responseType", responseType)
@@ -140,22 +148,25 @@ func (s *OriginsService) GetByID(ctx context.Context, id, responseType string) (
 
 // UpdateByID modifies an existing origin.
 func (s *OriginsService) UpdateByID(ctx context.Context, id string, req UpdateOriginRequest) (*Origin, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/origins/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathOrigin, id)
 	var updated Origin
 	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // Delete removes an origin by ID.
 func (s *OriginsService) Delete(ctx context.Context, id string) error {
-	if id == "" {
-		return fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return err
 	}
-	endpoint := fmt.Sprintf("/origins/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathOrigin, id)
 	return s.Client.Delete(ctx, endpoint, nil)
 }
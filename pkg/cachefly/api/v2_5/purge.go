@@ -0,0 +1,164 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/internal/jobs"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// MaxPurgePathsPerRequest is the most paths the purge endpoint accepts in a
+// single request.
+const MaxPurgePathsPerRequest = 100
+
+// PurgeService handles cache purge operations.
+type PurgeService struct {
+	Client *httpclient.Client
+}
+
+// PurgeRequest is the payload of a single purge request.
+type PurgeRequest struct {
+	Paths []string `json:"paths"`
+}
+
+// PurgeOptions controls how Paths splits and sends purge requests.
+type PurgeOptions struct {
+	// ChunkSize is the number of paths per request. Zero or values above
+	// MaxPurgePathsPerRequest use MaxPurgePathsPerRequest.
+	ChunkSize int
+
+	// Concurrency is the number of requests in flight at once. Zero uses 4.
+	Concurrency int
+
+	// Interval is the minimum time between starting two requests. Zero uses
+	// 200ms, negative disables the rate limit.
+	Interval time.Duration
+}
+
+// DefaultPurgeOptions returns the options used by Paths.
+func DefaultPurgeOptions() PurgeOptions {
+	return PurgeOptions{
+		ChunkSize:   MaxPurgePathsPerRequest,
+		Concurrency: 4,
+		Interval:    200 * time.Millisecond,
+	}
+}
+
+// PurgeChunk reports the outcome of a single purge request.
+type PurgeChunk struct {
+	Index int      `json:"index"`
+	Paths []string `json:"paths"`
+	Error string   `json:"error,omitempty"`
+
+	// Err is the error returned for the request, nil on success
+	Err error `json:"-"`
+}
+
+// PurgeReport aggregates the per-request results of Paths.
+type PurgeReport struct {
+	Requested int          `json:"requested"`
+	Purged    int          `json:"purged"`
+	Failed    int          `json:"failed"`
+	Chunks    []PurgeChunk `json:"chunks"`
+}
+
+// FailedPaths returns the paths of every failed request, in input order.
+func (r *PurgeReport) FailedPaths() []string {
+	var paths []string
+	for _, chunk := range r.Chunks {
+		if chunk.Err != nil {
+			paths = append(paths, chunk.Paths...)
+		}
+	}
+	return paths
+}
+
+// Paths purges the given paths from a service's cache using
+// DefaultPurgeOptions. See PathsWithOptions.
+func (s *PurgeService) Paths(ctx context.Context, serviceID string, paths []string) (*PurgeReport, error) {
+	return s.PathsWithOptions(ctx, serviceID, paths, DefaultPurgeOptions())
+}
+
+// PathsWithOptions purges the given paths from a service's cache.
+//
+// Duplicate paths are dropped and the rest are split into chunks of at most
+// opts.ChunkSize paths, which are sent concurrently under the configured rate
+// limit. Failures are recorded per chunk and do not stop the other chunks; an
+// error is returned only for invalid input or when ctx ends before every
+// chunk was sent.
+func (s *PurgeService) PathsWithOptions(ctx context.Context, serviceID string, paths []string, opts PurgeOptions) (*PurgeReport, error) {
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("at least one path is required")
+	}
+
+	size := opts.ChunkSize
+	if size <= 0 || size > MaxPurgePathsPerRequest {
+		size = MaxPurgePathsPerRequest
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	interval := opts.Interval
+	if interval == 0 {
+		interval = 200 * time.Millisecond
+	}
+
+	unique := make([]string, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		unique = append(unique, p)
+	}
+
+	report := &PurgeReport{Requested: len(unique)}
+	for start := 0; start < len(unique); start += size {
+		end := start + size
+		if end > len(unique) {
+			end = len(unique)
+		}
+		report.Chunks = append(report.Chunks, PurgeChunk{Index: len(report.Chunks), Paths: unique[start:end]})
+	}
+
+	endpoint := fmt.Sprintf(apispec.PathServicePurge, serviceID)
+	queue := jobs.New(jobs.Config{Workers: concurrency, Interval: interval})
+	queue.Start(ctx)
+
+	handles := make([]*jobs.Handle, len(report.Chunks))
+	for i := range report.Chunks {
+		chunk := &report.Chunks[i]
+		handles[i], _ = queue.Enqueue(func(ctx context.Context) error {
+			return s.Client.Post(ctx, endpoint, PurgeRequest{Paths: chunk.Paths}, nil)
+		}, jobs.Options{Name: "purge"})
+	}
+	queue.Close()
+
+	for i, h := range handles {
+		report.Chunks[i].Err = h.Err()
+	}
+
+	for i := range report.Chunks {
+		chunk := &report.Chunks[i]
+		if chunk.Err != nil {
+			chunk.Error = chunk.Err.Error()
+			report.Failed += len(chunk.Paths)
+		} else {
+			report.Purged += len(chunk.Paths)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
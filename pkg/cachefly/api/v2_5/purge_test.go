@@ -0,0 +1,136 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestPurgePaths_Chunks(t *testing.T) {
+	var mu sync.Mutex
+	var received []int
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/services/svc-1/purge" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+
+		var req PurgeRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		received = append(received, len(req.Paths))
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var paths []string
+	for i := 0; i < 250; i++ {
+		paths = append(paths, fmt.Sprintf("/img/%d.png", i))
+	}
+	paths = append(paths, "/img/0.png")
+
+	svc := &PurgeService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	report, err := svc.PathsWithOptions(context.Background(), "svc-1", paths, PurgeOptions{Concurrency: 2, Interval: -1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Requested != 250 || report.Purged != 250 || report.Failed != 0 {
+		t.Errorf("Expected 250 purged paths, got %+v", report)
+	}
+	if len(report.Chunks) != 3 || len(received) != 3 {
+		t.Fatalf("Expected 3 requests, got %d chunks and %d requests", len(report.Chunks), len(received))
+	}
+	if len(report.Chunks[2].Paths) != 50 {
+		t.Errorf("Expected last chunk of 50 paths, got %d", len(report.Chunks[2].Paths))
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestPurgePaths_AggregatesFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req PurgeRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Paths[0] == "/b" {
+			http.Error(w, `{"message":"invalid path"}`, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	svc := &PurgeService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	report, err := svc.PathsWithOptions(context.Background(), "svc-1", []string{"/a", "/b", "/c"}, PurgeOptions{ChunkSize: 1, Interval: -1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Purged != 2 || report.Failed != 1 {
+		t.Errorf("Expected 2 purged and 1 failed, got %d and %d", report.Purged, report.Failed)
+	}
+	failed := report.FailedPaths()
+	if len(failed) != 1 || failed[0] != "/b" {
+		t.Errorf("Expected /b to fail, got %v", failed)
+	}
+	if report.Chunks[1].Error == "" {
+		t.Errorf("Expected error message on failed chunk")
+	}
+}
+
+func TestPurgePaths_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	svc := &PurgeService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+
+	paths := make([]string, 50)
+	for i := range paths {
+		paths[i] = fmt.Sprintf("/file-%d", i)
+	}
+	start := time.Now()
+	report, err := svc.PathsWithOptions(ctx, "svc-1", paths, PurgeOptions{ChunkSize: 5, Concurrency: 1, Interval: time.Hour})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected to return promptly, took %s", elapsed)
+	}
+	if report == nil || report.Failed != 50 {
+		t.Errorf("Expected every path reported as failed, got %+v", report)
+	}
+}
+
+func TestPurgePaths_RequiresInput(t *testing.T) {
+	svc := &PurgeService{}
+	if _, err := svc.Paths(context.Background(), "", []string{"/a"}); err == nil {
+		t.Errorf("Expected error for missing service ID")
+	}
+	if _, err := svc.Paths(context.Background(), "svc-1", nil); err == nil {
+		t.Errorf("Expected error for empty paths")
+	}
+}
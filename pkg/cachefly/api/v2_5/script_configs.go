@@ -2,11 +2,14 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // ScriptConfigsService handles /scriptConfigs endpoints.
@@ -28,6 +31,9 @@ type ScriptConfig struct {
 	Value                  interface{}            `json:"value"`
 	CreatedAt              string                 `json:"createdAt"`
 	UpdatedAt              string                 `json:"updateAt"`
+
+	// Raw holds the response fields ScriptConfig does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // ListScriptConfigsOptions holds filters & pagination.
@@ -40,6 +46,13 @@ type ListScriptConfigsOptions struct {
 	ResponseType    string
 	Search          string
 	SortBy          []string
+	Order           SortOrder
+}
+
+// Apply sets the offset, limit, sort fields, order and search of o from
+// opts. The endpoint supports every listing field, so it returns nil.
+func (o *ListScriptConfigsOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit, sortBy: &o.SortBy, order: &o.Order, search: &o.Search}.apply(opts)
 }
 
 // ListScriptConfigsResponse wraps a paged list.
@@ -66,35 +79,30 @@ type UpdateScriptConfigRequest struct {
 	Value                  interface{} `json:"value,omitempty"`
 }
 
-// List returns script configs with optional filters.
+// List returns script configs with optional filters. Accounts without
+// script configs get a FeatureUnavailableError.
 func (s *ScriptConfigsService) List(ctx context.Context, opts ListScriptConfigsOptions) (*ListScriptConfigsResponse, error) {
-	endpoint := "/scriptConfigs"
+	if err := checkSort(opts.SortBy, opts.Order); err != nil {
+		return nil, err
+	}
+	endpoint := apispec.PathScriptConfigs
 	params := url.Values{}
 	params.Set("includeFeatures", strconv.FormatBool(opts.IncludeFeatures))
 	params.Set("includeHidden", strconv.FormatBool(opts.IncludeHidden))
 	if opts.Status != "" {
 		params.Set("status", opts.Status)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
 	if opts.ResponseType != "" {
 		params.Set("responseType", opts.ResponseType)
 	}
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
 	}
-	if len(opts.SortBy) > 0 {
-		params.Set("sortBy", fmt.Sprintf("%v", opts.SortBy))
-	}
+	addSort(params, opts.SortBy, opts.Order)
 
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 	var resp ListScriptConfigsResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
-		return nil, err
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Configs); err != nil {
+		return nil, featureUnavailable(FeatureScriptConfigs, err)
 	}
 	return &resp, nil
 }
@@ -102,7 +110,10 @@ func (s *ScriptConfigsService) List(ctx context.Context, opts ListScriptConfigsO
 // Create posts a new script config.
 func (s *ScriptConfigsService) Create(ctx context.Context, req CreateScriptConfigRequest) (*ScriptConfig, error) {
 	var created ScriptConfig
-	if err := s.Client.Post(ctx, "/scriptConfigs", req, &created); err != nil {
+	if err := s.Client.Post(ctx, apispec.PathScriptConfigs, req, &created); err != nil {
+		return nil, err
+	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathScriptConfig, created.ID), &created); err != nil {
 		return nil, err
 	}
 	return &created, nil
@@ -110,10 +121,10 @@ func (s *ScriptConfigsService) Create(ctx context.Context, req CreateScriptConfi
 
 // GetByID fetches a single config by ID.
 func (s *ScriptConfigsService) GetByID(ctx context.Context, id, responseType string) (*ScriptConfig, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigs/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathScriptConfig, id)
 	params := url.Values{}
 	if responseType != "" {
 		params.Set("responseType", responseType)
@@ -129,24 +140,27 @@ func (s *ScriptConfigsService) GetByID(ctx context.Context, id, responseType str
 
 // UpdateByID modifies an existing config.
 func (s *ScriptConfigsService) UpdateByID(ctx context.Context, id string, req UpdateScriptConfigRequest) (*ScriptConfig, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigs/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathScriptConfig, id)
 
 	var updated ScriptConfig
 	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // GetSchemaByID retrieves the JSON schema for a config.
 func (s *ScriptConfigsService) GetSchemaByID(ctx context.Context, id string) (map[string]interface{}, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigs/%s/schema", id)
+	endpoint := fmt.Sprintf(apispec.PathScriptConfigSchema, id)
 
 	var schema map[string]interface{}
 	if err := s.Client.Get(ctx, endpoint, &schema); err != nil {
@@ -157,39 +171,45 @@ func (s *ScriptConfigsService) GetSchemaByID(ctx context.Context, id string) (ma
 
 // ActivateByID activates a script config.
 func (s *ScriptConfigsService) ActivateByID(ctx context.Context, id string) (*ScriptConfig, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigs/%s/activate", id)
+	endpoint := fmt.Sprintf(apispec.PathScriptConfigActivate, id)
 
 	var cfg ScriptConfig
 	if err := s.Client.Put(ctx, endpoint, struct{}{}, &cfg); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathScriptConfig, id), &cfg); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
 
 // DeactivateByID deactivates a script config.
 func (s *ScriptConfigsService) DeactivateByID(ctx context.Context, id string) (*ScriptConfig, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigs/%s/deactivate", id)
+	endpoint := fmt.Sprintf(apispec.PathScriptConfigDeactivate, id)
 
 	var cfg ScriptConfig
 	if err := s.Client.Put(ctx, endpoint, struct{}{}, &cfg); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathScriptConfig, id), &cfg); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
 
 // GetValueAsFile retrieves the raw script configuration file content for the given config ID.
 // It calls GET /scriptConfigs/{id}/file and returns the file bytes.
 func (s *ScriptConfigsService) GetValueAsFile(ctx context.Context, configID string) (*interface{}, error) {
-	if configID == "" {
-		return nil, fmt.Errorf("config ID is required")
+	if err := validate.ID("config ID", configID); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigs/%s/file", url.PathEscape(configID))
+	endpoint := fmt.Sprintf(apispec.PathScriptConfigFile, url.PathEscape(configID))
 
 	// The client should return raw bytes for non-JSON endpoints.
 	var content interface{}
@@ -201,10 +221,10 @@ func (s *ScriptConfigsService) GetValueAsFile(ctx context.Context, configID stri
 
 // UpdateScriptConfigValue updates the script configuration content using raw file data.
 func (s *ScriptConfigsService) UpdateValueAsFile(ctx context.Context, configID string, content []byte) (*ScriptConfig, error) {
-	if configID == "" {
-		return nil, fmt.Errorf("config ID is required")
+	if err := validate.ID("config ID", configID); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigs/%s/value", url.PathEscape(configID))
+	endpoint := fmt.Sprintf(apispec.PathScriptConfigValue, url.PathEscape(configID))
 
 	var updated ScriptConfig
 	// Pass raw bytes as body; Client.Put must handle []byte by sending as-is
@@ -214,10 +234,11 @@ func (s *ScriptConfigsService) UpdateValueAsFile(ctx context.Context, configID s
 	return &updated, nil
 }
 
-// ListPromo retrieves promo script config definitions.
+// ListPromo retrieves promo script config definitions. Accounts without
+// script configs get a FeatureUnavailableError.
 // GET /scriptConfigDefinitions/promo
 func (s *ScriptConfigsService) ListPromo(ctx context.Context, includeFeatures bool) ([]ScriptConfig, error) {
-	endpoint := "/scriptConfigDefinitions/promo"
+	endpoint := apispec.PathScriptConfigDefinitionsPromo
 	params := url.Values{}
 	// only send includeFeatures when true
 	if includeFeatures {
@@ -227,17 +248,17 @@ func (s *ScriptConfigsService) ListPromo(ctx context.Context, includeFeatures bo
 
 	var defs []ScriptConfig
 	if err := s.Client.Get(ctx, fullURL, &defs); err != nil {
-		return nil, err
+		return nil, featureUnavailable(FeatureScriptConfigs, err)
 	}
 	return defs, nil
 }
 
 // GetDefinitionByID retrieves definition script config.
 func (s *ScriptConfigsService) GetDefinitionByID(ctx context.Context, id string) (*ScriptConfig, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/scriptConfigDefinitions/%s", url.PathEscape(id))
+	endpoint := fmt.Sprintf(apispec.PathScriptConfigDefinition, url.PathEscape(id))
 
 	var def ScriptConfig
 	if err := s.Client.Get(ctx, endpoint, &def); err != nil {
@@ -247,26 +268,20 @@ func (s *ScriptConfigsService) GetDefinitionByID(ctx context.Context, id string)
 }
 
 // List returns account-level script config definitions with optional filters.
+// Accounts without script configs get a FeatureUnavailableError.
 // GET /scriptConfigDefinitions
 func (s *ScriptConfigsService) ListAccountScriptConfigDefinitions(ctx context.Context, opts ListScriptConfigsOptions) (*ListScriptConfigsResponse, error) {
-	endpoint := "/scriptConfigDefinitions"
+	endpoint := apispec.PathScriptConfigDefinitions
 	params := url.Values{}
 	params.Set("includeFeatures", strconv.FormatBool(opts.IncludeFeatures))
 	params.Set("includeHidden", strconv.FormatBool(opts.IncludeHidden))
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
 	if opts.ResponseType != "" {
 		params.Set("responseType", opts.ResponseType)
 	}
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
 	var resp ListScriptConfigsResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
-		return nil, err
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Configs); err != nil {
+		return nil, featureUnavailable(FeatureScriptConfigs, err)
 	}
 	return &resp, nil
 }
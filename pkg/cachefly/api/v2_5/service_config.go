@@ -0,0 +1,321 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// listAllPageSize is the page size used when walking every page of a list endpoint.
+const listAllPageSize = 100
+
+// ServiceConfig is a portable document describing a service configuration.
+// It is produced by ExportConfig and applied by ImportConfig.
+type ServiceConfig struct {
+	Service Service                  `json:"service"`
+	Options ServiceOptions           `json:"options"`
+	Domains []ServiceDomain          `json:"domains"`
+	Origins []Origin                 `json:"origins"`
+	Rules   []map[string]interface{} `json:"rules"`
+}
+
+// ImportConfigResult describes the changes ImportConfig made to the target service.
+type ImportConfigResult struct {
+	Service        *Service        `json:"service"`
+	Options        ServiceOptions  `json:"options"`
+	CreatedDomains []ServiceDomain `json:"createdDomains"`
+	CreatedOrigins []Origin        `json:"createdOrigins"`
+	SkippedOptions []string        `json:"skippedOptions"`
+	RulesUpdated   bool            `json:"rulesUpdated"`
+
+	// ConflictingDomains are the hostnames the API refused to create on the
+	// target with 409 Conflict, typically because they are still bound to another service,
+	// such as the source of a clone in the same account
+	ConflictingDomains []string `json:"conflictingDomains,omitempty"`
+}
+
+// rawRulesResponse decodes rules without dropping fields ServiceRule does not model.
+type rawRulesResponse struct {
+	Meta  MetaInfo                 `json:"meta"`
+	Rules []map[string]interface{} `json:"data"`
+}
+
+// ExportConfig gathers the basic configuration, options, domains, origins and
+// rules of a service into a single document.
+//
+// Origins are account-scoped in the CacheFly API, so every origin of the
+// account is included.
+func (s *ServicesService) ExportConfig(ctx context.Context, id string) (*ServiceConfig, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+
+	svc, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+
+	options, err := (&ServiceOptionsService{Client: s.Client}).GetOptions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service options: %w", err)
+	}
+
+	cfg := &ServiceConfig{Service: *svc, Options: options}
+
+	domains := &ServiceDomainsService{Client: s.Client}
+	for offset := 0; ; offset += listAllPageSize {
+		page, err := domains.List(ctx, id, ListServiceDomainsOptions{Offset: offset, Limit: listAllPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list service domains: %w", err)
+		}
+		cfg.Domains = append(cfg.Domains, page.Domains...)
+		if len(page.Domains) < listAllPageSize {
+			break
+		}
+	}
+
+	origins := &OriginsService{Client: s.Client}
+	for offset := 0; ; offset += listAllPageSize {
+		page, err := origins.List(ctx, ListOriginsOptions{Offset: offset, Limit: listAllPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list origins: %w", err)
+		}
+		cfg.Origins = append(cfg.Origins, page.Origins...)
+		if len(page.Origins) < listAllPageSize {
+			break
+		}
+	}
+
+	if cfg.Rules, err = s.listRawRules(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// listRawRules returns every rule of a service without dropping fields
+// ServiceRule does not model.
+func (s *ServicesService) listRawRules(ctx context.Context, id string) ([]map[string]interface{}, error) {
+	var rules []map[string]interface{}
+	endpoint := fmt.Sprintf(apispec.PathServiceRules, id)
+	for offset := 0; ; offset += listAllPageSize {
+		var page rawRulesResponse
+		if err := getList(ctx, s.Client, endpoint, url.Values{}, offset, listAllPageSize, &page.Meta, &page.Rules); err != nil {
+			return nil, fmt.Errorf("failed to list service rules: %w", err)
+		}
+		rules = append(rules, page.Rules...)
+		if len(page.Rules) < listAllPageSize {
+			return rules, nil
+		}
+	}
+}
+
+// ImportConfig applies an exported configuration to the service identified by id.
+//
+// Basic settings and rules are replaced, options that are available and
+// writable on the target are updated, and domains and origins that do not
+// exist yet are created. Options the target does not support are reported in
+// ImportConfigResult.SkippedOptions. The legacy API key and ProtectServe key
+// toggles are only changed when they differ from the target's, so importing
+// never regenerates the target's keys.
+//
+// Domains are matched by hostname, ignoring case. A domain the API refuses
+// to create with 409 Conflict is reported in
+// ImportConfigResult.ConflictingDomains and the import goes on; any other
+// error, such as a rejected hostname, fails the import. When a step
+// fails, the result of the steps already applied is returned with the error.
+func (s *ServicesService) ImportConfig(ctx context.Context, id string, cfg *ServiceConfig) (*ImportConfigResult, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("config is required")
+	}
+
+	result := &ImportConfigResult{}
+
+	updated, err := s.UpdateServiceByID(ctx, id, UpdateServiceRequest{
+		Description:       cfg.Service.Description,
+		TLSProfile:        cfg.Service.TLSProfile,
+		AutoSSL:           cfg.Service.AutoSSL,
+		DeliveryRegion:    cfg.Service.DeliveryRegion,
+		ConfigurationMode: cfg.Service.ConfigurationMode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update service: %w", err)
+	}
+	result.Service = updated
+
+	// Options
+	if len(cfg.Options) > 0 {
+		optionsService := &ServiceOptionsService{Client: s.Client}
+		metadata, err := optionsService.GetOptionsMetadata(ctx, id)
+		if err != nil {
+			return result, fmt.Errorf("failed to get options metadata: %w", err)
+		}
+
+		writable := make(map[string]bool)
+		for _, opt := range metadata.Data {
+			if opt.ReadOnly {
+				continue
+			}
+			if opt.Type == "dynamic" && opt.Property != nil {
+				writable[opt.Property.Name] = true
+			} else if opt.Type == "standard" {
+				writable[standardOptionName(opt.Name)] = true
+			}
+		}
+
+		// Enabling either key toggle regenerates the key, so they are only
+		// sent when the target's current setting differs
+		var current ServiceOptions
+		_, apiKey := cfg.Options["apiKeyEnabled"]
+		_, protectServeKey := cfg.Options["protectServeKeyEnabled"]
+		if apiKey || protectServeKey {
+			current, err = optionsService.GetOptions(ctx, id)
+			if err != nil {
+				return result, fmt.Errorf("failed to get service options: %w", err)
+			}
+		}
+
+		toApply := make(ServiceOptions)
+		for name, value := range cfg.Options {
+			switch {
+			case name == "apiKeyEnabled" || name == "protectServeKeyEnabled":
+				want, _ := value.(bool)
+				have, _ := current[name].(bool)
+				if want != have {
+					toApply[name] = value
+				}
+			case writable[name]:
+				toApply[name] = value
+			default:
+				result.SkippedOptions = append(result.SkippedOptions, name)
+			}
+		}
+
+		if len(toApply) > 0 {
+			applied, err := optionsService.UpdateOptions(ctx, id, toApply)
+			if err != nil {
+				return result, fmt.Errorf("failed to update service options: %w", err)
+			}
+			result.Options = applied
+		}
+	}
+
+	// Domains
+	if len(cfg.Domains) > 0 {
+		domains := &ServiceDomainsService{Client: s.Client}
+		existing := make(map[string]bool)
+		for offset := 0; ; offset += listAllPageSize {
+			page, err := domains.List(ctx, id, ListServiceDomainsOptions{Offset: offset, Limit: listAllPageSize})
+			if err != nil {
+				return result, fmt.Errorf("failed to list service domains: %w", err)
+			}
+			for _, d := range page.Domains {
+				existing[strings.ToLower(d.Name)] = true
+			}
+			if len(page.Domains) < listAllPageSize {
+				break
+			}
+		}
+
+		for _, d := range cfg.Domains {
+			name := strings.ToLower(d.Name)
+			if existing[name] {
+				continue
+			}
+			existing[name] = true
+			created, err := domains.Create(ctx, id, CreateServiceDomainRequest{
+				Name:           d.Name,
+				Description:    d.Description,
+				ValidationMode: d.ValidationMode,
+			})
+			if isConflict(err) {
+				result.ConflictingDomains = append(result.ConflictingDomains, d.Name)
+				continue
+			}
+			if err != nil {
+				return result, fmt.Errorf("failed to create domain %s: %w", d.Name, err)
+			}
+			result.CreatedDomains = append(result.CreatedDomains, *created)
+		}
+	}
+
+	// Origins
+	if len(cfg.Origins) > 0 {
+		origins := &OriginsService{Client: s.Client}
+		existing := make(map[string]bool)
+		for offset := 0; ; offset += listAllPageSize {
+			page, err := origins.List(ctx, ListOriginsOptions{Offset: offset, Limit: listAllPageSize})
+			if err != nil {
+				return result, fmt.Errorf("failed to list origins: %w", err)
+			}
+			for _, o := range page.Origins {
+				existing[o.Type+"|"+o.Hostname] = true
+			}
+			if len(page.Origins) < listAllPageSize {
+				break
+			}
+		}
+
+		for _, o := range cfg.Origins {
+			if existing[o.Type+"|"+o.Hostname] {
+				continue
+			}
+			created, err := origins.Create(ctx, CreateOriginRequest{
+				Type:                   o.Type,
+				Name:                   o.Name,
+				Hostname:               o.Hostname,
+				Gzip:                   o.Gzip,
+				CacheByQueryParam:      o.CacheByQueryParam,
+				Scheme:                 o.Scheme,
+				TTL:                    o.TTL,
+				MissedTTL:              o.MissedTTL,
+				ConnectionTimeout:      o.ConnectionTimeout,
+				TimeToFirstByteTimeout: o.TimeToFirstByteTimeout,
+				AccessKey:              o.AccessKey,
+				SecretKey:              o.SecretKey,
+				Region:                 o.Region,
+				SignatureVersion:       o.SignatureVersion,
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to create origin %s: %w", o.Hostname, err)
+			}
+			existing[o.Type+"|"+o.Hostname] = true
+			result.CreatedOrigins = append(result.CreatedOrigins, *created)
+		}
+	}
+
+	// Rules are replaced as a whole; server-assigned fields are stripped
+	if cfg.Rules != nil {
+		rules := make([]map[string]interface{}, 0, len(cfg.Rules))
+		for _, rule := range cfg.Rules {
+			rules = append(rules, StripServerFields(rule))
+		}
+
+		endpoint := fmt.Sprintf(apispec.PathServiceRules, id)
+		if err := s.Client.Put(ctx, endpoint, map[string]interface{}{"rules": rules}, nil); err != nil {
+			return result, fmt.Errorf("failed to update service rules: %w", err)
+		}
+		result.RulesUpdated = true
+	}
+
+	return result, nil
+}
+
+// isConflict reports whether err is the API rejecting a resource that
+// clashes with an existing one, such as a hostname bound to another service.
+// Validation errors are not conflicts, so a malformed hostname still fails
+// the import.
+func isConflict(err error) bool {
+	var apiErr *httpclient.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
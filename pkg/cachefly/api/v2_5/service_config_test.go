@@ -0,0 +1,302 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// READ - Test ExportConfig method
+func TestServicesService_ExportConfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/2.5/services/svc-123":
+			w.Write([]byte(`{"_id":"svc-123","name":"Test Service","description":"Main site"}`))
+		case "/api/2.5/services/svc-123/options":
+			w.Write([]byte(`{"cors":true}`))
+		case "/api/2.5/services/svc-123/domains":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"dom-1","name":"cdn.example.com"}]}`))
+		case "/api/2.5/origins":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"org-1","type":"WEB","hostname":"origin.example.com"}]}`))
+		case "/api/2.5/services/svc-123/rules":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"rule-1","path":"/images/*","ttl":3600}]}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	client := httpclient.New(cfg)
+	svc := &ServicesService{Client: client}
+
+	result, err := svc.ExportConfig(context.Background(), "svc-123")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Service.Description != "Main site" {
+		t.Errorf("Expected description Main site, got %s", result.Service.Description)
+	}
+	if result.Options["cors"] != true {
+		t.Errorf("Expected cors option true, got %v", result.Options["cors"])
+	}
+	if len(result.Domains) != 1 || result.Domains[0].Name != "cdn.example.com" {
+		t.Errorf("Expected domain cdn.example.com, got %v", result.Domains)
+	}
+	if len(result.Origins) != 1 {
+		t.Errorf("Expected 1 origin, got %d", len(result.Origins))
+	}
+	if len(result.Rules) != 1 || result.Rules[0]["path"] != "/images/*" {
+		t.Errorf("Expected rule fields to be preserved, got %v", result.Rules)
+	}
+}
+
+// UPDATE - Test ImportConfig method
+func TestServicesService_ExportConfigCanceledDuringPagination(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var domainPages int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/domains") {
+			w.Write([]byte(`{"_id":"svc-1"}`))
+			return
+		}
+		// Every page is full, so only cancellation ends the loop
+		domainPages++
+		if domainPages == 3 {
+			cancel()
+		}
+		domains := make([]string, listAllPageSize)
+		for i := range domains {
+			domains[i] = fmt.Sprintf(`{"_id":"d-%d"}`, i)
+		}
+		fmt.Fprintf(w, `{"meta":{},"data":[%s]}`, strings.Join(domains, ","))
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := svc.ExportConfig(ctx, "svc-1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected ExportConfig to return after cancellation")
+	}
+	if domainPages != 3 {
+		t.Errorf("Expected no page requested after cancellation, got %d pages", domainPages)
+	}
+}
+
+func TestServicesService_ImportConfig(t *testing.T) {
+	var createdDomain, updatedRules bool
+	var appliedOptions map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1":
+			w.Write([]byte(`{"_id":"target-1","description":"Main site"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/target-1/options/metadata":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"name":"CORS Override","type":"standard"}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1/options":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &appliedOptions)
+			w.Write(body)
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/target-1/domains":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/services/target-1/domains":
+			createdDomain = true
+			w.Write([]byte(`{"_id":"dom-2","name":"cdn.example.com"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/origins":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"org-1","type":"WEB","hostname":"origin.example.com"}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1/rules":
+			body, _ := io.ReadAll(r.Body)
+			var payload struct {
+				Rules []map[string]interface{} `json:"rules"`
+			}
+			json.Unmarshal(body, &payload)
+			if len(payload.Rules) != 1 || payload.Rules[0]["_id"] != nil {
+				t.Errorf("Expected one rule without _id, got %v", payload.Rules)
+			}
+			updatedRules = true
+			w.Write([]byte(`{"meta":{"count":1},"data":[]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	client := httpclient.New(cfg)
+	svc := &ServicesService{Client: client}
+
+	exported := &ServiceConfig{
+		Service: Service{Description: "Main site"},
+		Options: ServiceOptions{"cors": true, "legacyOnly": true},
+		Domains: []ServiceDomain{{Name: "cdn.example.com"}},
+		Origins: []Origin{{Type: "WEB", Hostname: "origin.example.com"}},
+		Rules:   []map[string]interface{}{{"_id": "rule-1", "path": "/images/*"}},
+	}
+	result, err := svc.ImportConfig(context.Background(), "target-1", exported)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !createdDomain || len(result.CreatedDomains) != 1 {
+		t.Error("Expected missing domain to be created")
+	}
+	if len(result.CreatedOrigins) != 0 {
+		t.Errorf("Expected existing origin to be reused, got %d created", len(result.CreatedOrigins))
+	}
+	if !updatedRules || !result.RulesUpdated {
+		t.Error("Expected rules to be updated")
+	}
+	if appliedOptions["cors"] != true || appliedOptions["legacyOnly"] != nil {
+		t.Errorf("Expected only available options to be applied, got %v", appliedOptions)
+	}
+	if len(result.SkippedOptions) != 1 || result.SkippedOptions[0] != "legacyOnly" {
+		t.Errorf("Expected legacyOnly to be skipped, got %v", result.SkippedOptions)
+	}
+}
+
+// UPDATE - Test ImportConfig leaves key toggles the target already matches
+func TestServicesService_ImportConfig_KeepsKeys(t *testing.T) {
+	var keyRequests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1":
+			w.Write([]byte(`{"_id":"target-1"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/target-1/options/metadata":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/target-1/options":
+			w.Write([]byte(`{"apiKeyEnabled":true,"protectServeKeyEnabled":false}`))
+		case strings.HasPrefix(r.URL.Path, "/api/2.5/services/target-1/options/"):
+			keyRequests = append(keyRequests, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	exported := &ServiceConfig{Options: ServiceOptions{"apiKeyEnabled": true, "protectServeKeyEnabled": true}}
+	if _, err := svc.ImportConfig(context.Background(), "target-1", exported); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(keyRequests) != 1 || keyRequests[0] != "POST /api/2.5/services/target-1/options/protectserve" {
+		t.Errorf("Expected only the ProtectServe key to be created, got %v", keyRequests)
+	}
+}
+
+// UPDATE - Test ImportConfig sends no options when none can be applied
+func TestServicesService_ImportConfig_NoWritableOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1":
+			w.Write([]byte(`{"_id":"target-1"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/target-1/options/metadata":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	result, err := svc.ImportConfig(context.Background(), "target-1", &ServiceConfig{Options: ServiceOptions{"legacyOnly": true}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(result.SkippedOptions, []string{"legacyOnly"}) {
+		t.Errorf("Expected legacyOnly to be skipped, got %v", result.SkippedOptions)
+	}
+}
+
+func TestServicesService_ImportConfig_ConflictingDomains(t *testing.T) {
+	var created []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1":
+			w.Write([]byte(`{"_id":"target-1"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/target-1/domains":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"dom-1","name":"www.example.com"}]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/services/target-1/domains":
+			var req CreateServiceDomainRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			created = append(created, req.Name)
+			switch req.Name {
+			case "cdn.example.com":
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"message":"domain is bound to another service"}`))
+			case "reserved.example.com":
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"message":"hostname is reserved"}`))
+			default:
+				w.Write([]byte(`{"_id":"dom-2","name":"` + req.Name + `"}`))
+			}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	exported := &ServiceConfig{Domains: []ServiceDomain{
+		{Name: "WWW.example.com"},
+		{Name: "cdn.example.com"},
+		{Name: "img.example.com"},
+		{Name: "reserved.example.com"},
+	}}
+	result, err := svc.ImportConfig(context.Background(), "target-1", exported)
+	if err == nil || !strings.Contains(err.Error(), "reserved.example.com") {
+		t.Fatalf("Expected the failed domain in the error, got %v", err)
+	}
+	if result == nil || result.Service == nil {
+		t.Fatalf("Expected the partial result with the error, got %+v", result)
+	}
+	if !reflect.DeepEqual(created, []string{"cdn.example.com", "img.example.com", "reserved.example.com"}) {
+		t.Errorf("Expected existing domains to match ignoring case, got creates %v", created)
+	}
+	if !reflect.DeepEqual(result.ConflictingDomains, []string{"cdn.example.com"}) || len(result.CreatedDomains) != 1 {
+		t.Errorf("Expected the conflict reported and the import to go on, got %+v", result)
+	}
+}
@@ -0,0 +1,179 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// DeleteOptions controls what Delete may remove along with a service.
+type DeleteOptions struct {
+	// Force deactivates an active service before deleting it. Without it,
+	// active services are not deleted.
+	Force bool
+
+	// DetachDomains deletes the domains of the service, which releases the
+	// certificates bound to them, before deleting it. Without it, services
+	// with domains are not deleted.
+	DetachDomains bool
+}
+
+// DeletePlan describes what deleting a service removes and, once Delete
+// ran, how far it got.
+type DeletePlan struct {
+	ServiceID string `json:"serviceId"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+
+	// Deactivate is set when the service is active and is deactivated first
+	Deactivate bool `json:"deactivate"`
+
+	// Domains are deleted from the service before it is
+	Domains []ServiceDomain `json:"domains,omitempty"`
+
+	// Certificates are the IDs of the certificates bound to Domains. They
+	// are released, not deleted.
+	Certificates []string `json:"certificates,omitempty"`
+
+	// Blockers are the reasons Delete refuses to delete the service with
+	// the options given
+	Blockers []string `json:"blockers,omitempty"`
+
+	Deactivated     bool     `json:"deactivated,omitempty"`
+	DetachedDomains []string `json:"detachedDomains,omitempty"`
+	Deleted         bool     `json:"deleted,omitempty"`
+}
+
+// String renders the plan as the steps Delete takes.
+func (p *DeletePlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "service %s (%s, %s)\n", p.ServiceID, p.Name, p.Status)
+	if p.Deactivate {
+		b.WriteString("  deactivate the service\n")
+	}
+	for _, d := range p.Domains {
+		fmt.Fprintf(&b, "  delete domain %s\n", d.Name)
+	}
+	for _, id := range p.Certificates {
+		fmt.Fprintf(&b, "  release certificate %s\n", id)
+	}
+	b.WriteString("  delete the service\n")
+	for _, reason := range p.Blockers {
+		fmt.Fprintf(&b, "  blocked: %s\n", reason)
+	}
+	return b.String()
+}
+
+// DeleteBlockedError is returned by Delete when the service cannot be
+// deleted with the options given. Nothing has been changed.
+type DeleteBlockedError struct {
+	ServiceID string
+	Reasons   []string
+}
+
+func (e *DeleteBlockedError) Error() string {
+	return fmt.Sprintf("service %s cannot be deleted: %s", e.ServiceID, strings.Join(e.Reasons, "; "))
+}
+
+// PlanDelete reports what Delete would remove with opts, and why it would
+// refuse to, without changing anything.
+func (s *ServicesService) PlanDelete(ctx context.Context, id string, opts DeleteOptions) (*DeletePlan, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+
+	service, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	protection, err := s.Protection(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get protection: %w", err)
+	}
+	domains, err := (&ServiceDomainsService{Client: s.Client}).listAll(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	plan := &DeletePlan{
+		ServiceID:  id,
+		Name:       service.Name,
+		Status:     service.Status,
+		Deactivate: strings.EqualFold(service.Status, "ACTIVE"),
+		Domains:    domains,
+	}
+	seen := make(map[string]bool)
+	for _, d := range domains {
+		for _, cert := range d.Certificates {
+			if !seen[cert] {
+				seen[cert] = true
+				plan.Certificates = append(plan.Certificates, cert)
+			}
+		}
+	}
+	sort.Strings(plan.Certificates)
+
+	if protection.DeletionProtection {
+		plan.Blockers = append(plan.Blockers, "deletion protection is enabled")
+	}
+	if plan.Deactivate && !opts.Force {
+		plan.Blockers = append(plan.Blockers, "the service is active; use Force to deactivate it")
+	}
+	if len(domains) > 0 && !opts.DetachDomains {
+		plan.Blockers = append(plan.Blockers, fmt.Sprintf("the service has %d domains; use DetachDomains to delete them", len(domains)))
+	}
+	return plan, nil
+}
+
+// Delete deletes a service, first deactivating it and deleting its domains
+// as opts allow.
+//
+// The plan is checked before anything changes: a *DeleteBlockedError is
+// returned with it when the service has deletion protection enabled, is
+// active without opts.Force, or has domains without opts.DetachDomains.
+// The steps already taken are recorded in the returned plan, also when a
+// later step fails; they are not undone.
+//
+// Example:
+//
+//	plan, err := client.Services.PlanDelete(ctx, serviceID, api.DeleteOptions{Force: true, DetachDomains: true})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(plan)
+//	// after confirmation
+//	plan, err = client.Services.Delete(ctx, serviceID, api.DeleteOptions{Force: true, DetachDomains: true})
+func (s *ServicesService) Delete(ctx context.Context, id string, opts DeleteOptions) (*DeletePlan, error) {
+	plan, err := s.PlanDelete(ctx, id, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Blockers) > 0 {
+		return plan, &DeleteBlockedError{ServiceID: id, Reasons: plan.Blockers}
+	}
+
+	if plan.Deactivate {
+		if _, err := s.DeactivateServiceByID(ctx, id); err != nil {
+			return plan, fmt.Errorf("failed to deactivate service: %w", err)
+		}
+		plan.Deactivated = true
+	}
+
+	domains := &ServiceDomainsService{Client: s.Client}
+	for _, d := range plan.Domains {
+		if err := domains.DeleteByID(ctx, id, d.ID); err != nil {
+			return plan, fmt.Errorf("failed to delete domain %s: %w", d.Name, err)
+		}
+		plan.DetachedDomains = append(plan.DetachedDomains, d.ID)
+	}
+
+	if err := s.Client.Delete(ctx, fmt.Sprintf(apispec.PathService, id), nil); err != nil {
+		return plan, fmt.Errorf("failed to delete service: %w", err)
+	}
+	plan.Deleted = true
+	return plan, nil
+}
@@ -0,0 +1,156 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// deleteServer fakes the endpoints Delete uses for service svc-1 and records
+// the changing requests.
+func deleteServer(t *testing.T, status string, protected bool, requests *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			*requests = append(*requests, r.Method+" "+r.URL.Path)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/2.5/services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"Shop","status":"` + status + `"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/2.5/services/svc-1/options":
+			if protected {
+				w.Write([]byte(`{"deletionProtection":true}`))
+			} else {
+				w.Write([]byte(`{}`))
+			}
+		case r.Method == http.MethodGet && r.URL.Path == "/api/2.5/services/svc-1/domains":
+			w.Write([]byte(`{"meta":{"count":2},"data":[
+				{"_id":"dom-1","name":"a.example.com","certificates":["cert-2","cert-1"]},
+				{"_id":"dom-2","name":"b.example.com","certificates":["cert-1"]}
+			]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/2.5/services/svc-1/deactivate":
+			w.Write([]byte(`{"_id":"svc-1","status":"inactive"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/2.5/services/svc-1/domains/dom-2":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestServicesService_PlanDelete(t *testing.T) {
+	var requests []string
+	server := deleteServer(t, "ACTIVE", true, &requests)
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	plan, err := svc.PlanDelete(context.Background(), "svc-1", DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("Expected no changes, got %v", requests)
+	}
+	if plan.Name != "Shop" || !plan.Deactivate || len(plan.Domains) != 2 {
+		t.Errorf("Unexpected plan %+v", plan)
+	}
+	if !reflect.DeepEqual(plan.Certificates, []string{"cert-1", "cert-2"}) {
+		t.Errorf("Expected each certificate once, got %v", plan.Certificates)
+	}
+	if len(plan.Blockers) != 3 {
+		t.Errorf("Expected protection, status and domains blockers, got %v", plan.Blockers)
+	}
+
+	s := plan.String()
+	for _, want := range []string{"deactivate the service", "delete domain b.example.com", "release certificate cert-2", "blocked: deletion protection is enabled"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("Expected plan to contain %q, got:\n%s", want, s)
+		}
+	}
+}
+
+func TestServicesService_Delete_Blocked(t *testing.T) {
+	var requests []string
+	server := deleteServer(t, "ACTIVE", false, &requests)
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	plan, err := svc.Delete(context.Background(), "svc-1", DeleteOptions{DetachDomains: true})
+	var blocked *DeleteBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("Expected *DeleteBlockedError, got %v", err)
+	}
+	if len(blocked.Reasons) != 1 || !strings.Contains(blocked.Reasons[0], "Force") {
+		t.Errorf("Expected only the active status to block, got %v", blocked.Reasons)
+	}
+	if plan == nil || plan.Deleted || len(requests) != 0 {
+		t.Errorf("Expected nothing to change, got plan %+v and requests %v", plan, requests)
+	}
+}
+
+func TestServicesService_Delete_PartialFailure(t *testing.T) {
+	var requests []string
+	server := deleteServer(t, "ACTIVE", false, &requests)
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	plan, err := svc.Delete(context.Background(), "svc-1", DeleteOptions{Force: true, DetachDomains: true})
+	if err == nil || !strings.Contains(err.Error(), "b.example.com") {
+		t.Fatalf("Expected the failed domain in the error, got %v", err)
+	}
+	if !plan.Deactivated || !reflect.DeepEqual(plan.DetachedDomains, []string{"dom-1"}) || plan.Deleted {
+		t.Errorf("Expected the steps taken to be recorded, got %+v", plan)
+	}
+
+	expected := []string{
+		"PUT /api/2.5/services/svc-1/deactivate",
+		"DELETE /api/2.5/services/svc-1/domains/dom-1",
+		"DELETE /api/2.5/services/svc-1/domains/dom-2",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestServicesService_Delete(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/2.5/services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"Shop","status":"inactive"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/2.5/services/svc-1/options":
+			w.Write([]byte(`{"deletionProtection":false}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/2.5/services/svc-1/domains":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case r.Method == http.MethodDelete:
+			requests = append(requests, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	plan, err := svc.Delete(context.Background(), "svc-1", DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !plan.Deleted || plan.Deactivated || len(plan.DetachedDomains) != 0 {
+		t.Errorf("Unexpected plan %+v", plan)
+	}
+	if !reflect.DeepEqual(requests, []string{"/api/2.5/services/svc-1"}) {
+		t.Errorf("Expected only the service to be deleted, got %v", requests)
+	}
+}
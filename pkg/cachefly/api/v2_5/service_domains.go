@@ -2,11 +2,13 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // ServiceDomain represents a domain attached to a service.
@@ -21,6 +23,9 @@ type ServiceDomain struct {
 	ValidationMode   string   `json:"validationMode"`
 	ValidationTarget string   `json:"validationTarget"`
 	ValidationStatus string   `json:"validationStatus"`
+
+	// Raw holds the response fields ServiceDomain does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // ListServiceDomainsResponse wraps the paged list of domains.
@@ -37,6 +42,13 @@ type ListServiceDomainsOptions struct {
 	ResponseType string
 }
 
+// Apply sets the offset, limit and search of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListServiceDomainsOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit, search: &o.Search}.apply(opts)
+}
+
 // CreateServiceDomainRequest is the payload to add a domain.
 type CreateServiceDomainRequest struct {
 	Name           string `json:"name"`
@@ -46,9 +58,10 @@ type CreateServiceDomainRequest struct {
 
 // UpdateServiceDomainRequest is the payload to update a domain.
 type UpdateServiceDomainRequest struct {
-	Name           string `json:"name,omitempty"`
-	Description    string `json:"description,omitempty"`
-	ValidationMode string `json:"validationMode,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	Description    string   `json:"description,omitempty"`
+	ValidationMode string   `json:"validationMode,omitempty"`
+	Certificates   []string `json:"certificates,omitempty"`
 }
 
 // ServiceDomainsService handles Service Domains endpoints.
@@ -58,28 +71,21 @@ type ServiceDomainsService struct {
 
 // List returns all domains for a given service ID.
 func (s *ServiceDomainsService) List(ctx context.Context, sid string, opts ListServiceDomainsOptions) (*ListServiceDomainsResponse, error) {
-	if sid == "" {
-		return nil, fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", sid); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/domains", sid)
+	endpoint := fmt.Sprintf(apispec.PathServiceDomains, sid)
 
 	params := url.Values{}
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
 	if opts.ResponseType != "" {
 		params.Set("responseType", opts.ResponseType)
 	}
 
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 	var resp ListServiceDomainsResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Domains); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -87,15 +93,21 @@ func (s *ServiceDomainsService) List(ctx context.Context, sid string, opts ListS
 
 // Create adds a new domain to the service.
 func (s *ServiceDomainsService) Create(ctx context.Context, sid string, req CreateServiceDomainRequest) (*ServiceDomain, error) {
-	if sid == "" {
-		return nil, fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", sid); err != nil {
+		return nil, err
+	}
+	if err := validate.Hostname("name", req.Name); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/domains", sid)
+	endpoint := fmt.Sprintf(apispec.PathServiceDomains, sid)
 
 	var created ServiceDomain
 	if err := s.Client.Post(ctx, endpoint, req, &created); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathServiceDomain, sid, created.ID), &created); err != nil {
+		return nil, err
+	}
 	return &created, nil
 }
 
@@ -104,7 +116,7 @@ func (s *ServiceDomainsService) GetByID(ctx context.Context, sid, id, responseTy
 	if sid == "" || id == "" {
 		return nil, fmt.Errorf("service ID and domain ID are required")
 	}
-	endpoint := fmt.Sprintf("/services/%s/domains/%s", sid, id)
+	endpoint := fmt.Sprintf(apispec.PathServiceDomain, sid, id)
 
 	params := url.Values{}
 	if responseType != "" {
@@ -124,12 +136,15 @@ func (s *ServiceDomainsService) UpdateByID(ctx context.Context, sid, id string,
 	if sid == "" || id == "" {
 		return nil, fmt.Errorf("service ID and domain ID are required")
 	}
-	endpoint := fmt.Sprintf("/services/%s/domains/%s", sid, id)
+	endpoint := fmt.Sprintf(apispec.PathServiceDomain, sid, id)
 
 	var updated ServiceDomain
 	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
@@ -138,7 +153,7 @@ func (s *ServiceDomainsService) DeleteByID(ctx context.Context, sid, id string)
 	if sid == "" || id == "" {
 		return fmt.Errorf("service ID and domain ID are required")
 	}
-	endpoint := fmt.Sprintf("/services/%s/domains/%s", sid, id)
+	endpoint := fmt.Sprintf(apispec.PathServiceDomain, sid, id)
 	return s.Client.Delete(ctx, endpoint, nil)
 }
 
@@ -147,12 +162,15 @@ func (s *ServiceDomainsService) ValidationReady(ctx context.Context, sid, id str
 	if sid == "" || id == "" {
 		return nil, fmt.Errorf("service ID and domain ID are required")
 	}
-	endpoint := fmt.Sprintf("/services/%s/domains/%s/validationReady", sid, id)
+	endpoint := fmt.Sprintf(apispec.PathServiceDomainValidation, sid, id)
 
 	var result ServiceDomain
 	// Empty JSON body ensures Content-Type header is set
 	if err := s.Client.Put(ctx, endpoint, struct{}{}, &result); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathServiceDomain, sid, id), &result); err != nil {
+		return nil, err
+	}
 	return &result, nil
 }
@@ -0,0 +1,241 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// compensationTimeout bounds the requests that undo a failed move. They run
+// even when the caller's context is done, since stopping halfway could leave
+// a domain on neither service.
+const compensationTimeout = time.Minute
+
+// MoveOptions controls how MoveMany migrates domains between services.
+type MoveOptions struct {
+	// ValidationMode overrides the validation mode of the recreated domains.
+	// Empty keeps each domain's current mode.
+	ValidationMode string
+
+	// SignalValidationReady calls ValidationReady on every recreated domain so
+	// the target service re-checks validation immediately.
+	SignalValidationReady bool
+
+	// NoRollback leaves already moved domains on the target service when a
+	// later domain fails, instead of moving them back.
+	NoRollback bool
+}
+
+// DomainMove describes a single domain moved by MoveMany.
+type DomainMove struct {
+	Hostname         string `json:"hostname"`
+	OldID            string `json:"oldId"`
+	NewID            string `json:"newId"`
+	ValidationStatus string `json:"validationStatus,omitempty"`
+}
+
+// MoveResult reports the outcome of MoveMany.
+type MoveResult struct {
+	Moved      []DomainMove `json:"moved"`
+	RolledBack []string     `json:"rolledBack,omitempty"`
+}
+
+// MoveMany moves the named domains from one service to another.
+//
+// The API has no native move, so each domain is deleted from the source
+// service and recreated on the target with the same description, validation
+// mode and certificates. All hostnames are checked before anything changes:
+// each must exist on the source and must not exist on the target.
+//
+// When a domain fails to move, the domains moved so far are moved back to
+// the source service (unless opts.NoRollback is set) and the error is
+// returned together with the partial result. Domains still listed in
+// result.Moved are on the target service. Moving back and restoring a
+// domain whose move failed are not stopped by the cancellation of ctx.
+func (s *ServiceDomainsService) MoveMany(ctx context.Context, fromServiceID, toServiceID string, hostnames []string, opts MoveOptions) (*MoveResult, error) {
+	if fromServiceID == "" || toServiceID == "" {
+		return nil, fmt.Errorf("source and target service IDs are required")
+	}
+	if fromServiceID == toServiceID {
+		return nil, fmt.Errorf("source and target service must differ")
+	}
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("at least one hostname is required")
+	}
+
+	source, err := s.listAll(ctx, fromServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source domains: %w", err)
+	}
+	target, err := s.listAll(ctx, toServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target domains: %w", err)
+	}
+
+	sourceByName := make(map[string]ServiceDomain, len(source))
+	for _, d := range source {
+		sourceByName[strings.ToLower(d.Name)] = d
+	}
+	targetNames := make(map[string]bool, len(target))
+	for _, d := range target {
+		targetNames[strings.ToLower(d.Name)] = true
+	}
+
+	var pending []ServiceDomain
+	seen := make(map[string]bool, len(hostnames))
+	for _, name := range hostnames {
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		d, ok := sourceByName[key]
+		if !ok {
+			return nil, fmt.Errorf("domain %s not found on service %s", name, fromServiceID)
+		}
+		if targetNames[key] {
+			return nil, fmt.Errorf("domain %s already exists on service %s", name, toServiceID)
+		}
+		pending = append(pending, d)
+	}
+
+	result := &MoveResult{}
+	for _, d := range pending {
+		mode := d.ValidationMode
+		if opts.ValidationMode != "" {
+			mode = opts.ValidationMode
+		}
+
+		moved, err := s.moveDomain(ctx, d, fromServiceID, toServiceID, mode, opts.SignalValidationReady)
+		if moved != nil {
+			// Recorded even on error, so that rollback covers it
+			result.Moved = append(result.Moved, *moved)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to move domain %s: %w", d.Name, err)
+			if !opts.NoRollback {
+				if rbErr := s.rollbackMoves(ctx, result, pending, fromServiceID, toServiceID); rbErr != nil {
+					return result, fmt.Errorf("%w; rollback failed: %v", err, rbErr)
+				}
+			}
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// moveDomain deletes d from the source service and recreates it on the
+// target. If the target rejects the domain, or its certificates, it is
+// removed from the target and recreated on the source so a single failed
+// move never loses the domain. When only signaling validation readiness
+// fails, the domain has moved and is returned with the error.
+func (s *ServiceDomainsService) moveDomain(ctx context.Context, d ServiceDomain, fromServiceID, toServiceID, mode string, signalReady bool) (*DomainMove, error) {
+	if err := s.DeleteByID(ctx, fromServiceID, d.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete from source: %w", err)
+	}
+
+	created, err := s.recreate(ctx, toServiceID, d, mode)
+	if err != nil {
+		cctx, cancel := compensationContext(ctx)
+		defer cancel()
+		if created != nil {
+			if delErr := s.DeleteByID(cctx, toServiceID, created.ID); delErr != nil {
+				return nil, fmt.Errorf("failed to create on target: %w; failed to remove %s (%s) from target: %v", err, d.Name, created.ID, delErr)
+			}
+		}
+		if _, restoreErr := s.recreate(cctx, fromServiceID, d, d.ValidationMode); restoreErr != nil {
+			return nil, fmt.Errorf("failed to create on target: %w; failed to restore on source: %v", err, restoreErr)
+		}
+		return nil, fmt.Errorf("failed to create on target: %w", err)
+	}
+
+	move := &DomainMove{
+		Hostname:         d.Name,
+		OldID:            d.ID,
+		NewID:            created.ID,
+		ValidationStatus: created.ValidationStatus,
+	}
+	if signalReady {
+		ready, err := s.ValidationReady(ctx, toServiceID, created.ID)
+		if err != nil {
+			return move, fmt.Errorf("failed to signal validation ready: %w", err)
+		}
+		move.ValidationStatus = ready.ValidationStatus
+	}
+	return move, nil
+}
+
+// recreate creates d on serviceID and rebinds its certificates. When only
+// binding the certificates fails, the created domain is returned with the
+// error.
+func (s *ServiceDomainsService) recreate(ctx context.Context, serviceID string, d ServiceDomain, mode string) (*ServiceDomain, error) {
+	created, err := s.Create(ctx, serviceID, CreateServiceDomainRequest{
+		Name:           d.Name,
+		Description:    d.Description,
+		ValidationMode: mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Certificates) > 0 {
+		updated, err := s.UpdateByID(ctx, serviceID, created.ID, UpdateServiceDomainRequest{Certificates: d.Certificates})
+		if err != nil {
+			return created, fmt.Errorf("failed to bind certificates: %w", err)
+		}
+		created = updated
+	}
+	return created, nil
+}
+
+// rollbackMoves moves every domain in result back to the source service, in
+// reverse order. Each domain moved back is taken off result.Moved, so when a
+// step fails result.Moved lists the domains left on the target.
+func (s *ServiceDomainsService) rollbackMoves(ctx context.Context, result *MoveResult, pending []ServiceDomain, fromServiceID, toServiceID string) error {
+	ctx, cancel := compensationContext(ctx)
+	defer cancel()
+
+	originals := make(map[string]ServiceDomain, len(pending))
+	for _, d := range pending {
+		originals[d.ID] = d
+	}
+
+	for i := len(result.Moved) - 1; i >= 0; i-- {
+		m := result.Moved[i]
+		if err := s.DeleteByID(ctx, toServiceID, m.NewID); err != nil {
+			return fmt.Errorf("failed to remove %s from target: %w", m.Hostname, err)
+		}
+		orig := originals[m.OldID]
+		if _, err := s.recreate(ctx, fromServiceID, orig, orig.ValidationMode); err != nil {
+			return fmt.Errorf("failed to restore %s on source: %w", m.Hostname, err)
+		}
+		result.RolledBack = append(result.RolledBack, m.Hostname)
+		result.Moved = result.Moved[:i]
+	}
+	result.Moved = nil
+	return nil
+}
+
+// compensationContext returns a context for undoing changes made with ctx:
+// it keeps the values of ctx but is not canceled with it.
+func compensationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), compensationTimeout)
+}
+
+// listAll returns every domain of a service.
+func (s *ServiceDomainsService) listAll(ctx context.Context, sid string) ([]ServiceDomain, error) {
+	var all []ServiceDomain
+	for offset := 0; ; offset += listAllPageSize {
+		page, err := s.List(ctx, sid, ListServiceDomainsOptions{Offset: offset, Limit: listAllPageSize})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Domains...)
+		if len(page.Domains) < listAllPageSize {
+			break
+		}
+	}
+	return all, nil
+}
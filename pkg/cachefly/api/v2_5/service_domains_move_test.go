@@ -0,0 +1,277 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// domainStore is an in-memory fake of the service domains endpoints.
+type domainStore struct {
+	mu      sync.Mutex
+	next    int
+	domains map[string][]ServiceDomain
+	reject  string
+
+	// rejectCerts and rejectReady fail binding certificates and signaling
+	// validation readiness on svc-to
+	rejectCerts bool
+	rejectReady bool
+
+	// rejectRestore fails recreating the named domain on svc-from
+	rejectRestore string
+
+	// onCreate is called, and the domain rejected, when a domain is created
+	// on svc-to
+	onCreate func()
+}
+
+func (d *domainStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/2.5/services/"), "/")
+	sid := parts[0]
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == "GET" && len(parts) == 2:
+		json.NewEncoder(w).Encode(ListServiceDomainsResponse{Domains: d.domains[sid]})
+	case r.Method == "POST":
+		var req CreateServiceDomainRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if sid == "svc-to" && d.onCreate != nil {
+			d.onCreate()
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message":"unavailable"}`))
+			return
+		}
+		if req.Name == d.reject && sid == "svc-to" || req.Name == d.rejectRestore && sid == "svc-from" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message":"conflict"}`))
+			return
+		}
+		d.next++
+		created := ServiceDomain{ID: fmt.Sprintf("new-%d", d.next), Name: req.Name, Service: sid, ValidationMode: req.ValidationMode}
+		d.domains[sid] = append(d.domains[sid], created)
+		json.NewEncoder(w).Encode(created)
+	case r.Method == "PUT" && sid == "svc-to" && (len(parts) == 3 && d.rejectCerts || len(parts) == 4 && d.rejectReady):
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"boom"}`))
+	case r.Method == "PUT":
+		var req UpdateServiceDomainRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		for i, dom := range d.domains[sid] {
+			if dom.ID == parts[2] {
+				d.domains[sid][i].Certificates = req.Certificates
+				json.NewEncoder(w).Encode(d.domains[sid][i])
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == "DELETE":
+		kept := d.domains[sid][:0]
+		for _, dom := range d.domains[sid] {
+			if dom.ID != parts[2] {
+				kept = append(kept, dom)
+			}
+		}
+		d.domains[sid] = kept
+	}
+}
+
+func (d *domainStore) names(sid string) []string {
+	var names []string
+	for _, dom := range d.domains[sid] {
+		names = append(names, dom.Name)
+	}
+	return names
+}
+
+func TestServiceDomainsService_MoveMany(t *testing.T) {
+	store := &domainStore{domains: map[string][]ServiceDomain{
+		"svc-from": {
+			{ID: "d1", Name: "a.example.com", ValidationMode: "HTTP", Certificates: []string{"cert-1"}},
+			{ID: "d2", Name: "b.example.com", ValidationMode: "DNS"},
+			{ID: "d3", Name: "keep.example.com"},
+		},
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	result, err := svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"A.example.com", "b.example.com"}, MoveOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Moved) != 2 {
+		t.Fatalf("Expected 2 moved domains, got %d", len(result.Moved))
+	}
+	if got := store.names("svc-from"); len(got) != 1 || got[0] != "keep.example.com" {
+		t.Errorf("Expected only keep.example.com on source, got %v", got)
+	}
+	moved := store.domains["svc-to"][0]
+	if moved.ValidationMode != "HTTP" || len(moved.Certificates) != 1 || moved.Certificates[0] != "cert-1" {
+		t.Errorf("Expected validation mode and certificates to be preserved, got %+v", moved)
+	}
+}
+
+func TestServiceDomainsService_MoveMany_RollsBack(t *testing.T) {
+	store := &domainStore{reject: "b.example.com", domains: map[string][]ServiceDomain{
+		"svc-from": {
+			{ID: "d1", Name: "a.example.com"},
+			{ID: "d2", Name: "b.example.com"},
+		},
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	result, err := svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"a.example.com", "b.example.com"}, MoveOptions{})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if len(result.RolledBack) != 1 || result.RolledBack[0] != "a.example.com" {
+		t.Errorf("Expected a.example.com to be rolled back, got %v", result.RolledBack)
+	}
+	if got := store.names("svc-to"); len(got) != 0 {
+		t.Errorf("Expected target to be empty after rollback, got %v", got)
+	}
+	if got := store.names("svc-from"); len(got) != 2 {
+		t.Errorf("Expected both domains back on source, got %v", got)
+	}
+}
+
+func TestServiceDomainsService_MoveMany_RollbackFails(t *testing.T) {
+	store := &domainStore{reject: "c.example.com", rejectRestore: "a.example.com", domains: map[string][]ServiceDomain{
+		"svc-from": {
+			{ID: "d1", Name: "a.example.com"},
+			{ID: "d2", Name: "b.example.com"},
+			{ID: "d3", Name: "c.example.com"},
+		},
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	result, err := svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"a.example.com", "b.example.com", "c.example.com"}, MoveOptions{})
+	if err == nil || !strings.Contains(err.Error(), "rollback failed") {
+		t.Fatalf("Expected the rollback to fail, got %v", err)
+	}
+	if len(result.RolledBack) != 1 || result.RolledBack[0] != "b.example.com" {
+		t.Errorf("Expected b.example.com to be rolled back, got %v", result.RolledBack)
+	}
+	if len(result.Moved) != 1 || result.Moved[0].Hostname != "a.example.com" {
+		t.Errorf("Expected only a.example.com to be left moved, got %+v", result.Moved)
+	}
+}
+
+func TestServiceDomainsService_MoveMany_CanceledRestores(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := &domainStore{onCreate: cancel, domains: map[string][]ServiceDomain{
+		"svc-from": {{ID: "d1", Name: "a.example.com", Certificates: []string{"cert-1"}}},
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	if _, err := svc.MoveMany(ctx, "svc-from", "svc-to", []string{"a.example.com"}, MoveOptions{}); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if got := store.names("svc-from"); len(got) != 1 || got[0] != "a.example.com" {
+		t.Fatalf("Expected the domain to be restored on source after cancellation, got %v", got)
+	}
+	if certs := store.domains["svc-from"][0].Certificates; len(certs) != 1 {
+		t.Errorf("Expected the certificates to be rebound, got %v", certs)
+	}
+}
+
+func TestServiceDomainsService_MoveMany_CertificateBindFails(t *testing.T) {
+	store := &domainStore{rejectCerts: true, domains: map[string][]ServiceDomain{
+		"svc-from": {{ID: "d1", Name: "a.example.com", Certificates: []string{"cert-1"}}},
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	result, err := svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"a.example.com"}, MoveOptions{})
+	if err == nil || !strings.Contains(err.Error(), "bind certificates") {
+		t.Fatalf("Expected the certificate error, got %v", err)
+	}
+	if len(result.Moved) != 0 {
+		t.Errorf("Expected nothing moved, got %+v", result.Moved)
+	}
+	if got := store.names("svc-to"); len(got) != 0 {
+		t.Errorf("Expected the created domain to be removed from the target, got %v", got)
+	}
+	if got := store.names("svc-from"); len(got) != 1 {
+		t.Errorf("Expected the domain back on source, got %v", got)
+	}
+}
+
+func TestServiceDomainsService_MoveMany_ValidationReadyFails(t *testing.T) {
+	store := &domainStore{rejectReady: true, domains: map[string][]ServiceDomain{
+		"svc-from": {{ID: "d1", Name: "a.example.com"}},
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	opts := MoveOptions{SignalValidationReady: true, NoRollback: true}
+	result, err := svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"a.example.com"}, opts)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if len(result.Moved) != 1 || result.Moved[0].Hostname != "a.example.com" {
+		t.Errorf("Expected the moved domain to be recorded, got %+v", result.Moved)
+	}
+	if got := store.names("svc-to"); len(got) != 1 {
+		t.Errorf("Expected the domain on the target, got %v", got)
+	}
+
+	store.domains = map[string][]ServiceDomain{"svc-from": {{ID: "d1", Name: "a.example.com"}}}
+	result, err = svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"a.example.com"}, MoveOptions{SignalValidationReady: true})
+	if err == nil || len(result.RolledBack) != 1 {
+		t.Errorf("Expected the move to be rolled back, got %+v, %v", result, err)
+	}
+	if got := store.names("svc-to"); len(got) != 0 {
+		t.Errorf("Expected target to be empty after rollback, got %v", got)
+	}
+}
+
+func TestServiceDomainsService_MoveMany_PreflightChecks(t *testing.T) {
+	store := &domainStore{domains: map[string][]ServiceDomain{
+		"svc-from": {{ID: "d1", Name: "a.example.com"}},
+		"svc-to":   {{ID: "d9", Name: "a.example.com"}},
+	}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	if _, err := svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"a.example.com"}, MoveOptions{}); err == nil {
+		t.Error("Expected error for domain already on target, got nil")
+	}
+	if _, err := svc.MoveMany(context.Background(), "svc-from", "svc-to", []string{"missing.example.com"}, MoveOptions{}); err == nil {
+		t.Error("Expected error for missing domain, got nil")
+	}
+	if got := store.names("svc-from"); len(got) != 1 {
+		t.Errorf("Expected source to be untouched, got %v", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // CREATE - Test Create method
@@ -40,6 +41,26 @@ func TestServiceDomainsService_Create(t *testing.T) {
 	}
 }
 
+// CREATE - Test Create rejects invalid hostnames before sending
+func TestServiceDomainsService_CreateInvalidHostname(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	svc := &ServiceDomainsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+
+	_, err := svc.Create(context.Background(), "svc-123", CreateServiceDomainRequest{Name: "bad_host.example.com"})
+	if validate.CodeOf(err) != validate.CodeInvalidHostname {
+		t.Errorf("Expected %s, got %v", validate.CodeInvalidHostname, err)
+	}
+
+	_, err = svc.Create(context.Background(), "svc/123", CreateServiceDomainRequest{Name: "example.com"})
+	if validate.CodeOf(err) != validate.CodeInvalidID {
+		t.Errorf("Expected %s, got %v", validate.CodeInvalidID, err)
+	}
+}
+
 // READ - Test List method
 func TestServiceDomainsService_List(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
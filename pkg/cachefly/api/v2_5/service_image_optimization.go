@@ -6,6 +6,8 @@ import (
 	"net/url"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // ServiceImageOptimizationService handles account-related API operations.
@@ -24,10 +26,10 @@ type CreateImageOptimizationOptions struct {
 // GetConfiguration fetches the current image optimization configuration (YAML or JSON string).
 // GET /services/{id}/imageopt4
 func (s *ServiceImageOptimizationService) GetConfiguration(ctx context.Context, serviceID string) (string, error) {
-	if serviceID == "" {
-		return "", fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return "", err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimization, serviceID)
 
 	var configStr string
 	if err := s.Client.Get(ctx, endpoint, &configStr); err != nil {
@@ -39,10 +41,10 @@ func (s *ServiceImageOptimizationService) GetConfiguration(ctx context.Context,
 // CreateConfiguration creates a new configuration; body is YAML or JSON string.
 // POST /services/{id}/imageopt4
 func (s *ServiceImageOptimizationService) CreateConfiguration(ctx context.Context, serviceID string, configStr CreateImageOptimizationOptions) (string, error) {
-	if serviceID == "" {
-		return "", fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return "", err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimization, serviceID)
 
 	var createdStr string
 	if err := s.Client.Post(ctx, endpoint, configStr, &createdStr); err != nil {
@@ -54,10 +56,10 @@ func (s *ServiceImageOptimizationService) CreateConfiguration(ctx context.Contex
 // UpdateConfiguration updates an existing configuration; body is YAML or JSON string.
 // PUT /services/{id}/imageopt4
 func (s *ServiceImageOptimizationService) UpdateConfiguration(ctx context.Context, serviceID string, configStr string) (string, error) {
-	if serviceID == "" {
-		return "", fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return "", err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimization, serviceID)
 
 	var updatedStr string
 	if err := s.Client.Put(ctx, endpoint, configStr, &updatedStr); err != nil {
@@ -69,20 +71,20 @@ func (s *ServiceImageOptimizationService) UpdateConfiguration(ctx context.Contex
 // DeleteConfiguration removes the existing configuration.
 // DELETE /services/{id}/imageopt4
 func (s *ServiceImageOptimizationService) DeleteConfiguration(ctx context.Context, serviceID string) error {
-	if serviceID == "" {
-		return fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimization, serviceID)
 	return s.Client.Delete(ctx, endpoint, nil)
 }
 
 // GetSchema fetches the validation schema for image optimization config.
 // GET /services/{id}/imageopt4/schema
 func (s *ServiceImageOptimizationService) GetSchema(ctx context.Context, serviceID string) (map[string]interface{}, error) {
-	if serviceID == "" {
-		return nil, fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4/schema", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimizationSchema, serviceID)
 
 	var schema map[string]interface{}
 	if err := s.Client.Get(ctx, endpoint, &schema); err != nil {
@@ -94,10 +96,10 @@ func (s *ServiceImageOptimizationService) GetSchema(ctx context.Context, service
 // GetDefaults fetches the default config for image optimization.
 // GET /services/{id}/imageopt4/defaults
 func (s *ServiceImageOptimizationService) GetDefaults(ctx context.Context, serviceID string) (string, error) {
-	if serviceID == "" {
-		return "", fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return "", err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4/default", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimizationDefault, serviceID)
 
 	var defStr string
 	if err := s.Client.Get(ctx, endpoint, &defStr); err != nil {
@@ -107,10 +109,10 @@ func (s *ServiceImageOptimizationService) GetDefaults(ctx context.Context, servi
 }
 
 func (s *ServiceImageOptimizationService) GetDetail(ctx context.Context, serviceID string) (string, error) {
-	if serviceID == "" {
-		return "", fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return "", err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4/details", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimizationDetails, serviceID)
 
 	var exStr string
 	if err := s.Client.Get(ctx, endpoint, &exStr); err != nil {
@@ -122,10 +124,10 @@ func (s *ServiceImageOptimizationService) GetDetail(ctx context.Context, service
 // ValidateConfiguration validates a config string against the schema.
 // POST /services/{id}/imageopt4/validate
 func (s *ServiceImageOptimizationService) ValidateConfiguration(ctx context.Context, serviceID string, configStr string) (map[string]interface{}, error) {
-	if serviceID == "" {
-		return nil, fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4/validate", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimizationValidate, serviceID)
 
 	var result map[string]interface{}
 	if err := s.Client.Post(ctx, endpoint, configStr, &result); err != nil {
@@ -136,10 +138,10 @@ func (s *ServiceImageOptimizationService) ValidateConfiguration(ctx context.Cont
 
 // ActivateConfiguration enables the image optimization configuration for a service.
 func (s *ServiceImageOptimizationService) ActivateConfiguration(ctx context.Context, serviceID string) error {
-	if serviceID == "" {
-		return fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", serviceID); err != nil {
+		return err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4/activate", url.PathEscape(serviceID))
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimizationActivate, url.PathEscape(serviceID))
 
 	emptyBody := struct{}{}
 
@@ -152,10 +154,10 @@ func (s *ServiceImageOptimizationService) ActivateConfiguration(ctx context.Cont
 
 // DeactivateConfiguration disables the image optimization configuration for a service.
 func (s *ServiceImageOptimizationService) DeactivateConfiguration(ctx context.Context, serviceID string) error {
-	if serviceID == "" {
-		return fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", serviceID); err != nil {
+		return err
 	}
-	endpoint := fmt.Sprintf("/services/%s/imageopt4/deactivate", url.PathEscape(serviceID))
+	endpoint := fmt.Sprintf(apispec.PathServiceImageOptimizationDeactivate, url.PathEscape(serviceID))
 	// Perform PUT with no request body
 	if err := s.Client.Put(ctx, endpoint, nil, nil); err != nil {
 		return err
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // OptionProperty represents detailed metadata about an option property
@@ -20,6 +23,7 @@ type OptionProperty struct {
 	Default    interface{} `json:"default,omitempty"`
 	EnumValues []EnumValue `json:"enumValues,omitempty"`
 	BitFields  []BitField  `json:"bitFields,omitempty"`
+	Unit       string      `json:"unit,omitempty"` // "seconds", "milliseconds", ... for durations
 	UpdatedAt  string      `json:"updatedAt"`
 	CreatedAt  string      `json:"createdAt"`
 }
@@ -74,6 +78,67 @@ type ValidationError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 	Code    string `json:"code"`
+
+	// The remaining fields describe the option from its metadata; they are
+	// empty when the option is not available for the service.
+
+	// Type is the property type, such as "integer" or "enum", or "standard"
+	// for standard options
+	Type string `json:"type,omitempty"`
+
+	// AllowedValues lists the values of enum options and the keys of
+	// bitfield options
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	// MinValue and MaxValue bound integer options
+	MinValue *int `json:"minValue,omitempty"`
+	MaxValue *int `json:"maxValue,omitempty"`
+
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// optionValidationEntry returns the validation error entry for an option,
+// filled with the constraints from opt when it is not nil.
+func optionValidationEntry(name, code, message string, opt *OptionMetadata) ValidationError {
+	e := ValidationError{Field: name, Message: message, Code: code}
+	if opt == nil {
+		return e
+	}
+	e.ReadOnly = opt.ReadOnly
+	e.Type = opt.Type
+	if prop := opt.Property; prop != nil {
+		e.Type = prop.Type
+		e.MinValue, e.MaxValue = prop.MinValue, prop.MaxValue
+		for _, ev := range prop.EnumValues {
+			e.AllowedValues = append(e.AllowedValues, ev.Value)
+		}
+		for _, f := range prop.BitFields {
+			e.AllowedValues = append(e.AllowedValues, f.Key)
+		}
+	}
+	return e
+}
+
+// Expected describes the values the option accepts, such as "integer
+// between 0 and 86400" or "one of: GZIP, BROTLI", for rendering next to
+// Message. It is empty when nothing is known about the option.
+func (e ValidationError) Expected() string {
+	if e.ReadOnly {
+		return "read-only"
+	}
+	switch {
+	case e.Type == "enum" && len(e.AllowedValues) > 0:
+		return "one of: " + strings.Join(e.AllowedValues, ", ")
+	case e.Type == "bitfield" && len(e.AllowedValues) > 0:
+		return "any of: " + strings.Join(e.AllowedValues, ", ")
+	case e.MinValue != nil && e.MaxValue != nil:
+		return fmt.Sprintf("%s between %d and %d", e.Type, *e.MinValue, *e.MaxValue)
+	case e.MinValue != nil:
+		return fmt.Sprintf("%s of at least %d", e.Type, *e.MinValue)
+	case e.MaxValue != nil:
+		return fmt.Sprintf("%s of at most %d", e.Type, *e.MaxValue)
+	}
+	return e.Type
 }
 
 // ServiceOptionsValidationError represents multiple validation errors
@@ -96,10 +161,10 @@ type ServiceOptionsService struct {
 
 // GetOptionsMetadata retrieves metadata about available options for a service
 func (s *ServiceOptionsService) GetOptionsMetadata(ctx context.Context, id string) (*ServiceOptionsMetadata, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/metadata", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsMetadata, id)
 
 	var metadata ServiceOptionsMetadata
 	if err := s.Client.Get(ctx, endpoint, &metadata); err != nil {
@@ -108,12 +173,13 @@ func (s *ServiceOptionsService) GetOptionsMetadata(ctx context.Context, id strin
 	return &metadata, nil
 }
 
-// GetOptions retrieves current options for a service
+// GetOptions retrieves current options for a service as returned by the
+// API. Use Get for typed values and options without a value.
 func (s *ServiceOptionsService) GetOptions(ctx context.Context, id string) (ServiceOptions, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptions, id)
 
 	var opts ServiceOptions
 	if err := s.Client.Get(ctx, endpoint, &opts); err != nil {
@@ -124,8 +190,8 @@ func (s *ServiceOptionsService) GetOptions(ctx context.Context, id string) (Serv
 
 // UpdateOptions updates service options with strict validation and handles special cases
 func (s *ServiceOptionsService) UpdateOptions(ctx context.Context, id string, options ServiceOptions) (ServiceOptions, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
 	// Handle special key management fields
@@ -167,10 +233,13 @@ func (s *ServiceOptionsService) UpdateOptions(ctx context.Context, id string, op
 		transformedOptions := s.transformOptionsForAPI(options, metadata)
 
 		// Update options
-		endpoint := fmt.Sprintf("/services/%s/options", id)
+		endpoint := fmt.Sprintf(apispec.PathServiceOptions, id)
 		if err := s.Client.Put(ctx, endpoint, transformedOptions, &updated); err != nil {
 			return nil, err
 		}
+		if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+			return nil, err
+		}
 	} else {
 		// If no options to update, get current options for return value
 		var err error
@@ -221,7 +290,8 @@ func (s *ServiceOptionsService) UpdateOptions(ctx context.Context, id string, op
 	return updated, nil
 }
 
-// validateOptions performs strict validation against metadata
+// validateOptions performs strict validation against metadata. Read-only and
+// beta options are only accepted when the client allows them.
 func (s *ServiceOptionsService) validateOptions(options ServiceOptions, metadata *ServiceOptionsMetadata) error {
 	var validationErrors []ValidationError
 
@@ -234,23 +304,7 @@ func (s *ServiceOptionsService) validateOptions(options ServiceOptions, metadata
 			dynamicOptions[opt.Property.Name] = opt
 		} else if opt.Type == "standard" {
 			// Map standard option names to their expected field names
-			var optName string
-			switch opt.Name {
-			case "Reverse Proxy":
-				optName = "reverseProxy"
-			case "ProtectServe":
-				optName = "protectServeKeyEnabled"
-			case "CORS Override":
-				optName = "cors"
-			case "Expiry Overrides":
-				optName = "expiryHeaders"
-			case "Referrer Blocking":
-				optName = "referrerBlocking"
-			case "Auto HTTPS Redirect":
-				optName = "autoRedirect"
-			default:
-				optName = opt.Name
-			}
+			optName := standardOptionName(opt.Name)
 			standardOptions[optName] = opt
 		}
 	}
@@ -267,21 +321,22 @@ func (s *ServiceOptionsService) validateOptions(options ServiceOptions, metadata
 		} else if optMeta, exists = standardOptions[optionName]; exists {
 			isDynamic = false
 		} else {
-			validationErrors = append(validationErrors, ValidationError{
-				Field:   optionName,
-				Message: fmt.Sprintf("Option '%s' is not available for this service", optionName),
-				Code:    "OPTION_NOT_AVAILABLE",
-			})
+			validationErrors = append(validationErrors, optionValidationEntry(optionName, "OPTION_NOT_AVAILABLE",
+				fmt.Sprintf("Option '%s' is not available for this service", optionName), nil))
 			continue
 		}
 
 		// Check if option is read-only
-		if optMeta.ReadOnly {
-			validationErrors = append(validationErrors, ValidationError{
-				Field:   optionName,
-				Message: fmt.Sprintf("Option '%s' is read-only and cannot be modified", optionName),
-				Code:    "OPTION_READ_ONLY",
-			})
+		if optMeta.ReadOnly && !s.Client.AllowsReadOnlyOptions() {
+			validationErrors = append(validationErrors, optionValidationEntry(optionName, "OPTION_READ_ONLY",
+				fmt.Sprintf("Option '%s' is read-only and cannot be modified", optionName), &optMeta))
+			continue
+		}
+
+		// Beta options require an explicit opt-in
+		if optMeta.Stability() == OptionBeta && !s.Client.AllowsBetaOptions() {
+			validationErrors = append(validationErrors, optionValidationEntry(optionName, "OPTION_BETA",
+				fmt.Sprintf("Option '%s' is a beta option and requires the client to allow beta options", optionName), &optMeta))
 			continue
 		}
 
@@ -289,20 +344,12 @@ func (s *ServiceOptionsService) validateOptions(options ServiceOptions, metadata
 		if isDynamic {
 			// Dynamic options can be direct values or enabled/value structure
 			if err := s.validateDynamicOptionValue(optionName, optMeta, value); err != nil {
-				validationErrors = append(validationErrors, ValidationError{
-					Field:   optionName,
-					Message: err.Error(),
-					Code:    "INVALID_VALUE",
-				})
+				validationErrors = append(validationErrors, optionValidationEntry(optionName, "INVALID_VALUE", err.Error(), &optMeta))
 			}
 		} else {
 			// Standard options have various structures
 			if err := s.validateStandardOptionValue(optionName, optMeta, value); err != nil {
-				validationErrors = append(validationErrors, ValidationError{
-					Field:   optionName,
-					Message: err.Error(),
-					Code:    "INVALID_VALUE",
-				})
+				validationErrors = append(validationErrors, optionValidationEntry(optionName, "INVALID_VALUE", err.Error(), &optMeta))
 			}
 		}
 	}
@@ -370,21 +417,21 @@ func (s *ServiceOptionsService) validateEnabledValueStructure(optionName string,
 // validateStandardOptionValue validates standard option values with their various structures
 func (s *ServiceOptionsService) validateStandardOptionValue(optionName string, opt OptionMetadata, value interface{}) error {
 	switch optionName {
-	case "protectServeKeyEnabled", "cors", "referrerBlocking", "autoRedirect":
+	case apispec.OptionProtectServeKeyEnabled, apispec.OptionCors, apispec.OptionReferrerBlocking, apispec.OptionAutoRedirect:
 		// Simple boolean options
 		if _, ok := value.(bool); !ok {
 			return fmt.Errorf("option '%s' expects a boolean value, got %T", optionName, value)
 		}
 
-	case "reverseProxy":
+	case apispec.OptionReverseProxy:
 		// Complex object with enabled flag and configuration
 		return s.validateReverseProxyOption(value)
 
-	case "rawLogs":
+	case apispec.OptionRawLogs:
 		// Complex object with enabled flag and configuration
 		return s.validateRawLogsOption(value)
 
-	case "expiryHeaders":
+	case apispec.OptionExpiryHeaders:
 		// Array of expiry header configurations or enabled/value structure
 		return s.validateExpiryHeadersOption(value)
 
@@ -740,34 +787,34 @@ func (s *ServiceOptionsService) IsOptionAvailable(ctx context.Context, id string
 		return false, nil, err
 	}
 
-	// Check both dynamic and standard options
-	for _, opt := range metadata.Data {
-		if opt.Type == "dynamic" && opt.Property != nil && opt.Property.Name == optionName {
-			return true, &opt, nil
-		} else if opt.Type == "standard" {
-			var mappedName string
-			switch opt.Name {
-			case "Reverse Proxy":
-				mappedName = "reverseProxy"
-			case "ProtectServe":
-				mappedName = "protectServeKeyEnabled"
-			case "CORS Override":
-				mappedName = "cors"
-			case "Expiry Overrides":
-				mappedName = "expiryHeaders"
-			case "Referrer Blocking":
-				mappedName = "referrerBlocking"
-			case "Auto HTTPS Redirect":
-				mappedName = "autoRedirect"
-			default:
-				mappedName = opt.Name
-			}
-			if mappedName == optionName {
-				return true, &opt, nil
-			}
+	opt, ok := metadata.Find(optionName)
+	return ok, opt, nil
+}
+
+// Find returns the metadata of the option with the given field name,
+// checking both dynamic and standard options.
+func (m *ServiceOptionsMetadata) Find(optionName string) (*OptionMetadata, bool) {
+	for i := range m.Data {
+		if opt := &m.Data[i]; opt.FieldName() != "" && opt.FieldName() == optionName {
+			return opt, true
 		}
 	}
-	return false, nil, nil
+	return nil, false
+}
+
+// FieldName returns the key used for the option in the options map: the
+// property name of dynamic options and the mapped name of standard options.
+// It is empty for dynamic options without a property.
+func (o *OptionMetadata) FieldName() string {
+	switch o.Type {
+	case "dynamic":
+		if o.Property != nil {
+			return o.Property.Name
+		}
+	case "standard":
+		return standardOptionName(o.Name)
+	}
+	return ""
 }
 
 // GetAvailableOptionNames returns a list of all available option names
@@ -779,27 +826,8 @@ func (s *ServiceOptionsService) GetAvailableOptionNames(ctx context.Context, id
 
 	var names []string
 	for _, opt := range metadata.Data {
-		if opt.Type == "dynamic" && opt.Property != nil {
-			names = append(names, opt.Property.Name)
-		} else if opt.Type == "standard" {
-			var mappedName string
-			switch opt.Name {
-			case "Reverse Proxy":
-				mappedName = "reverseProxy"
-			case "ProtectServe":
-				mappedName = "protectServeKeyEnabled"
-			case "CORS Override":
-				mappedName = "cors"
-			case "Expiry Overrides":
-				mappedName = "expiryHeaders"
-			case "Referrer Blocking":
-				mappedName = "referrerBlocking"
-			case "Auto HTTPS Redirect":
-				mappedName = "autoRedirect"
-			default:
-				mappedName = opt.Name
-			}
-			names = append(names, mappedName)
+		if name := opt.FieldName(); name != "" {
+			names = append(names, name)
 		}
 	}
 	return names, nil
@@ -820,13 +848,29 @@ func (s *ServiceOptionsService) GetOptionsByGroup(ctx context.Context, id string
 }
 
 // Helper functions
-func (s *ServiceOptionsService) isValidEnumValue(value string, validValues []string) bool {
-	for _, valid := range validValues {
-		if value == valid {
-			return true
-		}
+
+// standardOptionName maps a standard option's display name to its field name.
+func standardOptionName(name string) string {
+	switch name {
+	case "Reverse Proxy":
+		return apispec.OptionReverseProxy
+	case "ProtectServe":
+		return apispec.OptionProtectServeKeyEnabled
+	case "CORS Override":
+		return apispec.OptionCors
+	case "Expiry Overrides":
+		return apispec.OptionExpiryHeaders
+	case "Referrer Blocking":
+		return apispec.OptionReferrerBlocking
+	case "Auto HTTPS Redirect":
+		return apispec.OptionAutoRedirect
+	default:
+		return name
 	}
-	return false
+}
+
+func (s *ServiceOptionsService) isValidEnumValue(value string, validValues []string) bool {
+	return validate.OneOf("value", value, validValues...) == nil
 }
 
 func (s *ServiceOptionsService) isNumeric(value interface{}) bool {
@@ -841,6 +885,9 @@ func (s *ServiceOptionsService) isNumeric(value interface{}) bool {
 // LegacyAPIKeyResponse represents API key payload.
 type LegacyAPIKeyResponse struct {
 	APIKey string `json:"apiKey"`
+
+	// CreatedAt is when the key was generated, if the API reports it
+	CreatedAt string `json:"createdAt,omitempty"`
 }
 
 // ProtectServeKeyResponse for protectserve.
@@ -862,10 +909,10 @@ type FTPSettingsResponse struct {
 
 // GetLegacyAPIKey returns the legacy API key for a service.
 func (s *ServiceOptionsService) GetLegacyAPIKey(ctx context.Context, id string) (*LegacyAPIKeyResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/apikey", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsAPIKey, id)
 
 	var res LegacyAPIKeyResponse
 	if err := s.Client.Get(ctx, endpoint, &res); err != nil {
@@ -876,10 +923,10 @@ func (s *ServiceOptionsService) GetLegacyAPIKey(ctx context.Context, id string)
 
 // RegenerateLegacyAPIKey creates a new legacy API key.
 func (s *ServiceOptionsService) RegenerateLegacyAPIKey(ctx context.Context, id string) (*LegacyAPIKeyResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/apikey", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsAPIKey, id)
 
 	//it needs empty body
 	emptyBody := struct{}{}
@@ -893,19 +940,19 @@ func (s *ServiceOptionsService) RegenerateLegacyAPIKey(ctx context.Context, id s
 
 // DeleteLegacyAPIKey deletes the legacy API key for a service.
 func (s *ServiceOptionsService) DeleteLegacyAPIKey(ctx context.Context, id string) error {
-	if id == "" {
-		return fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/apikey", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsAPIKey, id)
 	return s.Client.Delete(ctx, endpoint, nil)
 }
 
 // GetProtectServeKey retrieves the protectserve key (optional hideSecrets).
 func (s *ServiceOptionsService) GetProtectServeKey(ctx context.Context, id string, hideSecrets bool) (*ProtectServeKeyResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/protectserve", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsProtectServe, id)
 
 	params := url.Values{}
 	params.Set("hideSecrets", "false")
@@ -925,10 +972,10 @@ func (s *ServiceOptionsService) GetProtectServeKey(ctx context.Context, id strin
 
 // RecreateProtectServeKey regenerates or reverts the protectserve key.
 func (s *ServiceOptionsService) RecreateProtectServeKey(ctx context.Context, id, action string) (*ProtectServeKeyResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/protectserve", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsProtectServe, id)
 	params := url.Values{}
 	if action != "" {
 		params.Set("action", action)
@@ -947,10 +994,10 @@ func (s *ServiceOptionsService) RecreateProtectServeKey(ctx context.Context, id,
 
 // UpdateProtectServeOptions updates protectserve key and options.
 func (s *ServiceOptionsService) UpdateProtectServeOptions(ctx context.Context, id string, req UpdateProtectServeRequest) (*ProtectServeKeyResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/protectserve", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsProtectServe, id)
 
 	var res ProtectServeKeyResponse
 	if err := s.Client.Put(ctx, endpoint, req, &res); err != nil {
@@ -961,12 +1008,12 @@ func (s *ServiceOptionsService) UpdateProtectServeOptions(ctx context.Context, i
 
 // DeleteProtectServeKey deletes the ProtectServe key for the specified service.
 func (s *ServiceOptionsService) DeleteProtectServeKey(ctx context.Context, serviceID string) error {
-	if serviceID == "" {
-		return fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", serviceID); err != nil {
+		return err
 	}
 
 	// Build endpoint path: DELETE /services/{id}/options/protectserve
-	endpoint := fmt.Sprintf("/services/%s/options/protectserve", url.PathEscape(serviceID))
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsProtectServe, url.PathEscape(serviceID))
 
 	// Perform DELETE request. No request body expected.
 	if err := s.Client.Delete(ctx, endpoint, nil); err != nil {
@@ -976,11 +1023,12 @@ func (s *ServiceOptionsService) DeleteProtectServeKey(ctx context.Context, servi
 }
 
 // GetFTPSettings retrieves FTP settings for a service (optional hideSecrets).
+// Services without FTP get a FeatureUnavailableError.
 func (s *ServiceOptionsService) GetFTPSettings(ctx context.Context, id string, hideSecrets bool) (*FTPSettingsResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/ftp", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsFTP, id)
 	params := url.Values{}
 
 	params.Set("hideSecrets", "false")
@@ -992,17 +1040,17 @@ func (s *ServiceOptionsService) GetFTPSettings(ctx context.Context, id string, h
 
 	var res FTPSettingsResponse
 	if err := s.Client.Get(ctx, fullURL, &res); err != nil {
-		return nil, err
+		return nil, featureUnavailable(FeatureFTP, err)
 	}
 	return &res, nil
 }
 
 // RegenerateFTPPassword regenerates the FTP password for a service.
 func (s *ServiceOptionsService) RegenerateFTPPassword(ctx context.Context, id string, hideSecrets bool) (*FTPSettingsResponse, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/options/ftp", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOptionsFTP, id)
 	params := url.Values{}
 
 	params.Set("hideSecrets", "false")
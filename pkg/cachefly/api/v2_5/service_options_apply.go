@@ -0,0 +1,93 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// ApplyOptions controls how desired options are reconciled.
+type ApplyOptions struct {
+	// DryRun computes the planned changes without sending them to the API
+	DryRun bool
+}
+
+// OptionChange describes a single option that differs from the desired state.
+type OptionChange struct {
+	Name    string      `json:"name"`
+	Current interface{} `json:"current"`
+	Desired interface{} `json:"desired"`
+}
+
+// ApplyResult contains the planned changes and, unless DryRun was set,
+// the options returned by the API after applying them.
+type ApplyResult struct {
+	Changes []OptionChange `json:"changes"`
+	Applied bool           `json:"applied"`
+	Options ServiceOptions `json:"options,omitempty"`
+}
+
+// Apply reconciles a service's options with the desired state.
+//
+// Current options are read, compared against desired, and only keys whose
+// values differ are sent through UpdateOptions. Options absent from desired
+// are left untouched. With DryRun set, nothing is written and the planned
+// changes are returned.
+func (s *ServiceOptionsService) Apply(ctx context.Context, id string, desired ServiceOptions, opts ApplyOptions) (*ApplyResult, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetOptions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current options: %w", err)
+	}
+
+	result := &ApplyResult{Changes: planOptionChanges(current, desired)}
+	if opts.DryRun || len(result.Changes) == 0 {
+		result.Options = current
+		return result, nil
+	}
+
+	delta := make(ServiceOptions, len(result.Changes))
+	for _, c := range result.Changes {
+		delta[c.Name] = c.Desired
+	}
+
+	updated, err := s.UpdateOptions(ctx, id, delta)
+	if err != nil {
+		return nil, err
+	}
+	result.Applied = true
+	result.Options = updated
+	return result, nil
+}
+
+// planOptionChanges returns the desired options whose values differ from current,
+// sorted by name.
+func planOptionChanges(current, desired ServiceOptions) []OptionChange {
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []OptionChange
+	for _, name := range names {
+		cur, exists := current[name]
+		if exists && optionValuesEqual(cur, desired[name]) {
+			continue
+		}
+		changes = append(changes, OptionChange{Name: name, Current: cur, Desired: desired[name]})
+	}
+	return changes
+}
+
+// optionValuesEqual compares two option values after a JSON round trip, so
+// Go values like int(5) compare equal to decoded float64(5).
+func optionValuesEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(NormalizeValue(a), NormalizeValue(b))
+}
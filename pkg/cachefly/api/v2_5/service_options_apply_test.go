@@ -0,0 +1,84 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// UPDATE - Test Apply method sends only changed keys
+func TestServiceOptionsService_Apply(t *testing.T) {
+	var sent map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/svc-123/options":
+			w.Write([]byte(`{"cors":true,"autoRedirect":false,"maxAge":3600}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/svc-123/options/metadata":
+			w.Write([]byte(`{"meta":{"count":2},"data":[{"name":"Auto HTTPS Redirect","type":"standard"},{"name":"CORS Override","type":"standard"}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/svc-123/options":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &sent)
+			w.Write([]byte(`{"cors":true,"autoRedirect":true,"maxAge":3600}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	client := httpclient.New(cfg)
+	svc := &ServiceOptionsService{Client: client}
+
+	desired := ServiceOptions{"cors": true, "autoRedirect": true}
+	result, err := svc.Apply(context.Background(), "svc-123", desired, ApplyOptions{})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Applied {
+		t.Error("Expected changes to be applied")
+	}
+	if len(result.Changes) != 1 || result.Changes[0].Name != "autoRedirect" {
+		t.Errorf("Expected only autoRedirect to change, got %v", result.Changes)
+	}
+	if len(sent) != 1 || sent["autoRedirect"] != true {
+		t.Errorf("Expected PUT with only autoRedirect, got %v", sent)
+	}
+}
+
+// READ - Test Apply in dry-run mode does not write
+func TestServiceOptionsService_ApplyDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected only GET requests in dry run, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"maxAge":3600}`))
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	client := httpclient.New(cfg)
+	svc := &ServiceOptionsService{Client: client}
+
+	desired := ServiceOptions{"maxAge": 3600, "cors": true}
+	result, err := svc.Apply(context.Background(), "svc-123", desired, ApplyOptions{DryRun: true})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Applied {
+		t.Error("Expected dry run not to apply changes")
+	}
+	if len(result.Changes) != 1 || result.Changes[0].Name != "cors" {
+		t.Errorf("Expected only cors planned (int 3600 equals float 3600), got %v", result.Changes)
+	}
+}
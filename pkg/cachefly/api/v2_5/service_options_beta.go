@@ -0,0 +1,35 @@
+package v2_5
+
+// OptionStability tells whether an option is generally available or a
+// beta feature that may change or disappear.
+type OptionStability string
+
+const (
+	// OptionStable marks generally available options
+	OptionStable OptionStability = "stable"
+
+	// OptionBeta marks options the metadata flags as promo, that is beta
+	// features offered for early adoption. UpdateOptions refuses to set
+	// them unless the client allows beta options.
+	OptionBeta OptionStability = "beta"
+)
+
+// Stability returns OptionBeta for options flagged as promo in the
+// metadata and OptionStable otherwise.
+func (o *OptionMetadata) Stability() OptionStability {
+	if o.Promo.Enabled {
+		return OptionBeta
+	}
+	return OptionStable
+}
+
+// BetaOptions returns the metadata of the beta options, in metadata order.
+func (m *ServiceOptionsMetadata) BetaOptions() []OptionMetadata {
+	var beta []OptionMetadata
+	for _, opt := range m.Data {
+		if opt.Stability() == OptionBeta {
+			beta = append(beta, opt)
+		}
+	}
+	return beta
+}
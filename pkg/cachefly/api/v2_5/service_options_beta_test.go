@@ -0,0 +1,74 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+const betaOptionsMetadata = `{"meta":{"count":2},"data":[
+	{"name":"ttl","type":"dynamic","property":{"name":"ttl","type":"integer"}},
+	{"name":"http3","type":"dynamic","promo":{"enabled":true,"description":"Early access"},"property":{"name":"http3","type":"boolean"}}
+]}`
+
+func newBetaOptionsServer(t *testing.T, puts *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/2.5/services/svc-123/options/metadata":
+			w.Write([]byte(betaOptionsMetadata))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "PUT":
+			*puts++
+			w.Write([]byte(`{"http3":true}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestOptionMetadata_Stability(t *testing.T) {
+	server := newBetaOptionsServer(t, new(int))
+	defer server.Close()
+
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+	metadata, err := svc.GetOptionsMetadata(context.Background(), "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if s := metadata.Data[0].Stability(); s != OptionStable {
+		t.Errorf("Expected ttl to be stable, got %s", s)
+	}
+	beta := metadata.BetaOptions()
+	if len(beta) != 1 || beta[0].Name != "http3" {
+		t.Errorf("Expected http3 as the only beta option, got %+v", beta)
+	}
+}
+
+func TestServiceOptionsService_UpdateOptions_BetaRequiresOptIn(t *testing.T) {
+	var puts int
+	server := newBetaOptionsServer(t, &puts)
+	defer server.Close()
+
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+	_, err := svc.UpdateOptions(context.Background(), "svc-123", ServiceOptions{"http3": true})
+	var validationErr ServiceOptionsValidationError
+	if !errors.As(err, &validationErr) || validationErr.Errors[0].Code != "OPTION_BETA" {
+		t.Fatalf("Expected OPTION_BETA validation error, got %v", err)
+	}
+	if puts != 0 {
+		t.Errorf("Expected no update to be sent, got %d", puts)
+	}
+
+	svc = &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token", AllowBetaOptions: true})}
+	updated, err := svc.UpdateOptions(context.Background(), "svc-123", ServiceOptions{"http3": true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if puts != 1 || updated["http3"] != true {
+		t.Errorf("Expected the beta option to be set, got %d updates and %v", puts, updated)
+	}
+}
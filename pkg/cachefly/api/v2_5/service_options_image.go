@@ -0,0 +1,227 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// Service options managed by ImageOptimizationOptions.
+const (
+	OptionImageOptimization = apispec.OptionImageOptimization
+	OptionImageFormats      = apispec.OptionImageFormats
+	OptionImageQuality      = apispec.OptionImageQuality
+	OptionImageResizePolicy = apispec.OptionImageResizePolicy
+)
+
+// ImageFormat is an output format images can be converted to.
+type ImageFormat string
+
+// Image formats known to the SDK. The formats a service accepts are listed
+// by ImageOptimizationOptions.Capabilities.
+const (
+	ImageFormatWebP ImageFormat = "webp"
+	ImageFormatAVIF ImageFormat = "avif"
+)
+
+// ImageResizePolicy controls how images are resized to requested dimensions.
+type ImageResizePolicy string
+
+// Resize policies known to the SDK.
+const (
+	ImageResizeNone      ImageResizePolicy = "none"
+	ImageResizeFit       ImageResizePolicy = "fit"
+	ImageResizeFill      ImageResizePolicy = "fill"
+	ImageResizeCrop      ImageResizePolicy = "crop"
+	ImageResizeDownscale ImageResizePolicy = "downscale"
+)
+
+// ImageOptimizationSettings is the typed form of a service's image
+// optimization options.
+type ImageOptimizationSettings struct {
+	Enabled bool `json:"enabled"`
+
+	// Formats images are converted to when the client accepts them
+	Formats []ImageFormat `json:"formats,omitempty"`
+
+	// Quality is the output quality, usually 1-100. Zero keeps the current value.
+	Quality int `json:"quality,omitempty"`
+
+	// ResizePolicy is the resize policy. Empty keeps the current value.
+	ResizePolicy ImageResizePolicy `json:"resizePolicy,omitempty"`
+}
+
+// ImageOptimizationCapabilities lists the values a service accepts for its
+// image optimization options, read from the options metadata.
+type ImageOptimizationCapabilities struct {
+	Available      bool                `json:"available"`
+	Formats        []ImageFormat       `json:"formats,omitempty"`
+	ResizePolicies []ImageResizePolicy `json:"resizePolicies,omitempty"`
+	MinQuality     *int                `json:"minQuality,omitempty"`
+	MaxQuality     *int                `json:"maxQuality,omitempty"`
+}
+
+// ImageOptimizationOptions manages the image optimization option group of a
+// service as typed settings.
+type ImageOptimizationOptions struct {
+	options *ServiceOptionsService
+}
+
+// ImageOptimization returns the typed image optimization option group.
+func (s *ServiceOptionsService) ImageOptimization() *ImageOptimizationOptions {
+	return &ImageOptimizationOptions{options: s}
+}
+
+// Capabilities returns the image optimization values the service accepts.
+func (o *ImageOptimizationOptions) Capabilities(ctx context.Context, id string) (*ImageOptimizationCapabilities, error) {
+	metadata, err := o.options.GetOptionsMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get options metadata: %w", err)
+	}
+	return imageCapabilities(metadata), nil
+}
+
+// Get returns the image optimization settings of a service.
+func (o *ImageOptimizationOptions) Get(ctx context.Context, id string) (*ImageOptimizationSettings, error) {
+	options, err := o.options.GetOptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &ImageOptimizationSettings{}
+	settings.Enabled, _ = options[OptionImageOptimization].(bool)
+	if formats, ok := options[OptionImageFormats].(map[string]interface{}); ok {
+		for name, on := range formats {
+			if enabled, _ := on.(bool); enabled {
+				settings.Formats = append(settings.Formats, ImageFormat(name))
+			}
+		}
+		sort.Slice(settings.Formats, func(i, j int) bool { return settings.Formats[i] < settings.Formats[j] })
+	}
+	if quality, ok := options[OptionImageQuality].(float64); ok {
+		settings.Quality = int(quality)
+	}
+	if policy, ok := options[OptionImageResizePolicy].(string); ok {
+		settings.ResizePolicy = ImageResizePolicy(policy)
+	}
+	return settings, nil
+}
+
+// Update validates settings against the service's options metadata and
+// applies them, sending only changed options.
+func (o *ImageOptimizationOptions) Update(ctx context.Context, id string, settings ImageOptimizationSettings) (*ImageOptimizationSettings, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+
+	caps, err := o.Capabilities(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := caps.Validate(settings); err != nil {
+		return nil, err
+	}
+
+	desired := ServiceOptions{OptionImageOptimization: settings.Enabled}
+	if len(caps.Formats) > 0 {
+		formats := make(map[string]interface{}, len(caps.Formats))
+		for _, f := range caps.Formats {
+			formats[string(f)] = false
+		}
+		for _, f := range settings.Formats {
+			formats[string(f)] = true
+		}
+		desired[OptionImageFormats] = formats
+	}
+	if settings.Quality != 0 {
+		desired[OptionImageQuality] = settings.Quality
+	}
+	if settings.ResizePolicy != "" {
+		desired[OptionImageResizePolicy] = string(settings.ResizePolicy)
+	}
+
+	if _, err := o.options.Apply(ctx, id, desired, ApplyOptions{}); err != nil {
+		return nil, err
+	}
+	return o.Get(ctx, id)
+}
+
+// Validate checks settings against the accepted values.
+func (c *ImageOptimizationCapabilities) Validate(settings ImageOptimizationSettings) error {
+	if !c.Available {
+		return fmt.Errorf("option '%s' is not available for this service", OptionImageOptimization)
+	}
+
+	if len(settings.Formats) > 0 {
+		if len(c.Formats) == 0 {
+			return fmt.Errorf("option '%s' is not available for this service", OptionImageFormats)
+		}
+		for _, f := range settings.Formats {
+			if !containsImageFormat(c.Formats, f) {
+				return fmt.Errorf("image format '%s' is not valid, must be one of: %v", f, c.Formats)
+			}
+		}
+	}
+
+	if settings.Quality != 0 {
+		if c.MinQuality != nil && settings.Quality < *c.MinQuality {
+			return fmt.Errorf("image quality %d is below minimum %d", settings.Quality, *c.MinQuality)
+		}
+		if c.MaxQuality != nil && settings.Quality > *c.MaxQuality {
+			return fmt.Errorf("image quality %d is above maximum %d", settings.Quality, *c.MaxQuality)
+		}
+	}
+
+	if settings.ResizePolicy != "" && len(c.ResizePolicies) > 0 {
+		valid := false
+		for _, p := range c.ResizePolicies {
+			if p == settings.ResizePolicy {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("resize policy '%s' is not valid, must be one of: %v", settings.ResizePolicy, c.ResizePolicies)
+		}
+	}
+	return nil
+}
+
+// imageCapabilities extracts the image optimization property definitions
+// from the options metadata.
+func imageCapabilities(metadata *ServiceOptionsMetadata) *ImageOptimizationCapabilities {
+	caps := &ImageOptimizationCapabilities{}
+	for _, opt := range metadata.Data {
+		if opt.Type != "dynamic" || opt.Property == nil {
+			continue
+		}
+		prop := opt.Property
+		switch prop.Name {
+		case OptionImageOptimization:
+			caps.Available = true
+		case OptionImageFormats:
+			for _, field := range prop.BitFields {
+				caps.Formats = append(caps.Formats, ImageFormat(field.Key))
+			}
+		case OptionImageQuality:
+			caps.MinQuality, caps.MaxQuality = prop.MinValue, prop.MaxValue
+		case OptionImageResizePolicy:
+			for _, v := range prop.EnumValues {
+				caps.ResizePolicies = append(caps.ResizePolicies, ImageResizePolicy(v.Value))
+			}
+		}
+	}
+	return caps
+}
+
+func containsImageFormat(formats []ImageFormat, f ImageFormat) bool {
+	for _, candidate := range formats {
+		if candidate == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+const imageOptionsMetadata = `{"meta":{"count":4},"data":[
+	{"name":"imageOptimization","type":"dynamic","property":{"name":"imageOptimization","type":"boolean"}},
+	{"name":"imageFormats","type":"dynamic","property":{"name":"imageFormats","type":"bitfield","bitFields":[{"bitPosition":0,"key":"webp"},{"bitPosition":1,"key":"avif"}]}},
+	{"name":"imageQuality","type":"dynamic","property":{"name":"imageQuality","type":"integer","minValue":1,"maxValue":100}},
+	{"name":"imageResizePolicy","type":"dynamic","property":{"name":"imageResizePolicy","type":"enum","enumValues":[{"value":"fit"},{"value":"fill"}]}}
+]}`
+
+// UPDATE - Test Update converts typed settings to options
+func TestImageOptimizationOptions_Update(t *testing.T) {
+	var sent map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/2.5/services/svc-123/options/metadata":
+			w.Write([]byte(imageOptionsMetadata))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "GET":
+			if sent != nil {
+				w.Write([]byte(`{"imageOptimization":true,"imageFormats":{"webp":true,"avif":false},"imageQuality":80,"imageResizePolicy":"fit"}`))
+				return
+			}
+			w.Write([]byte(`{"imageOptimization":false}`))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "PUT":
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServiceOptionsService{Client: httpclient.New(cfg)}
+
+	settings, err := svc.ImageOptimization().Update(context.Background(), "svc-123", ImageOptimizationSettings{
+		Enabled:      true,
+		Formats:      []ImageFormat{ImageFormatWebP},
+		Quality:      80,
+		ResizePolicy: ImageResizeFit,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	formats, _ := sent["imageFormats"].(map[string]interface{})
+	if sent["imageOptimization"] != true || formats["webp"] != true || formats["avif"] != false {
+		t.Errorf("Unexpected options sent %v", sent)
+	}
+	if sent["imageQuality"] != float64(80) || sent["imageResizePolicy"] != "fit" {
+		t.Errorf("Unexpected options sent %v", sent)
+	}
+	if !settings.Enabled || len(settings.Formats) != 1 || settings.Quality != 80 {
+		t.Errorf("Unexpected settings %+v", settings)
+	}
+}
+
+// UPDATE - Test Update rejects values outside the metadata definitions
+func TestImageOptimizationOptions_UpdateValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected no writes for invalid settings, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(imageOptionsMetadata))
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	image := (&ServiceOptionsService{Client: httpclient.New(cfg)}).ImageOptimization()
+
+	invalid := []ImageOptimizationSettings{
+		{Enabled: true, Formats: []ImageFormat{"jxl"}},
+		{Enabled: true, Quality: 101},
+		{Enabled: true, ResizePolicy: ImageResizeCrop},
+	}
+	for _, settings := range invalid {
+		if _, err := image.Update(context.Background(), "svc-123", settings); err == nil {
+			t.Errorf("Expected error for %+v, got nil", settings)
+		}
+	}
+}
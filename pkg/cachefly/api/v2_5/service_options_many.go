@@ -0,0 +1,122 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/jobs"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// GetManyOptions controls how GetManyWithOptions reads options.
+type GetManyOptions struct {
+	// Concurrency is the number of services read at once. Zero uses 8.
+	Concurrency int
+}
+
+// ServiceOptionsBatchError is returned by GetMany when the options of some
+// services could not be read. The options of the other services are
+// returned along with it.
+type ServiceOptionsBatchError struct {
+	// Total is the number of services requested
+	Total int
+
+	// Failed maps the IDs of the services that could not be read to their
+	// errors
+	Failed map[string]error
+}
+
+func (e *ServiceOptionsBatchError) Error() string {
+	ids := e.FailedIDs()
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", id, e.Failed[id]))
+	}
+	return fmt.Sprintf("failed to get options of %d of %d services: %s", len(ids), e.Total, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed services, so errors.Is and
+// errors.As match any of them.
+func (e *ServiceOptionsBatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, id := range e.FailedIDs() {
+		errs = append(errs, e.Failed[id])
+	}
+	return errs
+}
+
+// FailedIDs returns the IDs of the services that could not be read, sorted.
+func (e *ServiceOptionsBatchError) FailedIDs() []string {
+	ids := make([]string, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// GetMany retrieves the current options of many services concurrently,
+// keyed by service ID. See GetManyWithOptions.
+func (s *ServiceOptionsService) GetMany(ctx context.Context, serviceIDs []string) (map[string]ServiceOptions, error) {
+	return s.GetManyWithOptions(ctx, serviceIDs, GetManyOptions{})
+}
+
+// GetManyWithOptions retrieves the current options of many services,
+// reading opts.Concurrency services at once. Duplicate IDs are read once.
+//
+// A failure to read one service does not stop the others: the options read
+// are returned together with a *ServiceOptionsBatchError listing the
+// failures. When ctx ends, the options read so far are returned with
+// ctx.Err().
+func (s *ServiceOptionsService) GetManyWithOptions(ctx context.Context, serviceIDs []string, opts GetManyOptions) (map[string]ServiceOptions, error) {
+	ids := make([]string, 0, len(serviceIDs))
+	seen := make(map[string]bool, len(serviceIDs))
+	for _, id := range serviceIDs {
+		if err := validate.ID("serviceID", id); err != nil {
+			return nil, err
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	results := make([]ServiceOptions, len(ids))
+	queue := jobs.New(jobs.Config{Workers: concurrency})
+	queue.Start(ctx)
+
+	handles := make([]*jobs.Handle, len(ids))
+	for i, id := range ids {
+		handles[i], _ = queue.Enqueue(func(ctx context.Context) error {
+			options, err := s.GetOptions(ctx, id)
+			results[i] = options
+			return err
+		}, jobs.Options{Name: "get-options"})
+	}
+	queue.Close()
+
+	all := make(map[string]ServiceOptions, len(ids))
+	batchErr := &ServiceOptionsBatchError{Total: len(ids), Failed: make(map[string]error)}
+	for i, h := range handles {
+		if err := h.Err(); err != nil {
+			batchErr.Failed[ids[i]] = err
+			continue
+		}
+		all[ids[i]] = results[i]
+	}
+
+	if err := ctx.Err(); err != nil {
+		return all, err
+	}
+	if len(batchErr.Failed) > 0 {
+		return all, batchErr
+	}
+	return all, nil
+}
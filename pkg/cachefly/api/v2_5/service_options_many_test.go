@@ -0,0 +1,83 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestServiceOptionsService_GetMany(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id := strings.Split(strings.TrimPrefix(r.URL.Path, "/services/"), "/")[0]
+		mu.Lock()
+		requests[id]++
+		mu.Unlock()
+		if id == "svc-bad" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"cors":true,"id":"` + id + `"}`))
+	}))
+	defer server.Close()
+
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+	ids := []string{"svc-1", "svc-2", "svc-bad", "svc-3", "svc-4", "svc-1"}
+	all, err := svc.GetManyWithOptions(context.Background(), ids, GetManyOptions{Concurrency: 2})
+
+	var batchErr *ServiceOptionsBatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected ServiceOptionsBatchError, got %v", err)
+	}
+	if batchErr.Total != 5 || len(batchErr.FailedIDs()) != 1 || batchErr.FailedIDs()[0] != "svc-bad" {
+		t.Errorf("Expected svc-bad to fail out of 5, got %v", err)
+	}
+	var apiErr *httpclient.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the API error to be reachable, got %v", err)
+	}
+
+	if len(all) != 4 {
+		t.Fatalf("Expected options of 4 services, got %d", len(all))
+	}
+	for _, id := range []string{"svc-1", "svc-2", "svc-3", "svc-4"} {
+		if all[id]["id"] != id {
+			t.Errorf("Expected options of %s, got %v", id, all[id])
+		}
+	}
+	if requests["svc-1"] != 1 {
+		t.Errorf("Expected duplicate IDs to be read once, got %d requests", requests["svc-1"])
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests at once, got %d", maxInFlight)
+	}
+}
+
+func TestServiceOptionsService_GetMany_InvalidID(t *testing.T) {
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: "http://test.com", AuthToken: "test-token"})}
+	if _, err := svc.GetMany(context.Background(), []string{"svc-1", ""}); err == nil {
+		t.Error("Expected error for empty service ID")
+	}
+}
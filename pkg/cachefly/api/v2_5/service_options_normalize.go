@@ -0,0 +1,146 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// OptionEnum is the value of an enum option, spelled as in the metadata.
+type OptionEnum string
+
+// durationOptions lists the integer options known to hold a duration, for
+// metadata that gives no unit.
+var durationOptions = map[string]time.Duration{
+	apispec.OptionHsts: time.Second,
+}
+
+// durationUnits maps the units of OptionProperty.Unit to durations.
+var durationUnits = map[string]time.Duration{
+	"milliseconds": time.Millisecond,
+	"seconds":      time.Second,
+	"minutes":      time.Minute,
+	"hours":        time.Hour,
+}
+
+// DurationUnit returns the unit of integer properties holding a duration,
+// taken from Unit or, when the metadata gives none, from the options known
+// to hold durations. It returns 0 for other properties.
+func (p *OptionProperty) DurationUnit() time.Duration {
+	if p.Type != "integer" {
+		return 0
+	}
+	if unit, ok := durationUnits[strings.ToLower(p.Unit)]; ok {
+		return unit
+	}
+	if p.Unit != "" {
+		return 0
+	}
+	return durationOptions[p.Name]
+}
+
+// NormalizeOptionValue converts a raw option value, as decoded from JSON or
+// given by the caller, to the Go type described by the option's metadata:
+//
+//   - boolean: bool
+//   - integer: int64, or time.Duration for properties with a DurationUnit
+//   - enum: OptionEnum in the spelling of the metadata; values unknown to
+//     the metadata are kept as they are
+//   - string: string
+//   - strings: []string
+//   - bitfield: map[string]bool
+//
+// Options using the enabled/value structure keep it, with the inner value
+// normalized. Values of options without a property are returned unchanged.
+// Normalized values of the same option compare equal with == or
+// reflect.DeepEqual when they are equal for the API.
+func NormalizeOptionValue(opt *OptionMetadata, raw interface{}) (interface{}, error) {
+	if obj, ok := raw.(map[string]interface{}); ok && opt.Property != nil && opt.Property.Type != "bitfield" {
+		if enabled, ok := obj["enabled"].(bool); ok {
+			normalized := map[string]interface{}{"enabled": enabled}
+			if inner, ok := obj["value"]; ok && inner != nil {
+				v, err := normalizePropertyValue(opt.Property, inner)
+				if err != nil {
+					return nil, err
+				}
+				normalized["value"] = v
+			}
+			return normalized, nil
+		}
+	}
+	if opt.Property == nil || raw == nil {
+		return raw, nil
+	}
+	return normalizePropertyValue(opt.Property, raw)
+}
+
+// normalizePropertyValue normalizes a bare value. Range constraints are not
+// checked, so that values set before a constraint changed can be read.
+func normalizePropertyValue(prop *OptionProperty, raw interface{}) (interface{}, error) {
+	if prop.Type == "enum" {
+		rv := reflect.ValueOf(raw)
+		if rv.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected string value for enum, got %T", raw)
+		}
+		for _, ev := range prop.EnumValues {
+			if strings.EqualFold(ev.Value, rv.String()) {
+				return OptionEnum(ev.Value), nil
+			}
+		}
+		return OptionEnum(rv.String()), nil
+	}
+
+	unbounded := *prop
+	unbounded.MinValue, unbounded.MaxValue = nil, nil
+	v, err := coerceOptionValue(&unbounded, raw)
+	if err != nil {
+		return nil, err
+	}
+	if n, ok := v.(int); ok {
+		if unit := prop.DurationUnit(); unit > 0 {
+			return time.Duration(n) * unit, nil
+		}
+		return int64(n), nil
+	}
+	return v, nil
+}
+
+// NormalizeOptions returns a copy of options with the value of every option
+// described by metadata normalized, see NormalizeOptionValue. Options
+// missing from metadata are copied unchanged. Values that do not match
+// their metadata are reported as ServiceOptionsValidationError.
+func NormalizeOptions(options ServiceOptions, metadata *ServiceOptionsMetadata) (ServiceOptions, error) {
+	normalized := make(ServiceOptions, len(options))
+	for name, raw := range options {
+		opt, ok := metadata.Find(name)
+		if !ok {
+			normalized[name] = raw
+			continue
+		}
+		v, err := NormalizeOptionValue(opt, raw)
+		if err != nil {
+			return nil, optionValidationError(name, "INVALID_VALUE", fmt.Sprintf("option '%s' has an unexpected value: %v", name, err), opt)
+		}
+		normalized[name] = v
+	}
+	return normalized, nil
+}
+
+// GetNormalizedOptions retrieves the current options of a service with
+// their values normalized according to the options metadata, see
+// NormalizeOptions.
+func (s *ServiceOptionsService) GetNormalizedOptions(ctx context.Context, id string) (ServiceOptions, error) {
+	metadata, err := s.GetOptionsMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get options metadata: %w", err)
+	}
+	options, err := s.GetOptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NormalizeOptions(options, metadata)
+}
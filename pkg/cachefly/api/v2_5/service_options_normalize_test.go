@@ -0,0 +1,99 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+const normalizeOptionsMetadata = `{"meta":{"count":5},"data":[
+	{"name":"hsts","type":"dynamic","property":{"name":"hsts","type":"integer","minValue":0}},
+	{"name":"edgeTtl","type":"dynamic","property":{"name":"edgeTtl","type":"integer","unit":"minutes"}},
+	{"name":"imageQuality","type":"dynamic","property":{"name":"imageQuality","type":"integer","maxValue":100}},
+	{"name":"compression","type":"dynamic","property":{"name":"compression","type":"enum","enumValues":[{"value":"GZIP"},{"value":"BROTLI"}]}},
+	{"name":"allowedMethods","type":"dynamic","property":{"name":"allowedMethods","type":"bitfield","bitFields":[{"key":"GET"},{"key":"POST"}]}}
+]}`
+
+func TestServiceOptionsService_GetNormalizedOptions(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/2.5/services/svc-123/options/metadata":
+			w.Write([]byte(normalizeOptionsMetadata))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "GET":
+			w.Write([]byte(`{"hsts":31536000,"edgeTtl":{"enabled":true,"value":90},"imageQuality":120,
+				"compression":"gzip","allowedMethods":{"GET":true,"POST":false},"legacy":"kept"}`))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "PUT":
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+	options, err := svc.GetNormalizedOptions(context.Background(), "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if options["hsts"] != 365*24*time.Hour {
+		t.Errorf("Expected hsts of one year, got %#v", options["hsts"])
+	}
+	edgeTTL := map[string]interface{}{"enabled": true, "value": 90 * time.Minute}
+	if !reflect.DeepEqual(options["edgeTtl"], edgeTTL) {
+		t.Errorf("Expected %#v, got %#v", edgeTTL, options["edgeTtl"])
+	}
+	// Out of range values are still read
+	if options["imageQuality"] != int64(120) {
+		t.Errorf("Expected int64 120, got %#v", options["imageQuality"])
+	}
+	if options["compression"] != OptionEnum("GZIP") {
+		t.Errorf("Expected canonical enum GZIP, got %#v", options["compression"])
+	}
+	if !reflect.DeepEqual(options["allowedMethods"], map[string]bool{"GET": true, "POST": false}) {
+		t.Errorf("Expected bitfield map, got %#v", options["allowedMethods"])
+	}
+	if options["legacy"] != "kept" {
+		t.Errorf("Expected options missing from metadata to be kept, got %#v", options["legacy"])
+	}
+
+	// Durations can be set and are sent in the unit of the option
+	if _, err := svc.SetOption(context.Background(), "svc-123", "hsts", 2*time.Hour); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent["hsts"] != float64(7200) {
+		t.Errorf("Expected hsts of 7200 seconds, got %#v", sent["hsts"])
+	}
+	if _, err := svc.SetOption(context.Background(), "svc-123", "imageQuality", time.Second); err == nil {
+		t.Error("Expected error setting a duration on a plain integer option")
+	}
+}
+
+func TestNormalizeOptionValue_ComparesDesiredAndCurrent(t *testing.T) {
+	var metadata ServiceOptionsMetadata
+	if err := json.Unmarshal([]byte(normalizeOptionsMetadata), &metadata); err != nil {
+		t.Fatal(err)
+	}
+	opt, _ := metadata.Find("compression")
+
+	current, err := NormalizeOptionValue(opt, "brotli")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	desired, err := NormalizeOptionValue(opt, OptionEnum("BROTLI"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if current != desired {
+		t.Errorf("Expected %#v to equal %#v", current, desired)
+	}
+}
@@ -0,0 +1,58 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+)
+
+// LegacyKeyRotationError is returned by RotateLegacyAPIKey when the key was
+// regenerated but the callback failed to store it. The previous key no
+// longer works, so NewKey must be stored by other means.
+type LegacyKeyRotationError struct {
+	// ServiceID is the service whose key was rotated
+	ServiceID string
+
+	// NewKey is the regenerated key, which is active
+	NewKey string
+
+	// Err is the error returned by the callback
+	Err error
+}
+
+// Error does not include the new key, so it can be logged safely.
+func (e *LegacyKeyRotationError) Error() string {
+	return fmt.Sprintf("legacy API key of service %s was rotated, but storing the new key failed; the previous key no longer works: %v",
+		e.ServiceID, e.Err)
+}
+
+// Unwrap returns the error of the callback.
+func (e *LegacyKeyRotationError) Unwrap() error {
+	return e.Err
+}
+
+// RotateLegacyAPIKey regenerates the legacy API key of a service and passes
+// the new key to store, e.g. to save it in a secret manager.
+//
+// When regenerating fails, store is not called and the previous key stays
+// valid. When store fails, the new key is already active: the error is a
+// *LegacyKeyRotationError holding the new key, so it is not lost. In dry-run
+// mode no key is generated and store is not called.
+func (s *ServiceOptionsService) RotateLegacyAPIKey(ctx context.Context, id string, store func(newKey string) error) error {
+	if store == nil {
+		return fmt.Errorf("store is required")
+	}
+	res, err := s.RegenerateLegacyAPIKey(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to regenerate legacy API key: %w", err)
+	}
+	if s.Client.DryRun(ctx) {
+		return nil
+	}
+	if res.APIKey == "" {
+		return fmt.Errorf("legacy API key of service %s was regenerated, but the API returned no key", id)
+	}
+	if err := store(res.APIKey); err != nil {
+		return &LegacyKeyRotationError{ServiceID: id, NewKey: res.APIKey, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package v2_5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestServiceOptionsService_RotateLegacyAPIKey(t *testing.T) {
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/2.5/services/svc-123/options/apikey" {
+			t.Errorf("Expected POST /api/2.5/services/svc-123/options/apikey, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status == http.StatusCreated {
+			w.Write([]byte(`{"apiKey":"new-api-key-456"}`))
+		} else {
+			w.Write([]byte(`{"message":"forbidden"}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	var stored string
+	err := svc.RotateLegacyAPIKey(context.Background(), "svc-123", func(key string) error {
+		stored = key
+		return nil
+	})
+	if err != nil || stored != "new-api-key-456" {
+		t.Fatalf("Expected the new key to be stored, got %q, %v", stored, err)
+	}
+
+	storeErr := errors.New("vault unavailable")
+	err = svc.RotateLegacyAPIKey(context.Background(), "svc-123", func(string) error { return storeErr })
+	var rotationErr *LegacyKeyRotationError
+	if !errors.As(err, &rotationErr) || !errors.Is(err, storeErr) {
+		t.Fatalf("Expected LegacyKeyRotationError wrapping the store error, got %v", err)
+	}
+	if rotationErr.NewKey != "new-api-key-456" || rotationErr.ServiceID != "svc-123" {
+		t.Errorf("Expected the new key in the error, got %+v", rotationErr)
+	}
+	if strings.Contains(err.Error(), "new-api-key-456") {
+		t.Errorf("Expected the message not to contain the key, got %q", err.Error())
+	}
+
+	status = http.StatusForbidden
+	called := false
+	err = svc.RotateLegacyAPIKey(context.Background(), "svc-123", func(string) error {
+		called = true
+		return nil
+	})
+	if err == nil || called || errors.As(err, &rotationErr) {
+		t.Errorf("Expected a plain error without calling store, got %v (called %v)", err, called)
+	}
+}
+
+func TestServiceOptionsService_RotateLegacyAPIKey_NoKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	called := false
+	store := func(string) error {
+		called = true
+		return nil
+	}
+
+	if err := svc.RotateLegacyAPIKey(httpclient.WithDryRun(context.Background()), "svc-123", store); err != nil || called {
+		t.Errorf("Expected a dry run not to call store, got %v (called %v)", err, called)
+	}
+
+	err := svc.RotateLegacyAPIKey(context.Background(), "svc-123", store)
+	if err == nil || called {
+		t.Errorf("Expected an error without calling store for an empty key, got %v (called %v)", err, called)
+	}
+}
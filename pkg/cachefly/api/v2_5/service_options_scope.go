@@ -0,0 +1,104 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// OptionsScope selects which options Get returns.
+type OptionsScope string
+
+// Options scopes.
+const (
+	// OptionsScopeBasic returns the options the service has a value for
+	OptionsScopeBasic OptionsScope = "basic"
+
+	// OptionsScopeFull also returns the options available for the service
+	// that have no value, with Set false
+	OptionsScopeFull OptionsScope = "full"
+
+	// OptionsScopeIncludeMetadata returns the same options as
+	// OptionsScopeFull with their metadata
+	OptionsScopeIncludeMetadata OptionsScope = "includeMetadata"
+)
+
+// ServiceOptionValues is the result of Get.
+type ServiceOptionValues struct {
+	ServiceID string       `json:"serviceId"`
+	Scope     OptionsScope `json:"scope"`
+
+	// Options is sorted by name. Metadata is only set for
+	// OptionsScopeIncludeMetadata.
+	Options []OptionValue `json:"options"`
+}
+
+// Lookup returns the option with the given name.
+func (v *ServiceOptionValues) Lookup(name string) (*OptionValue, bool) {
+	for i := range v.Options {
+		if v.Options[i].Name == name {
+			return &v.Options[i], true
+		}
+	}
+	return nil, false
+}
+
+// Get returns the options of a service in the given scope, each converted
+// to the Go type described by its metadata as GetOption does. Values of
+// options without metadata are returned as decoded from the API. An empty
+// scope selects OptionsScopeBasic.
+func (s *ServiceOptionsService) Get(ctx context.Context, id string, scope OptionsScope) (*ServiceOptionValues, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	if scope == "" {
+		scope = OptionsScopeBasic
+	}
+	if err := validate.OneOf("scope", string(scope), string(OptionsScopeBasic), string(OptionsScopeFull), string(OptionsScopeIncludeMetadata)); err != nil {
+		return nil, err
+	}
+
+	metadata, err := s.GetOptionsMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get options metadata: %w", err)
+	}
+	options, err := s.GetOptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	if scope != OptionsScopeBasic {
+		for i := range metadata.Data {
+			name := metadata.Data[i].FieldName()
+			if _, set := options[name]; name != "" && !set {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	result := &ServiceOptionValues{ServiceID: id, Scope: scope, Options: make([]OptionValue, 0, len(names))}
+	for _, name := range names {
+		raw, set := options[name]
+		value := OptionValue{Name: name, Value: raw, Set: set}
+		if opt, ok := metadata.Find(name); ok {
+			if set {
+				value.Value, value.Enabled, err = optionValue(opt, raw)
+				if err != nil {
+					return nil, optionValidationError(name, "INVALID_VALUE", fmt.Sprintf("option '%s' has an unexpected value: %v", name, err), opt)
+				}
+			}
+			if scope == OptionsScopeIncludeMetadata {
+				value.Metadata = opt
+			}
+		}
+		result.Options = append(result.Options, value)
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package v2_5
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// READ - Test Get returns typed values for each scope
+func TestServiceOptionsService_Get(t *testing.T) {
+	server := newTypedOptionsServer(t, nil)
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServiceOptionsService{Client: httpclient.New(cfg)}
+
+	basic, err := svc.Get(context.Background(), "svc-123", OptionsScopeBasic)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(basic.Options) != 3 || basic.Options[0].Name != "allowedMethods" {
+		t.Fatalf("Expected 3 set options sorted by name, got %+v", basic.Options)
+	}
+	ttl, ok := basic.Lookup("ttl")
+	if !ok || ttl.Value != 3600 || ttl.Enabled == nil || !*ttl.Enabled || ttl.Metadata != nil {
+		t.Errorf("Unexpected ttl %+v", ttl)
+	}
+
+	full, err := svc.Get(context.Background(), "svc-123", OptionsScopeFull)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	locked, ok := full.Lookup("locked")
+	if len(full.Options) != 4 || !ok || locked.Set || locked.Value != nil || locked.Metadata != nil {
+		t.Errorf("Expected unset locked option without metadata, got %+v", full.Options)
+	}
+
+	withMetadata, err := svc.Get(context.Background(), "svc-123", OptionsScopeIncludeMetadata)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, opt := range withMetadata.Options {
+		if opt.Metadata == nil {
+			t.Errorf("Expected metadata for %s", opt.Name)
+		}
+	}
+	compression, _ := withMetadata.Lookup("compression")
+	if compression.Value != "GZIP" {
+		t.Errorf("Expected GZIP, got %v", compression.Value)
+	}
+}
+
+// READ - Test Get rejects unknown scopes
+func TestServiceOptionsService_GetInvalidScope(t *testing.T) {
+	svc := &ServiceOptionsService{Client: httpclient.New(httpclient.Config{BaseURL: "http://localhost", AuthToken: "test-token"})}
+	if _, err := svc.Get(context.Background(), "svc-123", "everything"); err == nil {
+		t.Error("Expected error for unknown scope")
+	}
+}
@@ -0,0 +1,335 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OptionValue is a single option value together with its metadata.
+type OptionValue struct {
+	Name string `json:"name"`
+
+	// Value is the option value converted to the Go type of its property:
+	// bool, int, string, []string or map[string]bool. Options using the
+	// enabled/value structure report the inner value here.
+	Value interface{} `json:"value"`
+
+	// Enabled is set for options using the enabled/value structure
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Set reports whether the service has a value for the option
+	Set bool `json:"set"`
+
+	Metadata *OptionMetadata `json:"metadata"`
+}
+
+// GetOption returns a single option converted to the Go type described by
+// its metadata.
+func (s *ServiceOptionsService) GetOption(ctx context.Context, id, name string) (*OptionValue, error) {
+	if id == "" || name == "" {
+		return nil, fmt.Errorf("id and option name are required")
+	}
+
+	metadata, err := s.GetOptionsMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get options metadata: %w", err)
+	}
+	opt, ok := metadata.Find(name)
+	if !ok {
+		return nil, optionValidationError(name, "OPTION_NOT_AVAILABLE", fmt.Sprintf("Option '%s' is not available for this service", name), nil)
+	}
+
+	options, err := s.GetOptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OptionValue{Name: name, Metadata: opt}
+	raw, set := options[name]
+	result.Set = set
+	if !set {
+		return result, nil
+	}
+
+	result.Value, result.Enabled, err = optionValue(opt, raw)
+	if err != nil {
+		return nil, optionValidationError(name, "INVALID_VALUE", fmt.Sprintf("option '%s' has an unexpected value: %v", name, err), opt)
+	}
+	return result, nil
+}
+
+// optionValue converts a raw option value to the Go type of its property,
+// unwrapping the enabled/value structure.
+func optionValue(opt *OptionMetadata, raw interface{}) (interface{}, *bool, error) {
+	var enabled *bool
+	if obj, ok := raw.(map[string]interface{}); ok && opt.Property != nil && opt.Property.Type != "bitfield" {
+		if e, ok := obj["enabled"].(bool); ok {
+			enabled = &e
+			raw = obj["value"]
+		}
+	}
+	if opt.Property == nil || raw == nil {
+		return raw, enabled, nil
+	}
+
+	value, err := coerceOptionValue(opt.Property, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, enabled, nil
+}
+
+// SetOption converts value to the type described by the option's metadata,
+// validates it and updates the option.
+//
+// Values are coerced where the intent is unambiguous: "true" for booleans,
+// numeric strings and whole floats for integers, time.Duration for integers
+// holding a duration, named string types for enums, and []string for
+// bitfields. Enum values match case-insensitively
+// and are sent in the canonical form from the metadata. Validation failures
+// are returned as ServiceOptionsValidationError with a single entry for the
+// option.
+func (s *ServiceOptionsService) SetOption(ctx context.Context, id, name string, value interface{}) (ServiceOptions, error) {
+	if id == "" || name == "" {
+		return nil, fmt.Errorf("id and option name are required")
+	}
+
+	metadata, err := s.GetOptionsMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get options metadata: %w", err)
+	}
+	opt, ok := metadata.Find(name)
+	if !ok {
+		return nil, optionValidationError(name, "OPTION_NOT_AVAILABLE", fmt.Sprintf("Option '%s' is not available for this service", name), nil)
+	}
+	if opt.ReadOnly && !s.Client.AllowsReadOnlyOptions() {
+		return nil, optionValidationError(name, "OPTION_READ_ONLY", fmt.Sprintf("Option '%s' is read-only and cannot be modified", name), opt)
+	}
+
+	if opt.Property != nil {
+		value, err = coerceOptionInput(opt.Property, value)
+		if err != nil {
+			return nil, optionValidationError(name, "INVALID_VALUE", err.Error(), opt)
+		}
+	}
+
+	return s.UpdateOptions(ctx, id, ServiceOptions{name: value})
+}
+
+// coerceOptionInput coerces a caller-supplied value, including the
+// enabled/value structure, for sending to the API.
+func coerceOptionInput(prop *OptionProperty, value interface{}) (interface{}, error) {
+	if obj, ok := value.(map[string]interface{}); ok && prop.Type != "bitfield" {
+		if _, hasEnabled := obj["enabled"]; hasEnabled {
+			coerced := map[string]interface{}{"enabled": obj["enabled"]}
+			if inner, hasValue := obj["value"]; hasValue {
+				v, err := coerceOptionValue(prop, inner)
+				if err != nil {
+					return nil, err
+				}
+				coerced["value"] = toAPIValue(v)
+			}
+			return coerced, nil
+		}
+	}
+
+	v, err := coerceOptionValue(prop, value)
+	if err != nil {
+		return nil, err
+	}
+	return toAPIValue(v), nil
+}
+
+// toAPIValue converts coerced values to the JSON-decoded shapes the
+// validators expect.
+func toAPIValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]bool:
+		out := make(map[string]interface{}, len(val))
+		for k, b := range val {
+			out[k] = b
+		}
+		return out
+	case []string:
+		out := make([]interface{}, len(val))
+		for i, s := range val {
+			out[i] = s
+		}
+		return out
+	}
+	return v
+}
+
+// coerceOptionValue converts value to the Go type of prop and checks it
+// against the property's constraints.
+func coerceOptionValue(prop *OptionProperty, value interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("value is required")
+	}
+
+	switch prop.Type {
+	case "boolean":
+		switch rv.Kind() {
+		case reflect.Bool:
+			return rv.Bool(), nil
+		case reflect.String:
+			b, err := strconv.ParseBool(rv.String())
+			if err != nil {
+				return nil, fmt.Errorf("expected boolean value, got %q", rv.String())
+			}
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected boolean value, got %T", value)
+
+	case "integer":
+		var n int
+		if d, ok := value.(time.Duration); ok {
+			unit := prop.DurationUnit()
+			if unit == 0 {
+				return nil, fmt.Errorf("expected integer value, got duration %s", d)
+			}
+			if d%unit != 0 {
+				return nil, fmt.Errorf("duration %s is not a whole number of %s", d, unit)
+			}
+			rv = reflect.ValueOf(int(d / unit))
+		}
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = int(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n = int(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) {
+				return nil, fmt.Errorf("expected integer value, got %v", f)
+			}
+			n = int(f)
+		case reflect.String:
+			parsed, err := strconv.Atoi(strings.TrimSpace(rv.String()))
+			if err != nil {
+				return nil, fmt.Errorf("expected integer value, got %q", rv.String())
+			}
+			n = parsed
+		default:
+			return nil, fmt.Errorf("expected integer value, got %T", value)
+		}
+		if prop.MinValue != nil && n < *prop.MinValue {
+			return nil, fmt.Errorf("value %d is below minimum %d", n, *prop.MinValue)
+		}
+		if prop.MaxValue != nil && n > *prop.MaxValue {
+			return nil, fmt.Errorf("value %d is above maximum %d", n, *prop.MaxValue)
+		}
+		return n, nil
+
+	case "string":
+		if rv.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected string value, got %T", value)
+		}
+		return rv.String(), nil
+
+	case "enum":
+		if rv.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected string value for enum, got %T", value)
+		}
+		s := rv.String()
+		valid := make([]string, len(prop.EnumValues))
+		for i, ev := range prop.EnumValues {
+			valid[i] = ev.Value
+			if strings.EqualFold(ev.Value, s) {
+				return ev.Value, nil
+			}
+		}
+		return nil, fmt.Errorf("value '%s' is not valid, must be one of: %v", s, valid)
+
+	case "bitfield":
+		known := make(map[string]bool, len(prop.BitFields))
+		for _, f := range prop.BitFields {
+			known[f.Key] = true
+		}
+		bits := make(map[string]bool)
+		switch rv.Kind() {
+		case reflect.Map:
+			iter := rv.MapRange()
+			for iter.Next() {
+				if iter.Key().Kind() != reflect.String {
+					return nil, fmt.Errorf("expected string keys for bitfield, got %T", value)
+				}
+				on, ok := iter.Value().Interface().(bool)
+				if !ok {
+					return nil, fmt.Errorf("expected boolean values for bitfield key '%s'", iter.Key().String())
+				}
+				bits[iter.Key().String()] = on
+			}
+		case reflect.Slice:
+			keys := stringSlice(rv)
+			if keys == nil {
+				return nil, fmt.Errorf("expected a list of strings for bitfield, got %T", value)
+			}
+			for _, key := range keys {
+				bits[key] = true
+			}
+		default:
+			return nil, fmt.Errorf("expected object or list for bitfield, got %T", value)
+		}
+		for key := range bits {
+			if len(known) > 0 && !known[key] {
+				return nil, fmt.Errorf("invalid bitfield key '%s', must be one of: %v", key, sortedKeys(known))
+			}
+		}
+		return bits, nil
+
+	case "strings":
+		if rv.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("expected array of strings, got %T", value)
+		}
+		items := stringSlice(rv)
+		if items == nil && rv.Len() > 0 {
+			return nil, fmt.Errorf("all items in strings array must be strings")
+		}
+		return items, nil
+	}
+
+	return value, nil
+}
+
+// stringSlice returns the string elements of a slice value, or nil if any
+// element is not a string.
+func stringSlice(rv reflect.Value) []string {
+	items := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i)
+		if elem.Kind() == reflect.Interface {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.String {
+			return nil
+		}
+		items = append(items, elem.String())
+	}
+	return items
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// optionValidationError builds a validation error for a single option,
+// described by opt when it is not nil.
+func optionValidationError(name, code, message string, opt *OptionMetadata) error {
+	return ServiceOptionsValidationError{
+		Message: fmt.Sprintf("Validation failed for option '%s': %s", name, message),
+		Errors:  []ValidationError{optionValidationEntry(name, code, message, opt)},
+	}
+}
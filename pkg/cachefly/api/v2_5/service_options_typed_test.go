@@ -0,0 +1,191 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+const typedOptionsMetadata = `{"meta":{"count":4},"data":[
+	{"name":"ttl","type":"dynamic","property":{"name":"ttl","type":"integer","minValue":0,"maxValue":86400}},
+	{"name":"compression","type":"dynamic","property":{"name":"compression","type":"enum","enumValues":[{"value":"GZIP"},{"value":"BROTLI"}]}},
+	{"name":"allowedMethods","type":"dynamic","property":{"name":"allowedMethods","type":"bitfield","bitFields":[{"key":"GET"},{"key":"POST"}]}},
+	{"name":"locked","type":"dynamic","readOnly":true,"property":{"name":"locked","type":"boolean"}}
+]}`
+
+func newTypedOptionsServer(t *testing.T, sent *map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/2.5/services/svc-123/options/metadata":
+			w.Write([]byte(typedOptionsMetadata))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "GET":
+			w.Write([]byte(`{"ttl":{"enabled":true,"value":3600},"compression":"GZIP","allowedMethods":{"GET":true,"POST":false}}`))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "PUT":
+			json.NewDecoder(r.Body).Decode(sent)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+// READ - Test GetOption converts values to their property types
+func TestServiceOptionsService_GetOption(t *testing.T) {
+	server := newTypedOptionsServer(t, nil)
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServiceOptionsService{Client: httpclient.New(cfg)}
+
+	ttl, err := svc.GetOption(context.Background(), "svc-123", "ttl")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ttl.Value != 3600 || ttl.Enabled == nil || !*ttl.Enabled {
+		t.Errorf("Expected enabled ttl of 3600, got %+v", ttl)
+	}
+
+	methods, err := svc.GetOption(context.Background(), "svc-123", "allowedMethods")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	bits, ok := methods.Value.(map[string]bool)
+	if !ok || !bits["GET"] || bits["POST"] {
+		t.Errorf("Expected bitfield map, got %#v", methods.Value)
+	}
+
+	if _, err := svc.GetOption(context.Background(), "svc-123", "missing"); err == nil {
+		t.Error("Expected error for unavailable option, got nil")
+	}
+}
+
+// UPDATE - Test SetOption coerces values before sending
+func TestServiceOptionsService_SetOption(t *testing.T) {
+	var sent map[string]interface{}
+	server := newTypedOptionsServer(t, &sent)
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServiceOptionsService{Client: httpclient.New(cfg)}
+
+	type compression string
+	tests := []struct {
+		name  string
+		value interface{}
+		want  interface{}
+	}{
+		{"ttl", "7200", float64(7200)},
+		{"ttl", map[string]interface{}{"enabled": true, "value": 60.0}, map[string]interface{}{"enabled": true, "value": float64(60)}},
+		{"compression", compression("brotli"), "BROTLI"},
+		{"allowedMethods", []string{"GET", "POST"}, map[string]interface{}{"GET": true, "POST": true}},
+	}
+
+	for _, tt := range tests {
+		sent = nil
+		if _, err := svc.SetOption(context.Background(), "svc-123", tt.name, tt.value); err != nil {
+			t.Errorf("SetOption(%s, %v): expected no error, got %v", tt.name, tt.value, err)
+			continue
+		}
+		got, _ := json.Marshal(sent[tt.name])
+		want, _ := json.Marshal(tt.want)
+		if string(got) != string(want) {
+			t.Errorf("SetOption(%s): expected %s to be sent, got %s", tt.name, want, got)
+		}
+	}
+}
+
+// UPDATE - Test SetOption returns a precise validation error
+func TestServiceOptionsService_SetOptionValidation(t *testing.T) {
+	server := newTypedOptionsServer(t, nil)
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServiceOptionsService{Client: httpclient.New(cfg)}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		code     string
+		expected string
+	}{
+		{"ttl", 100000, "INVALID_VALUE", "integer between 0 and 86400"},
+		{"ttl", 1.5, "INVALID_VALUE", "integer between 0 and 86400"},
+		{"compression", "zstd", "INVALID_VALUE", "one of: GZIP, BROTLI"},
+		{"allowedMethods", []string{"PATCH"}, "INVALID_VALUE", "any of: GET, POST"},
+		{"locked", true, "OPTION_READ_ONLY", "read-only"},
+		{"missing", true, "OPTION_NOT_AVAILABLE", ""},
+	}
+
+	for _, tt := range tests {
+		_, err := svc.SetOption(context.Background(), "svc-123", tt.name, tt.value)
+		var verr ServiceOptionsValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("SetOption(%s, %v): expected validation error, got %v", tt.name, tt.value, err)
+			continue
+		}
+		if len(verr.Errors) != 1 || verr.Errors[0].Field != tt.name || verr.Errors[0].Code != tt.code {
+			t.Errorf("SetOption(%s, %v): expected %s for %s, got %+v", tt.name, tt.value, tt.code, tt.name, verr.Errors)
+			continue
+		}
+		if got := verr.Errors[0].Expected(); got != tt.expected {
+			t.Errorf("SetOption(%s, %v): expected %q, got %q", tt.name, tt.value, tt.expected, got)
+		}
+	}
+}
+
+// UPDATE - Test UpdateOptions describes the constraints of invalid options
+func TestServiceOptionsService_UpdateOptionsValidationContext(t *testing.T) {
+	server := newTypedOptionsServer(t, nil)
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServiceOptionsService{Client: httpclient.New(cfg)}
+
+	_, err := svc.UpdateOptions(context.Background(), "svc-123", ServiceOptions{"compression": "zstd"})
+	var verr ServiceOptionsValidationError
+	if !errors.As(err, &verr) || len(verr.Errors) != 1 {
+		t.Fatalf("Expected one validation error, got %v", err)
+	}
+	e := verr.Errors[0]
+	if e.Type != "enum" || len(e.AllowedValues) != 2 || e.AllowedValues[0] != "GZIP" || e.ReadOnly {
+		t.Errorf("Expected enum with GZIP and BROTLI, got %+v", e)
+	}
+
+	_, err = svc.UpdateOptions(context.Background(), "svc-123", ServiceOptions{"locked": false})
+	if !errors.As(err, &verr) || !verr.Errors[0].ReadOnly || verr.Errors[0].Type != "boolean" {
+		t.Errorf("Expected read-only boolean, got %v", err)
+	}
+}
+
+// UPDATE - Test read-only options can be set when the client allows it
+func TestServiceOptionsService_AllowReadOnlyOptions(t *testing.T) {
+	var sent map[string]interface{}
+	server := newTypedOptionsServer(t, &sent)
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServiceOptionsService{Client: httpclient.New(cfg)}
+	_, err := svc.UpdateOptions(context.Background(), "svc-123", ServiceOptions{"locked": true})
+	var verr ServiceOptionsValidationError
+	if !errors.As(err, &verr) || verr.Errors[0].Code != "OPTION_READ_ONLY" {
+		t.Fatalf("Expected OPTION_READ_ONLY, got %v", err)
+	}
+	if sent != nil {
+		t.Errorf("Expected no update to be sent, got %v", sent)
+	}
+
+	cfg.AllowReadOnlyOptions = true
+	svc = &ServiceOptionsService{Client: httpclient.New(cfg)}
+	if _, err := svc.SetOption(context.Background(), "svc-123", "locked", true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent["locked"] != true {
+		t.Errorf("Expected locked to be sent, got %v", sent)
+	}
+}
@@ -0,0 +1,141 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// Service options managed by the protection toggles.
+const (
+	OptionDeletionProtection = "deletionProtection"
+	OptionChangeTwoFactor    = "changeRequires2FA"
+)
+
+// ServiceProtection reports the protective toggles of a service.
+type ServiceProtection struct {
+	// DeletionProtection makes the API reject deleting the service
+	DeletionProtection bool `json:"deletionProtection"`
+
+	// ChangeTwoFactor makes the API require a two-factor confirmation for
+	// configuration changes of the service
+	ChangeTwoFactor bool `json:"changeTwoFactor"`
+}
+
+// ProtectionUnavailableError is returned when a protective toggle is not
+// offered for a service, usually because the account's plan does not
+// include it.
+type ProtectionUnavailableError struct {
+	ServiceID string
+	Option    string
+
+	// ReadOnly is set when the option exists but cannot be changed
+	ReadOnly bool
+}
+
+func (e *ProtectionUnavailableError) Error() string {
+	if e.ReadOnly {
+		return fmt.Sprintf("%s cannot be changed for service %s", protectionLabel(e.Option), e.ServiceID)
+	}
+	return fmt.Sprintf("%s is not available for service %s; the account's plan does not include it", protectionLabel(e.Option), e.ServiceID)
+}
+
+// Is reports whether target is ErrFeatureUnavailable, for options that are
+// not offered at all.
+func (e *ProtectionUnavailableError) Is(target error) bool {
+	return target == ErrFeatureUnavailable && !e.ReadOnly
+}
+
+// MessageKey returns "feature_unavailable." followed by the option, for
+// message catalogs.
+func (e *ProtectionUnavailableError) MessageKey() string {
+	return apispec.ErrorCodeFeatureUnavailable + "." + e.Option
+}
+
+// MessageParams returns the service ID and option for message catalogs.
+func (e *ProtectionUnavailableError) MessageParams() map[string]string {
+	return map[string]string{"serviceId": e.ServiceID, "option": e.Option}
+}
+
+func protectionLabel(option string) string {
+	switch option {
+	case OptionDeletionProtection:
+		return "deletion protection"
+	case OptionChangeTwoFactor:
+		return "two-factor confirmation of changes"
+	}
+	return option
+}
+
+// Protection returns the protective toggles of a service. Toggles the
+// service does not offer are reported as disabled.
+func (s *ServicesService) Protection(ctx context.Context, id string) (*ServiceProtection, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	options, err := (&ServiceOptionsService{Client: s.Client}).GetOptions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return protectionFromOptions(options), nil
+}
+
+// EnableDeletionProtection makes the API reject deleting the service until
+// deletion protection is disabled again. A *ProtectionUnavailableError is
+// returned when the account's plan does not include it.
+func (s *ServicesService) EnableDeletionProtection(ctx context.Context, id string) (*ServiceProtection, error) {
+	return s.setProtection(ctx, id, OptionDeletionProtection, true)
+}
+
+// DisableDeletionProtection allows deleting the service again.
+func (s *ServicesService) DisableDeletionProtection(ctx context.Context, id string) (*ServiceProtection, error) {
+	return s.setProtection(ctx, id, OptionDeletionProtection, false)
+}
+
+// EnableChangeTwoFactor requires a two-factor confirmation for
+// configuration changes of the service. A *ProtectionUnavailableError is
+// returned when the account's plan does not include it.
+func (s *ServicesService) EnableChangeTwoFactor(ctx context.Context, id string) (*ServiceProtection, error) {
+	return s.setProtection(ctx, id, OptionChangeTwoFactor, true)
+}
+
+// DisableChangeTwoFactor stops requiring a two-factor confirmation for
+// configuration changes of the service.
+func (s *ServicesService) DisableChangeTwoFactor(ctx context.Context, id string) (*ServiceProtection, error) {
+	return s.setProtection(ctx, id, OptionChangeTwoFactor, false)
+}
+
+// setProtection checks that the service offers the option and sets it.
+func (s *ServicesService) setProtection(ctx context.Context, id, option string, enabled bool) (*ServiceProtection, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+
+	options := &ServiceOptionsService{Client: s.Client}
+	metadata, err := options.GetOptionsMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get options metadata: %w", err)
+	}
+	opt, ok := metadata.Find(option)
+	if !ok {
+		return nil, &ProtectionUnavailableError{ServiceID: id, Option: option}
+	}
+	if opt.ReadOnly && !s.Client.AllowsReadOnlyOptions() {
+		return nil, &ProtectionUnavailableError{ServiceID: id, Option: option, ReadOnly: true}
+	}
+
+	updated, err := options.UpdateOptions(ctx, id, ServiceOptions{option: enabled})
+	if err != nil {
+		return nil, err
+	}
+	return protectionFromOptions(updated), nil
+}
+
+func protectionFromOptions(options ServiceOptions) *ServiceProtection {
+	p := &ServiceProtection{}
+	p.DeletionProtection, _ = options[OptionDeletionProtection].(bool)
+	p.ChangeTwoFactor, _ = options[OptionChangeTwoFactor].(bool)
+	return p
+}
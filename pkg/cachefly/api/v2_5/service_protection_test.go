@@ -0,0 +1,109 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// UPDATE - Test EnableDeletionProtection sets the option
+func TestServicesService_EnableDeletionProtection(t *testing.T) {
+	var sent map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/2.5/services/svc-123/options/metadata":
+			w.Write([]byte(`{"meta":{"count":2},"data":[
+				{"name":"deletionProtection","type":"dynamic","property":{"name":"deletionProtection","type":"boolean"}},
+				{"name":"changeRequires2FA","type":"dynamic","property":{"name":"changeRequires2FA","type":"boolean"}}
+			]}`))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "PUT":
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.Write([]byte(`{"deletionProtection":true,"changeRequires2FA":false}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServicesService{Client: httpclient.New(cfg)}
+
+	protection, err := svc.EnableDeletionProtection(context.Background(), "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent["deletionProtection"] != true || len(sent) != 1 {
+		t.Errorf("Expected only deletionProtection=true to be sent, got %v", sent)
+	}
+	if !protection.DeletionProtection || protection.ChangeTwoFactor {
+		t.Errorf("Unexpected protection %+v", protection)
+	}
+}
+
+// UPDATE - Test the toggles report plans without the option
+func TestServicesService_ProtectionUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected no writes for unavailable options, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"count":1},"data":[
+			{"name":"deletionProtection","type":"dynamic","readOnly":true,"property":{"name":"deletionProtection","type":"boolean"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServicesService{Client: httpclient.New(cfg)}
+
+	_, err := svc.EnableChangeTwoFactor(context.Background(), "svc-123")
+	var unavailable *ProtectionUnavailableError
+	if !errors.As(err, &unavailable) {
+		t.Fatalf("Expected ProtectionUnavailableError, got %v", err)
+	}
+	if unavailable.ReadOnly || unavailable.Option != OptionChangeTwoFactor {
+		t.Errorf("Unexpected error %+v", unavailable)
+	}
+	if !strings.Contains(err.Error(), "plan does not include it") {
+		t.Errorf("Expected plan in message, got %s", err.Error())
+	}
+	if unavailable.MessageKey() != "feature_unavailable.changeRequires2FA" {
+		t.Errorf("Expected feature_unavailable.changeRequires2FA, got %s", unavailable.MessageKey())
+	}
+
+	_, err = svc.DisableDeletionProtection(context.Background(), "svc-123")
+	if !errors.As(err, &unavailable) || !unavailable.ReadOnly {
+		t.Errorf("Expected read-only ProtectionUnavailableError, got %v", err)
+	}
+}
+
+// READ - Test Protection reads the toggles from the options
+func TestServicesService_Protection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/services/svc-123/options" {
+			t.Errorf("Expected path /api/2.5/services/svc-123/options, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"changeRequires2FA":true}`))
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &ServicesService{Client: httpclient.New(cfg)}
+
+	protection, err := svc.Protection(context.Background(), "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if protection.DeletionProtection || !protection.ChangeTwoFactor {
+		t.Errorf("Unexpected protection %+v", protection)
+	}
+}
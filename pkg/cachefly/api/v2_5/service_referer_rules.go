@@ -4,9 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // ServiceOptionsRefererRulesService handles referer rule API operations..
@@ -30,6 +31,13 @@ type ListRefererRulesOptions struct {
 	Limit  int
 }
 
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListRefererRulesOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
 // ListRefererRulesResponse contains paginated referer rule results.
 type ListRefererRulesResponse struct {
 	Meta  MetaInfo      `json:"meta"`
@@ -55,24 +63,15 @@ type UpdateRefererRuleRequest struct {
 
 // List retrieves referer rules for a service with optional pagination.
 func (s *ServiceOptionsRefererRulesService) List(ctx context.Context, sid string, opts ListRefererRulesOptions) (*ListRefererRulesResponse, error) {
-	if sid == "" {
-		return nil, fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", sid); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/options/refererrules", sid)
+	endpoint := fmt.Sprintf(apispec.PathServiceRefererRules, sid)
 	params := url.Values{}
 
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
-
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
-
 	var resp ListRefererRulesResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Rules); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -80,16 +79,19 @@ func (s *ServiceOptionsRefererRulesService) List(ctx context.Context, sid string
 
 // Create adds a new referer rule to a service.
 func (s *ServiceOptionsRefererRulesService) Create(ctx context.Context, sid string, req CreateRefererRuleRequest) (*RefererRule, error) {
-	if sid == "" {
-		return nil, fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", sid); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/options/refererrules", sid)
+	endpoint := fmt.Sprintf(apispec.PathServiceRefererRules, sid)
 
 	var created RefererRule
 	if err := s.Client.Post(ctx, endpoint, req, &created); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathServiceRefererRule, sid, created.ID), &created); err != nil {
+		return nil, err
+	}
 	return &created, nil
 }
 
@@ -99,7 +101,7 @@ func (s *ServiceOptionsRefererRulesService) GetByID(ctx context.Context, sid, id
 		return nil, fmt.Errorf("service ID and rule ID are required")
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/options/refererrules/%s", sid, id)
+	endpoint := fmt.Sprintf(apispec.PathServiceRefererRule, sid, id)
 
 	var rule RefererRule
 	if err := s.Client.Get(ctx, endpoint, &rule); err != nil {
@@ -114,12 +116,15 @@ func (s *ServiceOptionsRefererRulesService) Update(ctx context.Context, sid, id
 		return nil, fmt.Errorf("service ID and rule ID are required")
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/options/refererrules/%s", sid, id)
+	endpoint := fmt.Sprintf(apispec.PathServiceRefererRule, sid, id)
 
 	var updated RefererRule
 	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
@@ -129,6 +134,6 @@ func (s *ServiceOptionsRefererRulesService) Delete(ctx context.Context, sid, id
 		return fmt.Errorf("service ID and rule ID are required")
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/options/refererrules/%s", sid, id)
+	endpoint := fmt.Sprintf(apispec.PathServiceRefererRule, sid, id)
 	return s.Client.Delete(ctx, endpoint, nil)
 }
@@ -2,11 +2,13 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // ServiceRule represents a rule configuration for a service.
@@ -14,6 +16,9 @@ type ServiceRule struct {
 	ID        string `json:"_id"`
 	CreatedAt string `json:"createdAt"`
 	UpdatedAt string `json:"updateAt"`
+
+	// Raw holds the response fields ServiceRule does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // ListServiceRulesResponse contains paginated service rule results.
@@ -29,6 +34,13 @@ type ListServiceRulesOptions struct {
 	ResponseType string
 }
 
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListServiceRulesOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
 // ServiceRulesService handles service rule operations.
 type ServiceRulesService struct {
 	Client *httpclient.Client
@@ -41,27 +53,19 @@ type UpdateServiceRulesRequest struct {
 
 // List retrieves rules for a service with optional filtering and pagination.
 func (s *ServiceRulesService) List(ctx context.Context, serviceID string, opts ListServiceRulesOptions) (*ListServiceRulesResponse, error) {
-	if serviceID == "" {
-		return nil, fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/rules", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceRules, serviceID)
 	params := url.Values{}
 
 	if opts.ResponseType != "" {
 		params.Set("responseType", opts.ResponseType)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
-
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
 	var resp ListServiceRulesResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Rules); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -69,11 +73,11 @@ func (s *ServiceRulesService) List(ctx context.Context, serviceID string, opts L
 
 // Update performs a bulk update of rules for a service.
 func (s *ServiceRulesService) Update(ctx context.Context, serviceID string, req UpdateServiceRulesRequest) (*ListServiceRulesResponse, error) {
-	if serviceID == "" {
-		return nil, fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/rules", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceRules, serviceID)
 
 	var resp ListServiceRulesResponse
 	if err := s.Client.Put(ctx, endpoint, req, &resp); err != nil {
@@ -84,11 +88,11 @@ func (s *ServiceRulesService) Update(ctx context.Context, serviceID string, req
 
 // GetSchema retrieves the JSON schema for service rules.
 func (s *ServiceRulesService) GetSchema(ctx context.Context, serviceID string) (map[string]interface{}, error) {
-	if serviceID == "" {
-		return nil, fmt.Errorf("serviceID is required")
+	if err := validate.ID("serviceID", serviceID); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/services/%s/rules/schema", serviceID)
+	endpoint := fmt.Sprintf(apispec.PathServiceRulesSchema, serviceID)
 
 	var schema map[string]interface{}
 	if err := s.Client.Get(ctx, endpoint, &schema); err != nil {
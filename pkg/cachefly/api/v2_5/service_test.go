@@ -2,9 +2,11 @@ package v2_5
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
 )
@@ -104,6 +106,48 @@ func TestServicesService_List(t *testing.T) {
 	}
 }
 
+func TestServicesService_ListFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		expected := map[string]string{
+			"status":       "ACTIVE",
+			"search":       "shop",
+			"uniqueName":   "shop-prod",
+			"createdAfter": "2025-01-02T03:04:05Z",
+		}
+		for key, value := range expected {
+			if got := q.Get(key); got != value {
+				t.Errorf("Expected %s %s, got %s", key, value, got)
+			}
+		}
+		if sortBy := q["sortBy"]; len(sortBy) != 2 || sortBy[0] != "name" || sortBy[1] != "-createdAt" {
+			t.Errorf("Expected sortBy [name -createdAt], got %v", sortBy)
+		}
+		if q.Get("includeFeatures") != "true" {
+			t.Errorf("Expected includeFeatures true, got %s", q.Get("includeFeatures"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"meta":{"limit":10,"offset":0,"count":0},"data":[]}`))
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+
+	opts := ListOptions{
+		Status:          "ACTIVE",
+		Search:          "shop",
+		UniqueName:      "shop-prod",
+		CreatedAfter:    time.Date(2025, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600)),
+		SortBy:          []string{"name", "-createdAt"},
+		IncludeFeatures: true,
+	}
+	if _, err := svc.List(context.Background(), opts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
 // UPDATE - Test UpdateServiceByID method
 func TestServicesService_UpdateServiceByID(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -183,3 +227,110 @@ func TestServicesService_ErrorHandling(t *testing.T) {
 		t.Error("Expected error for 400 response")
 	}
 }
+
+func TestServicesService_ListEach(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("Expected limit 2, got %s", r.URL.Query().Get("limit"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch offset {
+		case "0":
+			w.Write([]byte(`{"meta":{"count":3},"data":[{"_id":"a"},{"_id":"b"}]}`))
+		default:
+			w.Write([]byte(`{"meta":{"count":3},"data":[{"_id":"c"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+
+	var ids []string
+	err := svc.ListEach(context.Background(), ListOptions{Limit: 2}, func(s *Service) error {
+		ids = append(ids, s.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ids) != 3 || ids[0] != "a" || ids[2] != "c" {
+		t.Errorf("Expected services a, b, c, got %v", ids)
+	}
+	if len(offsets) != 2 || offsets[1] != "2" {
+		t.Errorf("Expected pages at offsets 0 and 2, got %v", offsets)
+	}
+}
+
+func TestServicesService_ListStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write([]byte(`{"data":[{"_id":"a"},{"_id":"b"}]}`))
+		case "2":
+			w.Write([]byte(`{"data":[{"_id":"c"},{"_id":"d"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"boom"}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, AuthToken: "test-token"})}
+
+	services, errs := svc.ListStream(context.Background(), ListOptions{Limit: 2})
+	var ids []string
+	for s := range services {
+		ids = append(ids, s.ID)
+	}
+	if len(ids) != 4 || ids[3] != "d" {
+		t.Errorf("Expected services a to d, got %v", ids)
+	}
+	if err := <-errs; err == nil {
+		t.Error("Expected the error of the failed page")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	services, errs = svc.ListStream(ctx, ListOptions{Limit: 1})
+	<-services
+	cancel()
+	for range services {
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestServicesService_CreateReadAfterWrite(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			w.Write([]byte(`{"_id":"svc-1","name":"Test Service","tlsProfile":"stale"}`))
+		default:
+			w.Write([]byte(`{"_id":"svc-1","name":"Test Service","status":"ACTIVE"}`))
+		}
+	}))
+	defer server.Close()
+
+	svc := &ServicesService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL, ReadAfterWrite: true})}
+
+	created, err := svc.Create(context.Background(), CreateServiceRequest{Name: "Test Service", UniqueName: "test-service"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(methods) != 2 || methods[1] != "GET /services/svc-1" {
+		t.Fatalf("Expected the service to be read back, got %v", methods)
+	}
+	if created.Status != "ACTIVE" {
+		t.Errorf("Expected status from the read-back, got %s", created.Status)
+	}
+	if created.TLSProfile != "" {
+		t.Errorf("Expected fields missing from the read-back to be cleared, got %s", created.TLSProfile)
+	}
+}
@@ -2,11 +2,15 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // ServicesService handles service-related API operations.
@@ -24,6 +28,12 @@ type Service struct {
 	AutoSSL           bool   `json:"autoSsl"`
 	ConfigurationMode string `json:"configurationMode"`
 	Status            string `json:"status"`
+	Description       string `json:"description,omitempty"`
+	TLSProfile        string `json:"tlsProfile,omitempty"`
+	DeliveryRegion    string `json:"deliveryRegion,omitempty"`
+
+	// Raw holds the response fields Service does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // CreateServiceRequest contains the required fields for creating a new service.
@@ -54,13 +64,32 @@ type MetaInfo struct {
 	Count  int `json:"count"`
 }
 
-// ListOptions specifies filters and pagination for listing services.
+// ListOptions specifies filters and pagination for listing services. Its
+// Offset, Limit, SortBy, Order and Search fields are the listing fields
+// shared by all services, set with ListOption helpers such as WithLimit.
 type ListOptions struct {
 	ResponseType    string
 	IncludeFeatures bool
 	Status          string
 	Offset          int
 	Limit           int
+
+	// Search matches services whose name contains the string
+	Search string
+
+	// UniqueName matches the service with this uniqueName
+	UniqueName string
+
+	// CreatedAfter matches services created after the time
+	CreatedAfter time.Time
+
+	// SortBy orders the results by the given fields, e.g. "name"; prefix a
+	// field with "-" to sort in descending order, e.g. "-createdAt"
+	SortBy []string
+
+	// Order sorts all SortBy fields in descending order when
+	// SortDescending; List fails when it is set without SortBy
+	Order SortOrder
 }
 
 // UpdateServiceRequest contains fields for updating an existing service.
@@ -84,20 +113,23 @@ type EnableOriginLogsRequest struct {
 
 // Create creates a new service with the specified configuration.
 func (s *ServicesService) Create(ctx context.Context, req CreateServiceRequest) (*Service, error) {
-	endpoint := "/services"
+	endpoint := apispec.PathServices
 
 	var created Service
 	err := s.Client.Post(ctx, endpoint, req, &created)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathService, created.ID), &created); err != nil {
+		return nil, err
+	}
 
 	return &created, nil
 }
 
 // Get retrieves a service by ID with optional parameters.
 func (s *ServicesService) Get(ctx context.Context, id string, responseType string, includeFeatures bool) (*Service, error) {
-	endpoint := fmt.Sprintf("/services/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathService, id)
 
 	params := url.Values{}
 	if responseType != "" {
@@ -118,11 +150,11 @@ func (s *ServicesService) Get(ctx context.Context, id string, responseType strin
 
 // GetByID retrieves a service by its ID.
 func (s *ServicesService) GetByID(ctx context.Context, id string) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("service ID is required")
+	if err := validate.ID("service ID", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/services/%s", url.PathEscape(id))
+	endpoint := fmt.Sprintf(apispec.PathService, url.PathEscape(id))
 
 	var svc Service
 	if err := s.Client.Get(ctx, endpoint, &svc); err != nil {
@@ -133,7 +165,123 @@ func (s *ServicesService) GetByID(ctx context.Context, id string) (*Service, err
 
 // List retrieves services with optional filtering and pagination.
 func (s *ServicesService) List(ctx context.Context, opts ListOptions) (*ListServicesResponse, error) {
-	endpoint := "/services"
+	if err := checkSort(opts.SortBy, opts.Order); err != nil {
+		return nil, err
+	}
+	var result ListServicesResponse
+	err := getList(ctx, s.Client, apispec.PathServices, listServicesParams(opts), opts.Offset, opts.Limit, &result.Meta, &result.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListEach calls fn for every service matching opts, fetching page after
+// page from opts.Offset in pages of opts.Limit (100 when unset). Services
+// are decoded one at a time and never held together, so memory use stays
+// constant however many services the account has.
+//
+// fn receives the same *Service on every call; copy the value to keep it.
+// An error returned by fn stops the iteration and is returned as is.
+func (s *ServicesService) ListEach(ctx context.Context, opts ListOptions, fn func(*Service) error) error {
+	if err := checkSort(opts.SortBy, opts.Order); err != nil {
+		return err
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = listAllPageSize
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+
+	var svc Service
+	for {
+		n, pageSize, err := s.Client.GetEachPage(ctx, listServicesURL(opts), func(dec *json.Decoder) error {
+			svc = Service{}
+			if err := s.Client.DecodeElement(dec, &svc); err != nil {
+				return err
+			}
+			return fn(&svc)
+		})
+		if err != nil {
+			return err
+		}
+		// A page the API capped below opts.Limit is full at its own size
+		if pageSize <= 0 || pageSize > opts.Limit {
+			pageSize = opts.Limit
+		}
+		if n < pageSize {
+			return nil
+		}
+		opts.Offset += n
+	}
+}
+
+// ListStream sends every service matching opts on the returned channel,
+// fetching pages of opts.Limit (100 when unset) in the background. The
+// next page is fetched while the current one is consumed, and at most two
+// pages are held at a time, so pipelines over tens of thousands of
+// services use bounded memory.
+//
+// Both channels are closed once the services are exhausted. The error
+// channel then yields the error that ended the listing, if any; cancel ctx
+// to stop early, in which case it yields ctx.Err().
+//
+// Example:
+//
+//	services, errs := client.Services.ListStream(ctx, api.ListOptions{Status: "ACTIVE"})
+//	for svc := range services {
+//		fmt.Println(svc.UniqueName)
+//	}
+//	if err := <-errs; err != nil {
+//		return err
+//	}
+func (s *ServicesService) ListStream(ctx context.Context, opts ListOptions) (<-chan Service, <-chan error) {
+	if opts.Limit <= 0 {
+		opts.Limit = listAllPageSize
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+
+	services := make(chan Service, opts.Limit)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(services)
+		for {
+			page, err := s.List(ctx, opts)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for _, svc := range page.Services {
+				select {
+				case services <- svc:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+			if len(page.Services) < opts.Limit {
+				return
+			}
+			opts.Offset += len(page.Services)
+		}
+	}()
+	return services, errs
+}
+
+// listServicesURL returns the list endpoint with the query for opts.
+func listServicesURL(opts ListOptions) string {
+	params := listServicesParams(opts)
+	setPage(params, opts.Offset, opts.Limit)
+	return fmt.Sprintf("%s?%s", apispec.PathServices, params.Encode())
+}
+
+// listServicesParams returns the filters of opts as query parameters.
+func listServicesParams(opts ListOptions) url.Values {
 	params := url.Values{}
 
 	if opts.ResponseType != "" {
@@ -143,118 +291,135 @@ func (s *ServicesService) List(ctx context.Context, opts ListOptions) (*ListServ
 	if opts.Status != "" {
 		params.Set("status", opts.Status)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
+	if opts.Search != "" {
+		params.Set("search", opts.Search)
 	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
+	if opts.UniqueName != "" {
+		params.Set("uniqueName", opts.UniqueName)
 	}
-
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
-
-	var result ListServicesResponse
-	err := s.Client.Get(ctx, fullURL, &result)
-	if err != nil {
-		return nil, err
+	if !opts.CreatedAfter.IsZero() {
+		params.Set("createdAfter", opts.CreatedAfter.UTC().Format(time.RFC3339))
 	}
+	addSort(params, opts.SortBy, opts.Order)
 
-	return &result, nil
+	return params
 }
 
 // UpdateServiceByID updates an existing service configuration.
 func (s *ServicesService) UpdateServiceByID(ctx context.Context, id string, req UpdateServiceRequest) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathService, id)
 
 	var updated Service
 	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // ActivateServiceByID activates a service.
 func (s *ServicesService) ActivateServiceByID(ctx context.Context, id string) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/activate", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceActivate, id)
 
 	var updated Service
 	if err := s.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathService, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // DeactivateServiceByID deactivates a service.
 func (s *ServicesService) DeactivateServiceByID(ctx context.Context, id string) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/deactivate", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceDeactivate, id)
 
 	var updated Service
 	if err := s.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathService, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // EnableAccessLogging enables access logging for a service.
 func (s *ServicesService) EnableAccessLogging(ctx context.Context, id string, req EnableAccessLogsRequest) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/accessLogs", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceAccessLogs, id)
 
 	var updated Service
 	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathService, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // DeleteAccessLoggingByID disables access logging for a service.
 func (s *ServicesService) DeleteAccessLoggingByID(ctx context.Context, id string) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/accessLogs", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceAccessLogs, id)
 
 	var updated Service
 	if err := s.Client.Delete(ctx, endpoint, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathService, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // EnableOriginLogging enables origin logging for a service.
 func (s *ServicesService) EnableOriginLogging(ctx context.Context, id string, req EnableOriginLogsRequest) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/originLogs", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOriginLogs, id)
 
 	var updated Service
 	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathService, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // DeleteOriginLoggingByID disables origin logging for a service.
 func (s *ServicesService) DeleteOriginLoggingByID(ctx context.Context, id string) (*Service, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
-	endpoint := fmt.Sprintf("/services/%s/originLogs", id)
+	endpoint := fmt.Sprintf(apispec.PathServiceOriginLogs, id)
 
 	var updated Service
 	if err := s.Client.Delete(ctx, endpoint, &updated); err != nil {
 		return nil, err
 	}
+	if err := s.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathService, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
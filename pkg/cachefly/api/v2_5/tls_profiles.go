@@ -2,11 +2,13 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // TLSProfile represents a TLS configuration profile in CacheFly.
@@ -16,6 +18,9 @@ type TLSProfile struct {
 	CreatedAt string `json:"createdAt"`
 	Name      string `json:"name"`
 	// Add more fields here once the schema is known
+
+	// Raw holds the response fields TLSProfile does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // ListTLSProfilesResponse contains paginated TLS profile results.
@@ -27,11 +32,19 @@ type ListTLSProfilesResponse struct {
 // ListTLSProfilesOptions specifies filtering and pagination for listing TLS profiles.
 type ListTLSProfilesOptions struct {
 	SortBy []string
+	Order  SortOrder
 	Group  string
 	Offset int
 	Limit  int
 }
 
+// Apply sets the offset, limit, sort fields and order of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListTLSProfilesOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit, sortBy: &o.SortBy, order: &o.Order}.apply(opts)
+}
+
 // TLSProfilesService handles TLS profile operations.
 type TLSProfilesService struct {
 	Client *httpclient.Client
@@ -39,28 +52,19 @@ type TLSProfilesService struct {
 
 // List retrieves TLS profiles with optional sorting, grouping, and pagination.
 func (s *TLSProfilesService) List(ctx context.Context, opts ListTLSProfilesOptions) (*ListTLSProfilesResponse, error) {
-	endpoint := "/tlsprofiles"
+	if err := checkSort(opts.SortBy, opts.Order); err != nil {
+		return nil, err
+	}
+	endpoint := apispec.PathTLSProfiles
 	params := url.Values{}
 
-	if len(opts.SortBy) > 0 {
-		for _, v := range opts.SortBy {
-			params.Add("sortBy", v)
-		}
-	}
+	addSort(params, opts.SortBy, opts.Order)
 	if opts.Group != "" {
 		params.Set("group", opts.Group)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
-
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
 	var resp ListTLSProfilesResponse
-	if err := s.Client.Get(ctx, fullURL, &resp); err != nil {
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Profiles); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -68,11 +72,11 @@ func (s *TLSProfilesService) List(ctx context.Context, opts ListTLSProfilesOptio
 
 // GetByID retrieves a TLS profile by its ID.
 func (s *TLSProfilesService) GetByID(ctx context.Context, id string) (*TLSProfile, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/tlsprofiles/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathTLSProfile, id)
 
 	var p TLSProfile
 	if err := s.Client.Get(ctx, endpoint, &p); err != nil {
@@ -0,0 +1,195 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// Service options managed by TLSSettingsService.
+const (
+	OptionHTTPSRedirect         = apispec.OptionAutoRedirect
+	OptionHSTS                  = apispec.OptionHsts
+	OptionHSTSIncludeSubDomains = apispec.OptionHstsIncludeSubDomains
+	OptionHSTSPreload           = apispec.OptionHstsPreload
+)
+
+// HSTSPreloadMinMaxAge is the minimum HSTS max-age, in seconds, accepted by
+// browser preload lists.
+const HSTSPreloadMinMaxAge = 31536000
+
+// TLSSettingsService manages the TLS settings of services and their domains.
+//
+// TLS settings are spread over the service itself (TLS profile, AutoSSL),
+// its options (HTTPS redirect, HSTS) and its domains (certificate binding).
+// This service reads and writes them as one typed value and validates them
+// before anything is sent.
+type TLSSettingsService struct {
+	Client *httpclient.Client
+}
+
+// TLSSettings contains the TLS configuration of a service.
+type TLSSettings struct {
+	// TLSProfile is the ID of the TLS profile, which sets the minimum TLS
+	// version and cipher suites. Empty keeps the account default.
+	TLSProfile string `json:"tlsProfile,omitempty"`
+
+	// AutoSSL issues and renews certificates for the service's domains
+	AutoSSL bool `json:"autoSsl"`
+
+	// HTTPSRedirect redirects plain HTTP requests to HTTPS
+	HTTPSRedirect bool `json:"httpsRedirect"`
+
+	// HSTS sets the Strict-Transport-Security response header
+	HSTS HSTSSettings `json:"hsts"`
+}
+
+// HSTSSettings configures the Strict-Transport-Security header.
+type HSTSSettings struct {
+	Enabled           bool `json:"enabled"`
+	MaxAge            int  `json:"maxAge,omitempty"`
+	IncludeSubDomains bool `json:"includeSubDomains,omitempty"`
+	Preload           bool `json:"preload,omitempty"`
+}
+
+// Validate checks the settings for combinations the API or browsers reject.
+func (t TLSSettings) Validate() error {
+	if !t.HSTS.Enabled {
+		return nil
+	}
+	if t.HSTS.MaxAge <= 0 {
+		return fmt.Errorf("hsts maxAge must be positive when HSTS is enabled")
+	}
+	if !t.HTTPSRedirect {
+		return fmt.Errorf("hsts requires httpsRedirect to be enabled")
+	}
+	if t.HSTS.Preload {
+		if !t.HSTS.IncludeSubDomains {
+			return fmt.Errorf("hsts preload requires includeSubDomains")
+		}
+		if t.HSTS.MaxAge < HSTSPreloadMinMaxAge {
+			return fmt.Errorf("hsts preload requires maxAge of at least %d seconds", HSTSPreloadMinMaxAge)
+		}
+	}
+	return nil
+}
+
+// Get returns the TLS settings of a service.
+func (s *TLSSettingsService) Get(ctx context.Context, serviceID string) (*TLSSettings, error) {
+	if err := validate.ID("service ID", serviceID); err != nil {
+		return nil, err
+	}
+
+	services := &ServicesService{Client: s.Client}
+	svc, err := services.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := (&ServiceOptionsService{Client: s.Client}).GetOptions(ctx, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service options: %w", err)
+	}
+
+	settings := &TLSSettings{TLSProfile: svc.TLSProfile, AutoSSL: svc.AutoSSL}
+	settings.HTTPSRedirect, _ = options[OptionHTTPSRedirect].(bool)
+	settings.HSTS = hstsFromOptions(options)
+	return settings, nil
+}
+
+// Update validates and applies the TLS settings of a service.
+//
+// Service fields are updated only when they differ, and options are applied
+// through ServiceOptions.Apply so only changed keys are sent. HSTS options
+// are only written when HSTS is enabled or the service already has them, so
+// services without HSTS support can still manage their other settings.
+func (s *TLSSettingsService) Update(ctx context.Context, serviceID string, settings TLSSettings) (*TLSSettings, error) {
+	if err := validate.ID("service ID", serviceID); err != nil {
+		return nil, err
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	services := &ServicesService{Client: s.Client}
+	svc, err := services.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if svc.AutoSSL != settings.AutoSSL || (settings.TLSProfile != "" && svc.TLSProfile != settings.TLSProfile) {
+		// UpdateServiceRequest sends every field, so carry over the current values
+		req := UpdateServiceRequest{
+			Description:       svc.Description,
+			TLSProfile:        settings.TLSProfile,
+			AutoSSL:           settings.AutoSSL,
+			DeliveryRegion:    svc.DeliveryRegion,
+			ConfigurationMode: svc.ConfigurationMode,
+		}
+		if _, err := services.UpdateServiceByID(ctx, serviceID, req); err != nil {
+			return nil, fmt.Errorf("failed to update service: %w", err)
+		}
+	}
+
+	options := &ServiceOptionsService{Client: s.Client}
+	current, err := options.GetOptions(ctx, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service options: %w", err)
+	}
+
+	desired := ServiceOptions{OptionHTTPSRedirect: settings.HTTPSRedirect}
+	for name, value := range hstsToOptions(settings.HSTS) {
+		if _, exists := current[name]; exists || settings.HSTS.Enabled {
+			desired[name] = value
+		}
+	}
+	if _, err := options.Apply(ctx, serviceID, desired, ApplyOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to apply TLS options: %w", err)
+	}
+
+	return s.Get(ctx, serviceID)
+}
+
+// BindCertificates sets the certificates served for a domain of a service.
+func (s *TLSSettingsService) BindCertificates(ctx context.Context, serviceID, domainID string, certificateIDs ...string) (*ServiceDomain, error) {
+	if serviceID == "" || domainID == "" {
+		return nil, fmt.Errorf("service ID and domain ID are required")
+	}
+	if len(certificateIDs) == 0 {
+		return nil, fmt.Errorf("at least one certificate ID is required")
+	}
+
+	domains := &ServiceDomainsService{Client: s.Client}
+	return domains.UpdateByID(ctx, serviceID, domainID, UpdateServiceDomainRequest{Certificates: certificateIDs})
+}
+
+// hstsToOptions converts HSTS settings to service options. The max-age is
+// carried in the enabled/value structure of the hsts option.
+func hstsToOptions(h HSTSSettings) ServiceOptions {
+	hsts := map[string]interface{}{"enabled": h.Enabled}
+	if h.Enabled {
+		hsts["value"] = float64(h.MaxAge)
+	}
+	return ServiceOptions{
+		OptionHSTS:                  hsts,
+		OptionHSTSIncludeSubDomains: h.Enabled && h.IncludeSubDomains,
+		OptionHSTSPreload:           h.Enabled && h.Preload,
+	}
+}
+
+// hstsFromOptions reads HSTS settings from service options.
+func hstsFromOptions(options ServiceOptions) HSTSSettings {
+	var h HSTSSettings
+	if obj, ok := options[OptionHSTS].(map[string]interface{}); ok {
+		h.Enabled, _ = obj["enabled"].(bool)
+		if maxAge, ok := obj["value"].(float64); ok {
+			h.MaxAge = int(maxAge)
+		}
+	}
+	h.IncludeSubDomains, _ = options[OptionHSTSIncludeSubDomains].(bool)
+	h.Preload, _ = options[OptionHSTSPreload].(bool)
+	return h
+}
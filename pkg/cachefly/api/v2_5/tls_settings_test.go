@@ -0,0 +1,106 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// READ - Test Get combines service fields and options
+func TestTLSSettingsService_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/2.5/services/svc-123":
+			w.Write([]byte(`{"_id":"svc-123","autoSsl":true,"tlsProfile":"tls-modern"}`))
+		case "/api/2.5/services/svc-123/options":
+			w.Write([]byte(`{"autoRedirect":true,"hsts":{"enabled":true,"value":31536000},"hstsIncludeSubDomains":true}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &TLSSettingsService{Client: httpclient.New(cfg)}
+
+	settings, err := svc.Get(context.Background(), "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !settings.AutoSSL || settings.TLSProfile != "tls-modern" || !settings.HTTPSRedirect {
+		t.Errorf("Unexpected settings %+v", settings)
+	}
+	if !settings.HSTS.Enabled || settings.HSTS.MaxAge != 31536000 || !settings.HSTS.IncludeSubDomains || settings.HSTS.Preload {
+		t.Errorf("Unexpected HSTS settings %+v", settings.HSTS)
+	}
+}
+
+// UPDATE - Test Update skips HSTS options the service does not have
+func TestTLSSettingsService_Update(t *testing.T) {
+	var serviceUpdate UpdateServiceRequest
+	var optionsUpdate map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/api/2.5/services/svc-123" && r.Method == "GET":
+			w.Write([]byte(`{"_id":"svc-123","autoSsl":false,"description":"Site","configurationMode":"API_RULES_AND_OPTIONS"}`))
+		case r.URL.Path == "/api/2.5/services/svc-123" && r.Method == "PUT":
+			json.NewDecoder(r.Body).Decode(&serviceUpdate)
+			w.Write([]byte(`{"_id":"svc-123","autoSsl":true}`))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "GET":
+			w.Write([]byte(`{"autoRedirect":false}`))
+		case r.URL.Path == "/api/2.5/services/svc-123/options" && r.Method == "PUT":
+			json.NewDecoder(r.Body).Decode(&optionsUpdate)
+			w.Write([]byte(`{"autoRedirect":true}`))
+		case r.URL.Path == "/api/2.5/services/svc-123/options/metadata":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"name":"Auto HTTPS Redirect","type":"standard"}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"}
+	svc := &TLSSettingsService{Client: httpclient.New(cfg)}
+
+	_, err := svc.Update(context.Background(), "svc-123", TLSSettings{AutoSSL: true, HTTPSRedirect: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !serviceUpdate.AutoSSL || serviceUpdate.Description != "Site" || serviceUpdate.ConfigurationMode != "API_RULES_AND_OPTIONS" {
+		t.Errorf("Expected AutoSSL update preserving other fields, got %+v", serviceUpdate)
+	}
+	if len(optionsUpdate) != 1 || optionsUpdate["autoRedirect"] != true {
+		t.Errorf("Expected only autoRedirect to be sent, got %v", optionsUpdate)
+	}
+}
+
+func TestTLSSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings TLSSettings
+		wantErr  bool
+	}{
+		{"hsts disabled", TLSSettings{}, false},
+		{"hsts without redirect", TLSSettings{HSTS: HSTSSettings{Enabled: true, MaxAge: 300}}, true},
+		{"hsts without max age", TLSSettings{HTTPSRedirect: true, HSTS: HSTSSettings{Enabled: true}}, true},
+		{"preload without subdomains", TLSSettings{HTTPSRedirect: true, HSTS: HSTSSettings{Enabled: true, MaxAge: HSTSPreloadMinMaxAge, Preload: true}}, true},
+		{"preload with short max age", TLSSettings{HTTPSRedirect: true, HSTS: HSTSSettings{Enabled: true, MaxAge: 300, IncludeSubDomains: true, Preload: true}}, true},
+		{"valid preload", TLSSettings{HTTPSRedirect: true, HSTS: HSTSSettings{Enabled: true, MaxAge: HSTSPreloadMinMaxAge, IncludeSubDomains: true, Preload: true}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package v2_5
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// Token scopes.
+const (
+	TokenScopeRead            = "read"
+	TokenScopeWrite           = "write"
+	TokenScopeServicesManage  = "services:manage"
+	TokenScopeCertificates    = "certificates:manage"
+	TokenScopeUsersManage     = "users:manage"
+	TokenScopeAccountsManage  = "accounts:manage"
+	TokenScopePurge           = "purge"
+	TokenScopeLogsRead        = "logs:read"
+	TokenScopeWebhooksManage  = "webhooks:manage"
+	TokenScopeBillingRead     = "billing:read"
+	TokenScopeChildAccountAll = "child-accounts:*"
+)
+
+// TokensService handles account-level API token operations.
+type TokensService struct {
+	Client *httpclient.Client
+}
+
+// APIToken represents an account-level API token.
+type APIToken struct {
+	ID         string   `json:"_id"`
+	UpdatedAt  string   `json:"updateAt"`
+	CreatedAt  string   `json:"createdAt"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expiresAt,omitempty"`
+	LastUsedAt string   `json:"lastUsedAt,omitempty"`
+	CreatedBy  string   `json:"createdBy,omitempty"`
+	Revoked    bool     `json:"revoked"`
+
+	// Token is the secret value. The API returns it only when the token is
+	// created.
+	Token string `json:"token,omitempty"`
+}
+
+// ListTokensResponse contains paginated token results.
+type ListTokensResponse struct {
+	Meta   MetaInfo   `json:"meta"`
+	Tokens []APIToken `json:"data"`
+}
+
+// ListTokensOptions specifies filters and pagination for listing tokens.
+type ListTokensOptions struct {
+	IncludeRevoked bool
+	Offset         int
+	Limit          int
+}
+
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListTokensOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
+// CreateTokenRequest is the payload for creating a token. ExpiresAt is an
+// RFC 3339 timestamp; empty creates a token that does not expire.
+type CreateTokenRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expiresAt,omitempty"`
+}
+
+// List retrieves the account's API tokens. Token values are never included.
+func (s *TokensService) List(ctx context.Context, opts ListTokensOptions) (*ListTokensResponse, error) {
+	endpoint := apispec.PathTokens
+	params := url.Values{}
+	if opts.IncludeRevoked {
+		params.Set("includeRevoked", "true")
+	}
+
+	var resp ListTokensResponse
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Tokens); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Create issues a new API token. The returned token carries its secret
+// value, which the API does not return again.
+func (s *TokensService) Create(ctx context.Context, req CreateTokenRequest) (*APIToken, error) {
+	if err := validate.Required("name", req.Name); err != nil {
+		return nil, err
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required")
+	}
+	var created APIToken
+	if err := s.Client.Post(ctx, apispec.PathTokens, req, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetByID fetches a single token by its ID.
+func (s *TokensService) GetByID(ctx context.Context, id string) (*APIToken, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(apispec.PathToken, id)
+	var token APIToken
+	if err := s.Client.Get(ctx, endpoint, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Revoke invalidates a token immediately.
+func (s *TokensService) Revoke(ctx context.Context, id string) error {
+	if err := validate.ID("id", id); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf(apispec.PathToken, id)
+	return s.Client.Delete(ctx, endpoint, nil)
+}
+
+// Rotate issues a replacement for a token with the same name and scopes,
+// expiring at expiresAt, and then revokes the old token. When revoking
+// fails, the new token is still returned together with the error, so the
+// caller can store it and retry the revocation.
+func (s *TokensService) Rotate(ctx context.Context, id, expiresAt string) (*APIToken, error) {
+	old, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	replacement, err := s.Create(ctx, CreateTokenRequest{Name: old.Name, Scopes: old.Scopes, ExpiresAt: expiresAt})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replacement token: %w", err)
+	}
+
+	if err := s.Revoke(ctx, id); err != nil {
+		return replacement, fmt.Errorf("failed to revoke token %s: %w", id, err)
+	}
+	return replacement, nil
+}
@@ -0,0 +1,104 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestTokensService_Create(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/tokens" || r.Method != "POST" {
+			t.Errorf("Expected POST /api/2.5/tokens, got %s %s", r.Method, r.URL.Path)
+		}
+		var req CreateTokenRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.ExpiresAt != "2026-01-01T00:00:00Z" || len(req.Scopes) != 2 {
+			t.Errorf("Unexpected request %+v", req)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"_id":"tok-1","name":"ci","scopes":["read","purge"],"expiresAt":"2026-01-01T00:00:00Z","token":"secret-value"}`))
+	}))
+	defer server.Close()
+
+	svc := &TokensService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+	token, err := svc.Create(context.Background(), CreateTokenRequest{
+		Name:      "ci",
+		Scopes:    []string{TokenScopeRead, TokenScopePurge},
+		ExpiresAt: "2026-01-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token.ID != "tok-1" || token.Token != "secret-value" {
+		t.Errorf("Expected token tok-1 with secret, got %+v", token)
+	}
+}
+
+func TestTokensService_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("includeRevoked") != "true" {
+			t.Errorf("Expected includeRevoked filter, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"tok-1","name":"ci","scopes":["read"],"revoked":true}]}`))
+	}))
+	defer server.Close()
+
+	svc := &TokensService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	resp, err := svc.List(context.Background(), ListTokensOptions{IncludeRevoked: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Tokens) != 1 || !resp.Tokens[0].Revoked {
+		t.Errorf("Expected 1 revoked token, got %+v", resp.Tokens)
+	}
+}
+
+func TestTokensService_Rotate(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /tokens/tok-1":
+			w.Write([]byte(`{"_id":"tok-1","name":"ci","scopes":["read","purge"]}`))
+		case "POST /tokens":
+			var req CreateTokenRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Name != "ci" || len(req.Scopes) != 2 {
+				t.Errorf("Expected replacement with same name and scopes, got %+v", req)
+			}
+			w.Write([]byte(`{"_id":"tok-2","name":"ci","scopes":["read","purge"],"token":"new-secret"}`))
+		case "DELETE /tokens/tok-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := &TokensService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	token, err := svc.Rotate(context.Background(), "tok-1", "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token.ID != "tok-2" || token.Token != "new-secret" {
+		t.Errorf("Expected replacement token, got %+v", token)
+	}
+	if len(calls) != 3 || calls[2] != "DELETE /tokens/tok-1" {
+		t.Errorf("Expected old token revoked last, got %v", calls)
+	}
+}
+
+func TestTokensService_RequiresInput(t *testing.T) {
+	svc := &TokensService{}
+	if _, err := svc.Create(context.Background(), CreateTokenRequest{Name: "ci"}); err == nil {
+		t.Error("Expected error for missing scopes")
+	}
+	if err := svc.Revoke(context.Background(), ""); err == nil {
+		t.Error("Expected error for missing id")
+	}
+}
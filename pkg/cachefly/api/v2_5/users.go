@@ -2,11 +2,13 @@ package v2_5
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
 )
 
 // User represents a CacheFly user account with permissions and service access.
@@ -22,6 +24,9 @@ type User struct {
 	Permissions            []string `json:"permissions"`
 	Services               []string `json:"services"`
 	Status                 string   `json:"status"`
+
+	// Raw holds the response fields User does not model
+	Raw json.RawMessage `json:"-"`
 }
 
 // ListUsersOptions specifies filtering and pagination for listing users.
@@ -32,6 +37,13 @@ type ListUsersOptions struct {
 	ResponseType string
 }
 
+// Apply sets the offset, limit and search of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListUsersOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit, search: &o.Search}.apply(opts)
+}
+
 // ListUsersResponse contains paginated user results.
 type ListUsersResponse struct {
 	Meta  MetaInfo `json:"meta"`
@@ -72,7 +84,7 @@ type UsersService struct {
 // GetCurrentUser retrieves the currently authenticated user.
 func (u *UsersService) GetCurrentUser(ctx context.Context) (*User, error) {
 	var usr User
-	if err := u.Client.Get(ctx, "/users/me", &usr); err != nil {
+	if err := u.Client.Get(ctx, apispec.PathCurrentUser, &usr); err != nil {
 		return nil, err
 	}
 	return &usr, nil
@@ -81,7 +93,10 @@ func (u *UsersService) GetCurrentUser(ctx context.Context) (*User, error) {
 // UpdateCurrentUser updates the currently authenticated user.
 func (u *UsersService) UpdateCurrentUser(ctx context.Context, req UpdateUserRequest) (*User, error) {
 	var updated User
-	if err := u.Client.Put(ctx, "/users/me", req, &updated); err != nil {
+	if err := u.Client.Put(ctx, apispec.PathCurrentUser, req, &updated); err != nil {
+		return nil, err
+	}
+	if err := u.Client.ReadBack(ctx, apispec.PathCurrentUser, &updated); err != nil {
 		return nil, err
 	}
 	return &updated, nil
@@ -89,26 +104,18 @@ func (u *UsersService) UpdateCurrentUser(ctx context.Context, req UpdateUserRequ
 
 // List retrieves users with optional search filtering and pagination.
 func (u *UsersService) List(ctx context.Context, opts ListUsersOptions) (*ListUsersResponse, error) {
-	endpoint := "/users"
+	endpoint := apispec.PathUsers
 	params := url.Values{}
 
 	if opts.Search != "" {
 		params.Set("search", opts.Search)
 	}
-	if opts.Offset >= 0 {
-		params.Set("offset", strconv.Itoa(opts.Offset))
-	}
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
-	}
 	if opts.ResponseType != "" {
 		params.Set("responseType", opts.ResponseType)
 	}
 
-	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
-
 	var resp ListUsersResponse
-	if err := u.Client.Get(ctx, fullURL, &resp); err != nil {
+	if err := getList(ctx, u.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Users); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -117,7 +124,10 @@ func (u *UsersService) List(ctx context.Context, opts ListUsersOptions) (*ListUs
 // Create adds a new user account.
 func (u *UsersService) Create(ctx context.Context, req CreateUserRequest) (*User, error) {
 	var created User
-	if err := u.Client.Post(ctx, "/users", req, &created); err != nil {
+	if err := u.Client.Post(ctx, apispec.PathUsers, req, &created); err != nil {
+		return nil, err
+	}
+	if err := u.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathUser, created.ID), &created); err != nil {
 		return nil, err
 	}
 	return &created, nil
@@ -125,11 +135,11 @@ func (u *UsersService) Create(ctx context.Context, req CreateUserRequest) (*User
 
 // GetByID retrieves a user by their ID.
 func (u *UsersService) GetByID(ctx context.Context, id, responseType string) (*User, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/users/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathUser, id)
 	params := url.Values{}
 	if responseType != "" {
 		params.Set("responseType", responseType)
@@ -146,36 +156,39 @@ func (u *UsersService) GetByID(ctx context.Context, id, responseType string) (*U
 
 // UpdateByID modifies an existing user by ID.
 func (u *UsersService) UpdateByID(ctx context.Context, id string, req UpdateUserRequest) (*User, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/users/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathUser, id)
 
 	var updated User
 	if err := u.Client.Put(ctx, endpoint, req, &updated); err != nil {
 		return nil, err
 	}
+	if err := u.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // DeleteByID removes a user by ID.
 func (u *UsersService) DeleteByID(ctx context.Context, id string) error {
-	if id == "" {
-		return fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return err
 	}
 
-	endpoint := fmt.Sprintf("/users/%s", id)
+	endpoint := fmt.Sprintf(apispec.PathUser, id)
 	return u.Client.Delete(ctx, endpoint, nil)
 }
 
 // GetAllowedPermissions returns permissions the current token can grant to a user.
 func (u *UsersService) GetAllowedPermissions(ctx context.Context, id string) ([]string, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/users/%s/allowedPermissions", id)
+	endpoint := fmt.Sprintf(apispec.PathUserPermissions, id)
 
 	var out struct {
 		Permissions []string `json:"permissions"`
@@ -188,37 +201,43 @@ func (u *UsersService) GetAllowedPermissions(ctx context.Context, id string) ([]
 
 // ActivateByID activates a user account.
 func (u *UsersService) ActivateByID(ctx context.Context, id string) (*User, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/users/%s/activate", id)
+	endpoint := fmt.Sprintf(apispec.PathUserActivate, id)
 
 	var updated User
 	if err := u.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := u.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathUser, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // DeactivateByID deactivates a user account.
 func (u *UsersService) DeactivateByID(ctx context.Context, id string) (*User, error) {
-	if id == "" {
-		return nil, fmt.Errorf("id is required")
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/users/%s/deactivate", id)
+	endpoint := fmt.Sprintf(apispec.PathUserDeactivate, id)
 
 	var updated User
 	if err := u.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
 		return nil, err
 	}
+	if err := u.Client.ReadBack(ctx, fmt.Sprintf(apispec.PathUser, id), &updated); err != nil {
+		return nil, err
+	}
 	return &updated, nil
 }
 
 // EnableTwoFactorAuth enables two-factor authentication for the current user.
 func (u *UsersService) EnableTwoFactorAuth(ctx context.Context) (*User, error) {
-	const endpoint = "/users/me/enable2FA"
+	const endpoint = apispec.PathCurrentUserEnable2FA
 
 	var updated User
 	if err := u.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
@@ -229,7 +248,7 @@ func (u *UsersService) EnableTwoFactorAuth(ctx context.Context) (*User, error) {
 
 // DisableTwoFactorAuth disables two-factor authentication for the current user.
 func (u *UsersService) DisableTwoFactorAuth(ctx context.Context) (*User, error) {
-	const endpoint = "/users/me/disable2FA"
+	const endpoint = apispec.PathCurrentUserDisable2FA
 
 	var updated User
 	if err := u.Client.Put(ctx, endpoint, struct{}{}, &updated); err != nil {
@@ -0,0 +1,191 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// WebhookEvent identifies a notification a webhook subscribes to.
+type WebhookEvent string
+
+// Webhook events.
+const (
+	WebhookEventCertificateExpiring WebhookEvent = "certificate.expiring"
+	WebhookEventCertificateRenewed  WebhookEvent = "certificate.renewed"
+	WebhookEventDomainValidated     WebhookEvent = "domain.validated"
+	WebhookEventTrafficThreshold    WebhookEvent = "traffic.threshold"
+	WebhookEventServiceUpdated      WebhookEvent = "service.updated"
+)
+
+// WebhooksService handles webhook and notification target operations.
+type WebhooksService struct {
+	Client *httpclient.Client
+}
+
+// WebhookThreshold configures when a traffic threshold event fires.
+type WebhookThreshold struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+	Period string  `json:"period,omitempty"`
+}
+
+// Webhook represents a notification endpoint in CacheFly.
+type Webhook struct {
+	ID        string            `json:"_id"`
+	UpdatedAt string            `json:"updateAt"`
+	CreatedAt string            `json:"createdAt"`
+	Name      string            `json:"name"`
+	URL       string            `json:"url"`
+	Events    []WebhookEvent    `json:"events"`
+	Services  []string          `json:"services,omitempty"`
+	Threshold *WebhookThreshold `json:"threshold,omitempty"`
+	Enabled   bool              `json:"enabled"`
+
+	// Secret signs the payloads sent to the webhook. The API returns it only
+	// when the webhook is created or its secret is rotated.
+	Secret string `json:"secret,omitempty"`
+
+	// Raw holds the response fields Webhook does not model
+	Raw json.RawMessage `json:"-"`
+}
+
+// ListWebhooksResponse contains paginated webhook results.
+type ListWebhooksResponse struct {
+	Meta     MetaInfo  `json:"meta"`
+	Webhooks []Webhook `json:"data"`
+}
+
+// ListWebhooksOptions specifies filters and pagination for listing webhooks.
+type ListWebhooksOptions struct {
+	Event  WebhookEvent
+	Offset int
+	Limit  int
+}
+
+// Apply sets the offset and limit of o from opts. It returns an
+// ErrUnsupportedListOption error, and leaves o unchanged, when opts set
+// another listing field.
+func (o *ListWebhooksOptions) Apply(opts ...ListOption) error {
+	return listFields{offset: &o.Offset, limit: &o.Limit}.apply(opts)
+}
+
+// CreateWebhookRequest is the payload for creating a webhook. When Secret
+// is empty the API generates one.
+type CreateWebhookRequest struct {
+	Name      string            `json:"name"`
+	URL       string            `json:"url"`
+	Events    []WebhookEvent    `json:"events"`
+	Services  []string          `json:"services,omitempty"`
+	Threshold *WebhookThreshold `json:"threshold,omitempty"`
+	Enabled   bool              `json:"enabled"`
+	Secret    string            `json:"secret,omitempty"`
+}
+
+// UpdateWebhookRequest is the payload for updating a webhook.
+type UpdateWebhookRequest struct {
+	Name      string            `json:"name,omitempty"`
+	URL       string            `json:"url,omitempty"`
+	Events    []WebhookEvent    `json:"events,omitempty"`
+	Services  []string          `json:"services,omitempty"`
+	Threshold *WebhookThreshold `json:"threshold,omitempty"`
+	Enabled   *bool             `json:"enabled,omitempty"`
+}
+
+// List retrieves webhooks with optional filters.
+func (s *WebhooksService) List(ctx context.Context, opts ListWebhooksOptions) (*ListWebhooksResponse, error) {
+	endpoint := apispec.PathWebhooks
+	params := url.Values{}
+	if opts.Event != "" {
+		params.Set("event", string(opts.Event))
+	}
+
+	var resp ListWebhooksResponse
+	if err := getList(ctx, s.Client, endpoint, params, opts.Offset, opts.Limit, &resp.Meta, &resp.Webhooks); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Create adds a new webhook. The returned webhook carries its secret, which
+// the API does not return again.
+func (s *WebhooksService) Create(ctx context.Context, req CreateWebhookRequest) (*Webhook, error) {
+	if req.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	if len(req.Events) == 0 {
+		return nil, fmt.Errorf("at least one event is required")
+	}
+	var created Webhook
+	if err := s.Client.Post(ctx, apispec.PathWebhooks, req, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetByID fetches a single webhook by its ID.
+func (s *WebhooksService) GetByID(ctx context.Context, id string) (*Webhook, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(apispec.PathWebhook, id)
+	var webhook Webhook
+	if err := s.Client.Get(ctx, endpoint, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// UpdateByID modifies an existing webhook.
+func (s *WebhooksService) UpdateByID(ctx context.Context, id string, req UpdateWebhookRequest) (*Webhook, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(apispec.PathWebhook, id)
+	var updated Webhook
+	if err := s.Client.Put(ctx, endpoint, req, &updated); err != nil {
+		return nil, err
+	}
+	if err := s.Client.ReadBack(ctx, endpoint, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes a webhook by ID.
+func (s *WebhooksService) Delete(ctx context.Context, id string) error {
+	if err := validate.ID("id", id); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf(apispec.PathWebhook, id)
+	return s.Client.Delete(ctx, endpoint, nil)
+}
+
+// RotateSecret replaces the signing secret of a webhook and returns the
+// webhook with its new secret. Payloads are signed with both secrets for a
+// grace period, so receivers can switch without dropping notifications.
+func (s *WebhooksService) RotateSecret(ctx context.Context, id string) (*Webhook, error) {
+	if err := validate.ID("id", id); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf(apispec.PathWebhookSecret, id)
+	var webhook Webhook
+	if err := s.Client.Post(ctx, endpoint, struct{}{}, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Test asks the API to send a test notification to the webhook.
+func (s *WebhooksService) Test(ctx context.Context, id string) error {
+	if err := validate.ID("id", id); err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf(apispec.PathWebhookTest, id)
+	return s.Client.Post(ctx, endpoint, struct{}{}, nil)
+}
@@ -0,0 +1,93 @@
+package v2_5
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader is the request header carrying the signature of a
+// webhook payload, in the form "t=<unix seconds>,v1=<hex HMAC-SHA256>". During
+// secret rotation it carries one v1 signature per active secret.
+const WebhookSignatureHeader = "X-CacheFly-Signature"
+
+// DefaultSignatureTolerance is the maximum age of a signed payload accepted
+// by VerifySignature when no tolerance is given.
+const DefaultSignatureTolerance = 5 * time.Minute
+
+// Signature verification errors.
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrSignatureExpired = errors.New("webhook signature timestamp outside tolerance")
+)
+
+// SignPayload returns the signature header value for payload signed with
+// secret at time t. It is useful to test webhook receivers.
+func SignPayload(payload []byte, secret string, t time.Time) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, sign(payload, secret, ts))
+}
+
+// VerifySignature checks the signature header of an incoming webhook
+// payload against secret. The payload must be the raw request body.
+//
+// The signature must have been made within tolerance of now, which protects
+// against replayed notifications; zero uses DefaultSignatureTolerance. It
+// returns ErrInvalidSignature when no signature matches and
+// ErrSignatureExpired when the timestamp is out of range.
+func VerifySignature(payload []byte, header, secret string, tolerance time.Duration) error {
+	if secret == "" {
+		return fmt.Errorf("secret is required")
+	}
+	if tolerance == 0 {
+		tolerance = DefaultSignatureTolerance
+	}
+
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if ts == "" || len(signatures) == 0 {
+		return fmt.Errorf("%w: malformed %s header", ErrInvalidSignature, WebhookSignatureHeader)
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp", ErrInvalidSignature)
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := []byte(sign(payload, secret, ts))
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// sign returns the hex HMAC-SHA256 of "<timestamp>.<payload>".
+func sign(payload []byte, secret, ts string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
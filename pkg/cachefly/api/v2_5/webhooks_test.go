@@ -0,0 +1,147 @@
+package v2_5
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+func TestWebhooksService_Create(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/webhooks" || r.Method != "POST" {
+			t.Errorf("Expected POST /api/2.5/webhooks, got %s %s", r.Method, r.URL.Path)
+		}
+		var req CreateWebhookRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Events) != 1 || req.Events[0] != WebhookEventCertificateExpiring {
+			t.Errorf("Expected certificate.expiring event, got %v", req.Events)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"_id":"wh-1","url":"https://hooks.example.com","events":["certificate.expiring"],"enabled":true,"secret":"whsec_123"}`))
+	}))
+	defer server.Close()
+
+	svc := &WebhooksService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL + "/api/2.5", AuthToken: "test-token"})}
+	result, err := svc.Create(context.Background(), CreateWebhookRequest{
+		Name:    "cert alerts",
+		URL:     "https://hooks.example.com",
+		Events:  []WebhookEvent{WebhookEventCertificateExpiring},
+		Enabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.ID != "wh-1" || result.Secret != "whsec_123" {
+		t.Errorf("Expected webhook wh-1 with secret, got %+v", result)
+	}
+}
+
+func TestWebhooksService_Create_RequiresEvents(t *testing.T) {
+	svc := &WebhooksService{}
+	if _, err := svc.Create(context.Background(), CreateWebhookRequest{URL: "https://hooks.example.com"}); err == nil {
+		t.Errorf("Expected error for missing events")
+	}
+}
+
+func TestWebhooksService_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("event") != "traffic.threshold" {
+			t.Errorf("Expected event filter, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"wh-1","threshold":{"metric":"bandwidth","value":500}}]}`))
+	}))
+	defer server.Close()
+
+	svc := &WebhooksService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	result, err := svc.List(context.Background(), ListWebhooksOptions{Event: WebhookEventTrafficThreshold})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Webhooks) != 1 || result.Webhooks[0].Threshold.Value != 500 {
+		t.Errorf("Expected 1 webhook with threshold, got %+v", result.Webhooks)
+	}
+}
+
+func TestWebhooksService_UpdateAndRotate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PUT /webhooks/wh-1":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["enabled"] != false {
+				t.Errorf("Expected enabled false to be sent, got %v", body)
+			}
+			w.Write([]byte(`{"_id":"wh-1","enabled":false}`))
+		case "POST /webhooks/wh-1/secret":
+			w.Write([]byte(`{"_id":"wh-1","secret":"whsec_new"}`))
+		case "DELETE /webhooks/wh-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	svc := &WebhooksService{Client: httpclient.New(httpclient.Config{BaseURL: server.URL})}
+	ctx := context.Background()
+
+	disabled := false
+	if _, err := svc.UpdateByID(ctx, "wh-1", UpdateWebhookRequest{Enabled: &disabled}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rotated, err := svc.RotateSecret(ctx, "wh-1")
+	if err != nil || rotated.Secret != "whsec_new" {
+		t.Errorf("Expected new secret, got %v, %v", rotated, err)
+	}
+	if err := svc.Delete(ctx, "wh-1"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"event":"certificate.expiring","certificateId":"cert-1"}`)
+	now := time.Now()
+
+	header := SignPayload(payload, "whsec_123", now)
+	if err := VerifySignature(payload, header, "whsec_123", 0); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+
+	if err := VerifySignature([]byte(`{"event":"tampered"}`), header, "whsec_123", 0); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for tampered payload, got %v", err)
+	}
+	if err := VerifySignature(payload, header, "other", 0); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for wrong secret, got %v", err)
+	}
+	if err := VerifySignature(payload, "garbage", "whsec_123", 0); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for malformed header, got %v", err)
+	}
+
+	old := SignPayload(payload, "whsec_123", now.Add(-time.Hour))
+	if err := VerifySignature(payload, old, "whsec_123", 0); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("Expected ErrSignatureExpired, got %v", err)
+	}
+}
+
+func TestVerifySignature_Rotation(t *testing.T) {
+	payload := []byte(`{}`)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	// During rotation the header carries a signature per secret
+	header := "t=" + ts + ",v1=" + sign(payload, "old-secret", ts) + ",v1=" + sign(payload, "new-secret", ts)
+	if err := VerifySignature(payload, header, "new-secret", 0); err != nil {
+		t.Errorf("Expected new secret to verify, got %v", err)
+	}
+	if err := VerifySignature(payload, header, "old-secret", 0); err != nil {
+		t.Errorf("Expected old secret to verify, got %v", err)
+	}
+}
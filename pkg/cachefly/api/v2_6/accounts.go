@@ -1 +1,51 @@
 package v2_6
+
+import api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+
+// AccountsService handles account-related API operations.
+type AccountsService = api.AccountsService
+
+// Account represents a CacheFly account with all configuration and metadata.
+type Account = api.Account
+
+// CreateChildAccountRequest contains the required fields for creating a child account.
+type CreateChildAccountRequest = api.CreateChildAccountRequest
+
+// ListAccountsResponse contains paginated account results.
+type ListAccountsResponse = api.ListAccountsResponse
+
+// ListAccountsOptions specifies filters and pagination for listing accounts.
+type ListAccountsOptions = api.ListAccountsOptions
+
+// UpdateAccountRequest contains fields for updating an existing account.
+type UpdateAccountRequest = api.UpdateAccountRequest
+
+// ChildAccountAuthResponse contains authentication token for child account access.
+type ChildAccountAuthResponse = api.ChildAccountAuthResponse
+
+// AccountLimits describes the capacity limits of an account.
+type AccountLimits = api.AccountLimits
+
+// APIRateLimit describes the number of API requests allowed per window.
+type APIRateLimit = api.APIRateLimit
+
+// LimitKind identifies a capacity limit of an account.
+type LimitKind = api.LimitKind
+
+// LimitExceededError is returned when an operation would exceed a limit.
+type LimitExceededError = api.LimitExceededError
+
+// AccountSecurityService handles account security settings.
+type AccountSecurityService = api.AccountSecurityService
+
+// AccountSecurity is the security configuration of an account.
+type AccountSecurity = api.AccountSecurity
+
+// TwoFactorSettings controls two-factor authentication for an account's users.
+type TwoFactorSettings = api.TwoFactorSettings
+
+// SAMLSettings describes the SAML identity provider of an account.
+type SAMLSettings = api.SAMLSettings
+
+// UpdateAccountSecurityRequest changes security settings.
+type UpdateAccountSecurityRequest = api.UpdateAccountSecurityRequest
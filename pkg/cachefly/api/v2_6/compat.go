@@ -0,0 +1,307 @@
+package v2_6
+
+import api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+
+// Shared types.
+type (
+	MetaInfo   = api.MetaInfo
+	ListOption = api.ListOption
+	SortOrder  = api.SortOrder
+)
+
+// Sort orders.
+const (
+	SortAscending  = api.SortAscending
+	SortDescending = api.SortDescending
+)
+
+// Services.
+type (
+	ServicesService         = api.ServicesService
+	Service                 = api.Service
+	CreateServiceRequest    = api.CreateServiceRequest
+	UpdateServiceRequest    = api.UpdateServiceRequest
+	UpdateServiceOptions    = api.UpdateServiceOptions
+	ListServicesResponse    = api.ListServicesResponse
+	ListOptions             = api.ListOptions
+	EnableAccessLogsRequest = api.EnableAccessLogsRequest
+	EnableOriginLogsRequest = api.EnableOriginLogsRequest
+	ServiceConfig           = api.ServiceConfig
+	ImportConfigResult      = api.ImportConfigResult
+	DeleteOptions           = api.DeleteOptions
+	DeletePlan              = api.DeletePlan
+	DeleteBlockedError      = api.DeleteBlockedError
+	EffectiveConfig         = api.EffectiveConfig
+	EffectiveSetting        = api.EffectiveSetting
+	SettingSource           = api.SettingSource
+)
+
+// Effective setting sources.
+const (
+	SourceService = api.SourceService
+	SourceAccount = api.SourceAccount
+	SourceOption  = api.SourceOption
+	SourceDefault = api.SourceDefault
+)
+
+// Service protection.
+type (
+	ServiceProtection          = api.ServiceProtection
+	ProtectionUnavailableError = api.ProtectionUnavailableError
+)
+
+// Service protection options.
+const (
+	OptionDeletionProtection = api.OptionDeletionProtection
+	OptionChangeTwoFactor    = api.OptionChangeTwoFactor
+)
+
+// Service domains.
+type (
+	ServiceDomainsService      = api.ServiceDomainsService
+	ServiceDomain              = api.ServiceDomain
+	ListServiceDomainsResponse = api.ListServiceDomainsResponse
+	ListServiceDomainsOptions  = api.ListServiceDomainsOptions
+	CreateServiceDomainRequest = api.CreateServiceDomainRequest
+	UpdateServiceDomainRequest = api.UpdateServiceDomainRequest
+	MoveOptions                = api.MoveOptions
+	DomainMove                 = api.DomainMove
+	MoveResult                 = api.MoveResult
+)
+
+// Service rules.
+type (
+	ServiceRulesService       = api.ServiceRulesService
+	ServiceRule               = api.ServiceRule
+	ListServiceRulesResponse  = api.ListServiceRulesResponse
+	ListServiceRulesOptions   = api.ListServiceRulesOptions
+	UpdateServiceRulesRequest = api.UpdateServiceRulesRequest
+)
+
+// Optional features.
+type (
+	FeatureUnavailableError = api.FeatureUnavailableError
+	FeatureSet              = api.FeatureSet
+	AccountCapabilities     = api.AccountCapabilities
+	ServiceFeatures         = api.ServiceFeatures
+)
+
+// Optional feature names.
+const (
+	FeatureReports       = api.FeatureReports
+	FeatureRawLogs       = api.FeatureRawLogs
+	FeatureScriptConfigs = api.FeatureScriptConfigs
+	FeatureFTP           = api.FeatureFTP
+)
+
+// Capabilities.
+const (
+	CapabilityImageOptimization = api.CapabilityImageOptimization
+	CapabilityWebSockets        = api.CapabilityWebSockets
+	CapabilityChinaDelivery     = api.CapabilityChinaDelivery
+	CapabilityScriptConfigs     = api.CapabilityScriptConfigs
+	CapabilityRawLogs           = api.CapabilityRawLogs
+	CapabilityReports           = api.CapabilityReports
+	CapabilityFTP               = api.CapabilityFTP
+)
+
+// Service options.
+type (
+	ServiceOptionsService         = api.ServiceOptionsService
+	ServiceOptions                = api.ServiceOptions
+	ServiceOptionsMetadata        = api.ServiceOptionsMetadata
+	OptionMetadata                = api.OptionMetadata
+	OptionProperty                = api.OptionProperty
+	EnumValue                     = api.EnumValue
+	BitField                      = api.BitField
+	PromoInfo                     = api.PromoInfo
+	ValidationError               = api.ValidationError
+	ServiceOptionsValidationError = api.ServiceOptionsValidationError
+	LegacyAPIKeyResponse          = api.LegacyAPIKeyResponse
+	ProtectServeKeyResponse       = api.ProtectServeKeyResponse
+	UpdateProtectServeRequest     = api.UpdateProtectServeRequest
+	FTPSettingsResponse           = api.FTPSettingsResponse
+	ApplyOptions                  = api.ApplyOptions
+	ApplyResult                   = api.ApplyResult
+	OptionChange                  = api.OptionChange
+	OptionsScope                  = api.OptionsScope
+	ServiceOptionValues           = api.ServiceOptionValues
+	OptionStability               = api.OptionStability
+	OptionEnum                    = api.OptionEnum
+	GetManyOptions                = api.GetManyOptions
+	ServiceOptionsBatchError      = api.ServiceOptionsBatchError
+	LegacyKeyRotationError        = api.LegacyKeyRotationError
+)
+
+// Option stabilities.
+const (
+	OptionStable = api.OptionStable
+	OptionBeta   = api.OptionBeta
+)
+
+// Options scopes.
+const (
+	OptionsScopeBasic           = api.OptionsScopeBasic
+	OptionsScopeFull            = api.OptionsScopeFull
+	OptionsScopeIncludeMetadata = api.OptionsScopeIncludeMetadata
+)
+
+// Referer rules.
+type (
+	ServiceOptionsRefererRulesService = api.ServiceOptionsRefererRulesService
+	RefererRule                       = api.RefererRule
+	ListRefererRulesOptions           = api.ListRefererRulesOptions
+	ListRefererRulesResponse          = api.ListRefererRulesResponse
+	CreateRefererRuleRequest          = api.CreateRefererRuleRequest
+	UpdateRefererRuleRequest          = api.UpdateRefererRuleRequest
+)
+
+// Image optimization.
+type (
+	ServiceImageOptimizationService = api.ServiceImageOptimizationService
+	CreateImageOptimizationOptions  = api.CreateImageOptimizationOptions
+)
+
+// Certificates.
+type (
+	CertificatesService      = api.CertificatesService
+	Certificate              = api.Certificate
+	ListCertificatesResponse = api.ListCertificatesResponse
+	ListCertificatesOptions  = api.ListCertificatesOptions
+	CreateCertificateRequest = api.CreateCertificateRequest
+	CertificateIssuer        = api.CertificateIssuer
+	CertificateRenewal       = api.CertificateRenewal
+	CertificateRenewalReport = api.CertificateRenewalReport
+	RenewalStatus            = api.RenewalStatus
+)
+
+// Origins.
+type (
+	OriginsService      = api.OriginsService
+	Origin              = api.Origin
+	ListOriginsResponse = api.ListOriginsResponse
+	ListOriginsOptions  = api.ListOriginsOptions
+	CreateOriginRequest = api.CreateOriginRequest
+	UpdateOriginRequest = api.UpdateOriginRequest
+)
+
+// Users.
+type (
+	UsersService      = api.UsersService
+	User              = api.User
+	ListUsersOptions  = api.ListUsersOptions
+	ListUsersResponse = api.ListUsersResponse
+	CreateUserRequest = api.CreateUserRequest
+	UpdateUserRequest = api.UpdateUserRequest
+)
+
+// Script configs.
+type (
+	ScriptConfigsService      = api.ScriptConfigsService
+	ScriptConfig              = api.ScriptConfig
+	ListScriptConfigsOptions  = api.ListScriptConfigsOptions
+	ListScriptConfigsResponse = api.ListScriptConfigsResponse
+	CreateScriptConfigRequest = api.CreateScriptConfigRequest
+	UpdateScriptConfigRequest = api.UpdateScriptConfigRequest
+)
+
+// TLS profiles.
+type (
+	TLSProfilesService      = api.TLSProfilesService
+	TLSProfile              = api.TLSProfile
+	ListTLSProfilesResponse = api.ListTLSProfilesResponse
+	ListTLSProfilesOptions  = api.ListTLSProfilesOptions
+)
+
+// TLS settings.
+type (
+	TLSSettingsService = api.TLSSettingsService
+	TLSSettings        = api.TLSSettings
+	HSTSSettings       = api.HSTSSettings
+)
+
+// Typed option values.
+type OptionValue = api.OptionValue
+
+// Image optimization options.
+type (
+	ImageOptimizationOptions      = api.ImageOptimizationOptions
+	ImageOptimizationSettings     = api.ImageOptimizationSettings
+	ImageOptimizationCapabilities = api.ImageOptimizationCapabilities
+	ImageFormat                   = api.ImageFormat
+	ImageResizePolicy             = api.ImageResizePolicy
+)
+
+// Purge.
+type (
+	PurgeService = api.PurgeService
+	PurgeRequest = api.PurgeRequest
+	PurgeOptions = api.PurgeOptions
+	PurgeChunk   = api.PurgeChunk
+	PurgeReport  = api.PurgeReport
+)
+
+// Logs.
+type (
+	LogsService         = api.LogsService
+	LogType             = api.LogType
+	DownloadLogsOptions = api.DownloadLogsOptions
+	LogEntry            = api.LogEntry
+	LogScanner          = api.LogScanner
+)
+
+// Webhooks.
+type (
+	WebhooksService      = api.WebhooksService
+	Webhook              = api.Webhook
+	WebhookEvent         = api.WebhookEvent
+	WebhookThreshold     = api.WebhookThreshold
+	ListWebhooksResponse = api.ListWebhooksResponse
+	ListWebhooksOptions  = api.ListWebhooksOptions
+	CreateWebhookRequest = api.CreateWebhookRequest
+	UpdateWebhookRequest = api.UpdateWebhookRequest
+)
+
+// API tokens.
+type (
+	TokensService      = api.TokensService
+	APIToken           = api.APIToken
+	ListTokensResponse = api.ListTokensResponse
+	ListTokensOptions  = api.ListTokensOptions
+	CreateTokenRequest = api.CreateTokenRequest
+)
+
+// Alerts.
+type (
+	AlertsService          = api.AlertsService
+	AlertRule              = api.AlertRule
+	AlertCondition         = api.AlertCondition
+	AlertChannel           = api.AlertChannel
+	AlertMetric            = api.AlertMetric
+	AlertUnit              = api.AlertUnit
+	AlertOperator          = api.AlertOperator
+	AlertChannelType       = api.AlertChannelType
+	ListAlertRulesResponse = api.ListAlertRulesResponse
+	ListAlertRulesOptions  = api.ListAlertRulesOptions
+	CreateAlertRuleRequest = api.CreateAlertRuleRequest
+	UpdateAlertRuleRequest = api.UpdateAlertRuleRequest
+)
+
+// Network.
+type (
+	NetworkService = api.NetworkService
+	POP            = api.POP
+)
+
+// Billing.
+type (
+	BillingService       = api.BillingService
+	Usage                = api.Usage
+	UsageOptions         = api.UsageOptions
+	ServiceUsage         = api.ServiceUsage
+	PlanLimits           = api.PlanLimits
+	Overage              = api.Overage
+	Invoice              = api.Invoice
+	ListInvoicesResponse = api.ListInvoicesResponse
+	ListInvoicesOptions  = api.ListInvoicesOptions
+)
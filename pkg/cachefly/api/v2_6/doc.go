@@ -0,0 +1,22 @@
+// Package v2_6 provides the CacheFly API v2.6 compatibility layer.
+//
+// API v2.6 keeps the v2.5 resource model, so this package re-exports the
+// v2_5 service and resource types as aliases. Code written against v2_5
+// types keeps compiling and values can be passed between both packages
+// without conversion.
+//
+// Select the API version on the main client:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithAPIVersion("2.6"),
+//	)
+//
+// Individual service groups can be routed to a different version while the
+// rest of the client stays on the default:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithServiceAPIVersion(cachefly.ServiceGroupCertificates, "2.6"),
+//	)
+package v2_6
@@ -0,0 +1,20 @@
+// Package apispec exports the names the CacheFly API uses as constants:
+// service option names and enum values, endpoint path templates, and the
+// error codes of SDK errors. Downstream code and tests should use these
+// instead of repeating strings such as "autoRedirect" or
+// "/services/%s/options/metadata".
+//
+// Option names and enum values in options_gen.go are generated by
+// cmd/optiongen from metadata.json, a snapshot of the options metadata
+// endpoint. To pick up new options, refresh the snapshot and regenerate:
+//
+//	curl -H "Authorization: Bearer $CACHEFLY_API_TOKEN" \
+//		https://api.cachefly.com/api/2.5/services/<id>/options/metadata > metadata.json
+//	go generate ./pkg/cachefly/apispec
+//
+// Path templates take their IDs as fmt verbs, in the order they appear:
+//
+//	endpoint := fmt.Sprintf(apispec.PathServiceDomain, serviceID, domainID)
+package apispec
+
+//go:generate go run ../../../cmd/optiongen -input metadata.json -constants -package apispec -out options_gen.go
@@ -0,0 +1,30 @@
+package apispec
+
+import "github.com/cachefly/cachefly-go-sdk/internal/errcode"
+
+// Error codes of SDK errors, as returned by validate.CodeOf and used as
+// message keys by the messages package.
+const (
+	// Validation
+	ErrorCodeRequired          = errcode.Required
+	ErrorCodeInvalidID         = errcode.InvalidID
+	ErrorCodeInvalidHostname   = errcode.InvalidHostname
+	ErrorCodeInvalidUniqueName = errcode.InvalidUniqueName
+	ErrorCodeInvalidCIDR       = errcode.InvalidCIDR
+	ErrorCodeInvalidPEM        = errcode.InvalidPEM
+	ErrorCodeInvalidEnum       = errcode.InvalidEnum
+	ErrorCodeOutOfRange        = errcode.OutOfRange
+
+	// Requests
+	ErrorCodeAPIError       = errcode.APIError
+	ErrorCodeAPIMaintenance = errcode.APIMaintenance
+	ErrorCodeRetryExhausted = errcode.RetryExhausted
+	ErrorCodeLimitExceeded  = errcode.LimitExceeded
+	ErrorCodeCircuitOpen    = errcode.CircuitOpen
+
+	// Environments
+	ErrorCodeEnvironmentMismatch = errcode.EnvironmentMismatch
+
+	// Features
+	ErrorCodeFeatureUnavailable = errcode.FeatureUnavailable
+)
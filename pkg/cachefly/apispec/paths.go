@@ -0,0 +1,126 @@
+package apispec
+
+// Endpoint path templates, relative to the versioned API base URL. IDs are
+// filled in with fmt.Sprintf in the order of the %s verbs.
+
+// Accounts.
+const (
+	PathAccounts                 = "/accounts"
+	PathAccount                  = "/accounts/%s"
+	PathAccountActivate          = "/accounts/%s/activate"
+	PathAccountDeactivate        = "/accounts/%s/deactivate"
+	PathAccountAuth              = "/accounts/%s/auth"
+	PathAccountSecurity          = "/accounts/%s/security"
+	PathAccountSecuritySAML      = "/accounts/%s/security/saml"
+	PathCurrentAccount           = "/accounts/me"
+	PathCurrentAccountLimits     = "/accounts/me/limits"
+	PathCurrentAccountFeatures   = "/accounts/me/features"
+	PathCurrentAccountEnable2FA  = "/accounts/me/enable2FA"
+	PathCurrentAccountDisable2FA = "/accounts/me/disable2FA"
+)
+
+// Services.
+const (
+	PathServices                = "/services"
+	PathService                 = "/services/%s"
+	PathServiceActivate         = "/services/%s/activate"
+	PathServiceFeatures         = "/services/%s/features"
+	PathServiceDeactivate       = "/services/%s/deactivate"
+	PathServiceAccessLogs       = "/services/%s/accessLogs"
+	PathServiceOriginLogs       = "/services/%s/originLogs"
+	PathServiceLogsDownload     = "/services/%s/logs/download"
+	PathServicePurge            = "/services/%s/purge"
+	PathServiceRules            = "/services/%s/rules"
+	PathServiceRulesSchema      = "/services/%s/rules/schema"
+	PathServiceDomains          = "/services/%s/domains"
+	PathServiceDomain           = "/services/%s/domains/%s"
+	PathServiceDomainValidation = "/services/%s/domains/%s/validationReady"
+)
+
+// Service options.
+const (
+	PathServiceOptions             = "/services/%s/options"
+	PathServiceOptionsMetadata     = "/services/%s/options/metadata"
+	PathServiceOptionsAPIKey       = "/services/%s/options/apikey"
+	PathServiceOptionsFTP          = "/services/%s/options/ftp"
+	PathServiceOptionsProtectServe = "/services/%s/options/protectserve"
+	PathServiceRefererRules        = "/services/%s/options/refererrules"
+	PathServiceRefererRule         = "/services/%s/options/refererrules/%s"
+)
+
+// Image optimization.
+const (
+	PathServiceImageOptimization           = "/services/%s/imageopt4"
+	PathServiceImageOptimizationActivate   = "/services/%s/imageopt4/activate"
+	PathServiceImageOptimizationDeactivate = "/services/%s/imageopt4/deactivate"
+	PathServiceImageOptimizationDefault    = "/services/%s/imageopt4/default"
+	PathServiceImageOptimizationDetails    = "/services/%s/imageopt4/details"
+	PathServiceImageOptimizationSchema     = "/services/%s/imageopt4/schema"
+	PathServiceImageOptimizationValidate   = "/services/%s/imageopt4/validate"
+)
+
+// Certificates, origins and TLS profiles.
+const (
+	PathCertificates = "/certificates"
+	PathCertificate  = "/certificates/%s"
+	PathOrigins      = "/origins"
+	PathOrigin       = "/origins/%s"
+	PathTLSProfiles  = "/tlsprofiles"
+	PathTLSProfile   = "/tlsprofiles/%s"
+)
+
+// Script configs.
+const (
+	PathScriptConfigs                = "/scriptConfigs"
+	PathScriptConfig                 = "/scriptConfigs/%s"
+	PathScriptConfigActivate         = "/scriptConfigs/%s/activate"
+	PathScriptConfigDeactivate       = "/scriptConfigs/%s/deactivate"
+	PathScriptConfigFile             = "/scriptConfigs/%s/file"
+	PathScriptConfigSchema           = "/scriptConfigs/%s/schema"
+	PathScriptConfigValue            = "/scriptConfigs/%s/value"
+	PathScriptConfigDefinitions      = "/scriptConfigDefinitions"
+	PathScriptConfigDefinition       = "/scriptConfigDefinitions/%s"
+	PathScriptConfigDefinitionsPromo = "/scriptConfigDefinitions/promo"
+)
+
+// Users.
+const (
+	PathUsers                 = "/users"
+	PathUser                  = "/users/%s"
+	PathUserActivate          = "/users/%s/activate"
+	PathUserDeactivate        = "/users/%s/deactivate"
+	PathUserPermissions       = "/users/%s/allowedPermissions"
+	PathCurrentUser           = "/users/me"
+	PathCurrentUserEnable2FA  = "/users/me/enable2FA"
+	PathCurrentUserDisable2FA = "/users/me/disable2FA"
+)
+
+// Tokens and webhooks.
+const (
+	PathTokens        = "/tokens"
+	PathToken         = "/tokens/%s"
+	PathWebhooks      = "/webhooks"
+	PathWebhook       = "/webhooks/%s"
+	PathWebhookSecret = "/webhooks/%s/secret"
+	PathWebhookTest   = "/webhooks/%s/test"
+)
+
+// Alerts.
+const (
+	PathAlerts = "/alerts"
+	PathAlert  = "/alerts/%s"
+)
+
+// Network.
+const (
+	PathNetworkPOPs = "/network/pops"
+)
+
+// Billing.
+const (
+	PathBillingUsage         = "/billing/usage"
+	PathBillingServicesUsage = "/billing/usage/services"
+	PathInvoices             = "/billing/invoices"
+	PathInvoice              = "/billing/invoices/%s"
+	PathInvoicePDF           = "/billing/invoices/%s/pdf"
+)
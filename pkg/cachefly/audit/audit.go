@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Policy maps option names to the values the options must have. A boolean
+// requirement on an option with the enabled/value structure is compared
+// with its enabled flag. An option a service does not have counts as
+// disabled, so it complies with a false requirement only.
+type Policy map[string]interface{}
+
+// Options configures Account.
+type Options struct {
+	// Status only audits services with this status, such as "ACTIVE";
+	// empty audits all services
+	Status string
+
+	// Concurrency is the number of services read at once; zero uses the
+	// default of ServiceOptions.GetManyWithOptions
+	Concurrency int
+
+	// Now returns the report time; nil uses time.Now
+	Now func() time.Time
+}
+
+// Report is the compliance of an account's services with a Policy.
+type Report struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Policy      Policy    `json:"policy"`
+
+	// Services lists the audited services, non-compliant ones first, then
+	// by name
+	Services []ServiceResult `json:"services"`
+
+	Compliant    int `json:"compliant"`
+	NonCompliant int `json:"nonCompliant"`
+
+	// Violations counts the non-compliant services per option
+	Violations map[string]int `json:"violations,omitempty"`
+
+	// Failed maps the IDs of services whose options could not be read to
+	// the error; they are not listed in Services
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// ServiceResult is the compliance of one service.
+type ServiceResult struct {
+	ServiceID  string      `json:"serviceId"`
+	Name       string      `json:"name"`
+	Compliant  bool        `json:"compliant"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// Violation is an option whose value differs from the policy.
+type Violation struct {
+	Option   string      `json:"option"`
+	Expected interface{} `json:"expected"`
+
+	// Actual is the value of the option; nil when Missing
+	Actual interface{} `json:"actual,omitempty"`
+
+	// Missing reports that the service does not have the option
+	Missing bool `json:"missing,omitempty"`
+}
+
+// Account audits the options of the account's services against policy.
+// Services whose options cannot be read are recorded in Report.Failed; an
+// error is returned when the services cannot be listed or ctx ends.
+func Account(ctx context.Context, client *cachefly.Client, policy Policy, opts Options) (*Report, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	var ids []string
+	names := make(map[string]string)
+	err := client.Services.ListEach(ctx, api.ListOptions{Status: opts.Status}, func(svc *api.Service) error {
+		ids = append(ids, svc.ID)
+		names[svc.ID] = svc.Name
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{GeneratedAt: now(), Policy: policy, Services: []ServiceResult{}}
+	options, err := client.ServiceOptions.GetManyWithOptions(ctx, ids, api.GetManyOptions{Concurrency: opts.Concurrency})
+	var batchErr *api.ServiceOptionsBatchError
+	switch {
+	case errors.As(err, &batchErr):
+		report.Failed = make(map[string]string, len(batchErr.Failed))
+		for id, err := range batchErr.Failed {
+			report.Failed[id] = err.Error()
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	for id, values := range options {
+		result := ServiceResult{ServiceID: id, Name: names[id], Violations: Check(values, policy)}
+		result.Compliant = len(result.Violations) == 0
+		if result.Compliant {
+			report.Compliant++
+		} else {
+			report.NonCompliant++
+		}
+		for _, v := range result.Violations {
+			if report.Violations == nil {
+				report.Violations = make(map[string]int)
+			}
+			report.Violations[v.Option]++
+		}
+		report.Services = append(report.Services, result)
+	}
+	sort.Slice(report.Services, func(i, j int) bool {
+		a, b := report.Services[i], report.Services[j]
+		if a.Compliant != b.Compliant {
+			return !a.Compliant
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ServiceID < b.ServiceID
+	})
+	return report, nil
+}
+
+// Check returns the options of a service that differ from policy, ordered
+// by option name.
+func Check(options api.ServiceOptions, policy Policy) []Violation {
+	names := make([]string, 0, len(policy))
+	for name := range policy {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []Violation
+	for _, name := range names {
+		expected := policy[name]
+		actual, ok := options[name]
+		if !ok || actual == nil {
+			if expected != false {
+				violations = append(violations, Violation{Option: name, Expected: expected, Missing: true})
+			}
+			continue
+		}
+		if !matches(expected, actual) {
+			violations = append(violations, Violation{Option: name, Expected: expected, Actual: actual})
+		}
+	}
+	return violations
+}
+
+// matches reports whether the option value actual satisfies expected.
+func matches(expected, actual interface{}) bool {
+	if want, ok := expected.(bool); ok {
+		if obj, ok := actual.(map[string]interface{}); ok {
+			if enabled, ok := obj["enabled"].(bool); ok {
+				return enabled == want
+			}
+		}
+	}
+	return reflect.DeepEqual(api.NormalizeValue(expected), api.NormalizeValue(actual))
+}
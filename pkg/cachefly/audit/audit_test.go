@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestAccount(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/services":               `{"data":[{"_id":"svc-1","name":"Shop"},{"_id":"svc-2","name":"Blog"},{"_id":"svc-3","name":"Gone"}]}`,
+		"/api/2.5/services/svc-1/options": `{"cors":{"enabled":true,"value":["*"]},"autoRedirect":true,"ttl":3600}`,
+		"/api/2.5/services/svc-2/options": `{"cors":{"enabled":false},"ftp":true}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	report, err := Account(context.Background(), client, Policy{
+		"cors":         true,
+		"autoRedirect": true,
+		"ftp":          false,
+		"ttl":          3600,
+	}, Options{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !report.GeneratedAt.Equal(now) || report.Compliant != 1 || report.NonCompliant != 1 {
+		t.Errorf("Expected 1 compliant and 1 non-compliant service, got %+v", report)
+	}
+	if len(report.Services) != 2 || report.Services[0].ServiceID != "svc-2" || !report.Services[1].Compliant {
+		t.Fatalf("Expected svc-2 first and svc-1 compliant, got %+v", report.Services)
+	}
+
+	violations := report.Services[0].Violations
+	expected := []Violation{
+		{Option: "autoRedirect", Expected: true, Missing: true},
+		{Option: "cors", Expected: true, Actual: map[string]interface{}{"enabled": false}},
+		{Option: "ftp", Expected: false, Actual: true},
+		{Option: "ttl", Expected: 3600, Missing: true},
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %+v", len(expected), violations)
+	}
+	for i, e := range expected {
+		v := violations[i]
+		if v.Option != e.Option || v.Missing != e.Missing || (e.Missing && v.Actual != nil) {
+			t.Errorf("Violation %d: expected %+v, got %+v", i, e, v)
+		}
+	}
+	if report.Violations["cors"] != 1 || len(report.Violations) != 4 {
+		t.Errorf("Expected one violation per option, got %v", report.Violations)
+	}
+	if _, ok := report.Failed["svc-3"]; !ok || len(report.Failed) != 1 {
+		t.Errorf("Expected svc-3 to fail, got %v", report.Failed)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  api.ServiceOptions
+		policy   Policy
+		violates bool
+	}{
+		{"bool", api.ServiceOptions{"autoRedirect": true}, Policy{"autoRedirect": true}, false},
+		{"enabled flag", api.ServiceOptions{"cors": map[string]interface{}{"enabled": true}}, Policy{"cors": true}, false},
+		{"number types", api.ServiceOptions{"ttl": 60.0}, Policy{"ttl": 60}, false},
+		{"different number", api.ServiceOptions{"ttl": 60.0}, Policy{"ttl": 120}, true},
+		{"missing disabled", api.ServiceOptions{}, Policy{"ftp": false}, false},
+		{"missing required", api.ServiceOptions{}, Policy{"cors": true}, true},
+		{"structured", api.ServiceOptions{"mimeTypes": []interface{}{"a", "b"}}, Policy{"mimeTypes": []string{"a", "b"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Check(tt.options, tt.policy)
+			if (len(got) > 0) != tt.violates {
+				t.Errorf("Expected violation %v, got %+v", tt.violates, got)
+			}
+		})
+	}
+}
+
+func TestLegacyKeys(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/services":                      `{"data":[{"_id":"svc-1","name":"Shop"},{"_id":"svc-2","name":"Blog"},{"_id":"svc-3","name":"Docs"}]}`,
+		"/api/2.5/services/svc-1/options/apikey": `{"apiKey":"abcdef123456","createdAt":"2019-04-01T00:00:00Z"}`,
+		"/api/2.5/services/svc-2/options/apikey": `{"apiKey":"xyz"}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	inventory, err := LegacyKeys(context.Background(), client, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if inventory.Services != 3 || len(inventory.Keys) != 2 || len(inventory.Failed) != 0 {
+		t.Fatalf("Expected 2 keys among 3 services, got %+v", inventory)
+	}
+	blog, shop := inventory.Keys[0], inventory.Keys[1]
+	if blog.ServiceID != "svc-2" || blog.Prefix != "" || blog.CreatedAt != "" {
+		t.Errorf("Expected the Blog key first and masked entirely, got %+v", blog)
+	}
+	if shop.Prefix != "abcdef" || shop.CreatedAt != "2019-04-01T00:00:00Z" {
+		t.Errorf("Expected a truncated prefix and the creation date, got %+v", shop)
+	}
+}
+
+func TestKeyPrefix(t *testing.T) {
+	for key, want := range map[string]string{"": "", "xyz": "", "abcdef": "", "abcdefghijk": "", "abcdefghijkl": "abcdef"} {
+		if got := keyPrefix(key); got != want {
+			t.Errorf("keyPrefix(%q) = %q, expected %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Package audit checks the options of every service in an account against
+// a baseline policy.
+//
+// A Policy maps option names to the values they must have. Account reads
+// the options of all services and reports, per service, the options that
+// differ from the policy:
+//
+//	report, err := audit.Account(ctx, client, audit.Policy{
+//		"cors":         true,
+//		"autoRedirect": true,
+//		"ftp":          false,
+//	}, audit.Options{Status: "ACTIVE"})
+//	if err != nil {
+//		return err
+//	}
+//	for _, svc := range report.Services {
+//		for _, v := range svc.Violations {
+//			fmt.Printf("%s %s: expected %v, got %v\n", svc.Name, v.Option, v.Expected, v.Actual)
+//		}
+//	}
+//
+// Check compares options that were already read without making any
+// request.
+//
+// LegacyKeys lists the services that still have a legacy API key, so the
+// keys can be retired:
+//
+//	inventory, err := audit.LegacyKeys(ctx, client, audit.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	for _, key := range inventory.Keys {
+//		fmt.Printf("%s: %s… created %s\n", key.Name, key.Prefix, key.CreatedAt)
+//	}
+package audit
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/jobs"
+)
+
+// KeyPrefixLength is the number of characters of a legacy API key kept in
+// LegacyKey.Prefix. Keys shorter than twice as long are masked entirely, so
+// the prefix never shows more than half of a key.
+const KeyPrefixLength = 6
+
+// LegacyKeyInventory lists the services of an account that still have a
+// legacy API key.
+type LegacyKeyInventory struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Services is the number of services checked
+	Services int `json:"services"`
+
+	// Keys lists the services with a legacy key, by service name
+	Keys []LegacyKey `json:"keys"`
+
+	// Failed maps the IDs of services whose key could not be read to the
+	// error
+	Failed map[string]string `json:"failed,omitempty"`
+}
+
+// LegacyKey is the legacy API key of a service. Only a prefix of the key
+// is kept, enough to recognize it without exposing it.
+type LegacyKey struct {
+	ServiceID string `json:"serviceId"`
+	Name      string `json:"name"`
+
+	// Prefix is the first KeyPrefixLength characters of the key, or empty
+	// when the key is too short to show any of it
+	Prefix string `json:"prefix"`
+
+	// CreatedAt is when the key was generated; empty when the API does not
+	// report it
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// LegacyKeys reads the legacy API key of every service of the account and
+// lists the services that have one, so the keys can be retired. Only
+// opts.Status and opts.Concurrency are used. Services whose key cannot be
+// read are recorded in LegacyKeyInventory.Failed; an error is returned
+// when the services cannot be listed or ctx ends.
+func LegacyKeys(ctx context.Context, client *cachefly.Client, opts Options) (*LegacyKeyInventory, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	var services []api.Service
+	err := client.Services.ListEach(ctx, api.ListOptions{Status: opts.Status}, func(svc *api.Service) error {
+		services = append(services, *svc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*api.LegacyAPIKeyResponse, len(services))
+	queue := jobs.New(jobs.Config{Workers: concurrency})
+	queue.Start(ctx)
+	handles := make([]*jobs.Handle, len(services))
+	for i, svc := range services {
+		handles[i], _ = queue.Enqueue(func(ctx context.Context) error {
+			key, err := client.ServiceOptions.GetLegacyAPIKey(ctx, svc.ID)
+			var apiErr *cachefly.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			keys[i] = key
+			return err
+		}, jobs.Options{Name: "get-legacy-key"})
+	}
+	queue.Close()
+
+	inventory := &LegacyKeyInventory{GeneratedAt: now(), Services: len(services), Keys: []LegacyKey{}}
+	for i, svc := range services {
+		if err := handles[i].Err(); err != nil {
+			if inventory.Failed == nil {
+				inventory.Failed = make(map[string]string)
+			}
+			inventory.Failed[svc.ID] = err.Error()
+			continue
+		}
+		if keys[i] == nil || keys[i].APIKey == "" {
+			continue
+		}
+		inventory.Keys = append(inventory.Keys, LegacyKey{
+			ServiceID: svc.ID,
+			Name:      svc.Name,
+			Prefix:    keyPrefix(keys[i].APIKey),
+			CreatedAt: keys[i].CreatedAt,
+		})
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(inventory.Keys, func(i, j int) bool {
+		a, b := inventory.Keys[i], inventory.Keys[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ServiceID < b.ServiceID
+	})
+	return inventory, nil
+}
+
+// keyPrefix returns the first KeyPrefixLength characters of key, or "" when
+// they would be more than half of it.
+func keyPrefix(key string) string {
+	if len(key) < 2*KeyPrefixLength {
+		return ""
+	}
+	return key[:KeyPrefixLength]
+}
@@ -0,0 +1,28 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// AuthScheme authenticates each request attempt, see WithAuthScheme.
+// Implementations must be safe for concurrent use.
+type AuthScheme = httpclient.AuthScheme
+
+// BearerAuth sends the token in an "Authorization: Bearer" header. It is
+// the default scheme.
+type BearerAuth = httpclient.BearerAuth
+
+// HMACAuth signs requests with HMAC-SHA256 over the method, path and
+// query, date and body hash, see WithHMACAuth.
+type HMACAuth = httpclient.HMACAuth
+
+// Headers set by HMACAuth.
+const (
+	HeaderHMACDate          = httpclient.HeaderHMACDate
+	HeaderHMACContentSHA256 = httpclient.HeaderHMACContentSHA256
+)
+
+// HMACSignature returns the signature HMACAuth computes for a request with
+// the given X-CF-Date and X-CF-Content-SHA256 values, for verifying signed
+// requests.
+func HMACSignature(secret []byte, method, requestURI, date, contentHash string) string {
+	return httpclient.HMACSignature(secret, method, requestURI, date, contentHash)
+}
@@ -0,0 +1,175 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/reconcile"
+)
+
+// BootstrapSpec describes a child account to provision.
+type BootstrapSpec struct {
+	// Account is the child account and its owner
+	Account api.CreateChildAccountRequest
+
+	// Require2FA enables two-factor authentication on the child account
+	Require2FA bool
+
+	// TwoFactorGracePeriod is the number of days users have to enroll in 2FA.
+	// Zero keeps the account default.
+	TwoFactorGracePeriod int
+
+	// Service, when set, is created in the child account as a template service
+	Service *reconcile.ServiceSpec
+
+	// Users are created in the child account after the template service
+	Users []UserSpec
+
+	// Tokens are API tokens created in the child account after the users
+	Tokens []api.CreateTokenRequest
+}
+
+// UserSpec describes an initial user of the child account.
+type UserSpec struct {
+	api.CreateUserRequest
+
+	// GrantTemplateService adds the template service to the user's services
+	GrantTemplateService bool
+}
+
+// Result reports what ChildAccount provisioned.
+type Result struct {
+	// Account is the created child account
+	Account *api.Account
+
+	// Client is authenticated as the child account and otherwise configured
+	// like the parent client, see cachefly.Client.Derive. Its token is
+	// obtained through the parent client and refreshed automatically before
+	// it expires.
+	Client *cachefly.Client
+
+	// Service is the reconcile result of the template service, if any
+	Service *reconcile.Result
+
+	// Users are the created users
+	Users []api.User
+
+	// Tokens are the created tokens, carrying the secret values the API
+	// returns only once
+	Tokens []api.APIToken
+
+	// Steps lists every completed step in order
+	Steps []string
+}
+
+// ChildAccount creates and configures a child account using the parent
+// account's client.
+//
+// Steps run in order: create account, enforce 2FA, create the template
+// service, create users, create tokens. On failure the partial Result is returned together
+// with an error naming the step that failed; completed steps are not undone.
+func ChildAccount(ctx context.Context, parent *cachefly.Client, spec BootstrapSpec) (*Result, error) {
+	if parent == nil {
+		return nil, fmt.Errorf("parent client is required")
+	}
+
+	result := &Result{}
+
+	account, err := parent.Accounts.CreateChildAccount(ctx, spec.Account)
+	if err != nil {
+		return result, fmt.Errorf("failed to create child account: %w", err)
+	}
+	result.Account = account
+	result.Steps = append(result.Steps, "created account "+account.ID)
+
+	result.Client = childClient(parent, account.ID)
+
+	if spec.Require2FA {
+		if err := enforce2FA(ctx, result.Client, spec.TwoFactorGracePeriod); err != nil {
+			return result, err
+		}
+		result.Steps = append(result.Steps, "enabled two-factor authentication")
+	}
+
+	if spec.Service != nil {
+		svc, err := reconcile.Service(ctx, result.Client, *spec.Service)
+		if err != nil {
+			return result, fmt.Errorf("failed to create template service: %w", err)
+		}
+		result.Service = &svc
+		result.Steps = append(result.Steps, "created service "+spec.Service.UniqueName)
+	}
+
+	for _, u := range spec.Users {
+		req := u.CreateUserRequest
+		if u.GrantTemplateService && result.Service != nil {
+			req.Services = append(append([]string(nil), req.Services...), result.Service.ServiceID)
+		}
+		user, err := result.Client.Users.Create(ctx, req)
+		if err != nil {
+			return result, fmt.Errorf("failed to create user %s: %w", req.Username, err)
+		}
+		result.Users = append(result.Users, *user)
+		result.Steps = append(result.Steps, "created user "+req.Username)
+	}
+
+	for _, req := range spec.Tokens {
+		token, err := result.Client.Tokens.Create(ctx, req)
+		if err != nil {
+			return result, fmt.Errorf("failed to create token %s: %w", req.Name, err)
+		}
+		result.Tokens = append(result.Tokens, *token)
+		result.Steps = append(result.Steps, "created token "+req.Name)
+	}
+
+	return result, nil
+}
+
+// childClient returns a client for the child account, configured like
+// parent, whose bearer token is issued by the parent account. As it
+// changes the credentials, Derive leaves out the parent's response cache.
+func childClient(parent *cachefly.Client, accountID string) *cachefly.Client {
+	return parent.Derive(
+		cachefly.WithCredentials(cachefly.RefreshingToken(cachefly.ChildAccountTokenRefresh(parent, accountID))),
+		cachefly.WithAuthScheme(nil),
+		func(c *cachefly.ClientConfig) {
+			if c.Scheduler != nil {
+				c.Account = accountID
+			}
+		},
+	)
+}
+
+// enforce2FA enables two-factor authentication on the child account and sets
+// the enrollment grace period.
+func enforce2FA(ctx context.Context, client *cachefly.Client, gracePeriod int) error {
+	account, err := client.Accounts.Enable2FAForCurrentAccount(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	if gracePeriod <= 0 || account.TwoFactorAuthGracePeriod == gracePeriod {
+		return nil
+	}
+
+	// UpdateAccountRequest sends every field, so carry over the current values
+	req := api.UpdateAccountRequest{
+		CompanyName:              account.CompanyName,
+		Website:                  account.Website,
+		Address1:                 account.Address1,
+		Address2:                 account.Address2,
+		City:                     account.City,
+		Country:                  account.Country,
+		State:                    account.State,
+		Phone:                    account.Phone,
+		Email:                    account.Email,
+		TwoFactorAuthGracePeriod: gracePeriod,
+		SAMLRequired:             account.SamlRequired,
+		DefaultDeliveryRegion:    account.DefaultDeliveryRegion,
+	}
+	if _, err := client.Accounts.UpdateCurrentAccount(ctx, req); err != nil {
+		return fmt.Errorf("failed to set two-factor grace period: %w", err)
+	}
+	return nil
+}
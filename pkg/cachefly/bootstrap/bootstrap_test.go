@@ -0,0 +1,169 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/reconcile"
+)
+
+func TestChildAccount(t *testing.T) {
+	var gracePeriod int
+	var userServices []string
+	var tokenScopes []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		auth := r.Header.Get("Authorization")
+		if ua := r.Header.Get("User-Agent"); !strings.HasSuffix(ua, " onboarding/1.0") {
+			t.Errorf("Expected the parent's User-Agent for %s %s, got %s", r.Method, r.URL.Path, ua)
+		}
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/accounts":
+			w.Write([]byte(`{"_id":"acc-child","companyName":"Example Inc","isChild":true}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/accounts/acc-child/auth":
+			if auth != "Bearer parent-token" {
+				t.Errorf("Expected parent token for child auth, got %s", auth)
+			}
+			w.Write([]byte(`{"token":"child-token","expiresAt":"2099-01-01T00:00:00Z"}`))
+		default:
+			if auth != "Bearer child-token" {
+				t.Errorf("Expected child token for %s %s, got %s", r.Method, r.URL.Path, auth)
+			}
+			switch {
+			case r.Method == "PUT" && r.URL.Path == "/api/2.5/accounts/me/enable2FA":
+				w.Write([]byte(`{"_id":"acc-child","companyName":"Example Inc","twoFactorAuthEnabled":true}`))
+			case r.Method == "PUT" && r.URL.Path == "/api/2.5/accounts/me":
+				var req api.UpdateAccountRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				if req.CompanyName != "Example Inc" {
+					t.Errorf("Expected company name to be preserved, got %s", req.CompanyName)
+				}
+				gracePeriod = req.TwoFactorAuthGracePeriod
+				w.Write([]byte(`{"_id":"acc-child"}`))
+			case r.Method == "GET" && r.URL.Path == "/api/2.5/services":
+				w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+			case r.URL.Path == "/api/2.5/services" || r.URL.Path == "/api/2.5/services/svc-1":
+				w.Write([]byte(`{"_id":"svc-1","uniqueName":"example-cdn"}`))
+			case r.Method == "POST" && r.URL.Path == "/api/2.5/users":
+				var req api.CreateUserRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				userServices = req.Services
+				w.Write([]byte(`{"_id":"usr-1","username":"deploy"}`))
+			case r.Method == "POST" && r.URL.Path == "/api/2.5/tokens":
+				var req api.CreateTokenRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				tokenScopes = req.Scopes
+				w.Write([]byte(`{"_id":"tok-1","name":"ci","token":"secret-value"}`))
+			default:
+				t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}
+	}))
+	defer server.Close()
+
+	parent := cachefly.NewClient(
+		cachefly.WithToken("parent-token"),
+		cachefly.WithBaseURL(server.URL+"/api/2.5"),
+		cachefly.WithUserAgentSuffix("onboarding/1.0"),
+		cachefly.WithResponseCache(cachefly.NewResponseCache(time.Minute)),
+	)
+
+	result, err := ChildAccount(context.Background(), parent, BootstrapSpec{
+		Account:              api.CreateChildAccountRequest{CompanyName: "Example Inc", Username: "admin", Password: "secret", FullName: "Admin", Email: "admin@example.com"},
+		Require2FA:           true,
+		TwoFactorGracePeriod: 7,
+		Service:              &reconcile.ServiceSpec{Name: "Example CDN", UniqueName: "example-cdn"},
+		Users: []UserSpec{{
+			CreateUserRequest:    api.CreateUserRequest{Username: "deploy"},
+			GrantTemplateService: true,
+		}},
+		Tokens: []api.CreateTokenRequest{{Name: "ci", Scopes: []string{api.TokenScopePurge}}},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Account.ID != "acc-child" {
+		t.Errorf("Expected account acc-child, got %s", result.Account.ID)
+	}
+	if gracePeriod != 7 {
+		t.Errorf("Expected grace period 7, got %d", gracePeriod)
+	}
+	if len(userServices) != 1 || userServices[0] != "svc-1" {
+		t.Errorf("Expected user to be granted svc-1, got %v", userServices)
+	}
+	if len(result.Users) != 1 || len(result.Steps) != 5 {
+		t.Errorf("Expected 1 user and 5 steps, got %d users and steps %v", len(result.Users), result.Steps)
+	}
+	if len(result.Tokens) != 1 || result.Tokens[0].Token != "secret-value" || len(tokenScopes) != 1 {
+		t.Errorf("Expected the created token with its value, got %+v and scopes %v", result.Tokens, tokenScopes)
+	}
+}
+
+func TestChildAccount_ReturnsPartialResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/2.5/accounts":
+			w.Write([]byte(`{"_id":"acc-child"}`))
+		case "/api/2.5/accounts/acc-child/auth":
+			w.Write([]byte(`{"token":"child-token"}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"forbidden"}`))
+		}
+	}))
+	defer server.Close()
+
+	parent := cachefly.NewClient(cachefly.WithToken("parent-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	result, err := ChildAccount(context.Background(), parent, BootstrapSpec{
+		Account:    api.CreateChildAccountRequest{CompanyName: "Example Inc", Username: "admin", Password: "secret", FullName: "Admin", Email: "admin@example.com"},
+		Require2FA: true,
+	})
+
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if result.Account == nil || len(result.Steps) != 1 {
+		t.Errorf("Expected partial result with the created account, got %+v", result)
+	}
+}
+
+func TestChildAccount_DryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	var skipped []cachefly.DryRunRequest
+	parent := cachefly.NewClient(
+		cachefly.WithToken("parent-token"),
+		cachefly.WithBaseURL(server.URL+"/api/2.5"),
+		cachefly.WithDryRun(true),
+		cachefly.WithDryRunLogger(func(req cachefly.DryRunRequest) { skipped = append(skipped, req) }),
+	)
+
+	result, err := ChildAccount(context.Background(), parent, BootstrapSpec{
+		Account:              api.CreateChildAccountRequest{CompanyName: "Example Inc", Username: "admin", Password: "secret", FullName: "Admin", Email: "admin@example.com"},
+		Require2FA:           true,
+		TwoFactorGracePeriod: 7,
+		Users:                []UserSpec{{CreateUserRequest: api.CreateUserRequest{Username: "deploy"}}},
+		Tokens:               []api.CreateTokenRequest{{Name: "ci", Scopes: []string{api.TokenScopePurge}}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Steps) != 4 || len(skipped) != 5 {
+		t.Errorf("Expected every write to be skipped, got steps %v and %d skipped", result.Steps, len(skipped))
+	}
+}
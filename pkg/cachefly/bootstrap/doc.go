@@ -0,0 +1,30 @@
+// Package bootstrap provisions new CacheFly child accounts in a single call.
+//
+// ChildAccount replaces the manual onboarding runbook: it creates the child
+// account, enforces two-factor authentication, creates a template service,
+// the initial users and API tokens, and returns a client authenticated as the
+// new account:
+//
+//	result, err := bootstrap.ChildAccount(ctx, parent, bootstrap.BootstrapSpec{
+//		Account: api.CreateChildAccountRequest{
+//			CompanyName: "Example Inc",
+//			Username:    "example-admin",
+//			Password:    password,
+//			FullName:    "Example Admin",
+//			Email:       "admin@example.com",
+//		},
+//		Require2FA: true,
+//		Service: &reconcile.ServiceSpec{
+//			Name:       "Example CDN",
+//			UniqueName: "example-cdn",
+//		},
+//		Users: []bootstrap.UserSpec{{
+//			CreateUserRequest:    api.CreateUserRequest{Username: "deploy", ...},
+//			GrantTemplateService: true,
+//		}},
+//		Tokens: []api.CreateTokenRequest{{Name: "ci", Scopes: []string{api.TokenScopePurge}}},
+//	})
+//
+// A failed bootstrap returns the partial Result so callers can see which
+// steps completed and clean up or resume.
+package bootstrap
@@ -0,0 +1,27 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// ErrCircuitOpen is matched by errors.Is for requests failed fast because
+// the circuit breaker is open. Use errors.As with *CircuitOpenError to read
+// when the breaker lets requests through again.
+var ErrCircuitOpen = httpclient.ErrCircuitOpen
+
+// CircuitOpenError is returned instead of sending a request while the
+// circuit breaker is open.
+type CircuitOpenError = httpclient.CircuitOpenError
+
+// CircuitBreaker stops sending requests after consecutive network errors,
+// timeouts and 5xx responses, and lets a trial request through after a
+// cooldown. WithCircuitBreaker sets one up for a client.
+type CircuitBreaker = httpclient.CircuitBreaker
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState = httpclient.CircuitState
+
+// Circuit breaker states.
+const (
+	CircuitClosed   = httpclient.CircuitClosed
+	CircuitOpen     = httpclient.CircuitOpen
+	CircuitHalfOpen = httpclient.CircuitHalfOpen
+)
@@ -0,0 +1,60 @@
+package cachefly
+
+import (
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// ResponseCache is an opt-in client-side cache for GET responses, keyed by
+// URL. Fresh entries are served without a request; stale entries are
+// revalidated with ETag/Last-Modified when the API provided them. Writes
+// through the client invalidate affected entries, and Invalidate and Purge
+// drop entries manually.
+type ResponseCache = httpclient.ResponseCache
+
+// NewResponseCache returns a cache whose entries stay fresh for ttl. When
+// patterns are given, only matching GET endpoints are cached, using the
+// pattern syntax of WithEndpointPolicy:
+//
+//	cache := cachefly.NewResponseCache(5*time.Minute,
+//		"/services/*/options/metadata",
+//		"/accounts/me",
+//		"/services",
+//	)
+func NewResponseCache(ttl time.Duration, patterns ...string) *ResponseCache {
+	return httpclient.NewResponseCache(ttl, patterns...)
+}
+
+// CacheStore persists the entries of a ResponseCache. Implement it on top
+// of Redis, memcached or a shared file system to let horizontally scaled
+// workers share cached responses; freshness is computed from the time an
+// entry was stored, so the TTL holds across workers. Store errors are
+// treated as cache misses.
+type CacheStore = httpclient.CacheStore
+
+// CacheEntry is a cached response, as kept in a CacheStore. It encodes to
+// JSON for stores that serialize entries.
+type CacheEntry = httpclient.CacheEntry
+
+// MemoryCacheStore is the in-memory CacheStore used by NewResponseCache.
+type MemoryCacheStore = httpclient.MemoryCacheStore
+
+// NewMemoryCacheStore returns an empty in-memory CacheStore.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return httpclient.NewMemoryCacheStore()
+}
+
+// NewResponseCacheWithStore returns a cache whose entries stay fresh for
+// ttl and are kept in store. Patterns work as for NewResponseCache.
+//
+// Example:
+//
+//	cache := cachefly.NewResponseCacheWithStore(redisStore{rdb}, time.Minute)
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithResponseCache(cache),
+//	)
+func NewResponseCacheWithStore(store CacheStore, ttl time.Duration, patterns ...string) *ResponseCache {
+	return httpclient.NewResponseCacheWithStore(store, ttl, patterns...)
+}
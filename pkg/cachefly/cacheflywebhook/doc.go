@@ -0,0 +1,21 @@
+// Package cacheflywebhook receives CacheFly webhook notifications.
+//
+// NewHandler returns an http.Handler that authenticates each delivery with
+// the webhook secret, decodes it into an Event and passes it to a callback,
+// so receivers never hand-parse JSON or signatures:
+//
+//	handler := cacheflywebhook.NewHandler(os.Getenv("CACHEFLY_WEBHOOK_SECRET"), func(event cacheflywebhook.Event) error {
+//		switch event.Type {
+//		case cacheflywebhook.EventCertificateExpiring:
+//			data, err := event.CertificateExpiring()
+//			if err != nil {
+//				return err
+//			}
+//			log.Printf("certificate %s expires in %d days", data.CertificateID, data.DaysRemaining)
+//		}
+//		return nil
+//	})
+//	http.Handle("/hooks/cachefly", handler)
+//
+// A callback error answers 500, so CacheFly delivers the event again.
+package cacheflywebhook
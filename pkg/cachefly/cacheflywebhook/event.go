@@ -0,0 +1,115 @@
+package cacheflywebhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Event types, matching the events webhooks subscribe to.
+const (
+	EventCertificateExpiring = api.WebhookEventCertificateExpiring
+	EventCertificateRenewed  = api.WebhookEventCertificateRenewed
+	EventDomainValidated     = api.WebhookEventDomainValidated
+	EventTrafficThreshold    = api.WebhookEventTrafficThreshold
+	EventServiceUpdated      = api.WebhookEventServiceUpdated
+)
+
+// Event is a webhook delivery. Data holds the event-specific payload, which
+// the typed accessors decode.
+type Event struct {
+	ID        string           `json:"id"`
+	Type      api.WebhookEvent `json:"event"`
+	CreatedAt time.Time        `json:"createdAt"`
+	AccountID string           `json:"accountId"`
+	WebhookID string           `json:"webhookId"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+// CertificateExpiringData is the payload of certificate.expiring events.
+type CertificateExpiringData struct {
+	CertificateID     string    `json:"certificateId"`
+	SubjectCommonName string    `json:"subjectCommonName"`
+	NotAfter          time.Time `json:"notAfter"`
+	DaysRemaining     int       `json:"daysRemaining"`
+	Domains           []string  `json:"domains"`
+}
+
+// CertificateRenewedData is the payload of certificate.renewed events.
+type CertificateRenewedData struct {
+	OldCertificateID string    `json:"oldCertificateId"`
+	NewCertificateID string    `json:"newCertificateId"`
+	NotAfter         time.Time `json:"notAfter"`
+	Domains          []string  `json:"domains"`
+}
+
+// DomainValidatedData is the payload of domain.validated events.
+type DomainValidatedData struct {
+	ServiceID        string `json:"serviceId"`
+	DomainID         string `json:"domainId"`
+	Hostname         string `json:"hostname"`
+	ValidationStatus string `json:"validationStatus"`
+}
+
+// TrafficThresholdData is the payload of traffic.threshold events.
+type TrafficThresholdData struct {
+	ServiceID string  `json:"serviceId"`
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Period    string  `json:"period"`
+}
+
+// ServiceUpdatedData is the payload of service.updated events.
+type ServiceUpdatedData struct {
+	ServiceID string   `json:"serviceId"`
+	UserID    string   `json:"userId,omitempty"`
+	Changes   []string `json:"changes"`
+}
+
+// Decode decodes the event's data into v.
+func (e Event) Decode(v interface{}) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s data: %w", e.Type, err)
+	}
+	return nil
+}
+
+// CertificateExpiring decodes the data of a certificate.expiring event.
+func (e Event) CertificateExpiring() (*CertificateExpiringData, error) {
+	var data CertificateExpiringData
+	return &data, e.decodeAs(EventCertificateExpiring, &data)
+}
+
+// CertificateRenewed decodes the data of a certificate.renewed event.
+func (e Event) CertificateRenewed() (*CertificateRenewedData, error) {
+	var data CertificateRenewedData
+	return &data, e.decodeAs(EventCertificateRenewed, &data)
+}
+
+// DomainValidated decodes the data of a domain.validated event.
+func (e Event) DomainValidated() (*DomainValidatedData, error) {
+	var data DomainValidatedData
+	return &data, e.decodeAs(EventDomainValidated, &data)
+}
+
+// TrafficThreshold decodes the data of a traffic.threshold event.
+func (e Event) TrafficThreshold() (*TrafficThresholdData, error) {
+	var data TrafficThresholdData
+	return &data, e.decodeAs(EventTrafficThreshold, &data)
+}
+
+// ServiceUpdated decodes the data of a service.updated event.
+func (e Event) ServiceUpdated() (*ServiceUpdatedData, error) {
+	var data ServiceUpdatedData
+	return &data, e.decodeAs(EventServiceUpdated, &data)
+}
+
+func (e Event) decodeAs(want api.WebhookEvent, v interface{}) error {
+	if e.Type != want {
+		return fmt.Errorf("event is %s, not %s", e.Type, want)
+	}
+	return e.Decode(v)
+}
@@ -0,0 +1,85 @@
+package cacheflywebhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// MaxPayloadSize is the largest delivery the handler accepts.
+const MaxPayloadSize = 1 << 20
+
+// Option configures a handler.
+type Option func(*handler)
+
+// WithTolerance sets how old a signed delivery may be; zero uses
+// api.DefaultSignatureTolerance.
+func WithTolerance(tolerance time.Duration) Option {
+	return func(h *handler) {
+		h.tolerance = tolerance
+	}
+}
+
+type handler struct {
+	secret    string
+	fn        func(Event) error
+	tolerance time.Duration
+}
+
+// NewHandler returns an http.Handler that verifies the signature of each
+// webhook delivery against secret, decodes it and calls fn.
+//
+// It answers 405 for methods other than POST, 401 for invalid or expired
+// signatures, 400 for malformed payloads, 500 when fn fails and 204 on
+// success.
+func NewHandler(secret string, fn func(Event) error, opts ...Option) http.Handler {
+	h := &handler{secret: secret, fn: fn}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, MaxPayloadSize+1))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+	if len(payload) > MaxPayloadSize {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	err = api.VerifySignature(payload, r.Header.Get(api.WebhookSignatureHeader), h.secret, h.tolerance)
+	if err != nil {
+		status := http.StatusUnauthorized
+		if !errors.Is(err, api.ErrInvalidSignature) && !errors.Is(err, api.ErrSignatureExpired) {
+			status = http.StatusInternalServerError
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.fn(event); err != nil {
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package cacheflywebhook
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+const expiringPayload = `{
+  "id": "evt-1",
+  "event": "certificate.expiring",
+  "createdAt": "2025-06-10T12:00:00Z",
+  "accountId": "acc-1",
+  "data": {"certificateId": "cert-1", "subjectCommonName": "example.com", "daysRemaining": 14, "domains": ["cdn.example.com"]}
+}`
+
+func deliver(t *testing.T, h http.Handler, payload, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/hooks", bytes.NewBufferString(payload))
+	req.Header.Set(api.WebhookSignatureHeader, signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_DispatchesEvent(t *testing.T) {
+	var got *CertificateExpiringData
+	h := NewHandler("whsec_123", func(event Event) error {
+		if event.ID != "evt-1" || event.Type != EventCertificateExpiring {
+			t.Errorf("Unexpected event %+v", event)
+		}
+		var err error
+		got, err = event.CertificateExpiring()
+		return err
+	})
+
+	rec := deliver(t, h, expiringPayload, api.SignPayload([]byte(expiringPayload), "whsec_123", time.Now()))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d: %s", rec.Code, rec.Body)
+	}
+	if got == nil || got.CertificateID != "cert-1" || got.DaysRemaining != 14 {
+		t.Errorf("Expected typed certificate data, got %+v", got)
+	}
+}
+
+func TestHandler_RejectsInvalidSignature(t *testing.T) {
+	called := false
+	h := NewHandler("whsec_123", func(event Event) error {
+		called = true
+		return nil
+	})
+
+	rec := deliver(t, h, expiringPayload, api.SignPayload([]byte(expiringPayload), "wrong", time.Now()))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, got %d", rec.Code)
+	}
+	if called {
+		t.Error("Expected callback not to be called")
+	}
+}
+
+func TestHandler_CallbackError(t *testing.T) {
+	h := NewHandler("whsec_123", func(event Event) error {
+		return errors.New("database down")
+	})
+
+	rec := deliver(t, h, expiringPayload, api.SignPayload([]byte(expiringPayload), "whsec_123", time.Now()))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 so the event is redelivered, got %d", rec.Code)
+	}
+}
+
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	h := NewHandler("whsec_123", func(event Event) error { return nil })
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hooks", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", rec.Code)
+	}
+}
+
+func TestEvent_WrongTypeAccessor(t *testing.T) {
+	event := Event{Type: EventDomainValidated, Data: []byte(`{}`)}
+	if _, err := event.CertificateExpiring(); err == nil {
+		t.Error("Expected error decoding a domain event as certificate data")
+	}
+}
@@ -0,0 +1,18 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// ChangeEvent describes a create, update or delete request that succeeded.
+// Before and After hold the JSON representations of the resource, when
+// known; unmarshal them into the API type of the endpoint.
+type ChangeEvent = httpclient.ChangeEvent
+
+// ChangeAction classifies a ChangeEvent.
+type ChangeAction = httpclient.ChangeAction
+
+// Change actions, derived from the request method.
+const (
+	ChangeCreate = httpclient.ChangeCreate
+	ChangeUpdate = httpclient.ChangeUpdate
+	ChangeDelete = httpclient.ChangeDelete
+)
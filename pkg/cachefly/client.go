@@ -25,6 +25,14 @@
 package cachefly
 
 import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/har"
 	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
 	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
 )
@@ -34,8 +42,16 @@ import (
 // It provides access to all CacheFly API service groups through
 // organized service properties. Each service group handles
 // specific aspects of the CacheFly platform.
+//
+// A Client is safe for concurrent use by multiple goroutines, across and
+// within service groups, and should be created once and reused.
 type Client struct {
 	httpClient *httpclient.Client
+	apiVersion string
+
+	// config is what Derive starts from: the configuration the client was
+	// created with, set to reuse its transport
+	config ClientConfig
 
 	// API service groups
 
@@ -74,8 +90,81 @@ type Client struct {
 
 	// TLSProfiles manages TLS security profiles
 	TLSProfiles *api.TLSProfilesService
+
+	// TLSSettings manages typed TLS settings of services and domains
+	TLSSettings *api.TLSSettingsService
+
+	// Purge purges paths from the cache of services
+	Purge *api.PurgeService
+
+	// Logs downloads raw access and origin logs
+	Logs *api.LogsService
+
+	// Webhooks manages webhook and notification endpoints
+	Webhooks *api.WebhooksService
+
+	// Tokens manages account-level API tokens
+	Tokens *api.TokensService
+
+	// AccountSecurity manages 2FA enforcement, SAML single sign-on and allowed IP ranges
+	AccountSecurity *api.AccountSecurityService
+
+	// Billing reports usage, plan limits and overage, and lists and downloads invoices
+	Billing *api.BillingService
+
+	// Alerts manages traffic and error rate alert rules
+	Alerts *api.AlertsService
+
+	// Network lists CacheFly edge locations
+	Network *api.NetworkService
+}
+
+const (
+	// DefaultAPIHost is the CacheFly API host used when no base URL is configured.
+	DefaultAPIHost = "https://api.cachefly.com"
+
+	// DefaultAPIVersion is the API version used when no version is configured.
+	DefaultAPIVersion = "2.5"
+
+	// Version is the version of the SDK, sent in the User-Agent header.
+	Version = "1.0.4"
+)
+
+// UserAgent returns the User-Agent header sent by clients without
+// WithUserAgentSuffix, e.g. "cachefly-sdk-go/1.0.4 go/1.23.2".
+func UserAgent() string {
+	return "cachefly-sdk-go/" + Version + " go/" + strings.TrimPrefix(runtime.Version(), "go")
 }
 
+// ServiceGroup identifies one of the client's API service groups.
+// It is used to route individual service groups to a specific API version.
+type ServiceGroup string
+
+// Service groups that can be routed to a specific API version.
+const (
+	ServiceGroupServices                   ServiceGroup = "Services"
+	ServiceGroupAccounts                   ServiceGroup = "Accounts"
+	ServiceGroupServiceDomains             ServiceGroup = "ServiceDomains"
+	ServiceGroupServiceRules               ServiceGroup = "ServiceRules"
+	ServiceGroupServiceOptions             ServiceGroup = "ServiceOptions"
+	ServiceGroupServiceOptionsRefererRules ServiceGroup = "ServiceOptionsRefererRules"
+	ServiceGroupServiceImageOptimization   ServiceGroup = "ServiceImageOptimization"
+	ServiceGroupCertificates               ServiceGroup = "Certificates"
+	ServiceGroupOrigins                    ServiceGroup = "Origins"
+	ServiceGroupUsers                      ServiceGroup = "Users"
+	ServiceGroupScriptConfigs              ServiceGroup = "ScriptConfigs"
+	ServiceGroupTLSProfiles                ServiceGroup = "TLSProfiles"
+	ServiceGroupTLSSettings                ServiceGroup = "TLSSettings"
+	ServiceGroupPurge                      ServiceGroup = "Purge"
+	ServiceGroupLogs                       ServiceGroup = "Logs"
+	ServiceGroupWebhooks                   ServiceGroup = "Webhooks"
+	ServiceGroupTokens                     ServiceGroup = "Tokens"
+	ServiceGroupAccountSecurity            ServiceGroup = "AccountSecurity"
+	ServiceGroupBilling                    ServiceGroup = "Billing"
+	ServiceGroupAlerts                     ServiceGroup = "Alerts"
+	ServiceGroupNetwork                    ServiceGroup = "Network"
+)
+
 // Option is a functional option for configuring the Client.
 type Option func(*ClientConfig)
 
@@ -84,8 +173,112 @@ type ClientConfig struct {
 	// Token is the Bearer token for API authentication
 	Token string
 
+	// Credentials supplies the token per request and takes precedence over Token
+	Credentials CredentialsProvider
+
+	// AuthScheme authenticates each request; nil sends the token as a Bearer token
+	AuthScheme AuthScheme
+
+	// MaintenanceWait is how long requests wait for API maintenance to end
+	MaintenanceWait time.Duration
+
+	// Retry enables retries of failed requests
+	Retry *RetryPolicy
+
+	// EndpointPolicies override Timeout and Retry for matching endpoints, first match wins
+	EndpointPolicies []EndpointPolicy
+
+	// Cache caches GET responses client-side
+	Cache *ResponseCache
+
+	// TransportOptions tunes the connection pool; nil uses http.DefaultTransport
+	TransportOptions *TransportOptions
+
+	// Proxy selects the proxy of each request and takes precedence over
+	// TransportOptions.Proxy
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// TLSConfig configures TLS connections and takes precedence over
+	// TransportOptions.TLSConfig
+	TLSConfig *tls.Config
+
+	// UserAgentSuffix is appended to the default User-Agent header
+	UserAgentSuffix string
+
+	// transport is a connection pool shared with other clients, set by ClientPool
+	transport http.RoundTripper
+
+	// authChanged is set by the options that change who the client
+	// authenticates as, so Derive knows not to share the response cache
+	authChanged bool
+
+	// HARPath is the file the session is recorded to as HAR; empty disables recording
+	HARPath string
+
+	// Scheduler is shared with other clients to cap their combined concurrency
+	Scheduler *Scheduler
+
+	// Account identifies this client's requests to the Scheduler
+	Account string
+
 	// BaseURL overrides the default API base URL
 	BaseURL string
+
+	// APIVersion selects the API version used by all service groups
+	APIVersion string
+
+	// ServiceVersions overrides the API version for individual service groups
+	ServiceVersions map[ServiceGroup]string
+
+	// Timeout bounds each request; zero uses the default of 35 seconds
+	Timeout time.Duration
+
+	// DryRun skips mutating requests and reports them to DryRunLog instead
+	DryRun bool
+
+	// DryRunLog receives the requests skipped in dry-run mode; nil logs them
+	DryRunLog func(DryRunRequest)
+
+	// LowMemoryDecoding decodes list responses element by element
+	LowMemoryDecoding bool
+
+	// StrictDecoding fails responses with fields the SDK does not model
+	StrictDecoding bool
+
+	// RateLimiter limits the rate of requests of all service groups
+	RateLimiter *RateLimiter
+
+	// Limiter coordinates the requests of all service groups with other
+	// processes, in addition to RateLimiter
+	Limiter Limiter
+
+	// CircuitBreaker fails the requests of all service groups fast during outages
+	CircuitBreaker *CircuitBreaker
+
+	// OnChange is called after each successful create, update or delete
+	OnChange func(ChangeEvent)
+
+	// ReadAfterWrite re-reads resources after writes
+	ReadAfterWrite bool
+
+	// Debug logs every request with its status and duration
+	Debug bool
+
+	// DeprecationLog receives warnings about deprecated endpoints; nil logs them
+	DeprecationLog func(DeprecationWarning)
+
+	// Environment is the declared environment, checked against
+	// EnvironmentPolicy before writes
+	Environment string
+
+	// EnvironmentPolicy adds to or replaces entries of DefaultEnvironmentPolicy
+	EnvironmentPolicy EnvironmentPolicy
+
+	// AllowBetaOptions lets service option updates set beta options
+	AllowBetaOptions bool
+
+	// AllowReadOnlyOptions lets service option updates set read-only options
+	AllowReadOnlyOptions bool
 }
 
 // WithToken sets the Bearer token for API authentication.
@@ -99,6 +292,582 @@ type ClientConfig struct {
 func WithToken(token string) Option {
 	return func(c *ClientConfig) {
 		c.Token = token
+		c.authChanged = true
+	}
+}
+
+// WithCredentials sets a CredentialsProvider that supplies the Bearer token
+// for every request, replacing WithToken.
+//
+// Use it to read the token from the environment or a mounted secret file,
+// or to refresh short-lived tokens without recreating the client.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithCredentials(cachefly.ChainCredentials(
+//			cachefly.EnvToken(""),
+//			cachefly.FileToken("/var/run/secrets/cachefly/token"),
+//		)),
+//	)
+func WithCredentials(provider CredentialsProvider) Option {
+	return func(c *ClientConfig) {
+		c.Credentials = provider
+		c.authChanged = true
+	}
+}
+
+// WithMaintenanceWait pauses requests that hit API maintenance until the
+// announced end time, then replays them, waiting at most max in total per
+// request. Batch operations thereby pause and resume on their own. Without
+// this option a maintenance response fails immediately with ErrAPIMaintenance.
+func WithMaintenanceWait(max time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.MaintenanceWait = max
+	}
+}
+
+// WithRetry retries failed requests according to policy. Without it, every
+// request is attempted once.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithRetry(cachefly.DefaultRetryPolicy()),
+//	)
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *ClientConfig) {
+		c.Retry = &policy
+	}
+}
+
+// WithEndpointPolicy overrides the timeout and retry policy for endpoints
+// matching pattern. Patterns are paths relative to the API version, such as
+// "/services/*/options", optionally prefixed by a method, such as
+// "POST /certificates". A trailing "/*" matches everything below the prefix.
+// When several patterns match, the one registered first wins.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithRetry(cachefly.DefaultRetryPolicy()),
+//		cachefly.WithEndpointPolicy("/reports/*", cachefly.Policy{Timeout: 2 * time.Minute}),
+//		cachefly.WithEndpointPolicy("POST /certificates", cachefly.Policy{NoRetry: true}),
+//	)
+func WithEndpointPolicy(pattern string, policy Policy) Option {
+	return func(c *ClientConfig) {
+		c.EndpointPolicies = append(c.EndpointPolicies, EndpointPolicy{
+			Pattern: pattern,
+			Timeout: policy.Timeout,
+			Retry:   policy.Retry,
+			NoRetry: policy.NoRetry,
+		})
+	}
+}
+
+// WithResponseCache caches GET responses in cache. Keep a reference to the
+// cache to invalidate entries after changes made outside this client.
+//
+// Example:
+//
+//	cache := cachefly.NewResponseCache(time.Minute)
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithResponseCache(cache),
+//	)
+//	...
+//	cache.Invalidate("/services")
+func WithResponseCache(cache *ResponseCache) Option {
+	return func(c *ClientConfig) {
+		c.Cache = cache
+	}
+}
+
+// WithHARRecorder records every HTTP interaction of the client to a HAR
+// file at path, for example to attach to a support case. The file is
+// rewritten after each response, so it is complete while the client is in
+// use. Authorization headers, cookies and JSON fields holding tokens,
+// passwords, secrets or keys are redacted; bodies that are not JSON or
+// exceed 1 MiB are left out. Failing to write the file does not fail
+// requests.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithHARRecorder("cachefly-session.har"),
+//	)
+func WithHARRecorder(path string) Option {
+	return func(c *ClientConfig) {
+		c.HARPath = path
+	}
+}
+
+// WithScheduler runs the client's requests through a Scheduler shared with
+// other clients. Requests of the same account are queued together; free
+// slots go to each waiting account in turn.
+//
+// Example:
+//
+//	scheduler := cachefly.NewScheduler(10)
+//	for _, child := range children {
+//		clients[child.ID] = cachefly.NewClient(
+//			cachefly.WithCredentials(child.Credentials),
+//			cachefly.WithScheduler(scheduler, child.ID),
+//		)
+//	}
+func WithScheduler(scheduler *Scheduler, account string) Option {
+	return func(c *ClientConfig) {
+		c.Scheduler = scheduler
+		c.Account = account
+	}
+}
+
+// WithDryRun puts the client in dry-run mode. Mutating methods still
+// validate their input and perform the reads they depend on, but instead of
+// sending POST, PUT and DELETE requests they log them and succeed with the
+// request payload decoded as the response. Server-assigned fields such as
+// IDs are therefore empty, and helpers that act on what they just created
+// may fail; prefer their own dry-run options where they have one, such as
+// ServiceOptions.Apply.
+//
+// Use DryRun to put a single call in dry-run mode instead.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithDryRun(true),
+//	)
+func WithDryRun(enabled bool) Option {
+	return func(c *ClientConfig) {
+		c.DryRun = enabled
+	}
+}
+
+// WithDryRunLogger receives the requests skipped in dry-run mode, which are
+// otherwise written to the standard logger with secret fields such as
+// passwords and certificate keys redacted. fn gets the bodies as they would
+// have been sent.
+//
+// Example:
+//
+//	var planned []cachefly.DryRunRequest
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithDryRun(true),
+//		cachefly.WithDryRunLogger(func(req cachefly.DryRunRequest) {
+//			planned = append(planned, req)
+//		}),
+//	)
+func WithDryRunLogger(fn func(DryRunRequest)) Option {
+	return func(c *ClientConfig) {
+		c.DryRunLog = fn
+	}
+}
+
+// WithRateLimit limits the client to rps requests per second on average,
+// with bursts of up to burst requests, so that bulk jobs such as mass purges
+// or option updates stay below the API's rate limits instead of running
+// into 429 responses. Requests over the limit wait for their turn; retries
+// count against the limit too. The limit applies to all service groups of
+// the client together.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithRateLimit(10, 20),
+//	)
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *ClientConfig) {
+		c.RateLimiter = httpclient.NewRateLimiter(rps, burst)
+	}
+}
+
+// WithLimiter makes every request attempt, retries included, wait for
+// limiter before it is sent. Use it to share an account's API limit between
+// worker replicas through Redis or a token service; an error from limiter
+// fails the request without sending it. It can be combined with
+// WithRateLimit, which smooths the requests of this client on its own.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithLimiter(cachefly.LimiterFunc(func(ctx context.Context) error {
+//			return fleetLimiter.Acquire(ctx, "cachefly-api")
+//		})),
+//	)
+func WithLimiter(limiter Limiter) Option {
+	return func(c *ClientConfig) {
+		c.Limiter = limiter
+	}
+}
+
+// WithCircuitBreaker stops sending requests after threshold consecutive
+// network errors, timeouts or 5xx responses, so long-running controllers do
+// not pile retries onto an API outage. While open, requests fail at once
+// with an error matching ErrCircuitOpen; after cooldown one trial request is
+// let through, which closes the breaker if it succeeds. The breaker applies
+// to all service groups of the client together.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithRetry(cachefly.DefaultRetryPolicy()),
+//		cachefly.WithCircuitBreaker(5, 30*time.Second),
+//	)
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.CircuitBreaker = httpclient.NewCircuitBreaker(threshold, cooldown)
+	}
+}
+
+// WithLowMemoryDecoding decodes the items of list responses one at a time
+// as they arrive instead of buffering each response whole, trading some
+// CPU for a peak memory use of about the decoded result. It suits workers
+// with little memory processing large accounts; to avoid holding a whole
+// list at all, use iterators such as Services.ListEach.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithLowMemoryDecoding(),
+//	)
+func WithLowMemoryDecoding() Option {
+	return func(c *ClientConfig) {
+		c.LowMemoryDecoding = true
+	}
+}
+
+// WithStrictDecoding makes responses with fields the SDK does not model
+// fail with an error matching ErrUnknownField when strict is true, so CI
+// catches API changes early. By default such fields are ignored, and those
+// of resources such as Service and Account are kept in their Raw field.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithStrictDecoding(os.Getenv("CI") != ""),
+//	)
+func WithStrictDecoding(strict bool) Option {
+	return func(c *ClientConfig) {
+		c.StrictDecoding = strict
+	}
+}
+
+// WithChangeListener calls fn after each successful create, update or
+// delete with the endpoint, the payload sent and the representations of the
+// resource before and after the change, so applications can update local
+// caches or emit events without wrapping every call. Before is only known
+// when WithResponseCache holds the resource. Requests skipped in dry-run
+// mode are not reported.
+//
+// fn runs on the goroutine that made the request, before the call returns,
+// and must not block.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithChangeListener(func(ev cachefly.ChangeEvent) {
+//			if ev.Action == cachefly.ChangeDelete {
+//				localCache.Remove(ev.Endpoint)
+//			}
+//		}),
+//	)
+func WithChangeListener(fn func(ChangeEvent)) Option {
+	return func(c *ClientConfig) {
+		c.OnChange = fn
+	}
+}
+
+// WithReadAfterWrite re-reads a resource with GET after every successful
+// create or update of it, and returns the server's state instead of the
+// write response, which for some endpoints omits derived fields. This costs
+// one extra request per write. It applies to services, service domains,
+// service options, origins, users, referer rules, script configs, accounts
+// and webhook updates. Creating tokens and webhooks and rotating webhook
+// secrets return secrets the API shows only once, so those responses are
+// kept as they are.
+//
+// If the write succeeds but the read fails, the call returns a
+// *ReadBackError.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithReadAfterWrite(),
+//	)
+func WithReadAfterWrite() Option {
+	return func(c *ClientConfig) {
+		c.ReadAfterWrite = true
+	}
+}
+
+// WithTransportOptions tunes the client's HTTP connection pool: idle and
+// total connections per host, idle timeout, TCP keep-alive and HTTP/2. The
+// defaults keep only two idle connections per host, so clients shared by
+// many goroutines should raise MaxIdleConnsPerHost to about their
+// concurrency to reuse connections instead of reopening them.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithTransportOptions(cachefly.TransportOptions{
+//			MaxIdleConnsPerHost: 32,
+//			IdleConnTimeout:     90 * time.Second,
+//		}),
+//	)
+func WithTransportOptions(opts TransportOptions) Option {
+	return func(c *ClientConfig) {
+		c.TransportOptions = &opts
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL, which may use the
+// http, https, socks5 or socks5h scheme and carry credentials. Hosts
+// matching noProxy are reached directly; without noProxy the NO_PROXY
+// environment variable is honored. Entries are host names, which also
+// match their subdomains, IP addresses, CIDR ranges or "*", each
+// optionally with a port. An invalid proxyURL fails every request.
+//
+// A proxy gives the client its own connection pool, even in a ClientPool;
+// set PoolConfig.TransportOptions.Proxy to proxy a whole pool instead.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithProxy("socks5://egress.internal:1080", "localhost", "10.0.0.0/8"),
+//	)
+func WithProxy(proxyURL string, noProxy ...string) Option {
+	return func(c *ClientConfig) {
+		c.Proxy = httpclient.ProxyURL(proxyURL, noProxy)
+	}
+}
+
+// WithProxyFromEnvironment selects the proxy from the HTTPS_PROXY,
+// HTTP_PROXY and NO_PROXY environment variables, as http.ProxyFromEnvironment
+// does. This is the default, so the option only matters to restore it
+// after a TransportOptions.Proxy or WithProxy.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithProxyFromEnvironment(),
+//	)
+func WithProxyFromEnvironment() Option {
+	return func(c *ClientConfig) {
+		c.Proxy = http.ProxyFromEnvironment
+	}
+}
+
+// WithTLSConfig configures the TLS connections to the API: trust a custom
+// CA bundle, e.g. of a TLS-intercepting proxy, present a client certificate
+// for mutual TLS or raise the minimum TLS version. The config is copied and
+// must not set InsecureSkipVerify in production.
+//
+// Like WithProxy, it gives the client its own connection pool.
+//
+// Example:
+//
+//	pool, _ := x509.SystemCertPool()
+//	pem, err := os.ReadFile("/etc/ssl/corp-proxy-ca.pem")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	pool.AppendCertsFromPEM(pem)
+//	cert, err := tls.LoadX509KeyPair("client.crt", "client.key")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithTLSConfig(&tls.Config{
+//			RootCAs:      pool,
+//			Certificates: []tls.Certificate{cert},
+//			MinVersion:   tls.VersionTLS13,
+//		}),
+//	)
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *ClientConfig) {
+		c.TLSConfig = config
+	}
+}
+
+// WithDebug logs the method, URL, status, duration and request ID of every
+// request, retries included, with the standard logger. Headers and bodies
+// are not logged.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithDebug(os.Getenv("DEBUG") != ""),
+//	)
+func WithDebug(enabled bool) Option {
+	return func(c *ClientConfig) {
+		c.Debug = enabled
+	}
+}
+
+// WithDeprecationLogger receives a warning the first time the client uses an
+// endpoint the API announces as deprecated with the Deprecation or Sunset
+// response header. Without it, the warnings are written to the standard
+// logger.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithDeprecationLogger(func(w cachefly.DeprecationWarning) {
+//			slog.Warn("deprecated CacheFly endpoint", "endpoint", w.Method+" "+w.Endpoint, "sunset", w.Sunset)
+//		}),
+//	)
+func WithDeprecationLogger(fn func(DeprecationWarning)) Option {
+	return func(c *ClientConfig) {
+		c.DeprecationLog = fn
+	}
+}
+
+// WithEnvironmentName declares the environment the client is meant for,
+// such as "prod" or "staging". Mutating calls fail with an
+// EnvironmentMismatchError, without being sent, when the base URL does not
+// fit the environment according to the policy, see WithEnvironmentPolicy:
+// by default "prod" and "production" may only write to the production API,
+// and every other environment may write anywhere but the production API.
+// Reads and dry runs are not affected.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken(os.Getenv("CACHEFLY_API_TOKEN")),
+//		cachefly.WithBaseURL(os.Getenv("CACHEFLY_BASE_URL")),
+//		cachefly.WithEnvironmentName("staging"),
+//	)
+func WithEnvironmentName(name string) Option {
+	return func(c *ClientConfig) {
+		c.Environment = name
+	}
+}
+
+// WithEnvironmentPolicy sets the API hosts environments may write to, see
+// WithEnvironmentName. Entries replace those of DefaultEnvironmentPolicy
+// with the same name; the others are kept.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.WithBaseURL("https://staging-api.cachefly.com/api/2.5"),
+//		cachefly.WithEnvironmentName("staging"),
+//		cachefly.WithEnvironmentPolicy(cachefly.EnvironmentPolicy{
+//			"staging": {"staging-api.cachefly.com"},
+//		}),
+//	)
+func WithEnvironmentPolicy(policy EnvironmentPolicy) Option {
+	return func(c *ClientConfig) {
+		c.EnvironmentPolicy = policy
+	}
+}
+
+// AllowBetaOptions lets the client set service options the API flags as
+// beta (promo) features. Without it, UpdateOptions and the calls built on it
+// fail with an OPTION_BETA validation error for such options, so automation
+// does not come to depend on unstable features unknowingly. Beta options
+// can always be read; OptionMetadata.Stability tells them apart.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("your-token"),
+//		cachefly.AllowBetaOptions(),
+//	)
+func AllowBetaOptions() Option {
+	return func(c *ClientConfig) {
+		c.AllowBetaOptions = true
+	}
+}
+
+// WithAllowReadOnly lets the client set service options the metadata marks
+// as read-only. Without it, UpdateOptions and the calls built on it fail
+// with an OPTION_READ_ONLY validation error for such options before any
+// request is sent. Only use it where the API is known to accept the
+// option anyway, such as for accounts with elevated permissions.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("admin-token"),
+//		cachefly.WithAllowReadOnly(),
+//	)
+func WithAllowReadOnly() Option {
+	return func(c *ClientConfig) {
+		c.AllowReadOnlyOptions = true
+	}
+}
+
+// WithAuthScheme authenticates requests with scheme instead of sending the
+// token as a Bearer token. The scheme receives the token of WithToken or
+// WithCredentials and may ignore it.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithAuthScheme(cachefly.BearerAuth{}),
+//	)
+func WithAuthScheme(scheme AuthScheme) Option {
+	return func(c *ClientConfig) {
+		c.AuthScheme = scheme
+		c.authChanged = true
+	}
+}
+
+// WithHMACAuth signs every request with HMAC-SHA256 using the key ID and
+// shared secret, for accounts set up for request signing, instead of
+// sending a Bearer token. See HMACAuth for the signature format.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithHMACAuth(os.Getenv("CACHEFLY_KEY_ID"), os.Getenv("CACHEFLY_SECRET")),
+//	)
+func WithHMACAuth(keyID, secret string) Option {
+	return WithAuthScheme(&HMACAuth{KeyID: keyID, Secret: []byte(secret)})
+}
+
+// WithUserAgentSuffix appends product tokens such as "my-app/2.0" to the
+// User-Agent header, so CacheFly support and API logs can tell the traffic
+// of an integration apart. Repeated calls append in order.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithUserAgentSuffix("my-app/2.0"),
+//	)
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *ClientConfig) {
+		suffix = strings.TrimSpace(suffix)
+		if suffix == "" {
+			return
+		}
+		if c.UserAgentSuffix != "" {
+			suffix = c.UserAgentSuffix + " " + suffix
+		}
+		c.UserAgentSuffix = suffix
 	}
 }
 
@@ -119,6 +888,82 @@ func WithBaseURL(url string) Option {
 	}
 }
 
+// WithAPIVersion selects the CacheFly API version used by the client.
+//
+// The version replaces the "/api/<version>" segment of the base URL, so it
+// can be combined with WithBaseURL. Types from the v2_5 package remain valid
+// for every supported version.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithAPIVersion("2.6"),
+//	)
+func WithAPIVersion(version string) Option {
+	return func(c *ClientConfig) {
+		c.APIVersion = version
+	}
+}
+
+// WithServiceAPIVersion routes a single service group to a specific API version.
+//
+// This lets callers adopt endpoints from a newer API version one service
+// group at a time while the rest of the client stays on the default version.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithServiceAPIVersion(cachefly.ServiceGroupCertificates, "2.6"),
+//	)
+func WithServiceAPIVersion(group ServiceGroup, version string) Option {
+	return func(c *ClientConfig) {
+		if c.ServiceVersions == nil {
+			c.ServiceVersions = make(map[ServiceGroup]string)
+		}
+		c.ServiceVersions[group] = version
+	}
+}
+
+// WithTimeout sets the default timeout applied to every request.
+//
+// The timeout never extends a deadline already set on the caller's context;
+// whichever ends first wins. Requests that end because of either return
+// context.DeadlineExceeded or context.Canceled unwrapped, so they can be
+// compared directly. A negative value disables the client-level timeout.
+//
+// Example:
+//
+//	client := cachefly.NewClient(
+//		cachefly.WithToken("token"),
+//		cachefly.WithTimeout(10*time.Second),
+//	)
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.Timeout = timeout
+	}
+}
+
+// newTransport returns a transport built from TransportOptions, Proxy and
+// TLSConfig, or nil when none is set.
+func (c *ClientConfig) newTransport() http.RoundTripper {
+	if c.TransportOptions == nil && c.Proxy == nil && c.TLSConfig == nil {
+		return nil
+	}
+	var opts TransportOptions
+	if c.TransportOptions != nil {
+		opts = *c.TransportOptions
+	}
+	if c.Proxy != nil {
+		opts.Proxy = c.Proxy
+	}
+	if c.TLSConfig != nil {
+		opts.TLSConfig = c.TLSConfig
+	}
+	return httpclient.NewTransport(opts)
+}
+
 // NewClient initializes and returns a new CacheFly API client.
 //
 // The client is configured with functional options and provides
@@ -144,31 +989,176 @@ func WithBaseURL(url string) Option {
 //	}
 func NewClient(opts ...Option) *Client {
 	cfg := &ClientConfig{
-		BaseURL: "https://api.cachefly.com/api/2.5",
+		BaseURL: DefaultAPIHost + "/api/" + DefaultAPIVersion,
 	}
 
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	hc := httpclient.New(httpclient.Config{
-		BaseURL:   cfg.BaseURL,
-		AuthToken: cfg.Token,
-	})
+	// All API versions share the transport and recorder, so they share one
+	// connection pool and the session ends up in one file
+	transport := cfg.transport
+	if t := cfg.newTransport(); t != nil {
+		transport = t
+	}
+	if cfg.HARPath != "" {
+		transport = har.NewRecorder(cfg.HARPath, transport)
+	}
+	if cfg.Debug {
+		transport = httpclient.NewDebugTransport(transport, nil)
+	}
+
+	userAgent := UserAgent()
+	if cfg.UserAgentSuffix != "" {
+		userAgent += " " + cfg.UserAgentSuffix
+	}
+
+	// One HTTP client per base URL, shared by all service groups on that version.
+	// The base URL is only rewritten when a version was explicitly requested.
+	clients := make(map[string]*httpclient.Client)
+	policy := cfg.EnvironmentPolicy.merge()
+	clientFor := func(group ServiceGroup) *httpclient.Client {
+		baseURL := cfg.BaseURL
+		if v, ok := cfg.ServiceVersions[group]; ok && v != "" {
+			baseURL = versionedBaseURL(cfg.BaseURL, v)
+		} else if cfg.APIVersion != "" {
+			baseURL = versionedBaseURL(cfg.BaseURL, cfg.APIVersion)
+		}
+		if hc, ok := clients[baseURL]; ok {
+			return hc
+		}
+		hc := httpclient.New(httpclient.Config{
+			BaseURL:              baseURL,
+			AuthToken:            cfg.Token,
+			Credentials:          cfg.Credentials,
+			AuthScheme:           cfg.AuthScheme,
+			UserAgent:            userAgent,
+			Timeout:              cfg.Timeout,
+			MaintenanceWait:      cfg.MaintenanceWait,
+			Retry:                cfg.Retry,
+			EndpointPolicies:     cfg.EndpointPolicies,
+			Cache:                cfg.Cache,
+			Transport:            transport,
+			Scheduler:            cfg.Scheduler,
+			Account:              cfg.Account,
+			DryRun:               cfg.DryRun,
+			DryRunLog:            cfg.DryRunLog,
+			LowMemoryDecoding:    cfg.LowMemoryDecoding,
+			StrictDecoding:       cfg.StrictDecoding,
+			RateLimiter:          cfg.RateLimiter,
+			Limiter:              cfg.Limiter,
+			CircuitBreaker:       cfg.CircuitBreaker,
+			OnChange:             cfg.OnChange,
+			ReadAfterWrite:       cfg.ReadAfterWrite,
+			DeprecationLog:       cfg.DeprecationLog,
+			RefuseWrites:         policy.check(cfg.Environment, baseURL),
+			AllowBetaOptions:     cfg.AllowBetaOptions,
+			AllowReadOnlyOptions: cfg.AllowReadOnlyOptions,
+		})
+		clients[baseURL] = hc
+		return hc
+	}
+
+	version := cfg.APIVersion
+	if version == "" {
+		version = versionFromBaseURL(cfg.BaseURL)
+	}
+
+	hc := clientFor("")
+
+	// Derived clients reuse the transport built above rather than building,
+	// and recording to the HAR file, a second one
+	derived := *cfg
+	derived.transport = transport
+	derived.TransportOptions = nil
+	derived.Proxy = nil
+	derived.TLSConfig = nil
+	derived.HARPath = ""
+	derived.Debug = false
 
 	return &Client{
 		httpClient:                 hc,
-		Services:                   &api.ServicesService{Client: hc},
-		Accounts:                   &api.AccountsService{Client: hc},
-		ServiceDomains:             &api.ServiceDomainsService{Client: hc},
-		ServiceRules:               &api.ServiceRulesService{Client: hc},
-		ServiceOptions:             &api.ServiceOptionsService{Client: hc},
-		ServiceOptionsRefererRules: &api.ServiceOptionsRefererRulesService{Client: hc},
-		ServiceImageOptimization:   &api.ServiceImageOptimizationService{Client: hc},
-		Certificates:               &api.CertificatesService{Client: hc},
-		Origins:                    &api.OriginsService{Client: hc},
-		Users:                      &api.UsersService{Client: hc},
-		ScriptConfigs:              &api.ScriptConfigsService{Client: hc},
-		TLSProfiles:                &api.TLSProfilesService{Client: hc},
+		apiVersion:                 version,
+		config:                     derived,
+		Services:                   &api.ServicesService{Client: clientFor(ServiceGroupServices)},
+		Accounts:                   &api.AccountsService{Client: clientFor(ServiceGroupAccounts)},
+		ServiceDomains:             &api.ServiceDomainsService{Client: clientFor(ServiceGroupServiceDomains)},
+		ServiceRules:               &api.ServiceRulesService{Client: clientFor(ServiceGroupServiceRules)},
+		ServiceOptions:             &api.ServiceOptionsService{Client: clientFor(ServiceGroupServiceOptions)},
+		ServiceOptionsRefererRules: &api.ServiceOptionsRefererRulesService{Client: clientFor(ServiceGroupServiceOptionsRefererRules)},
+		ServiceImageOptimization:   &api.ServiceImageOptimizationService{Client: clientFor(ServiceGroupServiceImageOptimization)},
+		Certificates:               &api.CertificatesService{Client: clientFor(ServiceGroupCertificates)},
+		Origins:                    &api.OriginsService{Client: clientFor(ServiceGroupOrigins)},
+		Users:                      &api.UsersService{Client: clientFor(ServiceGroupUsers)},
+		ScriptConfigs:              &api.ScriptConfigsService{Client: clientFor(ServiceGroupScriptConfigs)},
+		TLSProfiles:                &api.TLSProfilesService{Client: clientFor(ServiceGroupTLSProfiles)},
+		TLSSettings:                &api.TLSSettingsService{Client: clientFor(ServiceGroupTLSSettings)},
+		Purge:                      &api.PurgeService{Client: clientFor(ServiceGroupPurge)},
+		Logs:                       &api.LogsService{Client: clientFor(ServiceGroupLogs)},
+		Webhooks:                   &api.WebhooksService{Client: clientFor(ServiceGroupWebhooks)},
+		Tokens:                     &api.TokensService{Client: clientFor(ServiceGroupTokens)},
+		AccountSecurity:            &api.AccountSecurityService{Client: clientFor(ServiceGroupAccountSecurity)},
+		Billing:                    &api.BillingService{Client: clientFor(ServiceGroupBilling)},
+		Alerts:                     &api.AlertsService{Client: clientFor(ServiceGroupAlerts)},
+		Network:                    &api.NetworkService{Client: clientFor(ServiceGroupNetwork)},
+	}
+}
+
+// Derive returns a new client configured like c and then changed by opts.
+// It shares c's connection pool, HAR recording, rate limiter, circuit
+// breaker and scheduler; options that set them replace them for the new
+// client only. The response cache is shared too, unless opts change the
+// token, credentials or auth scheme: cache entries are keyed by URL, so a
+// client authenticating as another account must not see c's responses.
+// Dry-run mode, the environment guard and every other setting carry over
+// unless opts change them.
+//
+// Example:
+//
+//	child := parent.Derive(
+//		cachefly.WithCredentials(childCredentials),
+//		cachefly.WithResponseCache(cachefly.NewResponseCache(time.Minute)),
+//	)
+func (c *Client) Derive(opts ...Option) *Client {
+	cfg := c.config
+	cfg.authChanged = false
+	cfg.EndpointPolicies = append([]EndpointPolicy(nil), cfg.EndpointPolicies...)
+	if cfg.ServiceVersions != nil {
+		versions := make(map[ServiceGroup]string, len(cfg.ServiceVersions))
+		for group, v := range cfg.ServiceVersions {
+			versions[group] = v
+		}
+		cfg.ServiceVersions = versions
+	}
+	opts = append([]Option{func(dst *ClientConfig) { *dst = cfg }}, opts...)
+	opts = append(opts, func(dst *ClientConfig) {
+		if dst.authChanged && dst.Cache == cfg.Cache {
+			dst.Cache = nil
+		}
+	})
+	return NewClient(opts...)
+}
+
+// APIVersion returns the default API version the client was configured with.
+func (c *Client) APIVersion() string {
+	return c.apiVersion
+}
+
+// versionFromBaseURL extracts the version from a ".../api/<version>" base URL.
+func versionFromBaseURL(baseURL string) string {
+	base := strings.TrimRight(baseURL, "/")
+	if idx := strings.LastIndex(base, "/api/"); idx >= 0 {
+		return base[idx+len("/api/"):]
+	}
+	return DefaultAPIVersion
+}
+
+// versionedBaseURL replaces or appends the "/api/<version>" segment of baseURL.
+func versionedBaseURL(baseURL, version string) string {
+	base := strings.TrimRight(baseURL, "/")
+	if idx := strings.LastIndex(base, "/api/"); idx >= 0 {
+		base = base[:idx]
 	}
+	return base + "/api/" + version
 }
@@ -0,0 +1,436 @@
+package cachefly
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestNewClient_DefaultBaseURL(t *testing.T) {
+	client := NewClient(WithToken("test-token"))
+
+	if got := client.httpClient.BaseURL(); got != "https://api.cachefly.com/api/2.5" {
+		t.Errorf("Expected default base URL, got %s", got)
+	}
+	if client.APIVersion() != "2.5" {
+		t.Errorf("Expected API version 2.5, got %s", client.APIVersion())
+	}
+}
+
+func TestNewClient_WithAPIVersion(t *testing.T) {
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL("https://staging-api.cachefly.com/api/2.5"),
+		WithAPIVersion("2.6"),
+	)
+
+	if got := client.Services.Client.BaseURL(); got != "https://staging-api.cachefly.com/api/2.6" {
+		t.Errorf("Expected versioned base URL, got %s", got)
+	}
+	if client.APIVersion() != "2.6" {
+		t.Errorf("Expected API version 2.6, got %s", client.APIVersion())
+	}
+}
+
+func TestNewClient_WithServiceAPIVersion(t *testing.T) {
+	client := NewClient(
+		WithToken("test-token"),
+		WithServiceAPIVersion(ServiceGroupCertificates, "2.6"),
+	)
+
+	if got := client.Certificates.Client.BaseURL(); got != "https://api.cachefly.com/api/2.6" {
+		t.Errorf("Expected certificates on 2.6, got %s", got)
+	}
+	if got := client.Services.Client.BaseURL(); got != "https://api.cachefly.com/api/2.5" {
+		t.Errorf("Expected services on 2.5, got %s", got)
+	}
+	if client.Services.Client != client.Accounts.Client {
+		t.Error("Expected service groups on the same version to share an HTTP client")
+	}
+}
+
+func TestNewClient_BaseURLWithoutVersionUnchanged(t *testing.T) {
+	client := NewClient(WithBaseURL("http://127.0.0.1:8080"))
+
+	if got := client.Services.Client.BaseURL(); got != "http://127.0.0.1:8080" {
+		t.Errorf("Expected base URL to be used as-is, got %s", got)
+	}
+}
+
+func TestNewClient_WithHARRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"_id":"svc-1"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "session.har")
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL(server.URL),
+		WithHARRecorder(path),
+	)
+
+	if _, err := client.Services.Get(context.Background(), "svc-1", "", false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected HAR file, got %v", err)
+	}
+	if !strings.Contains(string(data), "/services/svc-1") {
+		t.Errorf("Expected request in HAR file, got %s", data)
+	}
+	if strings.Contains(string(data), "test-token") {
+		t.Errorf("Expected token to be redacted")
+	}
+}
+
+func TestNewClient_WithDryRun(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	var skipped []DryRunRequest
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL(server.URL),
+		WithDryRun(true),
+		WithDryRunLogger(func(req DryRunRequest) { skipped = append(skipped, req) }),
+	)
+
+	svc, err := client.Services.UpdateServiceByID(context.Background(), "svc-1", api.UpdateServiceRequest{Description: "planned"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if svc.Description != "planned" {
+		t.Errorf("Expected the requested description, got %q", svc.Description)
+	}
+	if requests != 0 || len(skipped) != 1 {
+		t.Errorf("Expected the update to be skipped, got %d requests and %d skipped", requests, len(skipped))
+	}
+
+	// Input is still validated
+	if _, err := client.Services.UpdateServiceByID(context.Background(), "", api.UpdateServiceRequest{}); err == nil {
+		t.Error("Expected validation error for empty ID")
+	}
+}
+
+func TestDryRun_PerCall(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := NewClient(WithToken("test-token"), WithBaseURL(server.URL), WithDryRunLogger(func(DryRunRequest) {}))
+
+	if err := client.Certificates.Delete(DryRun(context.Background()), "cert-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request, got %d", requests)
+	}
+}
+
+func TestClient_Derive(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.Header.Get("Authorization")+" "+r.Header.Get("User-Agent"))
+		w.Write([]byte(`{"_id":"acc"}`))
+	}))
+	defer server.Close()
+
+	parent := NewClient(
+		WithToken("parent-token"),
+		WithBaseURL(server.URL+"/api/2.5"),
+		WithUserAgentSuffix("my-app/2.0"),
+		WithDryRun(true),
+		WithDryRunLogger(func(DryRunRequest) {}),
+		WithServiceAPIVersion(ServiceGroupCertificates, "2.6"),
+	)
+	child := parent.Derive(WithToken("child-token"), WithServiceAPIVersion(ServiceGroupCertificates, "2.7"))
+
+	if _, err := child.Accounts.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := child.Certificates.Delete(context.Background(), "cert-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{"GET Bearer child-token " + UserAgent() + " my-app/2.0"}
+	if len(requests) != 1 || requests[0] != expected[0] {
+		t.Errorf("Expected %q with the delete skipped in dry-run mode, got %q", expected, requests)
+	}
+
+	if got := child.Certificates.Client.BaseURL(); got != server.URL+"/api/2.7" {
+		t.Errorf("Expected the derived client's version, got %s", got)
+	}
+	if got := parent.Certificates.Client.BaseURL(); got != server.URL+"/api/2.6" {
+		t.Errorf("Expected the parent to keep its version, got %s", got)
+	}
+}
+
+func TestClient_Derive_ResponseCache(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"_id":"` + r.Header.Get("Authorization")[len("Bearer "):] + `"}`))
+	}))
+	defer server.Close()
+
+	parent := NewClient(WithToken("parent"), WithBaseURL(server.URL+"/api/2.5"), WithResponseCache(NewResponseCache(time.Minute)))
+	if _, err := parent.Accounts.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for name, opt := range map[string]Option{
+		"token":       WithToken("child"),
+		"credentials": WithCredentials(StaticToken("child")),
+	} {
+		account, err := parent.Derive(opt).Accounts.Get(context.Background(), "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if account.ID != "child" {
+			t.Errorf("Expected the %s client not to get the parent's cached response, got %s", name, account.ID)
+		}
+	}
+
+	if _, err := parent.Derive(WithUserAgentSuffix("my-app/2.0")).Accounts.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Expected a client with the same credentials to share the cache, got %d requests", requests)
+	}
+}
+
+func TestNewClient_WithRateLimit(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithToken("test-token"), WithBaseURL(server.URL), WithRateLimit(20, 1))
+
+	// Both service groups share the limit: 3 requests at 20/s take about 100ms
+	start := time.Now()
+	client.Services.GetByID(context.Background(), "svc-1")
+	client.Accounts.Get(context.Background(), "")
+	client.Services.GetByID(context.Background(), "svc-2")
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected requests to be throttled, 3 took %s", elapsed)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+}
+
+func TestNewClient_WithChangeListener(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"_id":"svc-1","uniqueName":"svc-one","name":"Service One"}`))
+	}))
+	defer server.Close()
+
+	var events []ChangeEvent
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL(server.URL),
+		WithChangeListener(func(ev ChangeEvent) { events = append(events, ev) }),
+	)
+
+	_, err := client.Services.Create(context.Background(), api.CreateServiceRequest{Name: "Service One", UniqueName: "svc-one"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	if events[0].Action != ChangeCreate || events[0].Endpoint != "/services" {
+		t.Errorf("Unexpected event %+v", events[0])
+	}
+	var created api.Service
+	if err := json.Unmarshal(events[0].After, &created); err != nil || created.ID != "svc-1" {
+		t.Errorf("Expected created service as after, got %s", events[0].After)
+	}
+}
+
+func TestNewClient_WithCircuitBreaker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithToken("test-token"), WithBaseURL(server.URL), WithCircuitBreaker(1, time.Minute))
+
+	client.Services.GetByID(context.Background(), "svc-1")
+	_, err := client.Accounts.Get(context.Background(), "")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected the breaker to be shared by service groups, got %v", err)
+	}
+}
+
+func TestNewClient_ConcurrentUse(t *testing.T) {
+	var mu sync.Mutex
+	conns := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		conns[r.RemoteAddr] = true
+		mu.Unlock()
+		w.Write([]byte(`{"_id":"x"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL(server.URL),
+		WithResponseCache(NewResponseCache(time.Minute)),
+		WithRateLimit(10000, 100),
+		WithCircuitBreaker(5, time.Second),
+		WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 8, MaxConnsPerHost: 8}),
+	)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := context.Background()
+			var err error
+			switch i % 4 {
+			case 0:
+				_, err = client.Services.GetByID(ctx, "svc-1")
+			case 1:
+				_, err = client.Accounts.Get(ctx, "")
+			case 2:
+				_, err = client.Services.UpdateServiceByID(ctx, "svc-1", api.UpdateServiceRequest{Description: "x"})
+			case 3:
+				_, err = client.Origins.GetByID(ctx, "org-1", "")
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	}
+	if len(conns) > 8 {
+		t.Errorf("Expected at most 8 connections, got %d", len(conns))
+	}
+}
+
+func TestNewClient_WithProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host+r.URL.Path)
+		w.Write([]byte(`{"_id":"acc"}`))
+	}))
+	defer proxy.Close()
+
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL("http://api.example.test/api/2.5"),
+		WithTransportOptions(TransportOptions{MaxIdleConnsPerHost: 4}),
+		WithProxy(proxy.URL, "direct.example.test"),
+	)
+	if _, err := client.Accounts.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Expected the request to go through the proxy, got %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "api.example.test/api/2.5/accounts/me" {
+		t.Errorf("Expected the proxy to receive the request, got %v", proxied)
+	}
+
+	invalid := NewClient(WithToken("test-token"), WithBaseURL("http://api.example.test/api/2.5"), WithProxy("://bad"))
+	if _, err := invalid.Accounts.Get(context.Background(), ""); err == nil || !strings.Contains(err.Error(), "invalid proxy URL") {
+		t.Errorf("Expected an invalid proxy URL error, got %v", err)
+	}
+}
+
+func TestNewClient_WithTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"_id":"acc"}`))
+	}))
+	defer server.Close()
+
+	untrusted := NewClient(WithToken("test-token"), WithBaseURL(server.URL+"/api/2.5"))
+	if _, err := untrusted.Accounts.Get(context.Background(), ""); err == nil {
+		t.Fatal("Expected the self-signed certificate to be rejected")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL(server.URL+"/api/2.5"),
+		WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}),
+	)
+	if _, err := client.Accounts.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Expected the custom CA to be trusted, got %v", err)
+	}
+}
+
+func TestNewClient_UserAgent(t *testing.T) {
+	var agents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents = append(agents, r.Header.Get("User-Agent"))
+		w.Write([]byte(`{"_id":"acc"}`))
+	}))
+	defer server.Close()
+
+	if !strings.HasPrefix(UserAgent(), "cachefly-sdk-go/"+Version+" go/1.") {
+		t.Errorf("Expected the SDK and Go versions, got %q", UserAgent())
+	}
+
+	for _, client := range []*Client{
+		NewClient(WithToken("test-token"), WithBaseURL(server.URL+"/api/2.5")),
+		NewClient(WithToken("test-token"), WithBaseURL(server.URL+"/api/2.5"),
+			WithUserAgentSuffix("my-app/2.0"), WithUserAgentSuffix(" "), WithUserAgentSuffix("terraform/1.9")),
+	} {
+		if _, err := client.Accounts.Get(context.Background(), ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if agents[0] != UserAgent() || agents[1] != UserAgent()+" my-app/2.0 terraform/1.9" {
+		t.Errorf("Expected the default and suffixed User-Agent, got %q", agents)
+	}
+}
+
+func TestNewClient_StrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"_id":"svc-1","name":"Web","edgeCompute":{"enabled":true}}`))
+	}))
+	defer server.Close()
+
+	lenient := NewClient(WithToken("test-token"), WithBaseURL(server.URL+"/api/2.5"))
+	svc, err := lenient.Services.GetByID(context.Background(), "svc-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if svc.Name != "Web" || string(svc.Raw) != `{"edgeCompute":{"enabled":true}}` {
+		t.Errorf("Expected the unknown field in Raw, got %+v", svc)
+	}
+
+	strict := NewClient(WithToken("test-token"), WithBaseURL(server.URL+"/api/2.5"), WithStrictDecoding(true))
+	if _, err := strict.Services.GetByID(context.Background(), "svc-1"); !errors.Is(err, ErrUnknownField) {
+		t.Errorf("Expected ErrUnknownField, got %v", err)
+	}
+}
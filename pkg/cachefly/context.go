@@ -0,0 +1,52 @@
+package cachefly
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// ContextWithHeaders returns a context whose calls send the given headers
+// in addition to those set by earlier ContextWithHeaders calls, so
+// middleware can attach request-scoped headers without changing call
+// sites. Headers the SDK sets itself, such as Authorization, Content-Type,
+// User-Agent and X-Request-ID, take precedence.
+//
+// Example:
+//
+//	ctx = cachefly.ContextWithHeaders(ctx, http.Header{"X-Tenant": {tenantID}})
+//	services, err := client.Services.List(ctx, api.ListOptions{})
+func ContextWithHeaders(ctx context.Context, header http.Header) context.Context {
+	return httpclient.WithHeaders(ctx, header)
+}
+
+// ContextWithBaggage returns a context whose calls send the members, along
+// with those of earlier ContextWithBaggage calls, in a W3C baggage header
+// for distributed tracing.
+//
+// Example:
+//
+//	ctx = cachefly.ContextWithBaggage(ctx, map[string]string{"tenant": tenantID})
+func ContextWithBaggage(ctx context.Context, members map[string]string) context.Context {
+	return httpclient.WithBaggage(ctx, members)
+}
+
+// ContextWithAccount returns a context whose calls are scheduled as
+// accountID by WithScheduler and, when credentials is non-nil, sent with
+// credentials instead of the client's, so one client can serve the
+// accounts of a request-scoped tenant. Responses of calls with their own
+// credentials bypass the response cache, which is shared by all accounts.
+//
+// Example:
+//
+//	creds := cachefly.ChildAccountCredentials(parent)
+//	provider, err := creds(childID)
+//	if err != nil {
+//		return err
+//	}
+//	ctx = cachefly.ContextWithAccount(ctx, childID, provider)
+//	services, err := client.Services.List(ctx, api.ListOptions{})
+func ContextWithAccount(ctx context.Context, accountID string, credentials CredentialsProvider) context.Context {
+	return httpclient.WithAccount(ctx, accountID, credentials)
+}
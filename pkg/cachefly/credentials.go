@@ -0,0 +1,215 @@
+package cachefly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// DefaultTokenEnvVar is the environment variable read by EnvToken when no
+// name is given.
+const DefaultTokenEnvVar = "CACHEFLY_API_TOKEN"
+
+// ErrNoCredentials is returned when a provider has no token to offer.
+var ErrNoCredentials = errors.New("cachefly: no credentials available")
+
+// CredentialsProvider supplies the bearer token for each API request.
+//
+// Token is called before every request, so providers can rotate tokens
+// without recreating the client. Implementations must be safe for
+// concurrent use.
+type CredentialsProvider = httpclient.CredentialsProvider
+
+// RefreshableCredentials is a CredentialsProvider that can replace a token
+// the API rejected. When a request fails with 401 Unauthorized the client
+// calls Refresh once and replays the request. Concurrent failures with the
+// same stale token share a single refresh.
+//
+// FileToken and RefreshingToken implement RefreshableCredentials.
+type RefreshableCredentials = httpclient.RefreshableCredentials
+
+// CredentialsFunc adapts a function to a CredentialsProvider.
+type CredentialsFunc func(ctx context.Context) (string, error)
+
+// Token calls f.
+func (f CredentialsFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticToken returns a provider that always returns token.
+func StaticToken(token string) CredentialsProvider {
+	return CredentialsFunc(func(ctx context.Context) (string, error) {
+		if token == "" {
+			return "", ErrNoCredentials
+		}
+		return token, nil
+	})
+}
+
+// EnvToken returns a provider that reads the token from an environment
+// variable on every request. An empty name uses DefaultTokenEnvVar.
+func EnvToken(name string) CredentialsProvider {
+	if name == "" {
+		name = DefaultTokenEnvVar
+	}
+	return CredentialsFunc(func(ctx context.Context) (string, error) {
+		token := strings.TrimSpace(os.Getenv(name))
+		if token == "" {
+			return "", fmt.Errorf("%w: environment variable %s is not set", ErrNoCredentials, name)
+		}
+		return token, nil
+	})
+}
+
+// FileToken returns a provider that reads the token from a file, such as a
+// mounted Kubernetes secret. The file is re-read when its modification time
+// changes, so rotated secrets are picked up automatically.
+func FileToken(path string) CredentialsProvider {
+	return &fileToken{path: path}
+}
+
+type fileToken struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	token   string
+}
+
+func (f *fileToken) Token(ctx context.Context) (string, error) {
+	return f.load(false)
+}
+
+// Refresh re-reads the file even if its modification time is unchanged.
+func (f *fileToken) Refresh(ctx context.Context, stale string) (string, error) {
+	return f.load(true)
+}
+
+func (f *fileToken) load(force bool) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", f.readError(err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if force || f.token == "" || !info.ModTime().Equal(f.modTime) {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return "", f.readError(err)
+		}
+		f.token = strings.TrimSpace(string(data))
+		f.modTime = info.ModTime()
+	}
+	if f.token == "" {
+		return "", fmt.Errorf("%w: token file %s is empty", ErrNoCredentials, f.path)
+	}
+	return f.token, nil
+}
+
+// readError reports a missing file as ErrNoCredentials, so ChainCredentials
+// tries the next provider.
+func (f *fileToken) readError(err error) error {
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: token file %s does not exist", ErrNoCredentials, f.path)
+	}
+	return fmt.Errorf("failed to read token file: %w", err)
+}
+
+// RefreshFunc obtains a new short-lived token and its expiry time.
+// A zero expiry means the token does not expire.
+type RefreshFunc func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+// RefreshingToken returns a provider that caches the token from refresh and
+// calls refresh again shortly before it expires.
+func RefreshingToken(refresh RefreshFunc) CredentialsProvider {
+	return &refreshingToken{refresh: refresh, skew: 30 * time.Second, mu: make(chan struct{}, 1)}
+}
+
+type refreshingToken struct {
+	refresh RefreshFunc
+	skew    time.Duration
+
+	// mu is a mutex that callers can stop waiting for when their context
+	// ends while another caller refreshes
+	mu        chan struct{}
+	token     string
+	expiresAt time.Time
+}
+
+func (r *refreshingToken) lock(ctx context.Context) error {
+	select {
+	case r.mu <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *refreshingToken) unlock() {
+	<-r.mu
+}
+
+func (r *refreshingToken) Token(ctx context.Context) (string, error) {
+	if err := r.lock(ctx); err != nil {
+		return "", err
+	}
+	defer r.unlock()
+
+	if r.token != "" && (r.expiresAt.IsZero() || time.Now().Add(r.skew).Before(r.expiresAt)) {
+		return r.token, nil
+	}
+	return r.refreshLocked(ctx)
+}
+
+// Refresh obtains a new token unless another caller already replaced stale.
+// The mutex serializes concurrent callers, so only the first one to see
+// stale calls the refresh function.
+func (r *refreshingToken) Refresh(ctx context.Context, stale string) (string, error) {
+	if err := r.lock(ctx); err != nil {
+		return "", err
+	}
+	defer r.unlock()
+
+	if r.token != "" && r.token != stale {
+		return r.token, nil
+	}
+	return r.refreshLocked(ctx)
+}
+
+func (r *refreshingToken) refreshLocked(ctx context.Context) (string, error) {
+	token, expiresAt, err := r.refresh(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if token == "" {
+		return "", fmt.Errorf("%w: refresh returned an empty token", ErrNoCredentials)
+	}
+	r.token, r.expiresAt = token, expiresAt
+	return token, nil
+}
+
+// ChainCredentials returns a provider that tries each provider in order and
+// uses the first token found. Providers reporting ErrNoCredentials are
+// skipped; any other error stops the chain.
+func ChainCredentials(providers ...CredentialsProvider) CredentialsProvider {
+	return CredentialsFunc(func(ctx context.Context) (string, error) {
+		for _, p := range providers {
+			token, err := p.Token(ctx)
+			if err == nil {
+				return token, nil
+			}
+			if !errors.Is(err, ErrNoCredentials) {
+				return "", err
+			}
+		}
+		return "", ErrNoCredentials
+	})
+}
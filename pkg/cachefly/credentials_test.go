@@ -0,0 +1,189 @@
+package cachefly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEnvToken(t *testing.T) {
+	t.Setenv("CACHEFLY_TEST_TOKEN", " env-token\n")
+
+	token, err := EnvToken("CACHEFLY_TEST_TOKEN").Token(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token != "env-token" {
+		t.Errorf("Expected env-token, got %s", token)
+	}
+
+	_, err = EnvToken("CACHEFLY_TEST_TOKEN_MISSING").Token(context.Background())
+	if !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Expected ErrNoCredentials, got %v", err)
+	}
+}
+
+func TestFileToken_PicksUpRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := FileToken(path)
+	token, err := provider.Token(context.Background())
+	if err != nil || token != "first" {
+		t.Fatalf("Expected first, got %s (%v)", token, err)
+	}
+
+	if err := os.WriteFile(path, []byte("second"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err = provider.Token(context.Background())
+	if err != nil || token != "second" {
+		t.Errorf("Expected second, got %s (%v)", token, err)
+	}
+}
+
+func TestRefreshingToken_CachesUntilExpiry(t *testing.T) {
+	var calls int32
+	provider := RefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			return "short-lived", time.Now().Add(time.Second), nil
+		}
+		return "fresh", time.Now().Add(time.Hour), nil
+	})
+
+	// the first token expires within the refresh skew, so each call refreshes
+	first, _ := provider.Token(context.Background())
+	second, _ := provider.Token(context.Background())
+	third, _ := provider.Token(context.Background())
+
+	if first != "short-lived" || second != "fresh" || third != "fresh" {
+		t.Errorf("Unexpected tokens: %s, %s, %s", first, second, third)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 refresh calls, got %d", calls)
+	}
+}
+
+func TestChainCredentials(t *testing.T) {
+	chain := ChainCredentials(EnvToken("CACHEFLY_TEST_TOKEN_MISSING"), StaticToken("fallback"))
+
+	token, err := chain.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if token != "fallback" {
+		t.Errorf("Expected fallback, got %s", token)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing-token")
+	chain = ChainCredentials(FileToken(missing), StaticToken("fallback"))
+	if token, err := chain.Token(context.Background()); err != nil || token != "fallback" {
+		t.Errorf("Expected a missing token file to fall through, got %q, %v", token, err)
+	}
+
+	boom := errors.New("boom")
+	chain = ChainCredentials(CredentialsFunc(func(ctx context.Context) (string, error) {
+		return "", boom
+	}), StaticToken("unused"))
+	if _, err := chain.Token(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("Expected chain to stop on error, got %v", err)
+	}
+}
+
+func TestWithCredentials_SetsAuthorizationHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer provided-token" {
+			t.Errorf("Expected provider token, got %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"svc-1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithToken("static-token"),
+		WithCredentials(StaticToken("provided-token")),
+		WithBaseURL(server.URL),
+	)
+
+	if _, err := client.Services.GetByID(context.Background(), "svc-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestRefreshingToken_WaitHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	provider := RefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		<-release
+		return "token", time.Time{}, nil
+	})
+
+	// The first caller holds the lock while refreshing
+	go provider.Token(context.Background())
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := provider.Token(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRefreshingToken_SingleFlightOnUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer rotated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"svc-1"}`))
+	}))
+	defer server.Close()
+
+	var calls int32
+	provider := RefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return "revoked", time.Time{}, nil
+		}
+		return "rotated", time.Time{}, nil
+	})
+	client := NewClient(WithCredentials(provider), WithBaseURL(server.URL))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Services.GetByID(context.Background(), "svc-1")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected initial fetch and a single refresh, got %d calls", calls)
+	}
+}
@@ -0,0 +1,7 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// DeprecationWarning reports an endpoint the API announced as deprecated.
+// Each endpoint is reported once per client, see WithDeprecationLogger.
+type DeprecationWarning = httpclient.DeprecationWarning
@@ -0,0 +1,307 @@
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// Area groups the findings of a report by what they affect.
+type Area string
+
+// Areas in the order the edge applies them.
+const (
+	AreaService Area = "service"
+	AreaHTTPS   Area = "https"
+	AreaAuth    Area = "auth"
+	AreaReferer Area = "referer"
+	AreaCORS    Area = "cors"
+	AreaRule    Area = "rule"
+	AreaScript  Area = "script"
+	AreaCache   Area = "cache"
+	AreaOrigin  Area = "origin"
+)
+
+// Finding is a setting that applies to the request.
+type Finding struct {
+	Area Area `json:"area"`
+
+	// Setting names the option, rule or script config
+	Setting string `json:"setting"`
+
+	// Source tells where the setting's value comes from, if it is a setting
+	Source api.SettingSource `json:"source,omitempty"`
+
+	Detail string `json:"detail"`
+
+	// Blocking is set when the setting can reject the request
+	Blocking bool `json:"blocking,omitempty"`
+}
+
+// Report lists the settings that apply to a request for a path.
+type Report struct {
+	ServiceID string    `json:"serviceId"`
+	Path      string    `json:"path"`
+	Findings  []Finding `json:"findings"`
+}
+
+// String renders the report as one line per finding.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", r.ServiceID, r.Path)
+	for _, f := range r.Findings {
+		fmt.Fprintf(&b, "  %-8s %s", f.Area, f.Setting)
+		if f.Source != "" {
+			fmt.Fprintf(&b, " (%s)", f.Source)
+		}
+		fmt.Fprintf(&b, ": %s", f.Detail)
+		if f.Blocking {
+			b.WriteString(" [may block]")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (r *Report) add(area Area, setting string, source api.SettingSource, format string, args ...interface{}) *Finding {
+	r.Findings = append(r.Findings, Finding{Area: area, Setting: setting, Source: source, Detail: fmt.Sprintf(format, args...)})
+	return &r.Findings[len(r.Findings)-1]
+}
+
+// Path reports which settings of the service would apply to a request for
+// requestPath. The query string of requestPath is ignored.
+func Path(ctx context.Context, client *cachefly.Client, serviceID, requestPath string) (*Report, error) {
+	cfg, err := client.Services.EffectiveConfig(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var referers []api.RefererRule
+	for offset := 0; ; offset += 100 {
+		page, err := client.ServiceOptionsRefererRules.List(ctx, serviceID, api.ListRefererRulesOptions{Offset: offset, Limit: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list referer rules: %w", err)
+		}
+		referers = append(referers, page.Rules...)
+		if len(page.Rules) < 100 {
+			break
+		}
+	}
+
+	return Evaluate(cfg, referers, requestPath), nil
+}
+
+// Evaluate reports which settings of cfg and which referer rules would
+// apply to a request for requestPath, without making any request.
+func Evaluate(cfg *api.EffectiveConfig, referers []api.RefererRule, requestPath string) *Report {
+	p, ext := splitPath(requestPath)
+	r := &Report{ServiceID: cfg.ServiceID, Path: p}
+
+	if cfg.Status != "" && !strings.EqualFold(cfg.Status, "ACTIVE") {
+		r.add(AreaService, "status", api.SourceService, "service is %s and does not serve requests", cfg.Status).Blocking = true
+	}
+
+	if s, on, _ := option(cfg, apispec.OptionAutoRedirect); on {
+		r.add(AreaHTTPS, apispec.OptionAutoRedirect, s.Source, "HTTP requests are redirected to HTTPS")
+	}
+	if s, _, v := option(cfg, apispec.OptionHsts); toInt(v) > 0 {
+		r.add(AreaHTTPS, apispec.OptionHsts, s.Source, "responses carry HSTS with max-age=%d", toInt(v))
+	}
+
+	if s, on, _ := option(cfg, apispec.OptionProtectServeKeyEnabled); on {
+		r.add(AreaAuth, apispec.OptionProtectServeKeyEnabled, s.Source, "requests must carry a valid ProtectServe token").Blocking = true
+	}
+
+	evaluateReferers(r, cfg, referers, p, ext)
+
+	if s, on, _ := option(cfg, apispec.OptionCors); on {
+		r.add(AreaCORS, apispec.OptionCors, s.Source, "CORS headers are added to responses")
+	}
+
+	for _, rule := range cfg.Rules {
+		if !matches(rule, p, ext) {
+			continue
+		}
+		r.add(AreaRule, ruleName(rule), "", "%s", ruleActions(rule))
+	}
+	for _, sc := range cfg.ScriptConfigs {
+		r.add(AreaScript, sc.Name, "", "script config %s (%s) applies to every request", sc.ID, sc.ScriptConfigDefinition)
+	}
+
+	evaluateCache(r, cfg, p, ext)
+	return r
+}
+
+// evaluateReferers reports the referer rule that applies to the path: the
+// first rule by order whose directory and extension match.
+func evaluateReferers(r *Report, cfg *api.EffectiveConfig, referers []api.RefererRule, p, ext string) {
+	if s, on, _ := option(cfg, apispec.OptionReferrerBlocking); on {
+		r.add(AreaReferer, apispec.OptionReferrerBlocking, s.Source, "requests from blocked referers are rejected").Blocking = true
+	}
+
+	rules := append([]api.RefererRule(nil), referers...)
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Order < rules[j].Order })
+	for _, rule := range rules {
+		if !prefixMatch(rule.Directory, p) || !extensionMatch(rule.Extension, ext) {
+			continue
+		}
+		f := r.add(AreaReferer, "referer rule "+rule.ID, "", "default action %s", rule.DefaultAction)
+		if len(rule.Exceptions) > 0 {
+			f.Detail += ", except for " + strings.Join(rule.Exceptions, ", ")
+		}
+		f.Blocking = !strings.EqualFold(rule.DefaultAction, "ALLOW") || len(rule.Exceptions) > 0
+		return
+	}
+}
+
+// evaluateCache reports the TTL and origin of the path. The expiry override
+// with the longest matching path wins, then one matching the extension, then
+// the TTL of the reverse proxy.
+func evaluateCache(r *Report, cfg *api.EffectiveConfig, p, ext string) {
+	proxy, proxyOn, proxyValue := option(cfg, apispec.OptionReverseProxy)
+	proxyConf, _ := proxyValue.(map[string]interface{})
+
+	var ttl *Finding
+	if s, on, v := option(cfg, apispec.OptionExpiryHeaders); on {
+		entries, _ := v.([]interface{})
+		var best map[string]interface{}
+		bestLen := -1
+		for _, e := range entries {
+			entry, _ := e.(map[string]interface{})
+			if dir, ok := entry["path"].(string); ok && prefixMatch(dir, p) && len(dir) > bestLen {
+				best, bestLen = entry, len(dir)
+			}
+		}
+		if best == nil {
+			for _, e := range entries {
+				entry, _ := e.(map[string]interface{})
+				if x, ok := entry["extension"].(string); ok && extensionMatch(x, ext) {
+					best = entry
+					break
+				}
+			}
+		}
+		if best != nil {
+			ttl = r.add(AreaCache, apispec.OptionExpiryHeaders, s.Source, "cached for %v seconds by the override for %s", best["expiryTime"], overrideTarget(best))
+		}
+	}
+	if ttl == nil && proxyOn && proxyConf["ttl"] != nil {
+		r.add(AreaCache, apispec.OptionReverseProxy, proxy.Source, "cached for %v seconds by the reverse proxy default", proxyConf["ttl"])
+	} else if ttl == nil {
+		r.add(AreaCache, "ttl", "", "no expiry override matches; origin cache headers apply")
+	}
+
+	if proxyOn {
+		f := r.add(AreaOrigin, apispec.OptionReverseProxy, proxy.Source, "cache misses are fetched from %v in %v mode", proxyConf["hostname"], proxyConf["mode"])
+		if scheme, _ := proxyConf["originScheme"].(string); scheme != "" {
+			f.Detail += " over " + scheme
+		}
+		if byQuery, _ := proxyConf["cacheByQueryParam"].(bool); byQuery {
+			f.Detail += ", cached per query string"
+		}
+	} else {
+		r.add(AreaOrigin, "storage", "", "reverse proxy is off; content is served from CacheFly storage")
+	}
+}
+
+// option returns an option setting, whether it is enabled and its value.
+func option(cfg *api.EffectiveConfig, name string) (api.EffectiveSetting, bool, interface{}) {
+	on, value := cfg.Enabled(name)
+	return cfg.Settings[name], on, value
+}
+
+// splitPath returns the cleaned path without query and its lowercase
+// extension without the dot.
+func splitPath(requestPath string) (string, string) {
+	if i := strings.IndexAny(requestPath, "?#"); i >= 0 {
+		requestPath = requestPath[:i]
+	}
+	p := path.Clean("/" + requestPath)
+	return p, strings.ToLower(strings.TrimPrefix(path.Ext(p), "."))
+}
+
+// prefixMatch reports whether p lies in directory; an empty directory
+// matches every path.
+func prefixMatch(directory, p string) bool {
+	if directory == "" || directory == "/" {
+		return true
+	}
+	directory = path.Clean("/" + directory)
+	return p == directory || strings.HasPrefix(p, directory+"/")
+}
+
+// extensionMatch reports whether ext is one of the comma-separated
+// extensions of want; an empty want matches every extension.
+func extensionMatch(want, ext string) bool {
+	if want == "" {
+		return true
+	}
+	for _, w := range strings.Split(want, ",") {
+		if strings.EqualFold(strings.TrimPrefix(strings.TrimSpace(w), "."), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func overrideTarget(entry map[string]interface{}) string {
+	if dir, ok := entry["path"].(string); ok {
+		return dir
+	}
+	return "*." + strings.TrimPrefix(fmt.Sprint(entry["extension"]), ".")
+}
+
+// matches reports whether a service rule applies to the path, by its
+// directory or path and its extension.
+func matches(rule map[string]interface{}, p, ext string) bool {
+	dir, _ := rule["directory"].(string)
+	if dir == "" {
+		dir, _ = rule["path"].(string)
+	}
+	x, _ := rule["extension"].(string)
+	return prefixMatch(dir, p) && extensionMatch(x, ext)
+}
+
+func ruleName(rule map[string]interface{}) string {
+	if id, ok := rule["_id"].(string); ok && id != "" {
+		return "rule " + id
+	}
+	return "rule"
+}
+
+// ruleActions renders the fields of a rule other than its matching criteria.
+func ruleActions(rule map[string]interface{}) string {
+	var keys []string
+	for k := range rule {
+		switch {
+		case api.IsServerField(k), k == "directory", k == "path", k == "extension":
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 {
+		return "matches the path"
+	}
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%v", k, rule[k])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
@@ -0,0 +1,141 @@
+package diagnose
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func testConfig() *api.EffectiveConfig {
+	return &api.EffectiveConfig{
+		ServiceID: "svc-1",
+		Status:    "ACTIVE",
+		Settings: map[string]api.EffectiveSetting{
+			"autoRedirect":           {Value: true, Source: api.SourceOption},
+			"protectServeKeyEnabled": {Value: false, Source: api.SourceDefault},
+			"expiryHeaders": {Source: api.SourceOption, Value: map[string]interface{}{
+				"enabled": true,
+				"value": []interface{}{
+					map[string]interface{}{"path": "/images", "expiryTime": 3600.0},
+					map[string]interface{}{"path": "/images/products", "expiryTime": 600.0},
+					map[string]interface{}{"extension": "css", "expiryTime": 86400.0},
+				},
+			}},
+			"reverseProxy": {Source: api.SourceOption, Value: map[string]interface{}{
+				"enabled": true, "mode": "WEB", "hostname": "origin.example.com", "ttl": 7200.0,
+			}},
+		},
+		Rules: []map[string]interface{}{
+			{"_id": "rule-geo", "directory": "/images", "geoBlock": []interface{}{"CN"}},
+			{"_id": "rule-js", "extension": "js", "cacheKey": "full"},
+		},
+	}
+}
+
+func find(r *Report, area Area) []Finding {
+	var found []Finding
+	for _, f := range r.Findings {
+		if f.Area == area {
+			found = append(found, f)
+		}
+	}
+	return found
+}
+
+func TestEvaluate(t *testing.T) {
+	referers := []api.RefererRule{
+		{ID: "ref-all", Directory: "/", DefaultAction: "ALLOW", Order: 2},
+		{ID: "ref-img", Directory: "/images", Extension: "png,jpg", DefaultAction: "DENY", Exceptions: []string{"example.com"}, Order: 1},
+	}
+
+	r := Evaluate(testConfig(), referers, "/images/products/foo.PNG?w=100")
+
+	if r.Path != "/images/products/foo.PNG" {
+		t.Errorf("Expected path without query, got %s", r.Path)
+	}
+	if https := find(r, AreaHTTPS); len(https) != 1 {
+		t.Errorf("Expected HTTPS redirect, got %+v", https)
+	}
+	if auth := find(r, AreaAuth); len(auth) != 0 {
+		t.Errorf("Expected no token auth, got %+v", auth)
+	}
+	ref := find(r, AreaReferer)
+	if len(ref) != 1 || ref[0].Setting != "referer rule ref-img" || !ref[0].Blocking {
+		t.Errorf("Expected the first matching referer rule by order, got %+v", ref)
+	}
+	rules := find(r, AreaRule)
+	if len(rules) != 1 || !strings.Contains(rules[0].Detail, "geoBlock=[CN]") {
+		t.Errorf("Expected the geo rule to match, got %+v", rules)
+	}
+	cache := find(r, AreaCache)
+	if len(cache) != 1 || !strings.Contains(cache[0].Detail, "600 seconds") {
+		t.Errorf("Expected the longest matching expiry override, got %+v", cache)
+	}
+	origin := find(r, AreaOrigin)
+	if len(origin) != 1 || !strings.Contains(origin[0].Detail, "origin.example.com") {
+		t.Errorf("Expected reverse proxy origin, got %+v", origin)
+	}
+}
+
+func TestEvaluate_Fallbacks(t *testing.T) {
+	cfg := testConfig()
+	cfg.Status = "inactive"
+
+	r := Evaluate(cfg, nil, "/app.js")
+	if svc := find(r, AreaService); len(svc) != 1 || !svc[0].Blocking {
+		t.Errorf("Expected deactivated service to block, got %+v", svc)
+	}
+	if rules := find(r, AreaRule); len(rules) != 1 || rules[0].Setting != "rule rule-js" {
+		t.Errorf("Expected the extension rule to match, got %+v", rules)
+	}
+	if cache := find(r, AreaCache); len(cache) != 1 || !strings.Contains(cache[0].Detail, "reverse proxy default") {
+		t.Errorf("Expected the reverse proxy TTL, got %+v", cache)
+	}
+
+	r = Evaluate(cfg, nil, "/styles/site.css")
+	if cache := find(r, AreaCache); len(cache) != 1 || !strings.Contains(cache[0].Detail, "*.css") {
+		t.Errorf("Expected the extension override, got %+v", cache)
+	}
+}
+
+func TestPath(t *testing.T) {
+	routes := map[string]string{
+		"/api/2.5/services/svc-1":                      `{"_id":"svc-1","status":"ACTIVE"}`,
+		"/api/2.5/accounts/me":                         `{"_id":"acc-1"}`,
+		"/api/2.5/services/svc-1/options/metadata":     `{"data":[{"name":"ProtectServe","type":"standard"}]}`,
+		"/api/2.5/services/svc-1/options":              `{"protectServeKeyEnabled":true}`,
+		"/api/2.5/scriptConfigs":                       `{"data":[]}`,
+		"/api/2.5/services/svc-1/rules":                `{"data":[]}`,
+		"/api/2.5/services/svc-1/options/refererrules": `{"data":[{"_id":"ref-1","directory":"/","defaultAction":"ALLOW"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	report, err := Path(context.Background(), client, "svc-1", "/index.html")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if auth := find(report, AreaAuth); len(auth) != 1 || !auth[0].Blocking {
+		t.Errorf("Expected ProtectServe token auth, got %+v", auth)
+	}
+	if ref := find(report, AreaReferer); len(ref) != 1 || ref[0].Blocking {
+		t.Errorf("Expected a non-blocking referer rule, got %+v", ref)
+	}
+	if !strings.Contains(report.String(), "svc-1 /index.html") {
+		t.Errorf("Expected report header, got:\n%s", report)
+	}
+}
@@ -0,0 +1,18 @@
+// Package diagnose explains how a service's configuration applies to a
+// request, as a traceroute for CDN config.
+//
+// Path resolves the effective configuration of a service and reports, in the
+// order the edge applies them, which settings a request for a path would
+// meet: service status, HTTPS redirects, token authentication, referer
+// policy, matching rules, the cache TTL and the origin:
+//
+//	report, err := diagnose.Path(ctx, client, serviceID, "/images/foo.png")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(report)
+//
+// The evaluation models the documented semantics of each setting; it does
+// not send the request. Geo restrictions and other behavior configured
+// through service rules are reported as the rules that match the path.
+package diagnose
@@ -0,0 +1,261 @@
+package cachefly
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// ChangeType describes how a value differs between two configurations.
+type ChangeType string
+
+// Change types reported in FieldChange.
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// FieldChange describes a single changed field or option.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Type  ChangeType  `json:"type"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// ConfigDiff is a structured diff between two service configurations.
+type ConfigDiff struct {
+	Service        []FieldChange `json:"service,omitempty"`
+	Options        []FieldChange `json:"options,omitempty"`
+	AddedDomains   []string      `json:"addedDomains,omitempty"`
+	RemovedDomains []string      `json:"removedDomains,omitempty"`
+	AddedOrigins   []string      `json:"addedOrigins,omitempty"`
+	RemovedOrigins []string      `json:"removedOrigins,omitempty"`
+	RulesChanged   bool          `json:"rulesChanged"`
+}
+
+// DiffOption changes what DiffConfigs compares.
+type DiffOption func(*diffSettings)
+
+type diffSettings struct {
+	identity bool
+}
+
+// DiffIdentity also compares the fields that identify a service: its name,
+// uniqueName and status. Use it when a and b are the same service at two
+// points in time.
+func DiffIdentity() DiffOption {
+	return func(s *diffSettings) {
+		s.identity = true
+	}
+}
+
+// DiffConfigs compares two exported service configurations and reports what
+// would change going from a to b.
+//
+// Server-assigned fields such as IDs and timestamps are ignored, and so,
+// unless DiffIdentity is given, are the name, uniqueName and status that
+// tell services apart, so the configurations of two different services can
+// be compared directly.
+//
+// Example:
+//
+//	staging, _ := client.Services.ExportConfig(ctx, "srv_staging")
+//	prod, _ := client.Services.ExportConfig(ctx, "srv_prod")
+//
+//	diff := cachefly.DiffConfigs(prod, staging)
+//	if !diff.Empty() {
+//		fmt.Println(diff)
+//	}
+func DiffConfigs(a, b *api.ServiceConfig, opts ...DiffOption) *ConfigDiff {
+	var settings diffSettings
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	if a == nil {
+		a = &api.ServiceConfig{}
+	}
+	if b == nil {
+		b = &api.ServiceConfig{}
+	}
+
+	diff := &ConfigDiff{}
+
+	type serviceField struct {
+		name     string
+		old, new interface{}
+	}
+	var serviceFields []serviceField
+	if settings.identity {
+		serviceFields = append(serviceFields,
+			serviceField{"name", a.Service.Name, b.Service.Name},
+			serviceField{"uniqueName", a.Service.UniqueName, b.Service.UniqueName},
+		)
+	}
+	serviceFields = append(serviceFields, []serviceField{
+		{"description", a.Service.Description, b.Service.Description},
+		{"autoSsl", a.Service.AutoSSL, b.Service.AutoSSL},
+		{"configurationMode", a.Service.ConfigurationMode, b.Service.ConfigurationMode},
+		{"tlsProfile", a.Service.TLSProfile, b.Service.TLSProfile},
+		{"deliveryRegion", a.Service.DeliveryRegion, b.Service.DeliveryRegion},
+	}...)
+	if settings.identity {
+		serviceFields = append(serviceFields, serviceField{"status", a.Service.Status, b.Service.Status})
+	}
+	for _, f := range serviceFields {
+		if f.old != f.new {
+			diff.Service = append(diff.Service, FieldChange{Field: f.name, Type: ChangeModified, Old: f.old, New: f.new})
+		}
+	}
+
+	diff.Options = diffOptions(a.Options, b.Options)
+
+	// Hostnames are compared ignoring case, as ImportConfig and
+	// resourceops.EnsureDomain match them
+	domainNames := func(domains []api.ServiceDomain) []string {
+		names := make([]string, len(domains))
+		for i, d := range domains {
+			names[i] = strings.ToLower(d.Name)
+		}
+		return names
+	}
+	diff.AddedDomains, diff.RemovedDomains = diffSets(domainNames(a.Domains), domainNames(b.Domains))
+
+	originKeys := func(origins []api.Origin) []string {
+		keys := make([]string, len(origins))
+		for i, o := range origins {
+			keys[i] = o.Type + ":" + strings.ToLower(o.Hostname)
+		}
+		return keys
+	}
+	diff.AddedOrigins, diff.RemovedOrigins = diffSets(originKeys(a.Origins), originKeys(b.Origins))
+
+	diff.RulesChanged = !reflect.DeepEqual(normalizeRules(a.Rules), normalizeRules(b.Rules))
+
+	return diff
+}
+
+// Empty reports whether the two configurations are equivalent.
+func (d *ConfigDiff) Empty() bool {
+	return len(d.Service) == 0 && len(d.Options) == 0 &&
+		len(d.AddedDomains) == 0 && len(d.RemovedDomains) == 0 &&
+		len(d.AddedOrigins) == 0 && len(d.RemovedOrigins) == 0 &&
+		!d.RulesChanged
+}
+
+// String renders the diff in a human-readable, line-oriented form.
+func (d *ConfigDiff) String() string {
+	if d.Empty() {
+		return "no changes"
+	}
+
+	var sb strings.Builder
+	for _, c := range d.Service {
+		fmt.Fprintf(&sb, "~ service.%s: %s -> %s\n", c.Field, formatValue(c.Old), formatValue(c.New))
+	}
+	for _, c := range d.Options {
+		switch c.Type {
+		case ChangeAdded:
+			fmt.Fprintf(&sb, "+ option %s = %s\n", c.Field, formatValue(c.New))
+		case ChangeRemoved:
+			fmt.Fprintf(&sb, "- option %s = %s\n", c.Field, formatValue(c.Old))
+		default:
+			fmt.Fprintf(&sb, "~ option %s: %s -> %s\n", c.Field, formatValue(c.Old), formatValue(c.New))
+		}
+	}
+	for _, name := range d.AddedDomains {
+		fmt.Fprintf(&sb, "+ domain %s\n", name)
+	}
+	for _, name := range d.RemovedDomains {
+		fmt.Fprintf(&sb, "- domain %s\n", name)
+	}
+	for _, key := range d.AddedOrigins {
+		fmt.Fprintf(&sb, "+ origin %s\n", key)
+	}
+	for _, key := range d.RemovedOrigins {
+		fmt.Fprintf(&sb, "- origin %s\n", key)
+	}
+	if d.RulesChanged {
+		sb.WriteString("~ rules changed\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// diffOptions compares option maps after normalizing values through JSON,
+// so that e.g. int(3600) and float64(3600) compare equal.
+func diffOptions(a, b api.ServiceOptions) []FieldChange {
+	names := make(map[string]bool)
+	for name := range a {
+		names[name] = true
+	}
+	for name := range b {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []FieldChange
+	for _, name := range sorted {
+		oldVal, inA := a[name]
+		newVal, inB := b[name]
+		switch {
+		case !inA:
+			changes = append(changes, FieldChange{Field: name, Type: ChangeAdded, New: newVal})
+		case !inB:
+			changes = append(changes, FieldChange{Field: name, Type: ChangeRemoved, Old: oldVal})
+		case !reflect.DeepEqual(api.NormalizeValue(oldVal), api.NormalizeValue(newVal)):
+			changes = append(changes, FieldChange{Field: name, Type: ChangeModified, Old: oldVal, New: newVal})
+		}
+	}
+	return changes
+}
+
+// diffSets returns the sorted elements only in b (added) and only in a (removed).
+func diffSets(a, b []string) (added, removed []string) {
+	inA := make(map[string]bool, len(a))
+	for _, v := range a {
+		inA[v] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, v := range b {
+		inB[v] = true
+		if !inA[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range a {
+		if !inB[v] {
+			removed = append(removed, v)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// normalizeRules strips server-assigned fields from rules before comparison.
+func normalizeRules(rules []map[string]interface{}) []interface{} {
+	normalized := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		normalized = append(normalized, api.NormalizeValue(api.StripServerFields(rule)))
+	}
+	return normalized
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
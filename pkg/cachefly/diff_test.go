@@ -0,0 +1,98 @@
+package cachefly
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	a := &api.ServiceConfig{
+		Service: api.Service{ID: "srv-a", Name: "Staging", UniqueName: "staging", Status: "inactive", Description: "old"},
+		Options: api.ServiceOptions{"cors": true, "ttl": float64(3600), "ftp": true},
+		Domains: []api.ServiceDomain{{Name: "a.example.com"}, {Name: "shared.example.com"}},
+		Rules:   []map[string]interface{}{{"_id": "rule-a", "path": "/img/*"}},
+	}
+	b := &api.ServiceConfig{
+		Service: api.Service{ID: "srv-b", Name: "Production", UniqueName: "production", Status: "ACTIVE", Description: "new"},
+		Options: api.ServiceOptions{"cors": false, "ttl": 3600, "autoRedirect": true},
+		Domains: []api.ServiceDomain{{Name: "shared.example.com"}, {Name: "b.example.com"}},
+		Rules:   []map[string]interface{}{{"_id": "rule-b", "path": "/img/*"}},
+	}
+
+	diff := DiffConfigs(a, b)
+
+	if len(diff.Service) != 1 || diff.Service[0].Field != "description" {
+		t.Errorf("Expected only description to change, got %v", diff.Service)
+	}
+	if len(diff.Options) != 3 {
+		t.Fatalf("Expected 3 option changes, got %v", diff.Options)
+	}
+	if diff.Options[0].Field != "autoRedirect" || diff.Options[0].Type != ChangeAdded {
+		t.Errorf("Expected autoRedirect added, got %v", diff.Options[0])
+	}
+	if diff.Options[1].Field != "cors" || diff.Options[1].Type != ChangeModified {
+		t.Errorf("Expected cors modified, got %v", diff.Options[1])
+	}
+	if diff.Options[2].Field != "ftp" || diff.Options[2].Type != ChangeRemoved {
+		t.Errorf("Expected ftp removed, got %v", diff.Options[2])
+	}
+	if len(diff.AddedDomains) != 1 || diff.AddedDomains[0] != "b.example.com" {
+		t.Errorf("Expected b.example.com added, got %v", diff.AddedDomains)
+	}
+	if len(diff.RemovedDomains) != 1 || diff.RemovedDomains[0] != "a.example.com" {
+		t.Errorf("Expected a.example.com removed, got %v", diff.RemovedDomains)
+	}
+	if diff.RulesChanged {
+		t.Error("Expected rules differing only by _id to be equal")
+	}
+
+	out := diff.String()
+	for _, want := range []string{"~ service.description", "+ option autoRedirect", "- domain a.example.com"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestDiffConfigs_Identity(t *testing.T) {
+	a := &api.ServiceConfig{Service: api.Service{ID: "srv-1", Name: "Shop", UniqueName: "shop", Status: "ACTIVE"}}
+	b := &api.ServiceConfig{Service: api.Service{ID: "srv-1", Name: "Store", UniqueName: "shop", Status: "inactive"}}
+
+	if diff := DiffConfigs(a, b); !diff.Empty() {
+		t.Errorf("Expected identity fields to be ignored, got %s", diff)
+	}
+
+	diff := DiffConfigs(a, b, DiffIdentity())
+	if len(diff.Service) != 2 || diff.Service[0].Field != "name" || diff.Service[1].Field != "status" {
+		t.Errorf("Expected name and status to change, got %v", diff.Service)
+	}
+}
+
+func TestDiffConfigs_Equal(t *testing.T) {
+	cfg := &api.ServiceConfig{Options: api.ServiceOptions{"cors": true}}
+
+	diff := DiffConfigs(cfg, cfg)
+	if !diff.Empty() {
+		t.Errorf("Expected empty diff, got %s", diff)
+	}
+	if diff.String() != "no changes" {
+		t.Errorf("Expected 'no changes', got %s", diff.String())
+	}
+}
+
+func TestDiffConfigs_HostnameCase(t *testing.T) {
+	a := &api.ServiceConfig{
+		Domains: []api.ServiceDomain{{Name: "WWW.Example.com"}},
+		Origins: []api.Origin{{Type: "WEB", Hostname: "Origin.Example.com"}},
+	}
+	b := &api.ServiceConfig{
+		Domains: []api.ServiceDomain{{Name: "www.example.com"}},
+		Origins: []api.Origin{{Type: "WEB", Hostname: "origin.example.com"}},
+	}
+
+	if diff := DiffConfigs(a, b); !diff.Empty() {
+		t.Errorf("Expected hostnames to match ignoring case, got %s", diff)
+	}
+}
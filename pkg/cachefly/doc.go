@@ -53,6 +53,38 @@
 //	    log.Printf("Failed to get service: %v", err)
 //	}
 //
+// # Cancellation
+//
+// Every method takes a context and returns promptly once it ends, including
+// while waiting for a retry backoff, for API maintenance to end, for a
+// Scheduler slot, for another caller's token refresh, between the pages of
+// multi-page helpers and between the chunks of Purge.Paths. No request is
+// started after the context has ended, even one that could be served from
+// the response cache.
+//
+// Single requests return context.Canceled or context.DeadlineExceeded
+// unwrapped. Helpers that make several requests, such as ExportConfig or
+// RenewExpiring, may wrap it with the step that was interrupted and return
+// the partial result they have, so test with errors.Is:
+//
+//	report, err := client.Purge.Paths(ctx, serviceID, paths)
+//	if errors.Is(err, context.Canceled) {
+//	    // report lists the chunks that were sent before cancellation
+//	}
+//
+// # Concurrency
+//
+// A Client and its service groups are safe for concurrent use by multiple
+// goroutines. Create one Client and share it: the service groups share its
+// connection pool, response cache, rate limiter and circuit breaker. For
+// highly concurrent use, raise the idle connections kept per host with
+// WithTransportOptions so connections are reused:
+//
+//	client := cachefly.NewClient(
+//	    cachefly.WithToken("your-token"),
+//	    cachefly.WithTransportOptions(cachefly.TransportOptions{MaxIdleConnsPerHost: 32}),
+//	)
+//
 // # Configuration Options
 //
 // The client supports several configuration options:
@@ -60,6 +92,7 @@
 //	client := cachefly.NewClient(
 //	    cachefly.WithToken("your-token"),           // API authentication
 //	    cachefly.WithBaseURL("https://api.example"), // Custom API endpoint
+//	    cachefly.WithAPIVersion("2.6"),              // Target a specific API version
 //	)
 //
 // # Examples
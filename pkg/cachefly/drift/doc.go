@@ -0,0 +1,22 @@
+// Package drift reports the response fields the API returns that the SDK's
+// types do not model, so that maintainers and users notice when the SDK has
+// fallen behind the API.
+//
+// Check GETs the read-only endpoints of the account, and of a service when
+// one is given, and compares each response with the type the SDK decodes
+// it into:
+//
+//	report, err := drift.Check(ctx, client, serviceID)
+//	if err != nil {
+//		return err
+//	}
+//	if report.Drifted() {
+//		fmt.Print(report)
+//	}
+//
+// Compare does the same for a response obtained elsewhere. Fields are
+// matched by their JSON names the way encoding/json matches them, so a
+// reported field is one a decoded value silently drops. Values decoded into
+// interface{}, maps of them or json.RawMessage are kept whole and never
+// reported.
+package drift
@@ -0,0 +1,283 @@
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// maxSample is the length past which samples are shortened.
+const maxSample = 80
+
+// Endpoint is a GET endpoint and the SDK type its response decodes into.
+type Endpoint struct {
+	Name string
+
+	// Path is relative to the API base URL, such as "/services"
+	Path string
+
+	// Model is a value of the SDK type, such as api.Service{}
+	Model interface{}
+}
+
+// Field is a response field the SDK type does not model.
+type Field struct {
+	// Path locates the field in the response, such as "data[].edgeCompute"
+	Path string `json:"path"`
+
+	// Type is the SDK type that drops the field
+	Type string `json:"type"`
+
+	// Sample is the first value the field had, shortened if long
+	Sample string `json:"sample"`
+}
+
+// Result is the comparison of one endpoint's response.
+type Result struct {
+	Endpoint string  `json:"endpoint"`
+	Path     string  `json:"path"`
+	Dropped  []Field `json:"dropped,omitempty"`
+
+	// Error is set when the endpoint could not be fetched, for example
+	// because the account's plan does not include it
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the comparison of each endpoint checked.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Drifted reports whether any response had fields the SDK drops.
+func (r *Report) Drifted() bool {
+	for _, res := range r.Results {
+		if len(res.Dropped) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the report as one line per endpoint, followed by the
+// fields it drops.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, res := range r.Results {
+		switch {
+		case res.Error != "":
+			fmt.Fprintf(&b, "%s %s: skipped: %s\n", res.Endpoint, res.Path, res.Error)
+		case len(res.Dropped) == 0:
+			fmt.Fprintf(&b, "%s %s: ok\n", res.Endpoint, res.Path)
+		default:
+			fmt.Fprintf(&b, "%s %s: %d dropped\n", res.Endpoint, res.Path, len(res.Dropped))
+			for _, f := range res.Dropped {
+				fmt.Fprintf(&b, "  %s (%s) = %s\n", f.Path, f.Type, f.Sample)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Endpoints returns the endpoints Check compares: those of the account and,
+// if serviceID is set, those of the service.
+func Endpoints(serviceID string) []Endpoint {
+	endpoints := []Endpoint{
+		{Name: "account", Path: apispec.PathCurrentAccount, Model: api.Account{}},
+		{Name: "account limits", Path: apispec.PathCurrentAccountLimits, Model: api.AccountLimits{}},
+		{Name: "account features", Path: apispec.PathCurrentAccountFeatures, Model: api.AccountCapabilities{}},
+		{Name: "current user", Path: apispec.PathCurrentUser, Model: api.User{}},
+		{Name: "services", Path: apispec.PathServices, Model: api.ListServicesResponse{}},
+		{Name: "certificates", Path: apispec.PathCertificates, Model: api.ListCertificatesResponse{}},
+		{Name: "origins", Path: apispec.PathOrigins, Model: api.ListOriginsResponse{}},
+		{Name: "users", Path: apispec.PathUsers, Model: api.ListUsersResponse{}},
+		{Name: "script configs", Path: apispec.PathScriptConfigs, Model: api.ListScriptConfigsResponse{}},
+		{Name: "tls profiles", Path: apispec.PathTLSProfiles, Model: api.ListTLSProfilesResponse{}},
+		{Name: "tokens", Path: apispec.PathTokens, Model: api.ListTokensResponse{}},
+		{Name: "webhooks", Path: apispec.PathWebhooks, Model: api.ListWebhooksResponse{}},
+		{Name: "alerts", Path: apispec.PathAlerts, Model: api.ListAlertRulesResponse{}},
+		{Name: "pops", Path: apispec.PathNetworkPOPs, Model: struct {
+			Data []api.POP `json:"data"`
+		}{}},
+	}
+	if serviceID == "" {
+		return endpoints
+	}
+
+	id := url.PathEscape(serviceID)
+	return append(endpoints,
+		Endpoint{Name: "service", Path: fmt.Sprintf(apispec.PathService, id), Model: api.Service{}},
+		Endpoint{Name: "service features", Path: fmt.Sprintf(apispec.PathServiceFeatures, id), Model: api.ServiceFeatures{}},
+		Endpoint{Name: "service domains", Path: fmt.Sprintf(apispec.PathServiceDomains, id), Model: api.ListServiceDomainsResponse{}},
+		Endpoint{Name: "service rules", Path: fmt.Sprintf(apispec.PathServiceRules, id), Model: api.ListServiceRulesResponse{}},
+		Endpoint{Name: "referer rules", Path: fmt.Sprintf(apispec.PathServiceRefererRules, id), Model: api.ListRefererRulesResponse{}},
+	)
+}
+
+// Check compares the responses of Endpoints(serviceID) with the SDK types.
+func Check(ctx context.Context, client *cachefly.Client, serviceID string) (*Report, error) {
+	return CheckEndpoints(ctx, client, Endpoints(serviceID))
+}
+
+// CheckEndpoints compares the responses of endpoints with the SDK types.
+// Requests go through the HTTP client of client.Services. An endpoint that
+// cannot be fetched is reported with its error rather than failing the
+// check; only the cancellation of ctx does.
+func CheckEndpoints(ctx context.Context, client *cachefly.Client, endpoints []Endpoint) (*Report, error) {
+	report := &Report{}
+	for _, e := range endpoints {
+		res := Result{Endpoint: e.Name, Path: e.Path}
+
+		var body json.RawMessage
+		if err := client.Services.Client.Get(ctx, e.Path, &body); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			res.Error = err.Error()
+		} else if res.Dropped, err = Compare(body, e.Model); err != nil {
+			res.Error = err.Error()
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report, nil
+}
+
+// Compare returns the fields of the JSON response data that model, a value
+// of the SDK type the response decodes into, does not model, sorted by
+// path. A field found in several list elements is reported once.
+func Compare(data []byte, model interface{}) ([]Field, error) {
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("response is not valid JSON")
+	}
+	dropped := make(map[string]Field)
+	walk(data, reflect.TypeOf(model), "", dropped)
+
+	fields := make([]Field, 0, len(dropped))
+	for _, f := range dropped {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+	return fields, nil
+}
+
+var (
+	rawMessageType  = reflect.TypeOf(json.RawMessage(nil))
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// walk records in dropped the fields of data that t does not model.
+func walk(data json.RawMessage, t reflect.Type, path string, dropped map[string]Field) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// types that decode themselves, and interface{}, keep every field
+	if t == nil || t == rawMessageType || t.Kind() == reflect.Interface ||
+		t.Implements(unmarshalerType) || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var members map[string]json.RawMessage
+		if json.Unmarshal(data, &members) != nil {
+			return
+		}
+		fields := modelFields(t)
+		for key, value := range members {
+			field, ok := fields[key]
+			if !ok {
+				field, ok = fields[strings.ToLower(key)]
+			}
+			if !ok {
+				name := join(path, key)
+				if _, seen := dropped[name]; !seen {
+					dropped[name] = Field{Path: name, Type: t.String(), Sample: sample(value)}
+				}
+				continue
+			}
+			walk(value, field, join(path, key), dropped)
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			return
+		}
+		for _, elem := range elems {
+			walk(elem, t.Elem(), path+"[]", dropped)
+		}
+	case reflect.Map:
+		var members map[string]json.RawMessage
+		if json.Unmarshal(data, &members) != nil {
+			return
+		}
+		for _, value := range members {
+			walk(value, t.Elem(), join(path, "*"), dropped)
+		}
+	}
+}
+
+// modelFields maps the JSON names of the fields of struct type t, including
+// promoted ones, to their types. Names are also keyed in lower case for
+// the case-insensitive match encoding/json falls back to.
+func modelFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for key, typ := range modelFields(ft) {
+				if _, ok := fields[key]; !ok {
+					fields[key] = typ
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+		if lower := strings.ToLower(name); lower != name {
+			if _, ok := fields[lower]; !ok {
+				fields[lower] = f.Type
+			}
+		}
+	}
+	return fields
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sample(value json.RawMessage) string {
+	s := string(value)
+	if len(s) > maxSample {
+		return s[:maxSample] + "..."
+	}
+	return s
+}
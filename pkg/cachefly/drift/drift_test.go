@@ -0,0 +1,122 @@
+package drift
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestCompare(t *testing.T) {
+	data := []byte(`{
+		"meta": {"count": 2, "limit": 10, "offset": 0, "cursor": "abc"},
+		"data": [
+			{"_id": "svc-1", "Name": "Shop", "edgeCompute": {"enabled": true}},
+			{"_id": "svc-2", "name": "Blog", "edgeCompute": {"enabled": false}, "tags": ["a"]}
+		]
+	}`)
+
+	fields, err := Compare(data, api.ListServicesResponse{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []Field{
+		{Path: "data[].edgeCompute", Type: "v2_5.Service", Sample: `{"enabled": true}`},
+		{Path: "data[].tags", Type: "v2_5.Service", Sample: `["a"]`},
+		{Path: "meta.cursor", Type: "v2_5.MetaInfo", Sample: `"abc"`},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, fields)
+	}
+}
+
+func TestCompare_KeepsUntypedValues(t *testing.T) {
+	data := []byte(`{"serviceId":"svc-1","settings":{"ttl":{"value":1,"source":"option","extra":true}},"rules":[{"anything":1}]}`)
+
+	fields, err := Compare(data, api.EffectiveConfig{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(fields) != 1 || fields[0].Path != "settings.*.extra" {
+		t.Errorf("Expected only settings.*.extra to be dropped, got %+v", fields)
+	}
+
+	if _, err := Compare([]byte(`{`), api.Service{}); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestCompare_ShortensSamples(t *testing.T) {
+	long := strings.Repeat("x", 200)
+	fields, err := Compare([]byte(`{"notes":"`+long+`"}`), api.Service{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(fields) != 1 || len(fields[0].Sample) != maxSample+3 || !strings.HasSuffix(fields[0].Sample, "...") {
+		t.Errorf("Expected a shortened sample, got %+v", fields)
+	}
+}
+
+func TestCheckEndpoints(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/accounts/me":    `{"_id":"acc-1","companyName":"Example","sso":{"enabled":true}}`,
+		"/api/2.5/services/svc-1": `{"_id":"svc-1","name":"Shop"}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	endpoints := Endpoints("svc-1")
+	var checked []Endpoint
+	for _, e := range endpoints {
+		if e.Name == "account" || e.Name == "service" || e.Name == "service rules" {
+			checked = append(checked, e)
+		}
+	}
+	if len(checked) != 3 {
+		t.Fatalf("Expected the account, service and service rules endpoints, got %+v", checked)
+	}
+
+	report, err := CheckEndpoints(context.Background(), client, checked)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !report.Drifted() {
+		t.Error("Expected drift")
+	}
+	if len(report.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %+v", report.Results)
+	}
+
+	account := report.Results[0]
+	if len(account.Dropped) != 1 || account.Dropped[0].Path != "sso" || account.Dropped[0].Type != "v2_5.Account" {
+		t.Errorf("Expected sso to be dropped from the account, got %+v", account.Dropped)
+	}
+	if service := report.Results[1]; service.Error != "" || len(service.Dropped) != 0 {
+		t.Errorf("Expected the service to match, got %+v", service)
+	}
+	if rules := report.Results[2]; rules.Error == "" {
+		t.Errorf("Expected the missing endpoint to be skipped with its error, got %+v", rules)
+	}
+
+	s := report.String()
+	for _, want := range []string{"account /accounts/me: 1 dropped", "  sso (v2_5.Account) = {\"enabled\":true}", "service /services/svc-1: ok", "service rules /services/svc-1/rules: skipped"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, s)
+		}
+	}
+}
+
+func TestCheckEndpoints_Canceled(t *testing.T) {
+	server := fakeapi.Serve(t, nil)
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := CheckEndpoints(ctx, client, Endpoints("")); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package cachefly
+
+import (
+	"context"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// DryRunRequest is a mutating request that was skipped in dry-run mode.
+type DryRunRequest = httpclient.DryRunRequest
+
+// DryRun returns a context that runs the calls made with it in dry-run mode,
+// as if the client had been created with WithDryRun(true).
+//
+// Example:
+//
+//	created, err := client.Services.Create(cachefly.DryRun(ctx), req)
+func DryRun(ctx context.Context) context.Context {
+	return httpclient.WithDryRun(ctx)
+}
@@ -0,0 +1,122 @@
+package cachefly
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// Environment variables read by NewClientFromEnv, in addition to
+// DefaultTokenEnvVar, ProfileEnvVar and ConfigFileEnvVar.
+const (
+	// BaseURLEnvVar overrides the API base URL
+	BaseURLEnvVar = "CACHEFLY_BASE_URL"
+
+	// APIVersionEnvVar selects the API version, see WithAPIVersion
+	APIVersionEnvVar = "CACHEFLY_API_VERSION"
+
+	// DebugEnvVar enables request logging when set to a true value such as
+	// "1" or "true", see WithDebug
+	DebugEnvVar = "CACHEFLY_DEBUG"
+
+	// EnvironmentEnvVar declares the environment the client is meant for,
+	// see WithEnvironmentName
+	EnvironmentEnvVar = "CACHEFLY_ENVIRONMENT"
+
+	// EnvFileEnvVar names the dotenv file loaded by NewClientFromEnv; when
+	// unset, ".env" in the working directory is loaded if it exists
+	EnvFileEnvVar = "CACHEFLY_ENV_FILE"
+)
+
+// NewClientFromEnv creates a client configured from the environment:
+//
+//  1. Variables are loaded from the dotenv file named by EnvFileEnvVar, or
+//     from ".env" when it exists. Variables already set in the environment
+//     take precedence over the file.
+//  2. When ProfileEnvVar is set, the profile's settings are applied, see
+//     LoadProfile.
+//  3. DefaultTokenEnvVar, BaseURLEnvVar, APIVersionEnvVar, DebugEnvVar and
+//     EnvironmentEnvVar are applied, overriding the profile.
+//  4. opts are applied last and override everything else.
+//
+// An error is returned when the dotenv file or the profile cannot be read,
+// when DebugEnvVar is not a boolean, or when neither the environment, the
+// profile nor opts provide a token.
+//
+// Example:
+//
+//	client, err := cachefly.NewClientFromEnv()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	if err := loadEnvFile(); err != nil {
+		return nil, err
+	}
+
+	var envOpts []Option
+	hasToken := false
+	if os.Getenv(ProfileEnvVar) != "" {
+		profile, err := LoadProfile("", "")
+		if err != nil {
+			return nil, err
+		}
+		envOpts = append(envOpts, profile.Options()...)
+		hasToken = profile.Token != "" || profile.TokenFile != "" || profile.TokenEnv != ""
+	}
+
+	if token := strings.TrimSpace(os.Getenv(DefaultTokenEnvVar)); token != "" {
+		envOpts = append(envOpts, WithToken(token))
+		hasToken = true
+	}
+	if !hasToken {
+		// opts may supply the token, through WithToken or WithCredentials
+		var cfg ClientConfig
+		for _, opt := range opts {
+			opt(&cfg)
+		}
+		hasToken = cfg.Token != "" || cfg.Credentials != nil
+	}
+	if !hasToken {
+		return nil, fmt.Errorf("no API token: set %s or select a profile with %s", DefaultTokenEnvVar, ProfileEnvVar)
+	}
+
+	if baseURL := os.Getenv(BaseURLEnvVar); baseURL != "" {
+		envOpts = append(envOpts, WithBaseURL(baseURL))
+	}
+	if version := os.Getenv(APIVersionEnvVar); version != "" {
+		envOpts = append(envOpts, WithAPIVersion(version))
+	}
+	if debug := os.Getenv(DebugEnvVar); debug != "" {
+		enabled, err := strconv.ParseBool(debug)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", DebugEnvVar, debug, err)
+		}
+		envOpts = append(envOpts, WithDebug(enabled))
+	}
+	if environment := os.Getenv(EnvironmentEnvVar); environment != "" {
+		envOpts = append(envOpts, WithEnvironmentName(environment))
+	}
+
+	return NewClient(append(envOpts, opts...)...), nil
+}
+
+// loadEnvFile loads the dotenv file without overriding set variables. A
+// missing ".env" is not an error; a missing EnvFileEnvVar file is.
+func loadEnvFile() error {
+	path := os.Getenv(EnvFileEnvVar)
+	explicit := path != ""
+	if !explicit {
+		path = ".env"
+	}
+	err := godotenv.Load(path)
+	if err == nil || (!explicit && errors.Is(err, fs.ErrNotExist)) {
+		return nil
+	}
+	return fmt.Errorf("failed to load %s: %w", path, err)
+}
@@ -0,0 +1,109 @@
+package cachefly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// clearEnv unsets the variables read by NewClientFromEnv for the test.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{DefaultTokenEnvVar, ProfileEnvVar, ConfigFileEnvVar, BaseURLEnvVar, APIVersionEnvVar, DebugEnvVar, EnvFileEnvVar} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func authServer(t *testing.T, token string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer "+token {
+			t.Errorf("Expected Authorization Bearer %s, got %s", token, got)
+		}
+		w.Write([]byte(`{"_id":"svc-1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewClientFromEnv_EnvFile(t *testing.T) {
+	clearEnv(t)
+	server := authServer(t, "file-token")
+
+	envFile := filepath.Join(t.TempDir(), "cachefly.env")
+	if err := os.WriteFile(envFile, []byte("CACHEFLY_API_TOKEN=file-token\nCACHEFLY_DEBUG=false\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvFileEnvVar, envFile)
+	t.Setenv(BaseURLEnvVar, server.URL+"/api/2.5")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Services.GetByID(context.Background(), "svc-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if client.APIVersion() != "2.5" {
+		t.Errorf("Expected API version 2.5, got %s", client.APIVersion())
+	}
+}
+
+func TestNewClientFromEnv_Profile(t *testing.T) {
+	clearEnv(t)
+	server := authServer(t, "env-token")
+
+	path := writeConfigFile(t, "prod:\n  token: profile-token\n  base_url: "+server.URL+"/api/2.5\n")
+	t.Setenv(ConfigFileEnvVar, path)
+	t.Setenv(ProfileEnvVar, "prod")
+	t.Setenv(DefaultTokenEnvVar, "env-token")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Services.GetByID(context.Background(), "svc-1"); err != nil {
+		t.Fatalf("Expected the environment token to override the profile, got %v", err)
+	}
+}
+
+func TestNewClientFromEnv_TokenOption(t *testing.T) {
+	clearEnv(t)
+	server := authServer(t, "option-token")
+	t.Setenv(BaseURLEnvVar, server.URL+"/api/2.5")
+
+	client, err := NewClientFromEnv(WithToken("option-token"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Services.GetByID(context.Background(), "svc-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := NewClientFromEnv(WithCredentials(StaticToken("option-token"))); err != nil {
+		t.Errorf("Expected no error with WithCredentials, got %v", err)
+	}
+}
+
+func TestNewClientFromEnv_Errors(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(EnvFileEnvVar, filepath.Join(t.TempDir(), "missing.env"))
+	if _, err := NewClientFromEnv(); err == nil || !strings.Contains(err.Error(), "missing.env") {
+		t.Errorf("Expected an error for the missing env file, got %v", err)
+	}
+
+	clearEnv(t)
+	if _, err := NewClientFromEnv(); err == nil || !strings.Contains(err.Error(), DefaultTokenEnvVar) {
+		t.Errorf("Expected a missing token error, got %v", err)
+	}
+
+	t.Setenv(DefaultTokenEnvVar, "token")
+	t.Setenv(DebugEnvVar, "sometimes")
+	if _, err := NewClientFromEnv(); err == nil || !strings.Contains(err.Error(), DebugEnvVar) {
+		t.Errorf("Expected an invalid debug flag error, got %v", err)
+	}
+}
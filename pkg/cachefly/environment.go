@@ -0,0 +1,107 @@
+package cachefly
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// EnvironmentPolicy maps environment names, as given to WithEnvironmentName,
+// to the API hosts a client declaring that environment may write to. Names
+// and hosts are matched case-insensitively.
+type EnvironmentPolicy map[string][]string
+
+// DefaultEnvironmentPolicy returns the policy used when WithEnvironmentPolicy
+// is not given: the "prod" and "production" environments may only write to
+// the production API host.
+func DefaultEnvironmentPolicy() EnvironmentPolicy {
+	host := hostOf(DefaultAPIHost)
+	return EnvironmentPolicy{
+		"prod":       {host},
+		"production": {host},
+	}
+}
+
+// EnvironmentMismatchError is returned by mutating calls of a client whose
+// declared environment does not match its base URL, see WithEnvironmentName.
+// Reads are not affected.
+type EnvironmentMismatchError struct {
+	// Environment is the declared environment
+	Environment string
+
+	// Host is the API host the client points at
+	Host string
+
+	// Allowed are the hosts the environment may write to; empty when the
+	// environment is not in the policy and Host is the production host
+	Allowed []string
+}
+
+func (e *EnvironmentMismatchError) Error() string {
+	if len(e.Allowed) == 0 {
+		return fmt.Sprintf("environment %q must not write to the production API host %s", e.Environment, e.Host)
+	}
+	return fmt.Sprintf("environment %q may only write to %s, but the client points at %s",
+		e.Environment, strings.Join(e.Allowed, ", "), e.Host)
+}
+
+// MessageKey returns "environment_mismatch" for message catalogs.
+func (e *EnvironmentMismatchError) MessageKey() string {
+	return apispec.ErrorCodeEnvironmentMismatch
+}
+
+// MessageParams returns the environment and hosts for message catalogs.
+func (e *EnvironmentMismatchError) MessageParams() map[string]string {
+	return map[string]string{
+		"environment": e.Environment,
+		"host":        e.Host,
+		"allowed":     strings.Join(e.Allowed, ", "),
+	}
+}
+
+// merge returns the default policy with the entries of p added, replacing
+// default entries of the same name. Names are lower-cased.
+func (p EnvironmentPolicy) merge() EnvironmentPolicy {
+	merged := DefaultEnvironmentPolicy()
+	for name, hosts := range p {
+		merged[strings.ToLower(name)] = hosts
+	}
+	return merged
+}
+
+// check returns an EnvironmentMismatchError when environment must not write
+// to baseURL. An environment listed in the policy may only write to its
+// hosts; any other environment may write anywhere but the production host.
+// Names in p must be lower-cased, as done by merge.
+func (p EnvironmentPolicy) check(environment, baseURL string) error {
+	if environment == "" {
+		return nil
+	}
+	host := hostOf(baseURL)
+	if allowed, ok := p[strings.ToLower(environment)]; ok {
+		for _, h := range allowed {
+			if strings.EqualFold(h, host) {
+				return nil
+			}
+		}
+		allowed = append([]string{}, allowed...)
+		sort.Strings(allowed)
+		return &EnvironmentMismatchError{Environment: environment, Host: host, Allowed: allowed}
+	}
+	if host == hostOf(DefaultAPIHost) {
+		return &EnvironmentMismatchError{Environment: environment, Host: host}
+	}
+	return nil
+}
+
+// hostOf returns the lower-cased host name of rawURL, without port.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
@@ -0,0 +1,94 @@
+package cachefly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestWithEnvironmentName_RefusesMismatchedWrites(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"svc-1","name":"example"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithToken("test-token"), WithBaseURL(server.URL), WithEnvironmentName("prod"))
+
+	if _, err := client.Services.Get(context.Background(), "svc-1", "", false); err != nil {
+		t.Fatalf("Expected reads to be allowed, got %v", err)
+	}
+
+	_, err := client.Services.UpdateServiceByID(context.Background(), "svc-1", api.UpdateServiceRequest{Description: "changed"})
+	var mismatch *EnvironmentMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("Expected EnvironmentMismatchError, got %v", err)
+	}
+	if mismatch.Environment != "prod" || mismatch.Host != "127.0.0.1" {
+		t.Errorf("Expected prod and 127.0.0.1, got %s and %s", mismatch.Environment, mismatch.Host)
+	}
+	if len(requests) != 1 || requests[0] != http.MethodGet {
+		t.Errorf("Expected only the GET to be sent, got %v", requests)
+	}
+
+	// Dry runs are still reported
+	dryRun := NewClient(WithToken("test-token"), WithBaseURL(server.URL), WithEnvironmentName("prod"),
+		WithDryRun(true), WithDryRunLogger(func(DryRunRequest) {}))
+	if err := dryRun.Certificates.Delete(context.Background(), "cert-1"); err != nil {
+		t.Errorf("Expected dry run to succeed, got %v", err)
+	}
+}
+
+func TestWithEnvironmentPolicy(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithToken("test-token"),
+		WithBaseURL(server.URL),
+		WithEnvironmentName("Staging"),
+		WithEnvironmentPolicy(EnvironmentPolicy{"staging": {"127.0.0.1"}}),
+	)
+	if err := client.Certificates.Delete(context.Background(), "cert-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
+
+func TestEnvironmentPolicy_Check(t *testing.T) {
+	policy := EnvironmentPolicy{"staging": {"staging-api.cachefly.com"}}.merge()
+
+	tests := []struct {
+		environment string
+		baseURL     string
+		wantErr     bool
+	}{
+		{"", DefaultAPIHost + "/api/2.5", false},
+		{"prod", DefaultAPIHost + "/api/2.5", false},
+		{"PRODUCTION", "https://API.cachefly.com:443/api/2.5", false},
+		{"prod", "https://staging-api.cachefly.com/api/2.5", true},
+		{"staging", "https://staging-api.cachefly.com/api/2.5", false},
+		{"staging", DefaultAPIHost + "/api/2.5", true},
+		{"staging", "http://localhost:8080", true},
+		{"dev", "http://localhost:8080", false},
+		{"dev", DefaultAPIHost + "/api/2.5", true},
+	}
+	for _, tt := range tests {
+		err := policy.check(tt.environment, tt.baseURL)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("check(%q, %q): expected error %v, got %v", tt.environment, tt.baseURL, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package cachefly
+
+import (
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// ErrAPIMaintenance is matched by errors.Is when the API is down for
+// maintenance. Use errors.As with *MaintenanceError to read the estimated end.
+var ErrAPIMaintenance = httpclient.ErrAPIMaintenance
+
+// ErrFeatureUnavailable is matched by errors.Is when an optional endpoint,
+// such as usage reports, raw logs or script configs, is not available for
+// the account. Use errors.As with *FeatureUnavailableError to read the
+// feature.
+var ErrFeatureUnavailable = api.ErrFeatureUnavailable
+
+// ErrUnknownField is matched by errors.Is when WithStrictDecoding rejects a
+// response with a field the SDK does not model.
+var ErrUnknownField = httpclient.ErrUnknownField
+
+// APIError is returned for API responses with a status code of 400 or above.
+type APIError = httpclient.APIError
+
+// MaintenanceError is returned when the API responds with 503 Service
+// Unavailable because of maintenance. EstimatedEnd is zero when the API
+// does not announce one.
+type MaintenanceError = httpclient.MaintenanceError
+
+// FeatureUnavailableError is returned instead of a 404 response by
+// endpoints that are not offered on every plan.
+type FeatureUnavailableError = api.FeatureUnavailableError
@@ -0,0 +1,34 @@
+// Package export provides snapshot and storage helpers for CacheFly service
+// configurations.
+//
+// Taking a snapshot that is safe to commit to version control:
+//
+//	snap, err := export.Snapshot(ctx, client, "srv_123", export.ScrubSecrets())
+//	data, err := snap.Marshal()
+//
+// Restoring it later, resolving scrubbed secrets from a secret manager:
+//
+//	result, err := export.Restore(ctx, client, "srv_456", snap,
+//		export.WithSecretResolver(resolver),
+//	)
+//
+// Snapshots may contain credential material such as legacy API keys and FTP
+// passwords, so this package can encrypt them at rest using AES-256-GCM.
+// The encryption key is supplied by a KeyProvider: either a static key held
+// by the caller or a KMS callback that wraps a per-snapshot data key.
+//
+// Encrypting with a static key:
+//
+//	key := make([]byte, 32) // load from your secret store
+//	sealed, err := export.Encrypt(ctx, data, export.StaticKey(key))
+//
+//	plain, err := export.Decrypt(ctx, sealed, export.StaticKey(key))
+//
+// Encrypting with a KMS:
+//
+//	kp := export.KMSKeyProvider{
+//		Wrap:   func(ctx context.Context, key []byte) ([]byte, error) { return kms.Encrypt(ctx, key) },
+//		Unwrap: func(ctx context.Context, wrapped []byte) ([]byte, error) { return kms.Decrypt(ctx, wrapped) },
+//	}
+//	sealed, err := export.Encrypt(ctx, data, kp)
+package export
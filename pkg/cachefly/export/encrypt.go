@@ -0,0 +1,187 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	encryptedFormat    = "cachefly-snapshot-encrypted"
+	encryptedVersion   = 1
+	encryptedAlgorithm = "AES-256-GCM"
+	dataKeySize        = 32
+)
+
+// KeyProvider supplies the data key used to encrypt and decrypt a snapshot.
+type KeyProvider interface {
+	// DataKey returns a key for encrypting a new snapshot together with the
+	// wrapped form that is stored next to the ciphertext.
+	DataKey(ctx context.Context) (key []byte, wrapped []byte, err error)
+
+	// UnwrapKey recovers the data key from its stored wrapped form.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// StaticKey returns a KeyProvider that encrypts directly with the given
+// 32-byte key. Nothing is stored alongside the ciphertext.
+func StaticKey(key []byte) KeyProvider {
+	return staticKey(key)
+}
+
+type staticKey []byte
+
+func (k staticKey) DataKey(ctx context.Context) ([]byte, []byte, error) {
+	if len(k) != dataKeySize {
+		return nil, nil, fmt.Errorf("static key must be %d bytes, got %d", dataKeySize, len(k))
+	}
+	return []byte(k), nil, nil
+}
+
+func (k staticKey) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	if len(k) != dataKeySize {
+		return nil, fmt.Errorf("static key must be %d bytes, got %d", dataKeySize, len(k))
+	}
+	return []byte(k), nil
+}
+
+// KMSKeyProvider generates a random data key per snapshot and delegates
+// wrapping it to a key management service (envelope encryption).
+type KMSKeyProvider struct {
+	// Wrap encrypts a data key with the KMS master key
+	Wrap func(ctx context.Context, key []byte) ([]byte, error)
+
+	// Unwrap decrypts a wrapped data key with the KMS master key
+	Unwrap func(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// DataKey generates a random data key and wraps it with the KMS callback.
+func (k KMSKeyProvider) DataKey(ctx context.Context) ([]byte, []byte, error) {
+	if k.Wrap == nil {
+		return nil, nil, fmt.Errorf("KMS wrap callback is required")
+	}
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	wrapped, err := k.Wrap(ctx, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	return key, wrapped, nil
+}
+
+// UnwrapKey recovers the data key with the KMS callback.
+func (k KMSKeyProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	if k.Unwrap == nil {
+		return nil, fmt.Errorf("KMS unwrap callback is required")
+	}
+	key, err := k.Unwrap(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return key, nil
+}
+
+// encryptedEnvelope is the on-disk representation of an encrypted snapshot.
+type encryptedEnvelope struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	Algorithm  string `json:"algorithm"`
+	WrappedKey []byte `json:"wrappedKey,omitempty"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Encrypt seals plaintext with a data key from kp and returns a
+// self-describing JSON envelope.
+func Encrypt(ctx context.Context, plaintext []byte, kp KeyProvider) ([]byte, error) {
+	if kp == nil {
+		return nil, fmt.Errorf("key provider is required")
+	}
+	key, wrapped, err := kp.DataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	env := encryptedEnvelope{
+		Format:     encryptedFormat,
+		Version:    encryptedVersion,
+		Algorithm:  encryptedAlgorithm,
+		WrappedKey: wrapped,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, []byte(encryptedFormat)),
+	}
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// Decrypt opens an envelope produced by Encrypt.
+func Decrypt(ctx context.Context, data []byte, kp KeyProvider) ([]byte, error) {
+	if kp == nil {
+		return nil, fmt.Errorf("key provider is required")
+	}
+	var env encryptedEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted snapshot: %w", err)
+	}
+	if env.Format != encryptedFormat {
+		return nil, fmt.Errorf("not an encrypted snapshot")
+	}
+	if env.Version != encryptedVersion || env.Algorithm != encryptedAlgorithm {
+		return nil, fmt.Errorf("unsupported encrypted snapshot version %d (%s)", env.Version, env.Algorithm)
+	}
+
+	key, err := kp.UnwrapKey(ctx, env.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length %d", len(env.Nonce))
+	}
+
+	plaintext, err := gcm.Open(nil, env.Nonce, env.Ciphertext, []byte(encryptedFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted reports whether data looks like an envelope produced by Encrypt.
+func IsEncrypted(data []byte) bool {
+	if !bytes.Contains(data, []byte(encryptedFormat)) {
+		return false
+	}
+	var env struct {
+		Format string `json:"format"`
+	}
+	return json.Unmarshal(data, &env) == nil && env.Format == encryptedFormat
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes, got %d", dataKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
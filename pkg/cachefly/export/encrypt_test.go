@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEncrypt_StaticKeyRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	plaintext := []byte(`{"legacyApiKey":"secret-key"}`)
+
+	sealed, err := Encrypt(context.Background(), plaintext, StaticKey(key))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bytes.Contains(sealed, []byte("secret-key")) {
+		t.Error("Expected ciphertext not to contain the plaintext secret")
+	}
+	if !IsEncrypted(sealed) {
+		t.Error("Expected IsEncrypted to report true")
+	}
+
+	opened, err := Decrypt(context.Background(), sealed, StaticKey(key))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("Expected %s, got %s", plaintext, opened)
+	}
+}
+
+func TestDecrypt_WrongKey(t *testing.T) {
+	sealed, err := Encrypt(context.Background(), []byte("data"), StaticKey(bytes.Repeat([]byte{1}, 32)))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := Decrypt(context.Background(), sealed, StaticKey(bytes.Repeat([]byte{2}, 32))); err == nil {
+		t.Error("Expected error when decrypting with the wrong key")
+	}
+}
+
+func TestEncrypt_KMSKeyProvider(t *testing.T) {
+	master := byte(0x5a)
+	xor := func(in []byte) []byte {
+		out := make([]byte, len(in))
+		for i, b := range in {
+			out[i] = b ^ master
+		}
+		return out
+	}
+	kp := KMSKeyProvider{
+		Wrap:   func(ctx context.Context, key []byte) ([]byte, error) { return xor(key), nil },
+		Unwrap: func(ctx context.Context, wrapped []byte) ([]byte, error) { return xor(wrapped), nil },
+	}
+
+	sealed, err := Encrypt(context.Background(), []byte("ftp-password"), kp)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	opened, err := Decrypt(context.Background(), sealed, kp)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(opened) != "ftp-password" {
+		t.Errorf("Expected ftp-password, got %s", opened)
+	}
+}
+
+func TestEncrypt_KMSWrapError(t *testing.T) {
+	kp := KMSKeyProvider{
+		Wrap: func(ctx context.Context, key []byte) ([]byte, error) { return nil, errors.New("kms down") },
+	}
+	if _, err := Encrypt(context.Background(), []byte("data"), kp); err == nil {
+		t.Error("Expected error when KMS wrap fails")
+	}
+}
+
+func TestStaticKey_InvalidLength(t *testing.T) {
+	if _, err := Encrypt(context.Background(), []byte("data"), StaticKey([]byte("short"))); err == nil {
+		t.Error("Expected error for short static key")
+	}
+}
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// SecretResolver returns the real value for a scrubbed secret placeholder,
+// typically by looking it up in Vault, SSM or another secret manager.
+type SecretResolver func(ctx context.Context, placeholder string) (string, error)
+
+// RestoreOption configures how a snapshot is restored.
+type RestoreOption func(*restoreConfig)
+
+type restoreConfig struct {
+	resolver SecretResolver
+}
+
+// WithSecretResolver sets the resolver used for scrubbed secrets. Without a
+// resolver, restoring a snapshot whose restorable secrets were scrubbed fails.
+func WithSecretResolver(resolver SecretResolver) RestoreOption {
+	return func(c *restoreConfig) {
+		c.resolver = resolver
+	}
+}
+
+// StaticSecrets returns a SecretResolver backed by a placeholder-to-value map.
+func StaticSecrets(values map[string]string) SecretResolver {
+	return func(ctx context.Context, placeholder string) (string, error) {
+		value, ok := values[placeholder]
+		if !ok {
+			return "", fmt.Errorf("no value for secret %s", placeholder)
+		}
+		return value, nil
+	}
+}
+
+// RestoreResult describes the outcome of Restore.
+type RestoreResult struct {
+	Import *api.ImportConfigResult `json:"import"`
+
+	// RestoredSecrets lists the secret fields written to the target service
+	RestoredSecrets []string `json:"restoredSecrets,omitempty"`
+
+	// UnrestorableSecrets lists secret fields the API cannot set to a given
+	// value. New values are generated by the API instead.
+	UnrestorableSecrets []string `json:"unrestorableSecrets,omitempty"`
+}
+
+// Restore applies a snapshot to the service identified by serviceID.
+//
+// The configuration is applied with ServicesService.ImportConfig, with the
+// S3 credentials of the origins it creates resolved. The ProtectServe key is then
+// written back; scrubbed placeholders are resolved
+// through the configured SecretResolver at apply time so secrets never have
+// to live in the snapshot file. The legacy API key and FTP password cannot be
+// set to a specific value through the API and are reported as unrestorable.
+//
+// Example:
+//
+//	result, err := export.Restore(ctx, client, "srv_123", snap,
+//		export.WithSecretResolver(func(ctx context.Context, placeholder string) (string, error) {
+//			return vault.Read(ctx, placeholder)
+//		}),
+//	)
+func Restore(ctx context.Context, client *cachefly.Client, serviceID string, snap *ServiceSnapshot, opts ...RestoreOption) (*RestoreResult, error) {
+	if serviceID == "" {
+		return nil, fmt.Errorf("service ID is required")
+	}
+	if snap == nil || snap.Config == nil {
+		return nil, fmt.Errorf("snapshot has no configuration")
+	}
+
+	cfg := &restoreConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Resolve secrets before touching the target, so a missing secret fails early
+	protectServeKey, err := cfg.resolve(ctx, snap.Secrets.ProtectServeKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", FieldProtectServeKey, err)
+	}
+
+	config, err := cfg.resolveOrigins(ctx, client, snap.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	imported, err := client.Services.ImportConfig(ctx, serviceID, config)
+	if imported == nil {
+		return nil, err
+	}
+	result := &RestoreResult{Import: imported}
+	if err != nil {
+		return result, err
+	}
+
+	if protectServeKey != "" {
+		_, err := client.ServiceOptions.UpdateProtectServeOptions(ctx, serviceID, api.UpdateProtectServeRequest{
+			ForceProtectServe: snap.Secrets.ForceProtectServe,
+			ProtectServeKey:   protectServeKey,
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to restore ProtectServe key: %w", err)
+		}
+		result.RestoredSecrets = append(result.RestoredSecrets, FieldProtectServeKey)
+	}
+	if snap.Secrets.LegacyAPIKey != "" {
+		result.UnrestorableSecrets = append(result.UnrestorableSecrets, FieldLegacyAPIKey)
+	}
+	if snap.Secrets.FTPPassword != "" {
+		result.UnrestorableSecrets = append(result.UnrestorableSecrets, FieldFTPPassword)
+	}
+
+	return result, nil
+}
+
+// resolve returns value unchanged unless it is a placeholder.
+func (c *restoreConfig) resolve(ctx context.Context, value string) (string, error) {
+	if !IsPlaceholder(value) {
+		return value, nil
+	}
+	if c.resolver == nil {
+		return "", fmt.Errorf("snapshot contains scrubbed secret %s and no SecretResolver was configured", value)
+	}
+	return c.resolver(ctx, value)
+}
+
+// originPageSize is the page size used to list the target's origins.
+const originPageSize = 100
+
+// resolveOrigins returns config with the scrubbed S3 credentials of its
+// origins resolved. Origins that already exist on the account, matched by
+// type and hostname like ImportConfig does, are skipped by the import, so
+// their credentials are left unresolved. config itself is left unchanged.
+func (c *restoreConfig) resolveOrigins(ctx context.Context, client *cachefly.Client, config *api.ServiceConfig) (*api.ServiceConfig, error) {
+	resolved := *config
+	resolved.Origins = append([]api.Origin(nil), config.Origins...)
+
+	var scrubbed bool
+	for _, o := range resolved.Origins {
+		scrubbed = scrubbed || IsPlaceholder(o.AccessKey) || IsPlaceholder(o.SecretKey)
+	}
+	if !scrubbed {
+		return &resolved, nil
+	}
+
+	existing := make(map[string]bool)
+	for offset := 0; ; offset += originPageSize {
+		page, err := client.Origins.List(ctx, api.ListOriginsOptions{Offset: offset, Limit: originPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list origins: %w", err)
+		}
+		for _, o := range page.Origins {
+			existing[o.Type+"|"+o.Hostname] = true
+		}
+		if len(page.Origins) < originPageSize {
+			break
+		}
+	}
+
+	for i := range resolved.Origins {
+		o := &resolved.Origins[i]
+		if existing[o.Type+"|"+o.Hostname] {
+			continue
+		}
+		for _, key := range []*string{&o.AccessKey, &o.SecretKey} {
+			value, err := c.resolve(ctx, *key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve credentials of origin %s: %w", o.Hostname, err)
+			}
+			*key = value
+		}
+	}
+	return &resolved, nil
+}
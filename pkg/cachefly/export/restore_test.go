@@ -0,0 +1,159 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestRestore_ResolvesScrubbedSecrets(t *testing.T) {
+	var restoredKey string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1":
+			w.Write([]byte(`{"_id":"target-1"}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1/options/protectserve":
+			body, _ := io.ReadAll(r.Body)
+			var req api.UpdateProtectServeRequest
+			json.Unmarshal(body, &req)
+			restoredKey = req.ProtectServeKey
+			w.Write([]byte(`{"protectServeKey":"` + req.ProtectServeKey + `"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	placeholder := Placeholder("svc-123", FieldProtectServeKey)
+	snap := &ServiceSnapshot{
+		ServiceID: "svc-123",
+		Config:    &api.ServiceConfig{},
+		Secrets:   Secrets{ProtectServeKey: placeholder, FTPPassword: Placeholder("svc-123", FieldFTPPassword)},
+	}
+
+	result, err := Restore(context.Background(), client, "target-1", snap,
+		WithSecretResolver(StaticSecrets(map[string]string{placeholder: "real-key"})))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if restoredKey != "real-key" {
+		t.Errorf("Expected resolved key real-key, got %s", restoredKey)
+	}
+	if len(result.RestoredSecrets) != 1 || result.RestoredSecrets[0] != FieldProtectServeKey {
+		t.Errorf("Expected ProtectServe key restored, got %v", result.RestoredSecrets)
+	}
+	if len(result.UnrestorableSecrets) != 1 || result.UnrestorableSecrets[0] != FieldFTPPassword {
+		t.Errorf("Expected FTP password unrestorable, got %v", result.UnrestorableSecrets)
+	}
+}
+
+func TestRestore_MissingResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no API calls, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	snap := &ServiceSnapshot{
+		Config:  &api.ServiceConfig{},
+		Secrets: Secrets{ProtectServeKey: Placeholder("svc-123", FieldProtectServeKey)},
+	}
+
+	if _, err := Restore(context.Background(), client, "target-1", snap); err == nil {
+		t.Error("Expected error when a scrubbed secret has no resolver")
+	}
+}
+
+func TestRestore_ResolvesOriginCredentials(t *testing.T) {
+	var created api.CreateOriginRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1":
+			w.Write([]byte(`{"_id":"target-1"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/origins":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/origins":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"_id":"org-2","type":"S3_BUCKET","hostname":"bucket.s3.amazonaws.com"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	access := Placeholder("svc-123", OriginField("org-1", "accessKey"))
+	secret := Placeholder("svc-123", OriginField("org-1", "secretKey"))
+	snap := &ServiceSnapshot{
+		ServiceID: "svc-123",
+		Config: &api.ServiceConfig{Origins: []api.Origin{
+			{ID: "org-1", Type: "S3_BUCKET", Hostname: "bucket.s3.amazonaws.com", AccessKey: access, SecretKey: secret},
+		}},
+	}
+
+	_, err := Restore(context.Background(), client, "target-1", snap,
+		WithSecretResolver(StaticSecrets(map[string]string{access: "s3-access", secret: "s3-secret"})))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.AccessKey != "s3-access" || created.SecretKey != "s3-secret" {
+		t.Errorf("Expected resolved origin credentials, got %+v", created)
+	}
+	if snap.Config.Origins[0].SecretKey != secret {
+		t.Error("Expected the snapshot to keep its placeholders")
+	}
+}
+
+func TestRestore_SkipsCredentialsOfExistingOrigins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/target-1":
+			w.Write([]byte(`{"_id":"target-1"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/origins":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"org-9","type":"S3_BUCKET","hostname":"bucket.s3.amazonaws.com"}]}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	snap := &ServiceSnapshot{
+		ServiceID: "svc-123",
+		Config: &api.ServiceConfig{Origins: []api.Origin{{
+			ID:        "org-1",
+			Type:      "S3_BUCKET",
+			Hostname:  "bucket.s3.amazonaws.com",
+			AccessKey: Placeholder("svc-123", OriginField("org-1", "accessKey")),
+			SecretKey: Placeholder("svc-123", OriginField("org-1", "secretKey")),
+		}}},
+	}
+
+	result, err := Restore(context.Background(), client, "target-1", snap)
+	if err != nil {
+		t.Fatalf("Expected no error without a resolver, got %v", err)
+	}
+	if len(result.Import.CreatedOrigins) != 0 {
+		t.Errorf("Expected the existing origin to be kept, got %+v", result.Import.CreatedOrigins)
+	}
+}
@@ -0,0 +1,266 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"gopkg.in/yaml.v3"
+)
+
+// SnapshotFormatVersion is the version of the snapshot document layout.
+// Version 2 scrubs the S3 credentials of the configuration's origins.
+const SnapshotFormatVersion = 2
+
+// ServiceSnapshot is a point-in-time export of a service configuration.
+type ServiceSnapshot struct {
+	FormatVersion int                `json:"formatVersion"`
+	ServiceID     string             `json:"serviceId"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	Config        *api.ServiceConfig `json:"config"`
+	Secrets       Secrets            `json:"secrets"`
+
+	// Scrubbed lists the secret fields replaced by placeholders, if any
+	Scrubbed []ScrubbedField `json:"scrubbed,omitempty"`
+
+	// Unavailable lists the features that were skipped because the account
+	// does not offer them, such as api.FeatureFTP
+	Unavailable []string `json:"unavailable,omitempty"`
+}
+
+// Secrets holds credential material attached to a service.
+type Secrets struct {
+	LegacyAPIKey    string `json:"legacyApiKey,omitempty"`
+	ProtectServeKey string `json:"protectServeKey,omitempty"`
+	FTPPassword     string `json:"ftpPassword,omitempty"`
+
+	// ForceProtectServe is not secret but is required to restore the ProtectServe key
+	ForceProtectServe string `json:"forceProtectServe,omitempty"`
+}
+
+// ScrubbedField records a secret that was replaced by a placeholder.
+type ScrubbedField struct {
+	Field       string `json:"field"`
+	Placeholder string `json:"placeholder"`
+}
+
+// Secret field names used in ScrubbedField.Field.
+const (
+	FieldLegacyAPIKey    = "secrets.legacyApiKey"
+	FieldProtectServeKey = "secrets.protectServeKey"
+	FieldFTPPassword     = "secrets.ftpPassword"
+)
+
+// OriginField returns the ScrubbedField.Field name of the origin credential
+// key, "accessKey" or "secretKey", of the origin identified by originID.
+func OriginField(originID, key string) string {
+	return fmt.Sprintf("config.origins.%s.%s", originID, key)
+}
+
+// SnapshotOption configures how a snapshot is taken.
+type SnapshotOption func(*snapshotConfig)
+
+type snapshotConfig struct {
+	scrubSecrets bool
+}
+
+// ScrubSecrets replaces credential material with stable placeholders so the
+// snapshot can be committed to version control. The replaced fields are
+// recorded in ServiceSnapshot.Scrubbed.
+func ScrubSecrets() SnapshotOption {
+	return func(c *snapshotConfig) {
+		c.scrubSecrets = true
+	}
+}
+
+// Placeholder returns the stable placeholder used for a scrubbed secret field.
+func Placeholder(serviceID, field string) string {
+	return fmt.Sprintf("${cachefly:%s:%s}", serviceID, field)
+}
+
+// IsPlaceholder reports whether value is a scrubbed-secret placeholder.
+func IsPlaceholder(value string) bool {
+	return len(value) > len("${cachefly:}") && value[:len("${cachefly:")] == "${cachefly:" && value[len(value)-1] == '}'
+}
+
+// Snapshot exports the configuration of a service (see
+// ServicesService.ExportConfig) together with its credential material.
+// Features the account does not offer are skipped and listed in
+// ServiceSnapshot.Unavailable.
+func Snapshot(ctx context.Context, client *cachefly.Client, serviceID string, opts ...SnapshotOption) (*ServiceSnapshot, error) {
+	if serviceID == "" {
+		return nil, fmt.Errorf("service ID is required")
+	}
+
+	cfg := &snapshotConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	config, err := client.Services.ExportConfig(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	options := config.Options
+
+	snap := &ServiceSnapshot{
+		FormatVersion: SnapshotFormatVersion,
+		ServiceID:     serviceID,
+		CreatedAt:     time.Now().UTC(),
+		Config:        config,
+	}
+
+	// Secrets are only fetched when the corresponding feature is enabled
+	if enabled, _ := options["apiKeyEnabled"].(bool); enabled {
+		key, err := client.ServiceOptions.GetLegacyAPIKey(ctx, serviceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get legacy API key: %w", err)
+		}
+		snap.Secrets.LegacyAPIKey = key.APIKey
+	}
+	if enabled, _ := options["protectServeKeyEnabled"].(bool); enabled {
+		ps, err := client.ServiceOptions.GetProtectServeKey(ctx, serviceID, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ProtectServe key: %w", err)
+		}
+		snap.Secrets.ProtectServeKey = ps.ProtectServeKey
+		snap.Secrets.ForceProtectServe = ps.ForceProtectServe
+	}
+	ftp, err := client.ServiceOptions.GetFTPSettings(ctx, serviceID, false)
+	switch {
+	case errors.Is(err, cachefly.ErrFeatureUnavailable):
+		snap.Unavailable = append(snap.Unavailable, api.FeatureFTP)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get FTP settings: %w", err)
+	default:
+		snap.Secrets.FTPPassword = ftp.FTPPassword
+	}
+
+	if cfg.scrubSecrets {
+		snap.scrub()
+	}
+	return snap, nil
+}
+
+// scrub replaces every non-empty secret, including the S3 credentials of
+// origins, with its placeholder.
+func (s *ServiceSnapshot) scrub() {
+	type secretField struct {
+		name  string
+		value *string
+	}
+	fields := []secretField{
+		{FieldLegacyAPIKey, &s.Secrets.LegacyAPIKey},
+		{FieldProtectServeKey, &s.Secrets.ProtectServeKey},
+		{FieldFTPPassword, &s.Secrets.FTPPassword},
+	}
+	if s.Config != nil {
+		for i := range s.Config.Origins {
+			o := &s.Config.Origins[i]
+			id := o.ID
+			if id == "" {
+				id = strconv.Itoa(i)
+			}
+			fields = append(fields,
+				secretField{OriginField(id, "accessKey"), &o.AccessKey},
+				secretField{OriginField(id, "secretKey"), &o.SecretKey},
+			)
+		}
+	}
+	for _, f := range fields {
+		if *f.value == "" || IsPlaceholder(*f.value) {
+			continue
+		}
+		placeholder := Placeholder(s.ServiceID, f.name)
+		*f.value = placeholder
+		s.Scrubbed = append(s.Scrubbed, ScrubbedField{Field: f.name, Placeholder: placeholder})
+	}
+}
+
+// Format is a snapshot document encoding.
+type Format string
+
+// Supported snapshot document encodings.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Marshal encodes the snapshot as indented JSON.
+func (s *ServiceSnapshot) Marshal() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// Encode encodes the snapshot in the given format. YAML documents use the
+// same field names as the JSON encoding.
+func (s *ServiceSnapshot) Encode(format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON, "":
+		return s.Marshal()
+	case FormatYAML:
+		data, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(doc)
+	default:
+		return nil, fmt.Errorf("unsupported snapshot format %q", format)
+	}
+}
+
+// MarshalEncrypted encodes the snapshot and encrypts it with kp.
+func (s *ServiceSnapshot) MarshalEncrypted(ctx context.Context, kp KeyProvider) ([]byte, error) {
+	data, err := s.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return Encrypt(ctx, data, kp)
+}
+
+// LoadSnapshot decodes a JSON or YAML snapshot, decrypting it first when it
+// is encrypted. kp may be nil for plaintext snapshots.
+func LoadSnapshot(ctx context.Context, data []byte, kp KeyProvider) (*ServiceSnapshot, error) {
+	if IsEncrypted(data) {
+		if kp == nil {
+			return nil, fmt.Errorf("snapshot is encrypted but no key provider was given")
+		}
+		plain, err := Decrypt(ctx, data, kp)
+		if err != nil {
+			return nil, err
+		}
+		data = plain
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] != '{' {
+		// YAML is converted to JSON so the json field tags apply
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+		}
+		converted, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+		}
+		data = converted
+	}
+
+	var snap ServiceSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if snap.FormatVersion > SnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d", snap.FormatVersion)
+	}
+	return &snap, nil
+}
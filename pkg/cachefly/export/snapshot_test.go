@@ -0,0 +1,166 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func newSnapshotServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/2.5/services/svc-123":
+			w.Write([]byte(`{"_id":"svc-123","name":"Test Service","uniqueName":"test-service"}`))
+		case "/api/2.5/services/svc-123/options":
+			w.Write([]byte(`{"cors":true,"apiKeyEnabled":true}`))
+		case "/api/2.5/services/svc-123/domains", "/api/2.5/services/svc-123/rules":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case "/api/2.5/origins":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"org-1","type":"S3_BUCKET","hostname":"bucket.s3.amazonaws.com","accessKey":"s3-access","secretKey":"s3-secret"}]}`))
+		case "/api/2.5/services/svc-123/options/apikey":
+			w.Write([]byte(`{"apiKey":"legacy-secret"}`))
+		case "/api/2.5/services/svc-123/options/ftp":
+			w.Write([]byte(`{"ftpPassword":"ftp-secret"}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSnapshot(t *testing.T) {
+	server := newSnapshotServer(t)
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	snap, err := Snapshot(context.Background(), client, "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if snap.Config.Service.UniqueName != "test-service" {
+		t.Errorf("Expected uniqueName test-service, got %s", snap.Config.Service.UniqueName)
+	}
+	if snap.Secrets.LegacyAPIKey != "legacy-secret" {
+		t.Errorf("Expected legacy API key, got %s", snap.Secrets.LegacyAPIKey)
+	}
+	if snap.Secrets.ProtectServeKey != "" {
+		t.Errorf("Expected no ProtectServe key, got %s", snap.Secrets.ProtectServeKey)
+	}
+	if len(snap.Scrubbed) != 0 {
+		t.Errorf("Expected no scrubbed fields, got %d", len(snap.Scrubbed))
+	}
+}
+
+func TestSnapshot_ScrubSecrets(t *testing.T) {
+	server := newSnapshotServer(t)
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	snap, err := Snapshot(context.Background(), client, "svc-123", ScrubSecrets())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, err := snap.Marshal()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, secret := range []string{"legacy-secret", "ftp-secret", "s3-access", "s3-secret"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("Expected %s to be scrubbed from the snapshot", secret)
+		}
+	}
+	if snap.Secrets.LegacyAPIKey != Placeholder("svc-123", FieldLegacyAPIKey) {
+		t.Errorf("Expected stable placeholder, got %s", snap.Secrets.LegacyAPIKey)
+	}
+	if key := snap.Config.Origins[0].SecretKey; key != Placeholder("svc-123", OriginField("org-1", "secretKey")) {
+		t.Errorf("Expected stable origin placeholder, got %s", key)
+	}
+	if len(snap.Scrubbed) != 4 {
+		t.Errorf("Expected 4 scrubbed fields, got %d", len(snap.Scrubbed))
+	}
+}
+
+func TestLoadSnapshot_Encrypted(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	snap := &ServiceSnapshot{FormatVersion: SnapshotFormatVersion, ServiceID: "svc-123", Secrets: Secrets{FTPPassword: "ftp-secret"}}
+
+	sealed, err := snap.MarshalEncrypted(context.Background(), StaticKey(key))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := LoadSnapshot(context.Background(), sealed, nil); err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Errorf("Expected error for missing key provider, got %v", err)
+	}
+
+	loaded, err := LoadSnapshot(context.Background(), sealed, StaticKey(key))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loaded.Secrets.FTPPassword != "ftp-secret" {
+		t.Errorf("Expected ftp-secret, got %s", loaded.Secrets.FTPPassword)
+	}
+}
+
+func TestServiceSnapshot_EncodeYAML(t *testing.T) {
+	snap := &ServiceSnapshot{FormatVersion: SnapshotFormatVersion, ServiceID: "svc-123", Secrets: Secrets{FTPPassword: "ftp-secret"}}
+
+	data, err := snap.Encode(FormatYAML)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(data), "serviceId: svc-123") {
+		t.Errorf("Expected JSON field names in YAML output, got %s", data)
+	}
+
+	loaded, err := LoadSnapshot(context.Background(), data, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if loaded.Secrets.FTPPassword != "ftp-secret" {
+		t.Errorf("Expected ftp-secret, got %s", loaded.Secrets.FTPPassword)
+	}
+}
+
+func TestSnapshot_SkipsUnavailableFeatures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/2.5/services/svc-123":
+			w.Write([]byte(`{"_id":"svc-123","name":"Test Service","uniqueName":"test-service"}`))
+		case "/api/2.5/services/svc-123/options":
+			w.Write([]byte(`{"cors":true}`))
+		case "/api/2.5/services/svc-123/domains", "/api/2.5/services/svc-123/rules":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case "/api/2.5/origins":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"org-1","type":"S3_BUCKET","hostname":"bucket.s3.amazonaws.com","accessKey":"s3-access","secretKey":"s3-secret"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	snap, err := Snapshot(context.Background(), client, "svc-123")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(snap.Unavailable) != 1 || snap.Unavailable[0] != api.FeatureFTP {
+		t.Errorf("Expected FTP to be noted as unavailable, got %v", snap.Unavailable)
+	}
+	if snap.Secrets.FTPPassword != "" {
+		t.Errorf("Expected no FTP password, got %s", snap.Secrets.FTPPassword)
+	}
+}
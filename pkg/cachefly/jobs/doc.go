@@ -0,0 +1,26 @@
+// Package jobs provides a lightweight in-process queue for mutation jobs,
+// with priorities, retries, rate limiting and persistence hooks.
+//
+// The SDK's bulk helpers run their requests through a Queue, and users can
+// enqueue any SDK operation onto one, which makes it the building block for
+// a CDN operations worker:
+//
+//	q := jobs.New(jobs.Config{Workers: 4, MaxAttempts: 3})
+//	q.Start(ctx)
+//	defer q.Close()
+//
+//	h, err := q.Enqueue(func(ctx context.Context) error {
+//		_, err := client.Services.ActivateServiceByID(ctx, serviceID)
+//		return err
+//	}, jobs.Options{Name: "activate", Priority: 10})
+//	if err != nil {
+//		return err
+//	}
+//	if err := h.Wait(ctx); err != nil {
+//		return err
+//	}
+//
+// Jobs enqueued by name with EnqueueNamed carry a payload instead of a
+// closure. A Store sees every state change of such jobs, so a worker can
+// persist them and Restore the unfinished ones after a restart.
+package jobs
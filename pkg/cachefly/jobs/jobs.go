@@ -0,0 +1,48 @@
+package jobs
+
+import "github.com/cachefly/cachefly-go-sdk/internal/jobs"
+
+// ErrClosed is returned when enqueueing onto a closed queue.
+var ErrClosed = jobs.ErrClosed
+
+// Status is the state of a job.
+type Status = jobs.Status
+
+// Job states.
+const (
+	StatusPending   = jobs.StatusPending
+	StatusRunning   = jobs.StatusRunning
+	StatusSucceeded = jobs.StatusSucceeded
+	StatusFailed    = jobs.StatusFailed
+	StatusCanceled  = jobs.StatusCanceled
+)
+
+// Func is the work of a job.
+type Func = jobs.Func
+
+// Handler runs a named job from its payload.
+type Handler = jobs.Handler
+
+// Store persists jobs. Save is called with a snapshot of the job on every
+// state change.
+type Store = jobs.Store
+
+// Job is a snapshot of a queued job.
+type Job = jobs.Job
+
+// Options describes a job being enqueued.
+type Options = jobs.Options
+
+// Config controls a Queue.
+type Config = jobs.Config
+
+// Queue runs jobs on a pool of workers.
+type Queue = jobs.Queue
+
+// Handle tracks an enqueued job.
+type Handle = jobs.Handle
+
+// New returns a Queue. Jobs only run after Start.
+func New(cfg Config) *Queue {
+	return jobs.New(cfg)
+}
@@ -0,0 +1,18 @@
+// Package legacycompat eases the migration from the legacy CacheFly API.
+//
+// Legacy call sites identify a service by its legacy API key. A Client
+// keeps that shape, so they can be switched over one at a time, but sends
+// every call to API 2.5 with the token of the wrapped client:
+//
+//	legacy := legacycompat.New(client)
+//
+//	// was: legacy purge of a path with the service's API key
+//	if err := legacy.Purge(ctx, apiKey, "/images/logo.png"); err != nil {
+//		return err
+//	}
+//
+// The service behind a key is found by reading the legacy keys of the
+// account's services, once per key; Register skips the lookup for keys
+// whose service is already known. Migrated call sites should use the
+// service ID and the methods of cachefly.Client directly.
+package legacycompat
@@ -0,0 +1,129 @@
+package legacycompat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// ErrUnknownAPIKey is returned when no service of the account has the given
+// legacy API key.
+var ErrUnknownAPIKey = errors.New("no service has this legacy API key")
+
+// errFound stops the service listing once the key was found.
+var errFound = errors.New("found")
+
+// Client serves legacy-style calls, which take a service's legacy API key,
+// on API 2.5. A Client is safe for concurrent use.
+type Client struct {
+	client *cachefly.Client
+
+	mu       sync.Mutex
+	services map[string]string
+}
+
+// New returns a Client sending its calls through client.
+func New(client *cachefly.Client) *Client {
+	return &Client{client: client, services: make(map[string]string)}
+}
+
+// Register records that apiKey belongs to serviceID, so calls with it do
+// not look the key up.
+func (c *Client) Register(apiKey, serviceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.services[apiKey] = serviceID
+}
+
+// ServiceID returns the ID of the service whose legacy API key is apiKey.
+// Unknown keys are looked up by reading the legacy key of each service of
+// the account until one matches; the keys read are remembered. Services
+// without a legacy key are skipped.
+func (c *Client) ServiceID(ctx context.Context, apiKey string) (string, error) {
+	if apiKey == "" {
+		return "", fmt.Errorf("apiKey is required")
+	}
+	c.mu.Lock()
+	id, ok := c.services[apiKey]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	err := c.client.Services.ListEach(ctx, api.ListOptions{}, func(svc *api.Service) error {
+		key, err := c.client.ServiceOptions.GetLegacyAPIKey(ctx, svc.ID)
+		var apiErr *cachefly.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get legacy API key of service %s: %w", svc.ID, err)
+		}
+		if key.APIKey == "" {
+			return nil
+		}
+		c.Register(key.APIKey, svc.ID)
+		if key.APIKey == apiKey {
+			id = svc.ID
+			return errFound
+		}
+		return nil
+	})
+	if errors.Is(err, errFound) {
+		return id, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return "", ErrUnknownAPIKey
+}
+
+// Purge purges one path from the cache of the service with the legacy API
+// key apiKey.
+func (c *Client) Purge(ctx context.Context, apiKey, path string) error {
+	return c.PurgePaths(ctx, apiKey, []string{path})
+}
+
+// PurgePaths purges paths from the cache of the service with the legacy
+// API key apiKey. Unlike Purge.Paths it fails when any path was not purged,
+// as the legacy call did.
+func (c *Client) PurgePaths(ctx context.Context, apiKey string, paths []string) error {
+	serviceID, err := c.ServiceID(ctx, apiKey)
+	if err != nil {
+		return err
+	}
+	report, err := c.client.Purge.Paths(ctx, serviceID, paths)
+	if err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d paths failed to purge", report.Failed, report.Requested)
+	}
+	return nil
+}
+
+// GetConfig returns the options of the service with the legacy API key
+// apiKey.
+func (c *Client) GetConfig(ctx context.Context, apiKey string) (api.ServiceOptions, error) {
+	serviceID, err := c.ServiceID(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.ServiceOptions.GetOptions(ctx, serviceID)
+}
+
+// SetConfig sets one option of the service with the legacy API key apiKey,
+// validated against the service's option metadata.
+func (c *Client) SetConfig(ctx context.Context, apiKey, name string, value interface{}) error {
+	serviceID, err := c.ServiceID(ctx, apiKey)
+	if err != nil {
+		return err
+	}
+	_, err = c.client.ServiceOptions.SetOption(ctx, serviceID, name, value)
+	return err
+}
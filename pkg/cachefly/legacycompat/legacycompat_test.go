@@ -0,0 +1,74 @@
+package legacycompat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func TestClient_Purge(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"GET /api/2.5/services":                      `{"data":[{"_id":"svc-1"},{"_id":"svc-2"},{"_id":"svc-3"}]}`,
+		"GET /api/2.5/services/svc-2/options/apikey": `{"apiKey":"key-2"}`,
+		"GET /api/2.5/services/svc-3/options/apikey": `{"apiKey":"key-3"}`,
+		"POST /api/2.5/services/svc-3/purge":         `{}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	legacy := New(client)
+
+	if err := legacy.Purge(context.Background(), "key-3", "/logo.png"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// svc-1 has no legacy key and is skipped; key-2 is remembered
+	if err := legacy.PurgePaths(context.Background(), "key-3", []string{"/a", "/b"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	id, err := legacy.ServiceID(context.Background(), "key-2")
+	if err != nil || id != "svc-2" {
+		t.Errorf("Expected svc-2, got %q, %v", id, err)
+	}
+
+	var lookups, purges int
+	for _, r := range server.Log() {
+		switch r {
+		case "GET /api/2.5/services":
+			lookups++
+		case "POST /api/2.5/services/svc-3/purge":
+			purges++
+		}
+	}
+	if lookups != 1 || purges != 2 {
+		t.Errorf("Expected 1 lookup and 2 purges, got %v", server.Log())
+	}
+
+	_, err = legacy.ServiceID(context.Background(), "unknown")
+	if !errors.Is(err, ErrUnknownAPIKey) {
+		t.Errorf("Expected ErrUnknownAPIKey, got %v", err)
+	}
+}
+
+func TestClient_Register(t *testing.T) {
+	options := map[string]interface{}{"cors": true}
+	body, _ := json.Marshal(options)
+	server := fakeapi.Serve(t, map[string]string{
+		"GET /api/2.5/services/svc-1/options": string(body),
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	legacy := New(client)
+	legacy.Register("key-1", "svc-1")
+
+	got, err := legacy.GetConfig(context.Background(), "key-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got["cors"] != true {
+		t.Errorf("Expected the service options, got %v", got)
+	}
+	if r := server.Log(); len(r) != 1 {
+		t.Errorf("Expected no lookup for a registered key, got %v", r)
+	}
+}
@@ -0,0 +1,31 @@
+// Package lint checks service configurations against best-practice rules.
+//
+// Service resolves the effective configuration of a service and runs the
+// rule set over it, returning machine-readable findings with a severity:
+//
+//	findings, err := lint.Service(ctx, client, serviceID)
+//	if err != nil {
+//		return err
+//	}
+//	for _, f := range findings {
+//		fmt.Printf("%s %s: %s\n", f.Severity, f.Rule, f.Message)
+//	}
+//
+// The built-in rules are listed by DefaultRules. Rules can be disabled,
+// tuned or extended with custom rules:
+//
+//	findings, err := lint.Service(ctx, client, serviceID,
+//		lint.WithMinTTL(5*time.Minute),
+//		lint.WithoutRules("cors-wildcard"),
+//		lint.WithRules(lint.Rule{
+//			ID:       "image-optimization",
+//			Severity: lint.SeverityInfo,
+//			Check: func(t *lint.Target) []lint.Finding {
+//				if on, _ := t.Config.Enabled("imageOptimization"); !on {
+//					return []lint.Finding{{Message: "image optimization is off"}}
+//				}
+//				return nil
+//			},
+//		}),
+//	)
+package lint
@@ -0,0 +1,163 @@
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Severity ranks findings.
+type Severity string
+
+// Severities, from most to least severe.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	}
+	return 2
+}
+
+// DefaultMinTTL is the TTL floor of the ttl-floor rule.
+const DefaultMinTTL = time.Minute
+
+// Finding is a rule violation.
+type Finding struct {
+	// Rule and Severity default to those of the rule reporting the finding
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+
+	// Setting is the option or setting the finding is about, if any
+	Setting string `json:"setting,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// Target is what rules check.
+type Target struct {
+	Config *api.EffectiveConfig
+
+	// FTPEnabled reports whether FTP access to the service's storage is set up
+	FTPEnabled bool
+
+	// MinTTL is the TTL floor, see WithMinTTL
+	MinTTL time.Duration
+}
+
+// Rule is a single check.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	Check       func(t *Target) []Finding
+}
+
+// Option configures Service.
+type Option func(*settings)
+
+type settings struct {
+	rules    []Rule
+	disabled map[string]bool
+	minTTL   time.Duration
+}
+
+// WithRules adds custom rules to the default rule set. A rule with the ID
+// of a default rule replaces it.
+func WithRules(rules ...Rule) Option {
+	return func(s *settings) {
+		for _, r := range rules {
+			replaced := false
+			for i := range s.rules {
+				if s.rules[i].ID == r.ID {
+					s.rules[i], replaced = r, true
+				}
+			}
+			if !replaced {
+				s.rules = append(s.rules, r)
+			}
+		}
+	}
+}
+
+// WithoutRules disables the rules with the given IDs.
+func WithoutRules(ids ...string) Option {
+	return func(s *settings) {
+		for _, id := range ids {
+			s.disabled[id] = true
+		}
+	}
+}
+
+// WithMinTTL sets the TTL floor of the ttl-floor rule; the default is DefaultMinTTL.
+func WithMinTTL(d time.Duration) Option {
+	return func(s *settings) {
+		s.minTTL = d
+	}
+}
+
+// Service lints the effective configuration of a service, returning the
+// findings ordered by severity.
+func Service(ctx context.Context, client *cachefly.Client, id string, opts ...Option) ([]Finding, error) {
+	cfg, err := client.Services.EffectiveConfig(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &Target{Config: cfg}
+	ftp, err := client.ServiceOptions.GetFTPSettings(ctx, id, true)
+	switch {
+	case errors.Is(err, cachefly.ErrFeatureUnavailable):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get FTP settings: %w", err)
+	default:
+		target.FTPEnabled = ftp.FTPPassword != ""
+	}
+
+	return Run(target, opts...), nil
+}
+
+// Run checks target against the rule set without making any request. A
+// zero Target.MinTTL uses the floor set with WithMinTTL.
+func Run(target *Target, opts ...Option) []Finding {
+	s := &settings{rules: DefaultRules(), disabled: make(map[string]bool), minTTL: DefaultMinTTL}
+	for _, opt := range opts {
+		opt(s)
+	}
+	t := *target
+	if t.MinTTL == 0 {
+		t.MinTTL = s.minTTL
+	}
+
+	var findings []Finding
+	for _, r := range s.rules {
+		if s.disabled[r.ID] || r.Check == nil {
+			continue
+		}
+		for _, f := range r.Check(&t) {
+			if f.Rule == "" {
+				f.Rule = r.ID
+			}
+			if f.Severity == "" {
+				f.Severity = r.Severity
+			}
+			findings = append(findings, f)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity.rank() < findings[j].Severity.rank()
+	})
+	return findings
+}
@@ -0,0 +1,110 @@
+package lint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func testTarget() *Target {
+	return &Target{
+		FTPEnabled: true,
+		Config: &api.EffectiveConfig{
+			ServiceID: "svc-1",
+			Status:    "ACTIVE",
+			Settings: map[string]api.EffectiveSetting{
+				"autoRedirect": {Value: true},
+				"cors":         {Value: map[string]interface{}{"enabled": true, "value": []interface{}{"*"}}},
+				"expiryHeaders": {Value: map[string]interface{}{"enabled": true, "value": []interface{}{
+					map[string]interface{}{"path": "/api", "expiryTime": 5.0},
+					map[string]interface{}{"extension": "css", "expiryTime": 86400.0},
+				}}},
+				"reverseProxy": {Value: map[string]interface{}{"enabled": true, "ttl": 30.0}},
+			},
+		},
+	}
+}
+
+func rules(findings []Finding) map[string]Finding {
+	byRule := make(map[string]Finding)
+	for _, f := range findings {
+		byRule[f.Rule] = f
+	}
+	return byRule
+}
+
+func TestRun_DefaultRules(t *testing.T) {
+	findings := Run(testTarget())
+
+	if len(findings) != 5 {
+		t.Fatalf("Expected 5 findings, got %+v", findings)
+	}
+	if findings[0].Rule != "ftp-disabled" || findings[0].Severity != SeverityError {
+		t.Errorf("Expected the FTP error first, got %+v", findings[0])
+	}
+	byRule := rules(findings)
+	if f := byRule["https-redirect-hsts"]; f.Setting != "hsts" || f.Severity != SeverityWarning {
+		t.Errorf("Expected missing HSTS warning, got %+v", f)
+	}
+	if _, ok := byRule["cors-wildcard"]; !ok {
+		t.Error("Expected CORS wildcard warning")
+	}
+	var ttl int
+	for _, f := range findings {
+		if f.Rule == "ttl-floor" {
+			ttl++
+		}
+	}
+	if ttl != 2 {
+		t.Errorf("Expected the /api override and reverse proxy below the floor, got %d", ttl)
+	}
+}
+
+func TestRun_Options(t *testing.T) {
+	target := testTarget()
+	target.Config.Settings["hsts"] = api.EffectiveSetting{Value: 31536000}
+
+	findings := Run(target,
+		WithoutRules("ftp-disabled", "cors-wildcard"),
+		WithMinTTL(time.Second),
+		WithRules(Rule{
+			ID:       "image-optimization",
+			Severity: SeverityInfo,
+			Check: func(t *Target) []Finding {
+				if on, _ := t.Config.Enabled("imageOptimization"); !on {
+					return []Finding{{Message: "image optimization is off"}}
+				}
+				return nil
+			},
+		}),
+	)
+
+	if len(findings) != 1 || findings[0].Rule != "image-optimization" || findings[0].Severity != SeverityInfo {
+		t.Errorf("Expected only the custom finding, got %+v", findings)
+	}
+}
+
+func TestService(t *testing.T) {
+	routes := map[string]string{
+		"/api/2.5/services/svc-1":                  `{"_id":"svc-1","status":"ACTIVE"}`,
+		"/api/2.5/accounts/me":                     `{"_id":"acc-1"}`,
+		"/api/2.5/services/svc-1/options/metadata": `{"data":[]}`,
+		"/api/2.5/services/svc-1/options":          `{"autoRedirect":true,"hsts":300}`,
+		"/api/2.5/scriptConfigs":                   `{"data":[]}`,
+		"/api/2.5/services/svc-1/rules":            `{"data":[]}`,
+	}
+	server := fakeapi.Serve(t, routes)
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	findings, err := Service(context.Background(), client, "svc-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("Expected no findings, got %+v", findings)
+	}
+}
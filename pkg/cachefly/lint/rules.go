@@ -0,0 +1,150 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// DefaultRules returns the built-in rules:
+//
+//   - ftp-disabled: FTP access is set up on an active service
+//   - https-redirect-hsts: autoRedirect and HSTS should be enabled together
+//   - ttl-floor: expiry overrides and the reverse proxy TTL below the floor
+//   - cors-wildcard: CORS allows any origin
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			ID:          "ftp-disabled",
+			Description: "FTP sends credentials and content unencrypted and should not be enabled on production services",
+			Severity:    SeverityError,
+			Check:       checkFTP,
+		},
+		{
+			ID:          "https-redirect-hsts",
+			Description: "Redirects to HTTPS should be pinned with HSTS, and HSTS needs HTTPS redirects to take effect",
+			Severity:    SeverityWarning,
+			Check:       checkHTTPS,
+		},
+		{
+			ID:          "ttl-floor",
+			Description: "Very short TTLs send most requests to the origin",
+			Severity:    SeverityWarning,
+			Check:       checkTTL,
+		},
+		{
+			ID:          "cors-wildcard",
+			Description: "A CORS wildcard lets any site read the responses",
+			Severity:    SeverityWarning,
+			Check:       checkCORS,
+		},
+	}
+}
+
+func checkFTP(t *Target) []Finding {
+	if !t.FTPEnabled || !strings.EqualFold(t.Config.Status, "ACTIVE") {
+		return nil
+	}
+	return []Finding{{Setting: "ftp", Message: "FTP access is enabled on an active service"}}
+}
+
+func checkHTTPS(t *Target) []Finding {
+	redirect, _ := t.Config.Enabled(apispec.OptionAutoRedirect)
+	_, hsts := t.Config.Enabled(apispec.OptionHsts)
+	maxAge := seconds(hsts)
+
+	switch {
+	case redirect && maxAge <= 0:
+		return []Finding{{Setting: apispec.OptionHsts, Message: "HTTP requests are redirected to HTTPS but HSTS is off, so clients keep trying HTTP first"}}
+	case !redirect && maxAge > 0:
+		return []Finding{{Setting: apispec.OptionAutoRedirect, Message: "HSTS is on but HTTP requests are not redirected to HTTPS, so clients first reaching the service over HTTP never receive it"}}
+	}
+	return nil
+}
+
+func checkTTL(t *Target) []Finding {
+	var findings []Finding
+	floor := t.MinTTL.Seconds()
+
+	if on, v := t.Config.Enabled(apispec.OptionExpiryHeaders); on {
+		entries, _ := v.([]interface{})
+		for _, e := range entries {
+			entry, _ := e.(map[string]interface{})
+			ttl := seconds(entry["expiryTime"])
+			if ttl < 0 || ttl >= floor {
+				continue
+			}
+			target, ok := entry["path"]
+			if !ok {
+				target = "*." + strings.TrimPrefix(fmt.Sprint(entry["extension"]), ".")
+			}
+			findings = append(findings, Finding{
+				Setting: apispec.OptionExpiryHeaders,
+				Message: fmt.Sprintf("expiry override for %v caches for %s, below the floor of %s", target, duration(ttl), t.MinTTL),
+			})
+		}
+	}
+
+	if on, v := t.Config.Enabled(apispec.OptionReverseProxy); on {
+		proxy, _ := v.(map[string]interface{})
+		if ttl := seconds(proxy["ttl"]); ttl >= 0 && ttl < floor {
+			findings = append(findings, Finding{
+				Setting: apispec.OptionReverseProxy,
+				Message: fmt.Sprintf("reverse proxy caches for %s, below the floor of %s", duration(ttl), t.MinTTL),
+			})
+		}
+	}
+	return findings
+}
+
+func checkCORS(t *Target) []Finding {
+	on, v := t.Config.Enabled(apispec.OptionCors)
+	if !on || !hasWildcard(v) {
+		return nil
+	}
+	return []Finding{{Setting: apispec.OptionCors, Message: "CORS allows requests from any origin"}}
+}
+
+// hasWildcard reports whether v is or contains the string "*".
+func hasWildcard(v interface{}) bool {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v) == "*"
+	case []interface{}:
+		for _, e := range v {
+			if hasWildcard(e) {
+				return true
+			}
+		}
+	case []string:
+		for _, e := range v {
+			if hasWildcard(e) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			if hasWildcard(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// seconds converts a numeric option value; other values return -1.
+func seconds(v interface{}) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return -1
+}
+
+func duration(secs float64) time.Duration {
+	return time.Duration(secs * float64(time.Second))
+}
@@ -0,0 +1,28 @@
+// Package logparse parses CacheFly raw access logs into typed entries
+// without regular expressions, one line at a time, so arbitrarily large logs
+// can be processed as they are downloaded.
+//
+// Both log variants CacheFly emits are supported: the NCSA combined format
+// followed by CacheFly's extra fields, and W3C extended logs described by a
+// #Fields directive. Lines are split and combined lines parsed by the api
+// package's ParseLogLine, the parser of its LogScanner; this package adds
+// W3C logs and types CacheFly's extra fields. The Scanner detects the
+// variant from the input:
+//
+//	logs, err := client.Logs.Download(ctx, serviceID, api.DownloadLogsOptions{Decompress: true})
+//	if err != nil {
+//		return err
+//	}
+//	defer logs.Close()
+//
+//	scanner := logparse.NewScanner(logs)
+//	for scanner.Scan() {
+//		entry := scanner.Entry()
+//		if entry.CacheStatus == logparse.CacheMiss {
+//			misses[entry.POP]++
+//		}
+//	}
+//	if err := scanner.Err(); err != nil {
+//		return err
+//	}
+package logparse
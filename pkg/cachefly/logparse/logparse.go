@@ -0,0 +1,186 @@
+package logparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// CombinedTimeLayout is the layout of timestamps in combined format logs.
+const CombinedTimeLayout = api.LogTimeLayout
+
+// CacheStatus is the cache result of a request, upper-cased.
+type CacheStatus string
+
+// Common cache statuses.
+const (
+	CacheHit     CacheStatus = "HIT"
+	CacheMiss    CacheStatus = "MISS"
+	CacheExpired CacheStatus = "EXPIRED"
+	CacheStale   CacheStatus = "STALE"
+	CacheBypass  CacheStatus = "BYPASS"
+)
+
+// DefaultExtraFields names the fields CacheFly appends to combined format
+// lines, in order.
+var DefaultExtraFields = []string{"host", "cache-status", "pop", "time-taken"}
+
+// LogEntry is a single request from a CacheFly access log. Fields logged as
+// "-" are empty or zero.
+type LogEntry struct {
+	Time        time.Time
+	ClientIP    string
+	User        string
+	Method      string
+	Path        string
+	Query       string
+	Protocol    string
+	Status      int
+	Bytes       int64
+	Referer     string
+	UserAgent   string
+	Host        string
+	CacheStatus CacheStatus
+	POP         string
+	Duration    time.Duration
+
+	// Fields holds every named field of the line as logged, including ones
+	// without a typed counterpart
+	Fields map[string]string
+
+	// Raw is the unparsed line
+	Raw string
+}
+
+// ParseCombined parses a combined format line with DefaultExtraFields.
+func ParseCombined(line string) (*LogEntry, error) {
+	return parseCombined(line, DefaultExtraFields)
+}
+
+// parseCombined parses a combined format line with api.ParseLogLine and
+// names its extra fields.
+func parseCombined(line string, extra []string) (*LogEntry, error) {
+	base, err := api.ParseLogLine(line)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &LogEntry{
+		Time:      base.Time,
+		ClientIP:  base.RemoteAddr,
+		User:      base.User,
+		Method:    base.Method,
+		Protocol:  base.Protocol,
+		Status:    base.Status,
+		Bytes:     base.Bytes,
+		Referer:   base.Referer,
+		UserAgent: base.UserAgent,
+		Fields:    make(map[string]string, len(base.Extra)),
+		Raw:       line,
+	}
+	entry.Path, entry.Query, _ = strings.Cut(base.Path, "?")
+
+	for i, value := range base.Extra {
+		name := fmt.Sprintf("extra-%d", i+1)
+		if i < len(extra) {
+			name = extra[i]
+		}
+		entry.Fields[name] = value
+	}
+	if err := entry.applyFields(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// parseW3C parses a W3C extended log line with the given #Fields.
+func parseW3C(line string, names []string) (*LogEntry, error) {
+	values, err := api.SplitLogFields(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(names) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(names), len(values))
+	}
+
+	entry := &LogEntry{Fields: make(map[string]string, len(names)), Raw: line}
+	for i, name := range names {
+		entry.Fields[strings.ToLower(name)] = values[i]
+	}
+
+	f := entry.Fields
+	if date, clock := dash(f["date"]), dash(f["time"]); date != "" && clock != "" {
+		entry.Time, err = time.Parse("2006-01-02 15:04:05", date+" "+clock)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q: %w", date+" "+clock, err)
+		}
+	}
+	entry.ClientIP = dash(f["c-ip"])
+	entry.User = dash(f["cs-username"])
+	entry.Method = dash(f["cs-method"])
+	entry.Path = dash(f["cs-uri-stem"])
+	entry.Query = dash(f["cs-uri-query"])
+	if entry.Path == "" {
+		entry.Path, entry.Query, _ = strings.Cut(dash(f["cs-uri"]), "?")
+	}
+	entry.Protocol = dash(f["cs-version"])
+	entry.Referer = dash(f["cs(referer)"])
+	entry.UserAgent = dash(f["cs(user-agent)"])
+
+	if status := dash(f["sc-status"]); status != "" {
+		if entry.Status, err = strconv.Atoi(status); err != nil {
+			return nil, fmt.Errorf("invalid status %q", status)
+		}
+	}
+	if entry.Bytes, err = parseInt(f["sc-bytes"]); err != nil {
+		return nil, fmt.Errorf("invalid size %q", f["sc-bytes"])
+	}
+	if err := entry.applyFields(); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// applyFields fills the host, cache status, POP and duration from the
+// named fields, accepting the names used by both log variants.
+func (e *LogEntry) applyFields() error {
+	e.Host = first(e.Fields, "host", "cs-host", "cs(host)")
+	e.CacheStatus = CacheStatus(strings.ToUpper(first(e.Fields, "cache-status", "x-cache-status", "x-cache")))
+	e.POP = first(e.Fields, "pop", "x-pop", "s-pop", "x-edge-location")
+
+	if taken := first(e.Fields, "time-taken"); taken != "" {
+		seconds, err := strconv.ParseFloat(taken, 64)
+		if err != nil {
+			return fmt.Errorf("invalid time-taken %q", taken)
+		}
+		e.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	return nil
+}
+
+// first returns the first non-empty value of the named fields.
+func first(fields map[string]string, names ...string) string {
+	for _, name := range names {
+		if v := dash(fields[name]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func parseInt(s string) (int64, error) {
+	if dash(s) == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func dash(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,101 @@
+package logparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const combinedLog = `203.0.113.7 - - [10/Jun/2025:13:55:36 +0000] "GET /img/logo.png?v=2 HTTP/1.1" 200 2326 "https://example.com/" "Mozilla/5.0 (X11; Linux)" "cdn.example.com" HIT LAX 0.012
+
+198.51.100.2 - - [10/Jun/2025:13:55:37 +0000] "HEAD /index.html HTTP/2.0" 304 - "-" "curl/8.5.0"
+`
+
+const w3cLog = `#Version: 1.0
+#Fields: date time c-ip cs-method cs-uri-stem cs-uri-query sc-status sc-bytes cs(Referer) cs(User-Agent) cs-host x-cache-status x-pop time-taken
+2025-06-10 13:55:36 203.0.113.7 GET /video/intro.mp4 - 206 1048576 - "VLC/3.0" media.example.com miss FRA 1.5
+`
+
+func TestScanner_Combined(t *testing.T) {
+	entries := scanAll(t, combinedLog)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	e := entries[0]
+	if e.ClientIP != "203.0.113.7" || e.Method != "GET" || e.Path != "/img/logo.png" || e.Query != "v=2" || e.Protocol != "HTTP/1.1" {
+		t.Errorf("Unexpected request fields %+v", e)
+	}
+	if e.Status != 200 || e.Bytes != 2326 {
+		t.Errorf("Expected 200 and 2326 bytes, got %d and %d", e.Status, e.Bytes)
+	}
+	if e.Referer != "https://example.com/" || e.UserAgent != "Mozilla/5.0 (X11; Linux)" {
+		t.Errorf("Unexpected referer or user agent %+v", e)
+	}
+	if e.Host != "cdn.example.com" || e.CacheStatus != CacheHit || e.POP != "LAX" || e.Duration != 12*time.Millisecond {
+		t.Errorf("Unexpected CacheFly fields %+v", e)
+	}
+	if !e.Time.Equal(time.Date(2025, 6, 10, 13, 55, 36, 0, time.UTC)) {
+		t.Errorf("Unexpected time %s", e.Time)
+	}
+
+	if entries[1].Bytes != 0 || entries[1].Referer != "" || entries[1].CacheStatus != "" {
+		t.Errorf("Expected dash and missing fields to be empty, got %+v", entries[1])
+	}
+}
+
+func TestScanner_W3C(t *testing.T) {
+	entries := scanAll(t, w3cLog)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+
+	e := entries[0]
+	if e.Method != "GET" || e.Path != "/video/intro.mp4" || e.Query != "" || e.Status != 206 || e.Bytes != 1048576 {
+		t.Errorf("Unexpected request fields %+v", e)
+	}
+	if e.UserAgent != "VLC/3.0" || e.Host != "media.example.com" {
+		t.Errorf("Unexpected user agent or host %+v", e)
+	}
+	if e.CacheStatus != CacheMiss || e.POP != "FRA" || e.Duration != 1500*time.Millisecond {
+		t.Errorf("Unexpected CacheFly fields %+v", e)
+	}
+	if !e.Time.Equal(time.Date(2025, 6, 10, 13, 55, 36, 0, time.UTC)) {
+		t.Errorf("Unexpected time %s", e.Time)
+	}
+}
+
+func TestScanner_InvalidLine(t *testing.T) {
+	input := "garbage\n" + combinedLog
+
+	scanner := NewScanner(strings.NewReader(input))
+	if scanner.Scan() {
+		t.Fatalf("Expected scan to stop on invalid line")
+	}
+	if scanner.Err() == nil || !strings.Contains(scanner.Err().Error(), "line 1") {
+		t.Errorf("Expected error for line 1, got %v", scanner.Err())
+	}
+
+	scanner = NewScanner(strings.NewReader(input))
+	scanner.SkipInvalid = true
+	n := 0
+	for scanner.Scan() {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 entries when skipping invalid lines, got %d", n)
+	}
+}
+
+func scanAll(t *testing.T, input string) []*LogEntry {
+	t.Helper()
+	scanner := NewScanner(strings.NewReader(input))
+	var entries []*LogEntry
+	for scanner.Scan() {
+		entries = append(entries, scanner.Entry())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return entries
+}
@@ -0,0 +1,92 @@
+package logparse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxLineSize is the longest log line the Scanner accepts.
+const maxLineSize = 1024 * 1024
+
+// Scanner reads LogEntry values line by line from an uncompressed log.
+//
+// Lines are parsed as combined format until a #Fields directive is seen,
+// after which they are parsed as W3C extended format with those fields.
+// Blank lines and other # directives are skipped.
+type Scanner struct {
+	// ExtraFields names the fields following the combined format fields;
+	// nil uses DefaultExtraFields
+	ExtraFields []string
+
+	// SkipInvalid skips lines that fail to parse instead of stopping
+	SkipInvalid bool
+
+	scanner *bufio.Scanner
+	w3c     []string
+	entry   *LogEntry
+	line    int
+	err     error
+}
+
+// NewScanner returns a Scanner reading from r.
+func NewScanner(r io.Reader) *Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	return &Scanner{scanner: scanner}
+}
+
+// Scan advances to the next entry, returning false at the end of the input
+// or on an error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.scanner.Scan() {
+		s.line++
+		line := strings.TrimRight(s.scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if fields, ok := strings.CutPrefix(line, "#Fields:"); ok {
+				s.w3c = strings.Fields(fields)
+			}
+			continue
+		}
+
+		var entry *LogEntry
+		var err error
+		if s.w3c != nil {
+			entry, err = parseW3C(line, s.w3c)
+		} else {
+			extra := s.ExtraFields
+			if extra == nil {
+				extra = DefaultExtraFields
+			}
+			entry, err = parseCombined(line, extra)
+		}
+		if err != nil {
+			if s.SkipInvalid {
+				continue
+			}
+			s.err = fmt.Errorf("line %d: %w", s.line, err)
+			return false
+		}
+		s.entry = entry
+		return true
+	}
+	s.err = s.scanner.Err()
+	return false
+}
+
+// Entry returns the entry read by the last call to Scan.
+func (s *Scanner) Entry() *LogEntry {
+	return s.entry
+}
+
+// Err returns the first error encountered by Scan.
+func (s *Scanner) Err() error {
+	return s.err
+}
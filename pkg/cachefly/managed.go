@@ -0,0 +1,315 @@
+package cachefly
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// ManagedConfig configures a ManagedClient. Zero intervals use the
+// defaults; negative intervals disable the loop.
+type ManagedConfig struct {
+	// Options are applied to the client, e.g. WithCredentials
+	Options []Option
+
+	// TokenRefreshInterval is how often the credentials are asked for a
+	// token, so a RefreshingToken renews it in the background instead of on
+	// a request. Zero uses one minute. Without WithCredentials there is
+	// nothing to refresh.
+	TokenRefreshInterval time.Duration
+
+	// HealthInterval is how often the API is pinged. Zero uses 30 seconds.
+	HealthInterval time.Duration
+
+	// HealthCheck pings the API; nil gets the current account
+	HealthCheck func(ctx context.Context, client *Client) error
+
+	// OnHealthChange is called from the health loop when the API turns
+	// healthy or unhealthy, and after the first check
+	OnHealthChange func(HealthStatus)
+
+	// CacheTTL caches GET responses for the given time when the options do
+	// not set WithResponseCache; zero disables caching
+	CacheTTL time.Duration
+
+	// CachePurgeInterval drops every cached response at this interval, which
+	// bounds how long changes made outside the client stay hidden after
+	// revalidation fails. Zero never purges.
+	CachePurgeInterval time.Duration
+}
+
+// HealthStatus is the result of the last health check of a ManagedClient.
+type HealthStatus struct {
+	Healthy   bool
+	CheckedAt time.Time
+
+	// Err is the error of the check; nil when healthy
+	Err error
+}
+
+// ManagedMetrics counts the activity of a ManagedClient since it was
+// created.
+type ManagedMetrics struct {
+	// Requests is the number of HTTP requests sent, including retries;
+	// responses served from the cache are not counted
+	Requests int64
+
+	// Errors counts network errors and 5xx responses
+	Errors int64
+
+	// Throttled counts 429 responses
+	Throttled int64
+
+	// Changes counts successful creates, updates and deletes
+	Changes int64
+
+	// TokenRefreshes and TokenRefreshFailures count the background token
+	// checks that succeeded and failed
+	TokenRefreshes       int64
+	TokenRefreshFailures int64
+
+	// HealthChecks counts the health checks; HealthFailures those that failed
+	HealthChecks   int64
+	HealthFailures int64
+
+	// CacheEntries is the number of cached responses
+	CacheEntries int
+}
+
+// ManagedClient is a Client that owns the background work of a long-running
+// service: it keeps the token fresh, pings the API, purges the response
+// cache and counts requests. Call Close to stop the background goroutines;
+// they also stop when the context given to NewManagedClient ends.
+//
+// Example:
+//
+//	client, err := cachefly.NewManagedClient(ctx, cachefly.ManagedConfig{
+//		Options: []cachefly.Option{
+//			cachefly.WithCredentials(cachefly.RefreshingToken(fetchToken)),
+//		},
+//		CacheTTL: time.Minute,
+//		OnHealthChange: func(s cachefly.HealthStatus) {
+//			slog.Info("CacheFly API health changed", "healthy", s.Healthy, "error", s.Err)
+//		},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer client.Close()
+//
+//	services, err := client.Services.List(ctx, api.ListOptions{})
+type ManagedClient struct {
+	*Client
+
+	cfg         ManagedConfig
+	credentials CredentialsProvider
+	cache       *ResponseCache
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	done   chan struct{}
+
+	requests, errors, throttled, changes int64
+	tokenRefreshes, tokenFailures        int64
+	healthChecks, healthFailures         int64
+
+	mu     sync.Mutex
+	health HealthStatus
+}
+
+// NewManagedClient creates the client and starts its background
+// goroutines, which run until Close is called or ctx ends.
+func NewManagedClient(ctx context.Context, cfg ManagedConfig) (*ManagedClient, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if cfg.TokenRefreshInterval == 0 {
+		cfg.TokenRefreshInterval = time.Minute
+	}
+	if cfg.HealthInterval == 0 {
+		cfg.HealthInterval = 30 * time.Second
+	}
+	if cfg.HealthCheck == nil {
+		cfg.HealthCheck = func(ctx context.Context, client *Client) error {
+			_, err := client.Accounts.Get(ctx, "")
+			return err
+		}
+	}
+
+	m := &ManagedClient{cfg: cfg, done: make(chan struct{})}
+	opts := append([]Option{}, cfg.Options...)
+	opts = append(opts, m.instrument)
+	m.Client = NewClient(opts...)
+
+	ctx, m.cancel = context.WithCancel(ctx)
+	if m.credentials != nil && cfg.TokenRefreshInterval > 0 {
+		m.loop(ctx, cfg.TokenRefreshInterval, m.refreshToken)
+	}
+	if cfg.HealthInterval > 0 {
+		m.loop(ctx, cfg.HealthInterval, m.checkHealth)
+	}
+	if m.cache != nil && cfg.CachePurgeInterval > 0 {
+		m.loop(ctx, cfg.CachePurgeInterval, func(context.Context) { m.cache.Purge() })
+	}
+	go func() {
+		m.wg.Wait()
+		close(m.done)
+	}()
+	return m, nil
+}
+
+// instrument is the last option applied to the client: it sets up the
+// cache, counts changes and wraps the transport to count requests.
+func (m *ManagedClient) instrument(c *ClientConfig) {
+	m.credentials = c.Credentials
+	if c.Cache == nil && m.cfg.CacheTTL > 0 {
+		c.Cache = NewResponseCache(m.cfg.CacheTTL)
+	}
+	m.cache = c.Cache
+
+	onChange := c.OnChange
+	c.OnChange = func(ev ChangeEvent) {
+		atomic.AddInt64(&m.changes, 1)
+		if onChange != nil {
+			onChange(ev)
+		}
+	}
+
+	base := c.transport
+	if t := c.newTransport(); t != nil {
+		base = t
+		c.TransportOptions, c.Proxy, c.TLSConfig = nil, nil, nil
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c.transport = &countingTransport{base: base, m: m}
+}
+
+// loop runs fn immediately and then every interval until ctx ends.
+func (m *ManagedClient) loop(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			fn(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (m *ManagedClient) refreshToken(ctx context.Context) {
+	if _, err := m.credentials.Token(ctx); err != nil {
+		if ctx.Err() == nil {
+			atomic.AddInt64(&m.tokenFailures, 1)
+		}
+		return
+	}
+	atomic.AddInt64(&m.tokenRefreshes, 1)
+}
+
+func (m *ManagedClient) checkHealth(ctx context.Context) {
+	// The ping must reach the API, not the cache
+	if m.cache != nil {
+		m.cache.Invalidate(apispec.PathCurrentAccount)
+	}
+	err := m.cfg.HealthCheck(ctx, m.Client)
+	if ctx.Err() != nil {
+		return
+	}
+	atomic.AddInt64(&m.healthChecks, 1)
+	if err != nil {
+		atomic.AddInt64(&m.healthFailures, 1)
+	}
+
+	status := HealthStatus{Healthy: err == nil, CheckedAt: time.Now(), Err: err}
+	m.mu.Lock()
+	changed := m.health.CheckedAt.IsZero() || m.health.Healthy != status.Healthy
+	m.health = status
+	m.mu.Unlock()
+	if changed && m.cfg.OnHealthChange != nil {
+		m.cfg.OnHealthChange(status)
+	}
+}
+
+// Health returns the result of the last health check; its CheckedAt is zero
+// before the first check completes.
+func (m *ManagedClient) Health() HealthStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.health
+}
+
+// Healthy reports whether the last health check succeeded.
+func (m *ManagedClient) Healthy() bool {
+	return m.Health().Healthy
+}
+
+// Metrics returns a snapshot of the counters.
+func (m *ManagedClient) Metrics() ManagedMetrics {
+	metrics := ManagedMetrics{
+		Requests:             atomic.LoadInt64(&m.requests),
+		Errors:               atomic.LoadInt64(&m.errors),
+		Throttled:            atomic.LoadInt64(&m.throttled),
+		Changes:              atomic.LoadInt64(&m.changes),
+		TokenRefreshes:       atomic.LoadInt64(&m.tokenRefreshes),
+		TokenRefreshFailures: atomic.LoadInt64(&m.tokenFailures),
+		HealthChecks:         atomic.LoadInt64(&m.healthChecks),
+		HealthFailures:       atomic.LoadInt64(&m.healthFailures),
+	}
+	if m.cache != nil {
+		metrics.CacheEntries = m.cache.Len()
+	}
+	return metrics
+}
+
+// Cache returns the response cache of the client, or nil when responses
+// are not cached. Use its Invalidate method when resources change outside
+// the client, e.g. on a webhook.
+func (m *ManagedClient) Cache() *ResponseCache {
+	return m.cache
+}
+
+// Close stops the background goroutines and waits for them to return.
+// Requests made with the client keep working. Close is safe to call more
+// than once.
+func (m *ManagedClient) Close() error {
+	m.cancel()
+	<-m.done
+	return nil
+}
+
+// Done is closed once the background goroutines have stopped.
+func (m *ManagedClient) Done() <-chan struct{} {
+	return m.done
+}
+
+// countingTransport counts the requests and failures of a ManagedClient.
+type countingTransport struct {
+	base http.RoundTripper
+	m    *ManagedClient
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt64(&t.m.requests, 1)
+	resp, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil:
+		atomic.AddInt64(&t.m.errors, 1)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		atomic.AddInt64(&t.m.throttled, 1)
+	case resp.StatusCode >= 500:
+		atomic.AddInt64(&t.m.errors, 1)
+	}
+	return resp, err
+}
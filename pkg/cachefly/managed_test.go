@@ -0,0 +1,135 @@
+package cachefly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewManagedClient(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/2.5/accounts/me" && down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"svc-1","name":"example"}`))
+	}))
+	defer server.Close()
+
+	var refreshes atomic.Int64
+	credentials := RefreshingToken(func(ctx context.Context) (string, time.Time, error) {
+		refreshes.Add(1)
+		// Expires within the refresh skew, so every check renews it
+		return "token", time.Now().Add(time.Second), nil
+	})
+
+	var mu sync.Mutex
+	var statuses []bool
+	client, err := NewManagedClient(context.Background(), ManagedConfig{
+		Options:              []Option{WithBaseURL(server.URL + "/api/2.5"), WithCredentials(credentials)},
+		TokenRefreshInterval: 5 * time.Millisecond,
+		HealthInterval:       5 * time.Millisecond,
+		CacheTTL:             time.Minute,
+		OnHealthChange: func(s HealthStatus) {
+			mu.Lock()
+			statuses = append(statuses, s.Healthy)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	waitFor(t, func() bool { return client.Metrics().HealthFailures > 0 })
+	if client.Healthy() || client.Health().Err == nil {
+		t.Errorf("Expected unhealthy with an error, got %+v", client.Health())
+	}
+	down.Store(false)
+	waitFor(t, client.Healthy)
+	waitFor(t, func() bool { return refreshes.Load() >= 2 })
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Services.Get(context.Background(), "svc-1", "", false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	select {
+	case <-client.Done():
+	default:
+		t.Error("Expected Done to be closed after Close")
+	}
+	client.Close()
+
+	metrics := client.Metrics()
+	if metrics.Errors == 0 || metrics.Requests <= metrics.Errors {
+		t.Errorf("Expected requests and errors to be counted, got %+v", metrics)
+	}
+	if metrics.CacheEntries == 0 {
+		t.Errorf("Expected the service to be cached, got %+v", metrics)
+	}
+	if metrics.TokenRefreshes == 0 {
+		t.Errorf("Expected token refreshes to be counted, got %+v", metrics)
+	}
+
+	// No check runs after Close
+	checks := metrics.HealthChecks
+	time.Sleep(20 * time.Millisecond)
+	if got := client.Metrics().HealthChecks; got != checks {
+		t.Errorf("Expected no health checks after Close, got %d more", got-checks)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(statuses) != 2 || statuses[0] || !statuses[1] {
+		t.Errorf("Expected unhealthy then healthy, got %v", statuses)
+	}
+}
+
+func TestNewManagedClient_ContextEnds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"_id":"acc"}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client, err := NewManagedClient(ctx, ManagedConfig{
+		Options:        []Option{WithToken("test-token"), WithBaseURL(server.URL + "/api/2.5")},
+		HealthInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cancel()
+
+	select {
+	case <-client.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected the background goroutines to stop when the context ends")
+	}
+
+	if _, err := NewManagedClient(ctx, ManagedConfig{}); err == nil {
+		t.Error("Expected an error for an ended context")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
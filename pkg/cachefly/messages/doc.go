@@ -0,0 +1,21 @@
+// Package messages lets applications translate or reword the error messages
+// produced by the SDK before showing them to their users.
+//
+// Errors created by the SDK, such as validation errors, API errors and
+// limit errors, implement Localizable: besides their English Error() text
+// they carry a stable message key and the parameters the message is built
+// from. A Catalog maps keys to templates in the user's language:
+//
+//	german := messages.Catalog{
+//		"required":                 "{field} ist erforderlich",
+//		"invalid_hostname":         "{value} ist kein gültiger Hostname",
+//		"invalid_hostname.hyphen":  "Labels von {value} dürfen nicht mit einem Bindestrich beginnen oder enden",
+//		"api_error":                "Die CacheFly API hat mit Status {status} geantwortet",
+//	}
+//	http.Error(w, german.Message(err), http.StatusBadRequest)
+//
+// Keys are hierarchical: "invalid_hostname.hyphen" falls back to
+// "invalid_hostname" when the catalog has no entry for the specific rule.
+// Errors without a matching entry keep their English message, so catalogs
+// can be filled in incrementally.
+package messages
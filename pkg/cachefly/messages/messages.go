@@ -0,0 +1,83 @@
+package messages
+
+import (
+	"errors"
+	"strings"
+)
+
+// Localizable is implemented by SDK errors whose message can be rendered
+// from a Catalog.
+type Localizable interface {
+	error
+
+	// MessageKey identifies the message, with more specific variants
+	// separated by dots, such as "invalid_hostname.hyphen"
+	MessageKey() string
+
+	// MessageParams returns the values the message template may reference
+	MessageParams() map[string]string
+}
+
+// Catalog maps message keys to templates. Templates reference parameters
+// as {name}; unknown names are left as they are.
+type Catalog map[string]string
+
+// Message returns the message for err from the catalog. The first
+// Localizable error in err's chain is rendered, which replaces the context
+// added by wrapping errors. When no error in the chain is Localizable or the
+// catalog has no template for it, err.Error() is returned.
+func (c Catalog) Message(err error) string {
+	if err == nil {
+		return ""
+	}
+	var l Localizable
+	if !errors.As(err, &l) {
+		return err.Error()
+	}
+	template, ok := c.Lookup(l.MessageKey())
+	if !ok {
+		return err.Error()
+	}
+	return Render(template, l.MessageParams())
+}
+
+// Lookup returns the template for key, falling back to less specific keys
+// by dropping dot-separated suffixes.
+func (c Catalog) Lookup(key string) (string, bool) {
+	for {
+		if template, ok := c[key]; ok {
+			return template, true
+		}
+		i := strings.LastIndexByte(key, '.')
+		if i < 0 {
+			return "", false
+		}
+		key = key[:i]
+	}
+}
+
+// Render replaces the {name} placeholders of template with params.
+func Render(template string, params map[string]string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(template[:start])
+		if value, ok := params[template[start+1:end]]; ok {
+			b.WriteString(value)
+		} else {
+			b.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
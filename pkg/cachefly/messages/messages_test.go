@@ -0,0 +1,82 @@
+package messages
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// SDK errors that must render from catalogs.
+var (
+	_ Localizable = (*validate.Error)(nil)
+	_ Localizable = (*httpclient.APIError)(nil)
+	_ Localizable = (*httpclient.MaintenanceError)(nil)
+	_ Localizable = (*httpclient.RetryExhaustedError)(nil)
+	_ Localizable = (*api.LimitExceededError)(nil)
+)
+
+var german = Catalog{
+	"required":                "{field} ist erforderlich",
+	"invalid_hostname":        "{value} ist kein gültiger Hostname",
+	"invalid_hostname.hyphen": "Labels von {value} dürfen nicht mit einem Bindestrich beginnen oder enden",
+	"api_error":               "Die CacheFly API hat mit Status {status} geantwortet",
+	"api_error.404":           "Nicht gefunden",
+	"limit_exceeded":          "Limit {limit} überschritten: maximal {max}",
+}
+
+func TestCatalog_Message(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{validate.Required("name", ""), "name ist erforderlich"},
+		{validate.Hostname("name", "-a.example.com"), "Labels von -a.example.com dürfen nicht mit einem Bindestrich beginnen oder enden"},
+		{validate.Hostname("name", "a_b.example.com"), "a_b.example.com ist kein gültiger Hostname"},
+		{fmt.Errorf("failed to create domain: %w", validate.Required("name", "")), "name ist erforderlich"},
+		{&httpclient.APIError{StatusCode: 500, Body: "boom"}, "Die CacheFly API hat mit Status 500 geantwortet"},
+		{&httpclient.APIError{StatusCode: 404}, "Nicht gefunden"},
+		{&api.LimitExceededError{Limit: api.LimitServices, Max: 10}, "Limit services überschritten: maximal 10"},
+	}
+	for _, tt := range tests {
+		if got := german.Message(tt.err); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestCatalog_MessageFallsBack(t *testing.T) {
+	err := validate.OneOf("mode", "FTP", "WEB", "API")
+	if got := german.Message(err); got != err.Error() {
+		t.Errorf("Expected English message for missing key, got %q", got)
+	}
+
+	plain := errors.New("plain")
+	if got := german.Message(plain); got != "plain" {
+		t.Errorf("Expected plain message, got %q", got)
+	}
+	if got := german.Message(nil); got != "" {
+		t.Errorf("Expected empty message for nil, got %q", got)
+	}
+
+	maint := &httpclient.MaintenanceError{
+		APIError:     &httpclient.APIError{StatusCode: http.StatusServiceUnavailable},
+		EstimatedEnd: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	catalog := Catalog{"api_maintenance": "Wartung bis {until}"}
+	if got := catalog.Message(maint); got != "Wartung bis 2025-01-01T12:00:00Z" {
+		t.Errorf("Expected maintenance message, got %q", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	got := Render("{field} {unknown} {", map[string]string{"field": "name"})
+	if got != "name {unknown} {" {
+		t.Errorf("Expected unknown placeholders to be kept, got %q", got)
+	}
+}
@@ -0,0 +1,73 @@
+package cachefly
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// ResponseMeta describes the last response of a call: its status, the
+// pagination metadata of list responses and the API rate limit state.
+type ResponseMeta = httpclient.ResponseMeta
+
+// RateLimitState is the API rate limit reported with a response.
+type RateLimitState = httpclient.RateLimitState
+
+// WithResponseMeta returns a context that records the metadata of the
+// responses received for calls made with it in meta. When a call sends
+// several requests, such as paging or read-back, meta describes the last
+// one. meta must not be shared by concurrent calls.
+//
+// Example:
+//
+//	var meta cachefly.ResponseMeta
+//	page, err := client.Services.List(cachefly.WithResponseMeta(ctx, &meta), opts)
+//	fmt.Printf("%d of %d, %d requests left\n", meta.Offset+len(page.Services), meta.Count, meta.RateLimit.Remaining)
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return httpclient.WithResponseMeta(ctx, meta)
+}
+
+// CaptureResponse returns a context that stores the last HTTP response
+// received for calls made with it in *resp, for inspecting headers and the
+// status alongside the typed result. The body is read by the SDK and
+// replaced by http.NoBody. Responses served from the response cache
+// without contacting the API are not stored.
+//
+// Example:
+//
+//	var resp *http.Response
+//	svc, err := client.Services.Get(cachefly.CaptureResponse(ctx, &resp), id, "", false)
+//	if resp != nil {
+//		log.Printf("request %s", resp.Header.Get("X-Request-Id"))
+//	}
+func CaptureResponse(ctx context.Context, resp **http.Response) context.Context {
+	return httpclient.WithCaptureResponse(ctx, resp)
+}
+
+// HeaderRequestID is the header every request carries its ID in.
+const HeaderRequestID = httpclient.HeaderRequestID
+
+// ContextWithRequestID returns a context whose calls are sent with id in
+// the X-Request-ID header, e.g. to propagate the ID of an incoming request.
+// Without it, each call gets a generated ID, shared by its retries. The ID
+// is reported in APIError.RequestID, ResponseMeta.RequestID and the
+// WithDebug log, so it can be quoted in support tickets.
+//
+// Example:
+//
+//	ctx = cachefly.ContextWithRequestID(ctx, r.Header.Get("X-Request-ID"))
+//	_, err := client.Services.Get(ctx, id, "", false)
+//	var apiErr *cachefly.APIError
+//	if errors.As(err, &apiErr) {
+//		log.Printf("request %s failed, API correlation ID %s", apiErr.RequestID, apiErr.ServerRequestID)
+//	}
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return httpclient.WithRequestID(ctx, id)
+}
+
+// RequestIDFromContext returns the ID set with ContextWithRequestID, or
+// empty.
+func RequestIDFromContext(ctx context.Context) string {
+	return httpclient.RequestIDFromContext(ctx)
+}
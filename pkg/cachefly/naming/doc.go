@@ -0,0 +1,12 @@
+// Package naming generates service names and uniqueNames that the CacheFly
+// API accepts from arbitrary customer input, and resolves collisions with
+// existing services.
+//
+// A uniqueName must be 3 to 63 characters of lowercase letters, digits and
+// single hyphens, starting with a letter and ending with a letter or digit.
+// The API rejects anything else with a 400, so callers building names from
+// user input should go through this package:
+//
+//	uniqueName, err := naming.UniqueName(ctx, "Müller & Söhne GmbH", naming.ServiceTaken(client))
+//	// "muller-sohne-gmbh", or "muller-sohne-gmbh-2" if taken
+package naming
@@ -0,0 +1,170 @@
+package naming
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// Limits enforced by the API.
+const (
+	MinUniqueNameLength = validate.MinUniqueNameLength
+	MaxUniqueNameLength = validate.MaxUniqueNameLength
+	MaxNameLength       = 100
+)
+
+// MaxSuffix is the highest numeric suffix UniqueName tries.
+const MaxSuffix = 1000
+
+// fallbackPrefix starts uniqueNames whose input does not start with a letter.
+const fallbackPrefix = "svc"
+
+// transliterations maps common accented Latin letters to ASCII.
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae",
+	'ç': "c", 'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i",
+	'î': "i", 'ï': "i", 'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o",
+	'ö': "o", 'ø': "o", 'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ý': "y",
+	'ÿ': "y", 'ß': "ss", 'œ': "oe", 'ł': "l", 'ś': "s", 'ź': "z", 'ż': "z",
+	'ć': "c", 'ń': "n", 'ą': "a", 'ę': "e", 'š': "s", 'č': "c", 'ž': "z",
+	'ř': "r", 'ě': "e", 'ů': "u", 'đ': "d",
+}
+
+// Slugify turns an arbitrary name into a valid uniqueName: accents are
+// transliterated, everything else outside [a-z0-9] becomes a single hyphen,
+// names not starting with a letter get a prefix, short names are padded and
+// long ones truncated. The result is deterministic.
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			hyphen = false
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+			hyphen = false
+		default:
+			if !hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+				hyphen = true
+			}
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+
+	if slug == "" || slug[0] < 'a' || slug[0] > 'z' {
+		slug = strings.TrimSuffix(fallbackPrefix+"-"+slug, "-")
+	}
+	for len(slug) < MinUniqueNameLength {
+		slug += "0"
+	}
+	return truncate(slug, MaxUniqueNameLength)
+}
+
+// ValidateUniqueName reports why the API would reject name as a uniqueName.
+// It is validate.UniqueName for the uniqueName field.
+func ValidateUniqueName(name string) error {
+	return validate.UniqueName("uniqueName", name)
+}
+
+// DisplayName cleans a customer name for use as a service name: whitespace
+// is collapsed, control characters are dropped and the result is truncated
+// to MaxNameLength characters.
+func DisplayName(name string) string {
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	})
+	runes := []rune(strings.Join(fields, " "))
+	if len(runes) > MaxNameLength {
+		runes = runes[:MaxNameLength]
+	}
+	return strings.TrimSpace(string(runes))
+}
+
+// WithSuffix appends "-<n>" to a uniqueName, truncating it so the result
+// stays within MaxUniqueNameLength.
+func WithSuffix(uniqueName string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	return truncate(uniqueName, MaxUniqueNameLength-len(suffix)) + suffix
+}
+
+// Taken reports whether a uniqueName is already in use.
+type Taken func(ctx context.Context, uniqueName string) (bool, error)
+
+// UniqueName returns the first free uniqueName for name: its slug, or the
+// slug with the lowest numeric suffix starting at 2 that is not taken.
+func UniqueName(ctx context.Context, name string, taken Taken) (string, error) {
+	base := Slugify(name)
+	candidate := base
+	for n := 2; n <= MaxSuffix+1; n++ {
+		inUse, err := taken(ctx, candidate)
+		if err != nil {
+			return "", fmt.Errorf("failed to check uniqueName %s: %w", candidate, err)
+		}
+		if !inUse {
+			return candidate, nil
+		}
+		candidate = WithSuffix(base, n)
+	}
+	return "", fmt.Errorf("no free uniqueName for %q after %d attempts", name, MaxSuffix)
+}
+
+// ServiceTaken returns a Taken backed by the services of the client's
+// account. The services are listed once, on first use.
+func ServiceTaken(client *cachefly.Client) Taken {
+	var once sync.Once
+	var names map[string]bool
+	var listErr error
+
+	return func(ctx context.Context, uniqueName string) (bool, error) {
+		once.Do(func() {
+			names = make(map[string]bool)
+			const pageSize = 100
+			for offset := 0; ; offset += pageSize {
+				page, err := client.Services.List(ctx, api.ListOptions{Offset: offset, Limit: pageSize})
+				if err != nil {
+					listErr = fmt.Errorf("failed to list services: %w", err)
+					return
+				}
+				for _, svc := range page.Services {
+					names[svc.UniqueName] = true
+				}
+				if len(page.Services) < pageSize {
+					return
+				}
+			}
+		})
+		if listErr != nil {
+			return false, listErr
+		}
+		return names[uniqueName], nil
+	}
+}
+
+// TakenNames returns a Taken backed by a fixed set of uniqueNames.
+func TakenNames(uniqueNames ...string) Taken {
+	set := make(map[string]bool, len(uniqueNames))
+	for _, n := range uniqueNames {
+		set[n] = true
+	}
+	return func(ctx context.Context, uniqueName string) (bool, error) {
+		return set[uniqueName], nil
+	}
+}
+
+// truncate shortens a slug to max bytes without leaving a trailing hyphen.
+func truncate(slug string, max int) string {
+	if len(slug) > max {
+		slug = strings.TrimRight(slug[:max], "-")
+	}
+	return slug
+}
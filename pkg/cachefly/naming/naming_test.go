@@ -0,0 +1,95 @@
+package naming
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"My Site":                 "my-site",
+		"Müller & Söhne GmbH":     "muller-sohne-gmbh",
+		"  --Acme__Corp!!  ":      "acme-corp",
+		"42 Media":                "svc-42-media",
+		"日本":                      "svc",
+		"ab":                      "ab0",
+		strings.Repeat("a", 70):   strings.Repeat("a", 63),
+		"Straße der Einheit 2025": "strasse-der-einheit-2025",
+	}
+	for input, want := range tests {
+		got := Slugify(input)
+		if got != want {
+			t.Errorf("Slugify(%q): expected %s, got %s", input, want, got)
+		}
+		if err := ValidateUniqueName(got); err != nil {
+			t.Errorf("Slugify(%q) produced invalid uniqueName %s: %v", input, got, err)
+		}
+	}
+}
+
+func TestValidateUniqueName(t *testing.T) {
+	for _, invalid := range []string{"ab", "1site", "site-", "my--site", "My-Site", "my_site", strings.Repeat("a", 64)} {
+		if err := ValidateUniqueName(invalid); err == nil {
+			t.Errorf("Expected %q to be invalid", invalid)
+		}
+	}
+	if err := ValidateUniqueName("my-site-2"); err != nil {
+		t.Errorf("Expected my-site-2 to be valid, got %v", err)
+	}
+}
+
+func TestWithSuffix_StaysWithinLimit(t *testing.T) {
+	long := Slugify(strings.Repeat("a", 61) + " b")
+	got := WithSuffix(long, 12)
+	if len(got) > MaxUniqueNameLength || !strings.HasSuffix(got, "-12") {
+		t.Errorf("Expected suffixed name within %d characters, got %s", MaxUniqueNameLength, got)
+	}
+	if err := ValidateUniqueName(got); err != nil {
+		t.Errorf("Expected valid uniqueName, got %v", err)
+	}
+}
+
+func TestUniqueName_Collisions(t *testing.T) {
+	got, err := UniqueName(context.Background(), "My Site", TakenNames("my-site", "my-site-2"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != "my-site-3" {
+		t.Errorf("Expected my-site-3, got %s", got)
+	}
+}
+
+func TestServiceTaken(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"svc-1","uniqueName":"my-site"}]}`))
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL))
+	got, err := UniqueName(context.Background(), "My Site", ServiceTaken(client))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != "my-site-2" {
+		t.Errorf("Expected my-site-2, got %s", got)
+	}
+	if requests != 1 {
+		t.Errorf("Expected services to be listed once, got %d requests", requests)
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	if got := DisplayName("  Acme\tCorp \n CDN "); got != "Acme Corp CDN" {
+		t.Errorf("Expected collapsed whitespace, got %q", got)
+	}
+	if got := DisplayName(strings.Repeat("é", 150)); len([]rune(got)) != MaxNameLength {
+		t.Errorf("Expected %d characters, got %d", MaxNameLength, len([]rune(got)))
+	}
+}
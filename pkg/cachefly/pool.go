@@ -0,0 +1,172 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// PoolConfig configures a ClientPool.
+type PoolConfig struct {
+	// Credentials returns the credentials of an account; it is called once
+	// per account, when its client is first requested. Required.
+	Credentials func(accountID string) (CredentialsProvider, error)
+
+	// Options are applied to every client, before the settings of the pool.
+	// A response cache set by WithResponseCache is dropped: its entries are
+	// keyed by URL, so accounts sharing it would see each other's responses.
+	Options []Option
+
+	// TransportOptions tunes the connection pool shared by all clients; nil
+	// uses http.DefaultTransport
+	TransportOptions *TransportOptions
+
+	// RateLimit and Burst limit the requests of each account, see
+	// WithRateLimit; a zero RateLimit disables the limit
+	RateLimit float64
+	Burst     int
+
+	// MaxConcurrent caps the concurrent requests of all accounts together,
+	// serving waiting accounts in turn, see WithScheduler; zero disables the cap
+	MaxConcurrent int
+}
+
+// ClientPool manages the clients of many accounts, such as the child
+// accounts of a reseller. Clients are created on first use and share one
+// connection pool and, when MaxConcurrent is set, one Scheduler; each has
+// its own credentials and rate limit. A ClientPool is safe for concurrent
+// use.
+//
+// Example:
+//
+//	parent := cachefly.NewClient(cachefly.WithToken("reseller-token"))
+//	pool, err := cachefly.NewClientPool(cachefly.PoolConfig{
+//		Credentials:   cachefly.ChildAccountCredentials(parent),
+//		RateLimit:     5,
+//		Burst:         10,
+//		MaxConcurrent: 20,
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client, err := pool.ForAccount(childID)
+type ClientPool struct {
+	cfg       PoolConfig
+	transport http.RoundTripper
+	scheduler *Scheduler
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientPool returns an empty pool.
+func NewClientPool(cfg PoolConfig) (*ClientPool, error) {
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("credentials are required")
+	}
+	p := &ClientPool{cfg: cfg, clients: make(map[string]*Client)}
+	if cfg.TransportOptions != nil {
+		p.transport = httpclient.NewTransport(*cfg.TransportOptions)
+	}
+	if cfg.MaxConcurrent > 0 {
+		p.scheduler = NewScheduler(cfg.MaxConcurrent)
+	}
+	return p, nil
+}
+
+// ForAccount returns the client of the account, creating it on first use.
+func (p *ClientPool) ForAccount(accountID string) (*Client, error) {
+	if err := validate.ID("accountID", accountID); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	client, ok := p.clients[accountID]
+	p.mu.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	// Credentials may be slow to set up, so other accounts are not blocked
+	// meanwhile; if two callers race, the first client stored wins
+	credentials, err := p.cfg.Credentials(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials for account %s: %w", accountID, err)
+	}
+	opts := append([]Option{}, p.cfg.Options...)
+	opts = append(opts, WithCredentials(credentials), WithResponseCache(nil), func(c *ClientConfig) {
+		c.TransportOptions = nil
+		c.transport = p.transport
+	})
+	if p.cfg.RateLimit > 0 {
+		opts = append(opts, WithRateLimit(p.cfg.RateLimit, p.cfg.Burst))
+	}
+	if p.scheduler != nil {
+		opts = append(opts, WithScheduler(p.scheduler, accountID))
+	}
+	client = NewClient(opts...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if existing, ok := p.clients[accountID]; ok {
+		return existing, nil
+	}
+	p.clients[accountID] = client
+	return client, nil
+}
+
+// Remove drops the client of the account, for example after its
+// credentials were revoked. The next ForAccount creates a new one.
+func (p *ClientPool) Remove(accountID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, accountID)
+}
+
+// Accounts returns the IDs of the accounts with a client, sorted.
+func (p *ClientPool) Accounts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ids := make([]string, 0, len(p.clients))
+	for id := range p.clients {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ChildAccountCredentials returns a PoolConfig.Credentials function giving
+// each child account of parent's account a token from
+// Accounts.GetChildAccountAuthToken, renewed before it expires.
+func ChildAccountCredentials(parent *Client) func(accountID string) (CredentialsProvider, error) {
+	return func(accountID string) (CredentialsProvider, error) {
+		return RefreshingToken(ChildAccountTokenRefresh(parent, accountID)), nil
+	}
+}
+
+// fallbackTokenLifetime is how long a child account token is used when the
+// API reports its expiry in an unexpected format. A zero expiry would mean
+// the token never expires, turning off proactive refresh.
+const fallbackTokenLifetime = 5 * time.Minute
+
+// ChildAccountTokenRefresh returns a RefreshFunc that issues tokens for the
+// child account through parent, for use with RefreshingToken.
+func ChildAccountTokenRefresh(parent *Client, accountID string) RefreshFunc {
+	return func(ctx context.Context) (string, time.Time, error) {
+		auth, err := parent.Accounts.GetChildAccountAuthToken(ctx, accountID)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		expiresAt, err := time.Parse(time.RFC3339, auth.ExpiresAt)
+		if err != nil {
+			expiresAt = time.Now().Add(fallbackTokenLifetime)
+		}
+		return auth.Token, expiresAt, nil
+	}
+}
@@ -0,0 +1,153 @@
+package cachefly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientPool_ForAccount(t *testing.T) {
+	var mu sync.Mutex
+	tokens := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens[r.Header.Get("Authorization")] = true
+		mu.Unlock()
+		w.Write([]byte(`{"_id":"acc"}`))
+	}))
+	defer server.Close()
+
+	calls := map[string]int{}
+	pool, err := NewClientPool(PoolConfig{
+		Credentials: func(id string) (CredentialsProvider, error) {
+			calls[id]++
+			if id == "revoked" {
+				return nil, fmt.Errorf("no token")
+			}
+			return StaticToken("token-" + id), nil
+		},
+		Options:       []Option{WithBaseURL(server.URL + "/api/2.5")},
+		RateLimit:     100,
+		Burst:         10,
+		MaxConcurrent: 2,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	a, err := pool.ForAccount("acc-a")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	again, _ := pool.ForAccount("acc-a")
+	if a != again || calls["acc-a"] != 1 {
+		t.Errorf("Expected the client to be reused, got %d credential calls", calls["acc-a"])
+	}
+	b, _ := pool.ForAccount("acc-b")
+	if a == b {
+		t.Error("Expected separate clients per account")
+	}
+
+	for _, c := range []*Client{a, b} {
+		if _, err := c.Accounts.Get(context.Background(), ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if !tokens["Bearer token-acc-a"] || !tokens["Bearer token-acc-b"] {
+		t.Errorf("Expected per-account tokens, got %v", tokens)
+	}
+
+	if _, err := pool.ForAccount("revoked"); err == nil {
+		t.Error("Expected credentials error")
+	}
+	if ids := pool.Accounts(); len(ids) != 2 || ids[0] != "acc-a" {
+		t.Errorf("Unexpected accounts %v", ids)
+	}
+	pool.Remove("acc-a")
+	if ids := pool.Accounts(); len(ids) != 1 {
+		t.Errorf("Expected acc-a to be removed, got %v", ids)
+	}
+}
+
+func TestChildAccountCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/2.5/accounts/child-1/auth":
+			if r.Header.Get("Authorization") != "Bearer parent-token" {
+				t.Errorf("Expected the parent token, got %s", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`{"token":"child-token","expiresAt":"2099-01-01T00:00:00Z"}`))
+		default:
+			if r.Header.Get("Authorization") != "Bearer child-token" {
+				t.Errorf("Expected the child token, got %s", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`{"_id":"child-1"}`))
+		}
+	}))
+	defer server.Close()
+
+	parent := NewClient(WithToken("parent-token"), WithBaseURL(server.URL+"/api/2.5"))
+	pool, _ := NewClientPool(PoolConfig{
+		Credentials: ChildAccountCredentials(parent),
+		Options:     []Option{WithBaseURL(server.URL + "/api/2.5")},
+	})
+	child, err := pool.ForAccount("child-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := child.Accounts.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestClientPool_ForAccount_DropsResponseCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"_id":"` + r.Header.Get("Authorization")[len("Bearer "):] + `"}`))
+	}))
+	defer server.Close()
+
+	pool, _ := NewClientPool(PoolConfig{
+		Credentials: func(id string) (CredentialsProvider, error) { return StaticToken(id), nil },
+		Options:     []Option{WithBaseURL(server.URL + "/api/2.5"), WithResponseCache(NewResponseCache(time.Minute))},
+	})
+	for _, id := range []string{"acc-a", "acc-b"} {
+		client, err := pool.ForAccount(id)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		account, err := client.Accounts.Get(context.Background(), "")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if account.ID != id {
+			t.Errorf("Expected account %s, got %s", id, account.ID)
+		}
+	}
+}
+
+func TestChildAccountTokenRefresh_UnparsableExpiry(t *testing.T) {
+	for _, expiresAt := range []string{"", "01/02/2099"} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"token":"child-token","expiresAt":"` + expiresAt + `"}`))
+		}))
+
+		parent := NewClient(WithToken("parent-token"), WithBaseURL(server.URL+"/api/2.5"))
+		token, expiry, err := ChildAccountTokenRefresh(parent, "acc-child")(context.Background())
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("Expected no error for %q, got %v", expiresAt, err)
+		}
+		if token != "child-token" {
+			t.Errorf("Expected child-token, got %s", token)
+		}
+		if expiry.IsZero() || expiry.After(time.Now().Add(fallbackTokenLifetime)) {
+			t.Errorf("Expected a fallback expiry within %s for %q, got %v", fallbackTokenLifetime, expiresAt, expiry)
+		}
+	}
+}
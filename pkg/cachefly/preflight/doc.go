@@ -0,0 +1,21 @@
+// Package preflight verifies that an account and token can carry out a
+// workflow before the workflow starts.
+//
+// Onboarding and migration tools call Check with the permissions, service
+// options and capacity they need, and stop early with a readable report
+// instead of failing halfway through:
+//
+//	report, err := preflight.Check(ctx, client, preflight.Requirements{
+//		Permissions: []string{"SERVICES_MANAGE"},
+//		ServiceID:   sourceID,
+//		Options:     []string{"cors", "reverseProxy"},
+//		NewServices: 3,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if !report.Ready() {
+//		fmt.Println(report)
+//		os.Exit(1)
+//	}
+package preflight
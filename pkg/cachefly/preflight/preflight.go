@@ -0,0 +1,231 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+// Check statuses. Warnings do not make a report unready.
+const (
+	StatusPass Status = "PASS"
+	StatusWarn Status = "WARN"
+	StatusFail Status = "FAIL"
+)
+
+// Requirements describes what a workflow needs.
+type Requirements struct {
+	// Permissions the token's user must hold
+	Permissions []string
+
+	// ParentAccount requires the account to be able to manage child accounts
+	ParentAccount bool
+
+	// ServiceID is the service Options and NewDomains are checked against
+	ServiceID string
+
+	// Options that must be available and writable on ServiceID
+	Options []string
+
+	// Capacity the workflow will consume
+	NewServices     int
+	NewDomains      int
+	NewCertificates int
+}
+
+// Result is the outcome of a single check.
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report collects the results of all checks.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Ready reports whether no check failed.
+func (r *Report) Ready() bool {
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures returns the failed checks.
+func (r *Report) Failures() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// String renders the report as one line per check followed by a summary.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, res := range r.Results {
+		fmt.Fprintf(&b, "[%s] %s", res.Status, res.Name)
+		if res.Detail != "" {
+			fmt.Fprintf(&b, ": %s", res.Detail)
+		}
+		b.WriteString("\n")
+	}
+	if r.Ready() {
+		b.WriteString("Ready\n")
+	} else {
+		fmt.Fprintf(&b, "Not ready: %d check(s) failed\n", len(r.Failures()))
+	}
+	return b.String()
+}
+
+func (r *Report) add(name string, status Status, format string, args ...interface{}) {
+	r.Results = append(r.Results, Result{Name: name, Status: status, Detail: fmt.Sprintf(format, args...)})
+}
+
+// Check verifies req against the account the client is authenticated as.
+//
+// API failures while checking are recorded as failed checks rather than
+// returned, so the report always covers every requirement. An error is
+// returned only when ctx ends.
+func Check(ctx context.Context, client *cachefly.Client, req Requirements) (*Report, error) {
+	report := &Report{}
+
+	user, err := client.Users.GetCurrentUser(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		report.add("authentication", StatusFail, "token rejected: %v", err)
+		return report, nil
+	}
+	report.add("authentication", StatusPass, "authenticated as %s", user.Username)
+
+	checkPermissions(report, user, req.Permissions)
+
+	if req.ParentAccount {
+		account, err := client.Accounts.Get(ctx, "")
+		switch {
+		case err != nil:
+			report.add("parent account", StatusFail, "failed to read account: %v", err)
+		case !account.IsParent:
+			report.add("parent account", StatusFail, "account %s cannot manage child accounts", account.ID)
+		default:
+			report.add("parent account", StatusPass, "")
+		}
+	}
+
+	if req.ServiceID != "" && len(req.Options) > 0 {
+		checkOptions(ctx, report, client, req.ServiceID, req.Options)
+	}
+
+	if req.NewServices > 0 || req.NewDomains > 0 || req.NewCertificates > 0 {
+		checkCapacity(ctx, report, client, req)
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return report, nil
+}
+
+func checkPermissions(report *Report, user *api.User, required []string) {
+	held := make(map[string]bool, len(user.Permissions))
+	for _, p := range user.Permissions {
+		held[p] = true
+	}
+	for _, p := range required {
+		if held[p] {
+			report.add("permission "+p, StatusPass, "")
+		} else {
+			report.add("permission "+p, StatusFail, "user %s does not hold %s", user.Username, p)
+		}
+	}
+}
+
+func checkOptions(ctx context.Context, report *Report, client *cachefly.Client, serviceID string, required []string) {
+	metadata, err := client.ServiceOptions.GetOptionsMetadata(ctx, serviceID)
+	if err != nil {
+		report.add("service options", StatusFail, "failed to read options metadata: %v", err)
+		return
+	}
+
+	for _, name := range required {
+		opt, ok := metadata.Find(name)
+		switch {
+		case !ok:
+			report.add("option "+name, StatusFail, "not available on service %s", serviceID)
+		case opt.ReadOnly:
+			report.add("option "+name, StatusFail, "read-only on service %s", serviceID)
+		default:
+			report.add("option "+name, StatusPass, "")
+		}
+	}
+}
+
+func checkCapacity(ctx context.Context, report *Report, client *cachefly.Client, req Requirements) {
+	limits, err := client.Accounts.Limits(ctx)
+	if err != nil {
+		report.add("quotas", StatusWarn, "limits unavailable, capacity not verified: %v", err)
+		return
+	}
+
+	check := func(name string, kind api.LimitKind, requested int, count func() (int, error)) {
+		if requested <= 0 {
+			return
+		}
+		if limits.Max(kind) <= 0 {
+			report.add(name, StatusPass, "no limit enforced")
+			return
+		}
+		current, err := count()
+		if err != nil {
+			report.add(name, StatusFail, "failed to count current usage: %v", err)
+			return
+		}
+		if err := limits.Check(kind, current, requested); err != nil {
+			report.add(name, StatusFail, "%v", err)
+			return
+		}
+		report.add(name, StatusPass, "%d of %d in use, %d requested", current, limits.Max(kind), requested)
+	}
+
+	check("service quota", api.LimitServices, req.NewServices, func() (int, error) {
+		page, err := client.Services.List(ctx, api.ListOptions{Limit: 1})
+		if err != nil {
+			return 0, err
+		}
+		return page.Meta.Count, nil
+	})
+
+	if req.NewDomains > 0 && req.ServiceID == "" {
+		report.add("domain quota", StatusWarn, "no service ID given, capacity not verified")
+	} else {
+		check("domain quota", api.LimitDomainsPerService, req.NewDomains, func() (int, error) {
+			page, err := client.ServiceDomains.List(ctx, req.ServiceID, api.ListServiceDomainsOptions{Limit: 1})
+			if err != nil {
+				return 0, err
+			}
+			return page.Meta.Count, nil
+		})
+	}
+
+	check("certificate quota", api.LimitCertificates, req.NewCertificates, func() (int, error) {
+		page, err := client.Certificates.List(ctx, api.ListCertificatesOptions{Limit: 1})
+		if err != nil {
+			return 0, err
+		}
+		return page.Meta.Count, nil
+	})
+}
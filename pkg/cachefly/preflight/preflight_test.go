@@ -0,0 +1,78 @@
+package preflight
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func TestCheck_Ready(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/users/me":                        `{"username":"deploy","permissions":["SERVICES_MANAGE"]}`,
+		"/api/2.5/services/svc-1/options/metadata": `{"data":[{"name":"CORS Override","type":"standard"},{"name":"ttl","type":"dynamic","property":{"name":"ttl","type":"integer"}}]}`,
+		"/api/2.5/accounts/me/limits":              `{"maxServices":10}`,
+		"/api/2.5/services":                        `{"meta":{"count":4},"data":[]}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	report, err := Check(context.Background(), client, Requirements{
+		Permissions: []string{"SERVICES_MANAGE"},
+		ServiceID:   "svc-1",
+		Options:     []string{"ttl", "cors"},
+		NewServices: 2,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !report.Ready() {
+		t.Errorf("Expected ready report, got:\n%s", report)
+	}
+	if !strings.Contains(report.String(), "4 of 10 in use") {
+		t.Errorf("Expected quota detail in report, got:\n%s", report)
+	}
+}
+
+func TestCheck_NotReady(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/users/me":                        `{"username":"deploy","permissions":[]}`,
+		"/api/2.5/accounts/me":                     `{"_id":"acc-1","isParent":false}`,
+		"/api/2.5/services/svc-1/options/metadata": `{"data":[{"name":"ttl","type":"dynamic","readOnly":true,"property":{"name":"ttl","type":"integer"}}]}`,
+		"/api/2.5/accounts/me/limits":              `{"maxServices":5}`,
+		"/api/2.5/services":                        `{"meta":{"count":5},"data":[]}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	report, err := Check(context.Background(), client, Requirements{
+		Permissions:   []string{"SERVICES_MANAGE"},
+		ParentAccount: true,
+		ServiceID:     "svc-1",
+		Options:       []string{"ttl", "cors"},
+		NewServices:   1,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Ready() {
+		t.Fatal("Expected report not to be ready")
+	}
+	// permission, parent account, read-only ttl, missing cors, service quota
+	if got := len(report.Failures()); got != 5 {
+		t.Errorf("Expected 5 failures, got %d:\n%s", got, report)
+	}
+}
+
+func TestCheck_AuthenticationFailure(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	report, err := Check(context.Background(), client, Requirements{Permissions: []string{"SERVICES_MANAGE"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Ready() || len(report.Results) != 1 {
+		t.Errorf("Expected a single failed authentication check, got:\n%s", report)
+	}
+}
@@ -0,0 +1,204 @@
+package cachefly
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileEnvVar names the config file read by LoadProfile when no path is given.
+	ConfigFileEnvVar = "CACHEFLY_CONFIG_FILE"
+
+	// ProfileEnvVar names the profile used by LoadProfile when no name is given.
+	ProfileEnvVar = "CACHEFLY_PROFILE"
+
+	// DefaultProfile is the profile used when neither a name nor ProfileEnvVar is given.
+	DefaultProfile = "default"
+)
+
+// ErrProfileNotFound is returned when the config file has no profile of the requested name.
+var ErrProfileNotFound = errors.New("cachefly: profile not found")
+
+// Profile is a named set of client settings in the shared config file.
+//
+// At most one of Token, TokenFile and TokenEnv may be set. Durations are
+// written as Go durations, such as "30s" or "5m".
+type Profile struct {
+	// Name is the profile's key in the config file
+	Name string `yaml:"-"`
+
+	// BaseURL overrides the default API base URL
+	BaseURL string `yaml:"base_url"`
+
+	// APIVersion selects the API version, see WithAPIVersion
+	APIVersion string `yaml:"api_version"`
+
+	// Token is the API token itself
+	Token string `yaml:"token"`
+
+	// TokenFile is a file holding the token, see FileToken
+	TokenFile string `yaml:"token_file"`
+
+	// TokenEnv is an environment variable holding the token, see EnvToken
+	TokenEnv string `yaml:"token_env"`
+
+	// Account identifies the account the profile acts for, used to share a
+	// Scheduler fairly between the clients of several profiles
+	Account string `yaml:"account"`
+
+	// Timeout bounds each request, see WithTimeout
+	Timeout time.Duration `yaml:"timeout"`
+
+	// MaintenanceWait is how long requests wait for API maintenance to end,
+	// see WithMaintenanceWait
+	MaintenanceWait time.Duration `yaml:"maintenance_wait"`
+
+	// Environment declares the environment the profile is meant for, see
+	// WithEnvironmentName
+	Environment string `yaml:"environment"`
+}
+
+// DefaultConfigFile returns the path of the shared config file:
+// ConfigFileEnvVar when set, otherwise ~/.cachefly/config.
+func DefaultConfigFile() (string, error) {
+	if path := os.Getenv(ConfigFileEnvVar); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config file: %w", err)
+	}
+	return filepath.Join(home, ".cachefly", "config"), nil
+}
+
+// LoadProfile reads a profile from the YAML config file at path. An empty
+// path uses DefaultConfigFile, an empty name uses ProfileEnvVar or
+// DefaultProfile. The file maps profile names to their settings:
+//
+//	default:
+//	  token_env: CACHEFLY_API_TOKEN
+//	prod:
+//	  token_file: /run/secrets/cachefly-token
+//	  environment: prod
+//	  account: acc_123
+//	  timeout: 20s
+//	staging:
+//	  base_url: https://staging-api.cachefly.com/api/2.5
+//	  environment: staging
+//	  token: staging-token
+func LoadProfile(path, name string) (*Profile, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultConfigFile(); err != nil {
+			return nil, err
+		}
+	}
+	if name == "" {
+		name = os.Getenv(ProfileEnvVar)
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var profiles map[string]*Profile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok || profile == nil {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: %q in %s (available: %s)", ErrProfileNotFound, name, path, strings.Join(names, ", "))
+	}
+	profile.Name = name
+
+	if err := profile.validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (p *Profile) validate() error {
+	var sources []string
+	for _, s := range []struct{ key, value string }{
+		{"token", p.Token},
+		{"token_file", p.TokenFile},
+		{"token_env", p.TokenEnv},
+	} {
+		if s.value != "" {
+			sources = append(sources, s.key)
+		}
+	}
+	if len(sources) > 1 {
+		return fmt.Errorf("profile %q sets %s; only one token source is allowed", p.Name, strings.Join(sources, " and "))
+	}
+	if p.Timeout < 0 || p.MaintenanceWait < 0 {
+		return fmt.Errorf("profile %q: timeout and maintenance_wait must not be negative", p.Name)
+	}
+	return nil
+}
+
+// Options returns the client options for the profile's settings.
+func (p *Profile) Options() []Option {
+	var opts []Option
+	switch {
+	case p.Token != "":
+		opts = append(opts, WithToken(p.Token))
+	case p.TokenFile != "":
+		opts = append(opts, WithCredentials(FileToken(p.TokenFile)))
+	case p.TokenEnv != "":
+		opts = append(opts, WithCredentials(EnvToken(p.TokenEnv)))
+	}
+	if p.BaseURL != "" {
+		opts = append(opts, WithBaseURL(p.BaseURL))
+	}
+	if p.APIVersion != "" {
+		opts = append(opts, WithAPIVersion(p.APIVersion))
+	}
+	if p.Account != "" {
+		opts = append(opts, func(c *ClientConfig) { c.Account = p.Account })
+	}
+	if p.Timeout > 0 {
+		opts = append(opts, WithTimeout(p.Timeout))
+	}
+	if p.MaintenanceWait > 0 {
+		opts = append(opts, WithMaintenanceWait(p.MaintenanceWait))
+	}
+	if p.Environment != "" {
+		opts = append(opts, WithEnvironmentName(p.Environment))
+	}
+	return opts
+}
+
+// NewClientFromProfile creates a client from a profile of the shared config
+// file, see LoadProfile. Options passed in opts are applied after the
+// profile's settings and override them.
+//
+// Example:
+//
+//	client, err := cachefly.NewClientFromProfile("prod",
+//		cachefly.WithRetry(cachefly.RetryPolicy{MaxAttempts: 3}),
+//	)
+func NewClientFromProfile(name string, opts ...Option) (*Client, error) {
+	profile, err := LoadProfile("", name)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(append(profile.Options(), opts...)...), nil
+}
@@ -0,0 +1,123 @@
+package cachefly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	path := writeConfigFile(t, `
+default:
+  token: default-token
+prod:
+  base_url: https://example.com/api/2.5
+  token_env: PROD_TOKEN
+  account: acc-1
+  timeout: 20s
+  maintenance_wait: 5m
+`)
+
+	profile, err := LoadProfile(path, "prod")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if profile.Name != "prod" || profile.BaseURL != "https://example.com/api/2.5" || profile.TokenEnv != "PROD_TOKEN" {
+		t.Errorf("Unexpected profile %+v", profile)
+	}
+	if profile.Timeout != 20*time.Second || profile.MaintenanceWait != 5*time.Minute {
+		t.Errorf("Expected durations 20s and 5m, got %s and %s", profile.Timeout, profile.MaintenanceWait)
+	}
+
+	t.Setenv(ProfileEnvVar, "")
+	profile, err = LoadProfile(path, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if profile.Name != DefaultProfile || profile.Token != "default-token" {
+		t.Errorf("Expected default profile, got %+v", profile)
+	}
+}
+
+func TestLoadProfile_Errors(t *testing.T) {
+	path := writeConfigFile(t, "default:\n  token: a\n  token_file: /tmp/token\nprod:\n  token: b\n")
+
+	_, err := LoadProfile(path, "missing")
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "default, prod") {
+		t.Errorf("Expected available profiles in error, got %v", err)
+	}
+
+	if _, err := LoadProfile(path, "default"); err == nil || !strings.Contains(err.Error(), "token and token_file") {
+		t.Errorf("Expected conflicting token sources error, got %v", err)
+	}
+
+	if _, err := LoadProfile(filepath.Join(t.TempDir(), "none"), "default"); err == nil {
+		t.Error("Expected error for missing config file")
+	}
+}
+
+func TestNewClientFromProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer env-token" {
+			t.Errorf("Expected token from environment, got %s", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/api/2.5/services/srv-1" {
+			t.Errorf("Expected path /api/2.5/services/srv-1, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"srv-1"}`))
+	}))
+	defer server.Close()
+
+	path := writeConfigFile(t, "staging:\n  base_url: "+server.URL+"/api/2.5\n  token_env: STAGING_TOKEN\n")
+	t.Setenv(ConfigFileEnvVar, path)
+	t.Setenv(ProfileEnvVar, "staging")
+	t.Setenv("STAGING_TOKEN", "env-token")
+
+	client, err := NewClientFromProfile("")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Services.GetByID(context.Background(), "srv-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestNewClientFromProfile_OptionsOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer override" {
+			t.Errorf("Expected overriding token, got %s", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	path := writeConfigFile(t, "default:\n  base_url: "+server.URL+"\n  token: profile-token\n")
+	t.Setenv(ConfigFileEnvVar, path)
+
+	client, err := NewClientFromProfile("default", WithToken("override"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Accounts.Get(context.Background(), ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
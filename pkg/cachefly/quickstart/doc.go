@@ -0,0 +1,18 @@
+// Package quickstart holds the flows of the examples directory as functions
+// that can be called from any program.
+//
+// Each function performs one request, or a short sequence of requests, and
+// prints the result as indented JSON under a heading to Output:
+//
+//	client := cachefly.NewClient(cachefly.WithToken(token))
+//	if err := quickstart.PrintAccount(ctx, client); err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := quickstart.DumpServiceOptions(ctx, client, serviceID); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Output defaults to os.Stdout; set it to capture the output, e.g. in tests.
+// The functions are meant for getting started and for scripts; programs
+// that process the results should call the client directly.
+package quickstart
@@ -0,0 +1,116 @@
+package quickstart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Output is where the functions of this package print to.
+var Output io.Writer = os.Stdout
+
+// PrintAccount prints the authenticated account.
+func PrintAccount(ctx context.Context, client *cachefly.Client) error {
+	account, err := client.Accounts.Get(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	return printJSON("Current account", account)
+}
+
+// PrintCurrentUser prints the authenticated user.
+func PrintCurrentUser(ctx context.Context, client *cachefly.Client) error {
+	user, err := client.Users.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+	return printJSON("Current user", user)
+}
+
+// ListServices prints a page of services matching opts.
+func ListServices(ctx context.Context, client *cachefly.Client, opts api.ListOptions) error {
+	resp, err := client.Services.List(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+	return printJSON("Services", resp)
+}
+
+// PrintService prints a service.
+func PrintService(ctx context.Context, client *cachefly.Client, id string) error {
+	service, err := client.Services.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get service %s: %w", id, err)
+	}
+	return printJSON("Service "+id, service)
+}
+
+// DumpServiceOptions prints the options of a service.
+func DumpServiceOptions(ctx context.Context, client *cachefly.Client, id string) error {
+	options, err := client.ServiceOptions.GetOptions(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get options of service %s: %w", id, err)
+	}
+	return printJSON("Options of service "+id, options)
+}
+
+// DumpServiceConfig prints the exported configuration of a service: its
+// settings, options, domains, origins and rules.
+func DumpServiceConfig(ctx context.Context, client *cachefly.Client, id string) error {
+	config, err := client.Services.ExportConfig(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to export service %s: %w", id, err)
+	}
+	return printJSON("Configuration of service "+id, config)
+}
+
+// ListServiceDomains prints the first page of domains of a service.
+func ListServiceDomains(ctx context.Context, client *cachefly.Client, id string) error {
+	resp, err := client.ServiceDomains.List(ctx, id, api.ListServiceDomainsOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list domains of service %s: %w", id, err)
+	}
+	return printJSON("Domains of service "+id, resp)
+}
+
+// ListServiceRules prints the first page of rules of a service.
+func ListServiceRules(ctx context.Context, client *cachefly.Client, id string) error {
+	resp, err := client.ServiceRules.List(ctx, id, api.ListServiceRulesOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list rules of service %s: %w", id, err)
+	}
+	return printJSON("Rules of service "+id, resp)
+}
+
+// ListOrigins prints the first page of origins.
+func ListOrigins(ctx context.Context, client *cachefly.Client) error {
+	resp, err := client.Origins.List(ctx, api.ListOriginsOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list origins: %w", err)
+	}
+	return printJSON("Origins", resp)
+}
+
+// ListCertificates prints the first page of certificates.
+func ListCertificates(ctx context.Context, client *cachefly.Client) error {
+	resp, err := client.Certificates.List(ctx, api.ListCertificatesOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list certificates: %w", err)
+	}
+	return printJSON("Certificates", resp)
+}
+
+// printJSON prints title followed by v as indented JSON.
+func printJSON(title string, v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", title, err)
+	}
+	_, err = fmt.Fprintf(Output, "%s:\n%s\n", title, out)
+	return err
+}
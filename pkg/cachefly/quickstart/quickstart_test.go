@@ -0,0 +1,77 @@
+package quickstart
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestPrintFunctions(t *testing.T) {
+	routes := map[string]string{
+		"/api/2.5/accounts/me":            `{"_id":"acc-1","companyName":"Example"}`,
+		"/api/2.5/users/me":               `{"_id":"user-1","username":"ops"}`,
+		"/api/2.5/services":               `{"meta":{"limit":10,"offset":0,"count":1},"data":[{"_id":"svc-1","name":"Shop"}]}`,
+		"/api/2.5/services/svc-1":         `{"_id":"svc-1","name":"Shop"}`,
+		"/api/2.5/services/svc-1/options": `{"autoRedirect":true}`,
+		"/api/2.5/services/svc-1/domains": `{"data":[{"_id":"dom-1","name":"cdn.example.com"}]}`,
+		"/api/2.5/services/svc-1/rules":   `{"data":[]}`,
+		"/api/2.5/origins":                `{"data":[]}`,
+		"/api/2.5/certificates":           `{"data":[]}`,
+	}
+	server := fakeapi.Serve(t, routes)
+
+	var buf bytes.Buffer
+	defer func(w io.Writer) { Output = w }(Output)
+	Output = &buf
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"PrintAccount", func() error { return PrintAccount(ctx, client) }, "Current account:\n{\n  \"_id\": \"acc-1\""},
+		{"PrintCurrentUser", func() error { return PrintCurrentUser(ctx, client) }, "\"username\": \"ops\""},
+		{"ListServices", func() error { return ListServices(ctx, client, api.ListOptions{Limit: 10}) }, "\"name\": \"Shop\""},
+		{"PrintService", func() error { return PrintService(ctx, client, "svc-1") }, "Service svc-1:"},
+		{"DumpServiceOptions", func() error { return DumpServiceOptions(ctx, client, "svc-1") }, "\"autoRedirect\": true"},
+		{"ListServiceDomains", func() error { return ListServiceDomains(ctx, client, "svc-1") }, "cdn.example.com"},
+		{"ListServiceRules", func() error { return ListServiceRules(ctx, client, "svc-1") }, "Rules of service svc-1:"},
+		{"ListOrigins", func() error { return ListOrigins(ctx, client) }, "Origins:"},
+		{"ListCertificates", func() error { return ListCertificates(ctx, client) }, "Certificates:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			if err := tt.run(); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("Expected output to contain %q, got:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestPrintService_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL))
+	err := PrintService(context.Background(), client, "svc-1")
+	if err == nil || !strings.Contains(err.Error(), "failed to get service svc-1") {
+		t.Errorf("Expected a wrapped error, got %v", err)
+	}
+}
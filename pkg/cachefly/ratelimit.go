@@ -0,0 +1,15 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// RateLimiter is a token bucket limiting the rate at which a client starts
+// requests. Every attempt takes a token, so retries count against the limit
+// too. WithRateLimit sets one up for a client.
+type RateLimiter = httpclient.RateLimiter
+
+// Limiter decides when a request attempt may start, see WithLimiter.
+// *RateLimiter implements it.
+type Limiter = httpclient.Limiter
+
+// LimiterFunc adapts a function to the Limiter interface.
+type LimiterFunc = httpclient.LimiterFunc
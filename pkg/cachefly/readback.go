@@ -0,0 +1,8 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// ReadBackError is returned by clients created with WithReadAfterWrite when
+// a write succeeded but reading the resource back failed. The write has been
+// applied, so retrying a create may duplicate the resource.
+type ReadBackError = httpclient.ReadBackError
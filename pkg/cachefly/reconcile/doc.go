@@ -0,0 +1,21 @@
+// Package reconcile provides idempotent helpers that drive CacheFly resources
+// toward a desired state, shaped for use inside Kubernetes controllers,
+// Crossplane providers and similar reconciliation loops.
+//
+// Each helper reads the current state, applies only what differs, and
+// returns a Result describing the action taken together with a requeue hint:
+//
+//	result, err := reconcile.Service(ctx, client, reconcile.ServiceSpec{
+//		Name:       "My Site",
+//		UniqueName: "my-site",
+//		Options:    api.ServiceOptions{"cors": true},
+//		Domains:    []string{"cdn.example.com"},
+//	})
+//	if err != nil {
+//		return ctrl.Result{RequeueAfter: result.RequeueAfter}, err
+//	}
+//	return ctrl.Result{Requeue: result.Requeue, RequeueAfter: result.RequeueAfter}, nil
+//
+// Calling a helper again with the same spec is a no-op once the resource has
+// converged.
+package reconcile
@@ -0,0 +1,179 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/resourceops"
+)
+
+// DefaultRequeueAfter is the delay suggested when a resource has not
+// converged yet or a reconcile attempt failed.
+const DefaultRequeueAfter = time.Minute
+
+// Action describes what a reconcile call did.
+type Action string
+
+// Actions reported in Result.
+const (
+	ActionNone    Action = "none"
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+)
+
+// Result reports the outcome of a reconcile call.
+type Result struct {
+	// ServiceID is the ID of the reconciled service, when known
+	ServiceID string
+
+	// Action is the most significant change made
+	Action Action
+
+	// Changes lists human-readable descriptions of every change made
+	Changes []string
+
+	// Requeue asks the caller to reconcile again after RequeueAfter,
+	// because the resource has not fully converged or the attempt failed
+	Requeue      bool
+	RequeueAfter time.Duration
+}
+
+// Changed reports whether the call made any change, for example to decide
+// whether to record an event or update the status of a custom resource.
+func (r Result) Changed() bool {
+	return r.Action != ActionNone
+}
+
+// ServiceSpec is the desired state of a service.
+//
+// Settings left at their zero value keep the service's current value. Name
+// is used when the service is created; services cannot be renamed, so a
+// different Name fails with resourceops.ErrRequiresReplace.
+type ServiceSpec struct {
+	Name        string
+	UniqueName  string
+	Description string
+
+	// AutoSSL turns automatic certificates on or off; nil keeps the current
+	// setting
+	AutoSSL *bool
+
+	ConfigurationMode string
+	TLSProfile        string
+	DeliveryRegion    string
+
+	// Options are applied with ServiceOptions.Apply; unlisted options are untouched
+	Options api.ServiceOptions
+
+	// Domains that must be attached to the service
+	Domains []string
+}
+
+// Service reconciles a service, identified by its UniqueName, with spec.
+//
+// The service is created when missing, reactivated when it was deactivated,
+// and updated when its settings, options or domains drift from spec. The
+// service and its domains are converged with resourceops.EnsureService and
+// resourceops.EnsureDomain. Domains still awaiting validation produce a
+// requeue hint.
+func Service(ctx context.Context, client *cachefly.Client, spec ServiceSpec) (Result, error) {
+	result := Result{Action: ActionNone}
+
+	if spec.UniqueName == "" {
+		return result, fmt.Errorf("uniqueName is required")
+	}
+
+	ensured, err := resourceops.EnsureService(ctx, client, resourceops.Service{
+		Name:              spec.Name,
+		UniqueName:        spec.UniqueName,
+		Description:       spec.Description,
+		AutoSSL:           spec.AutoSSL != nil && *spec.AutoSSL,
+		ConfigurationMode: spec.ConfigurationMode,
+		TLSProfile:        spec.TLSProfile,
+		DeliveryRegion:    spec.DeliveryRegion,
+	})
+	result.ServiceID = ensured.Resource.ID
+	switch ensured.Action {
+	case resourceops.EnsureCreated:
+		result.Action = ActionCreated
+		result.Changes = append(result.Changes, "created service "+spec.UniqueName)
+	case resourceops.EnsureUpdated:
+		result.Action = ActionUpdated
+	}
+	for _, c := range ensured.Changes {
+		if c.Field == "status" {
+			result.Changes = append(result.Changes, "activated service "+spec.UniqueName)
+		} else {
+			result.Changes = append(result.Changes, "updated service."+c.Field)
+		}
+	}
+	if err != nil {
+		return requeue(result), fmt.Errorf("failed to reconcile service: %w", err)
+	}
+
+	// EnsureService keeps the current value of false settings, so turning
+	// AutoSSL off is a separate update
+	if spec.AutoSSL != nil && !*spec.AutoSSL && ensured.Resource.AutoSSL {
+		desired := ensured.Resource
+		desired.AutoSSL = false
+		if _, err := resourceops.NewServices(client).Update(ctx, result.ServiceID, desired); err != nil {
+			return requeue(result), fmt.Errorf("failed to turn off AutoSSL: %w", err)
+		}
+		result.Changes = append(result.Changes, "updated service.autoSsl")
+		if result.Action == ActionNone {
+			result.Action = ActionUpdated
+		}
+	}
+
+	if len(spec.Options) > 0 {
+		applied, err := client.ServiceOptions.Apply(ctx, result.ServiceID, spec.Options, api.ApplyOptions{})
+		if err != nil {
+			return requeue(result), fmt.Errorf("failed to apply options: %w", err)
+		}
+		for _, c := range applied.Changes {
+			result.Changes = append(result.Changes, "updated option "+c.Name)
+		}
+		if applied.Applied && result.Action == ActionNone {
+			result.Action = ActionUpdated
+		}
+	}
+
+	pending := false
+	for _, name := range spec.Domains {
+		domain, err := resourceops.EnsureDomain(ctx, client, resourceops.Domain{ServiceID: result.ServiceID, Name: name})
+		if domain.Action == resourceops.EnsureCreated {
+			result.Changes = append(result.Changes, "created domain "+name)
+			if result.Action == ActionNone {
+				result.Action = ActionUpdated
+			}
+		}
+		if err != nil {
+			return requeue(result), fmt.Errorf("failed to reconcile domain %s: %w", name, err)
+		}
+		if awaitingValidation(domain.Resource.ValidationStatus) {
+			pending = true
+		}
+	}
+	if pending {
+		result.Requeue = true
+		result.RequeueAfter = DefaultRequeueAfter
+	}
+
+	return result, nil
+}
+
+// awaitingValidation reports whether a domain's validation status is
+// pending. Domains whose status the API leaves out are not requeued for.
+func awaitingValidation(status string) bool {
+	return strings.Contains(strings.ToUpper(status), "PENDING")
+}
+
+func requeue(result Result) Result {
+	result.Requeue = true
+	result.RequeueAfter = DefaultRequeueAfter
+	return result
+}
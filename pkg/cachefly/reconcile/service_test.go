@@ -0,0 +1,208 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestService_Converged(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected no writes for a converged service, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/2.5/services":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"svc-1","uniqueName":"my-site"}]}`))
+		case "/api/2.5/services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"My Site","uniqueName":"my-site","description":"Site"}`))
+		case "/api/2.5/services/svc-1/options":
+			w.Write([]byte(`{"cors":true}`))
+		case "/api/2.5/services/svc-1/domains":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"dom-1","name":"cdn.example.com","validationStatus":"VALIDATED"}]}`))
+		default:
+			t.Errorf("Unexpected request path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	result, err := Service(context.Background(), client, ServiceSpec{
+		Name:        "My Site",
+		UniqueName:  "my-site",
+		Description: "Site",
+		Options:     api.ServiceOptions{"cors": true},
+		Domains:     []string{"cdn.example.com"},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != ActionNone || len(result.Changes) != 0 {
+		t.Errorf("Expected no action, got %s %v", result.Action, result.Changes)
+	}
+	if result.Requeue {
+		t.Error("Expected no requeue for a converged service")
+	}
+}
+
+func TestService_CreatesMissing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/services":
+			w.Write([]byte(`{"_id":"svc-new","uniqueName":"my-site"}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/svc-new":
+			w.Write([]byte(`{"_id":"svc-new","uniqueName":"my-site"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/svc-new/domains":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/services/svc-new/domains":
+			w.Write([]byte(`{"_id":"dom-1","name":"cdn.example.com","validationStatus":"PENDING"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	result, err := Service(context.Background(), client, ServiceSpec{
+		Name:       "My Site",
+		UniqueName: "my-site",
+		Domains:    []string{"cdn.example.com"},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != ActionCreated || result.ServiceID != "svc-new" {
+		t.Errorf("Expected service to be created, got %s %s", result.Action, result.ServiceID)
+	}
+	if !result.Requeue || result.RequeueAfter != DefaultRequeueAfter {
+		t.Error("Expected requeue while the domain awaits validation")
+	}
+}
+
+func TestService_KeepsIDWhenSettingsFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/services":
+			w.Write([]byte(`{"_id":"svc-new","uniqueName":"my-site","status":"inactive"}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/svc-new":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"invalid tlsProfile"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	result, err := Service(context.Background(), client, ServiceSpec{UniqueName: "my-site", TLSProfile: "bogus"})
+
+	if err == nil {
+		t.Fatal("Expected the failed update to be reported")
+	}
+	if result.Action != ActionCreated || result.ServiceID != "svc-new" || !result.Requeue {
+		t.Errorf("Expected the created service to be reported and requeued, got %s %q %v", result.Action, result.ServiceID, result.Requeue)
+	}
+}
+
+func TestAwaitingValidation(t *testing.T) {
+	for status, want := range map[string]bool{"PENDING": true, "pending_validation": true, "VALIDATED": false, "": false} {
+		if got := awaitingValidation(status); got != want {
+			t.Errorf("awaitingValidation(%q) = %v, expected %v", status, got, want)
+		}
+	}
+}
+
+func TestService_ReactivatesInactive(t *testing.T) {
+	active := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"svc-1","uniqueName":"my-site","status":"inactive"}]}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/svc-1/activate":
+			active = true
+			w.Write([]byte(`{"_id":"svc-1","uniqueName":"my-site","status":"active"}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/svc-1":
+			status := "inactive"
+			if active {
+				status = "active"
+			}
+			w.Write([]byte(`{"_id":"svc-1","name":"My Site","uniqueName":"my-site","status":"` + status + `"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	result, err := Service(context.Background(), client, ServiceSpec{Name: "My Site", UniqueName: "my-site"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !active || result.Action != ActionUpdated || !result.Changed() {
+		t.Errorf("Expected service to be reactivated, got %s %v", result.Action, result.Changes)
+	}
+}
+
+func TestService_DisablesAutoSSL(t *testing.T) {
+	var updates []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"svc-1","uniqueName":"my-site"}]}`))
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"My Site","uniqueName":"my-site","description":"Site","autoSsl":true,"status":"active"}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/services/svc-1":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			updates = append(updates, body)
+			w.Write([]byte(`{"_id":"svc-1","name":"My Site","uniqueName":"my-site","description":"Site","autoSsl":false,"status":"active"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	off := false
+	result, err := Service(context.Background(), client, ServiceSpec{UniqueName: "my-site", AutoSSL: &off})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != ActionUpdated || len(result.Changes) != 1 || result.Changes[0] != "updated service.autoSsl" {
+		t.Errorf("Expected AutoSSL to be turned off, got %s %v", result.Action, result.Changes)
+	}
+	if len(updates) != 1 || updates[0]["autoSsl"] != false || updates[0]["description"] != "Site" {
+		t.Errorf("Expected one update turning off AutoSSL and keeping the rest, got %v", updates)
+	}
+
+	// nil keeps the current setting
+	updates = nil
+	if _, err := Service(context.Background(), client, ServiceSpec{UniqueName: "my-site"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(updates) != 0 {
+		t.Errorf("Expected no update without AutoSSL, got %v", updates)
+	}
+}
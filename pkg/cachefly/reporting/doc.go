@@ -0,0 +1,21 @@
+// Package reporting assembles an account-wide report for scheduled delivery.
+//
+// Generate collects the account's usage and top services, certificates
+// close to expiry, configuration drift against baselines and the results of
+// a security audit into one Report:
+//
+//	report, err := reporting.Generate(ctx, client, reporting.Options{
+//		From:      time.Now().AddDate(0, 0, -7),
+//		Baselines: baselines,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	postToSlack(report.Markdown())
+//
+// A section that cannot be collected does not fail the report; the failure
+// is recorded in Report.Errors and the other sections are still filled in.
+//
+// Reports encode to JSON with encoding/json and render to Markdown and HTML
+// with Report.Markdown and Report.HTML.
+package reporting
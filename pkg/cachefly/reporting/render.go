@@ -0,0 +1,184 @@
+package reporting
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Markdown renders the report as a Markdown document.
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# CacheFly report %s\n", r.GeneratedAt.Format("2006-01-02"))
+
+	if u := r.Usage; u != nil {
+		fmt.Fprintf(&sb, "\n## Usage\n\nPeriod %s to %s, plan %s\n\n", u.PeriodStart, u.PeriodEnd, u.Plan.Name)
+		sb.WriteString("| Metric | Used | Plan | Overage |\n|---|---|---|---|\n")
+		fmt.Fprintf(&sb, "| Bandwidth | %s | %s | %s |\n", formatBytes(u.BandwidthBytes), formatBytes(u.Plan.BandwidthBytes), formatBytes(u.Overage.BandwidthBytes))
+		fmt.Fprintf(&sb, "| Requests | %d | %d | %d |\n", u.Requests, u.Plan.Requests, u.Overage.Requests)
+		fmt.Fprintf(&sb, "| Storage | %s | %s | %s |\n", formatBytes(u.StorageBytes), formatBytes(u.Plan.StorageBytes), formatBytes(u.Overage.StorageBytes))
+		if u.Overage.Amount > 0 {
+			fmt.Fprintf(&sb, "\nOverage charges: %s\n", formatAmount(u.Overage.Amount, u.Overage.Currency))
+		}
+	}
+
+	if len(r.TopServices) > 0 {
+		sb.WriteString("\n## Top services\n\n| Service | Bandwidth | Requests |\n|---|---|---|\n")
+		for _, s := range r.TopServices {
+			fmt.Fprintf(&sb, "| %s | %s | %d |\n", serviceLabel(s.ServiceName, s.ServiceID), formatBytes(s.BandwidthBytes), s.Requests)
+		}
+	}
+
+	if len(r.Certificates) > 0 {
+		sb.WriteString("\n## Expiring certificates\n\n| Certificate | Expires | Days left | In use |\n|---|---|---|---|\n")
+		for _, c := range r.Certificates {
+			fmt.Fprintf(&sb, "| %s | %s | %d | %t |\n", c.CommonName, c.NotAfter.Format("2006-01-02"), c.DaysLeft, c.InUse)
+		}
+	}
+
+	if len(r.Drift) > 0 {
+		sb.WriteString("\n## Configuration drift\n")
+		for _, d := range r.Drift {
+			fmt.Fprintf(&sb, "\n### %s\n\n```\n%s\n```\n", d.ServiceID, d.Diff)
+		}
+	}
+
+	if s := r.Security; s != nil {
+		fmt.Fprintf(&sb, "\n## Security\n\n- Two-factor authentication enforced: %t\n- SAML enabled: %t\n", s.TwoFactorEnforced, s.SAMLEnabled)
+		if len(s.AllowedIPRanges) > 0 {
+			fmt.Fprintf(&sb, "- Allowed IP ranges: %s\n", strings.Join(s.AllowedIPRanges, ", "))
+		}
+		if len(s.Findings) > 0 {
+			sb.WriteString("\n| Severity | Scope | Rule | Finding |\n|---|---|---|---|\n")
+			for _, f := range s.Findings {
+				fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", f.Severity, scope(f), f.Rule, f.Message)
+			}
+		}
+	}
+
+	if len(r.Errors) > 0 {
+		sb.WriteString("\n## Incomplete sections\n\n")
+		for _, e := range r.Errors {
+			fmt.Fprintf(&sb, "- %s: %s\n", e.Section, e.Error)
+		}
+	}
+	if len(r.Unavailable) > 0 {
+		sb.WriteString("\n## Not available for this account\n\n")
+		for _, section := range r.Unavailable {
+			fmt.Fprintf(&sb, "- %s\n", section)
+		}
+	}
+	return sb.String()
+}
+
+// HTML renders the report as a self-contained HTML document.
+func (r *Report) HTML() (string, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"bytes":   formatBytes,
+	"amount":  formatAmount,
+	"service": serviceLabel,
+	"scope":   scope,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>CacheFly report {{.GeneratedAt.Format "2006-01-02"}}</title></head>
+<body>
+<h1>CacheFly report {{.GeneratedAt.Format "2006-01-02"}}</h1>
+{{with .Usage}}
+<h2>Usage</h2>
+<p>Period {{.PeriodStart}} to {{.PeriodEnd}}, plan {{.Plan.Name}}</p>
+<table>
+<tr><th>Metric</th><th>Used</th><th>Plan</th><th>Overage</th></tr>
+<tr><td>Bandwidth</td><td>{{bytes .BandwidthBytes}}</td><td>{{bytes .Plan.BandwidthBytes}}</td><td>{{bytes .Overage.BandwidthBytes}}</td></tr>
+<tr><td>Requests</td><td>{{.Requests}}</td><td>{{.Plan.Requests}}</td><td>{{.Overage.Requests}}</td></tr>
+<tr><td>Storage</td><td>{{bytes .StorageBytes}}</td><td>{{bytes .Plan.StorageBytes}}</td><td>{{bytes .Overage.StorageBytes}}</td></tr>
+</table>
+{{if gt .Overage.Amount 0}}<p>Overage charges: {{amount .Overage.Amount .Overage.Currency}}</p>{{end}}
+{{end}}
+{{with .TopServices}}
+<h2>Top services</h2>
+<table>
+<tr><th>Service</th><th>Bandwidth</th><th>Requests</th></tr>
+{{range .}}<tr><td>{{service .ServiceName .ServiceID}}</td><td>{{bytes .BandwidthBytes}}</td><td>{{.Requests}}</td></tr>
+{{end}}</table>
+{{end}}
+{{with .Certificates}}
+<h2>Expiring certificates</h2>
+<table>
+<tr><th>Certificate</th><th>Expires</th><th>Days left</th><th>In use</th></tr>
+{{range .}}<tr><td>{{.CommonName}}</td><td>{{.NotAfter.Format "2006-01-02"}}</td><td>{{.DaysLeft}}</td><td>{{.InUse}}</td></tr>
+{{end}}</table>
+{{end}}
+{{with .Drift}}
+<h2>Configuration drift</h2>
+{{range .}}<h3>{{.ServiceID}}</h3>
+<pre>{{.Diff.String}}</pre>
+{{end}}
+{{end}}
+{{with .Security}}
+<h2>Security</h2>
+<ul>
+<li>Two-factor authentication enforced: {{.TwoFactorEnforced}}</li>
+<li>SAML enabled: {{.SAMLEnabled}}</li>
+{{with .AllowedIPRanges}}<li>Allowed IP ranges: {{range $i, $r := .}}{{if $i}}, {{end}}{{$r}}{{end}}</li>{{end}}
+</ul>
+{{with .Findings}}
+<table>
+<tr><th>Severity</th><th>Scope</th><th>Rule</th><th>Finding</th></tr>
+{{range .}}<tr><td>{{.Severity}}</td><td>{{scope .}}</td><td>{{.Rule}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+{{with .Errors}}
+<h2>Incomplete sections</h2>
+<ul>
+{{range .}}<li>{{.Section}}: {{.Error}}</li>
+{{end}}</ul>
+{{end}}
+{{with .Unavailable}}
+<h2>Not available for this account</h2>
+<ul>
+{{range .}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}
+</body>
+</html>
+`))
+
+func formatBytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+func formatAmount(amount int64, currency string) string {
+	return fmt.Sprintf("%.2f %s", float64(amount)/100, currency)
+}
+
+func serviceLabel(name, id string) string {
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s (%s)", name, id)
+}
+
+func scope(f SecurityFinding) string {
+	if f.ServiceID == "" {
+		return "account"
+	}
+	return f.ServiceID
+}
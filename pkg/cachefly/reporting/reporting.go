@@ -0,0 +1,267 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/lint"
+)
+
+// Defaults used for zero Options fields.
+const (
+	DefaultTopServices       = 10
+	DefaultCertificateWindow = 30 * 24 * time.Hour
+)
+
+// Report sections, as recorded in SectionError.
+const (
+	SectionUsage        = "usage"
+	SectionTopServices  = "topServices"
+	SectionCertificates = "certificates"
+	SectionDrift        = "drift"
+	SectionSecurity     = "security"
+)
+
+// Options configures a report.
+type Options struct {
+	// From and To bound the usage period; zero values select the current
+	// billing period
+	From time.Time
+	To   time.Time
+
+	// TopServices is the number of services listed by bandwidth
+	TopServices int
+
+	// CertificateWindow lists certificates expiring within this duration
+	CertificateWindow time.Duration
+
+	// Baselines maps service IDs to their expected configuration; services
+	// without a baseline are not checked for drift
+	Baselines map[string]*api.ServiceConfig
+
+	// Lint configures the rules the active services are audited with
+	Lint []lint.Option
+
+	// Now returns the report time; nil uses time.Now
+	Now func() time.Time
+}
+
+// Report is an account-wide report.
+type Report struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	Usage        *api.Usage          `json:"usage,omitempty"`
+	TopServices  []api.ServiceUsage  `json:"topServices,omitempty"`
+	Certificates []CertificateExpiry `json:"certificates,omitempty"`
+	Drift        []ServiceDrift      `json:"drift,omitempty"`
+	Security     *SecurityAudit      `json:"security,omitempty"`
+
+	// Errors lists the sections that could not be collected
+	Errors []SectionError `json:"errors,omitempty"`
+
+	// Unavailable lists the sections that were skipped because the account
+	// does not offer the feature they need, such as usage reports
+	Unavailable []string `json:"unavailable,omitempty"`
+}
+
+// CertificateExpiry is a certificate expiring within the report window.
+type CertificateExpiry struct {
+	ID         string    `json:"id"`
+	CommonName string    `json:"commonName"`
+	NotAfter   time.Time `json:"notAfter"`
+	DaysLeft   int       `json:"daysLeft"`
+	Expired    bool      `json:"expired"`
+	InUse      bool      `json:"inUse"`
+	Managed    bool      `json:"managed"`
+}
+
+// ServiceDrift is the difference between a service's baseline and its
+// current configuration.
+type ServiceDrift struct {
+	ServiceID string               `json:"serviceId"`
+	Diff      *cachefly.ConfigDiff `json:"diff"`
+}
+
+// SecurityAudit summarizes the account's security settings and the lint
+// findings of its active services.
+type SecurityAudit struct {
+	TwoFactorEnforced bool     `json:"twoFactorEnforced"`
+	SAMLEnabled       bool     `json:"samlEnabled"`
+	AllowedIPRanges   []string `json:"allowedIpRanges,omitempty"`
+
+	Findings []SecurityFinding `json:"findings,omitempty"`
+}
+
+// SecurityFinding is a lint finding of a service, or of the account when
+// ServiceID is empty.
+type SecurityFinding struct {
+	ServiceID string `json:"serviceId,omitempty"`
+	lint.Finding
+}
+
+// SectionError records why a section is missing from a report.
+type SectionError struct {
+	Section string `json:"section"`
+	Error   string `json:"error"`
+}
+
+// Generate collects a report for the authenticated account. Sections that
+// fail are recorded in Report.Errors and sections the account does not
+// offer in Report.Unavailable; an error is returned only when ctx ends
+// before the report is complete.
+func Generate(ctx context.Context, client *cachefly.Client, opts Options) (*Report, error) {
+	if opts.TopServices <= 0 {
+		opts.TopServices = DefaultTopServices
+	}
+	if opts.CertificateWindow <= 0 {
+		opts.CertificateWindow = DefaultCertificateWindow
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	r := &Report{GeneratedAt: now().UTC()}
+	fail := func(section string, err error) {
+		if errors.Is(err, cachefly.ErrFeatureUnavailable) {
+			r.Unavailable = append(r.Unavailable, section)
+			return
+		}
+		r.Errors = append(r.Errors, SectionError{Section: section, Error: err.Error()})
+	}
+	period := api.UsageOptions{From: opts.From, To: opts.To}
+
+	if usage, err := client.Billing.Usage(ctx, period); err != nil {
+		fail(SectionUsage, err)
+	} else {
+		r.Usage = usage
+	}
+
+	if services, err := client.Billing.ServicesUsage(ctx, period); err != nil {
+		fail(SectionTopServices, err)
+	} else {
+		if len(services) > opts.TopServices {
+			services = services[:opts.TopServices]
+		}
+		r.TopServices = services
+	}
+
+	if certs, err := expiringCertificates(ctx, client, r.GeneratedAt, opts.CertificateWindow); err != nil {
+		fail(SectionCertificates, err)
+	} else {
+		r.Certificates = certs
+	}
+
+	for _, id := range sortedKeys(opts.Baselines) {
+		current, err := client.Services.ExportConfig(ctx, id)
+		if err != nil {
+			fail(SectionDrift, fmt.Errorf("service %s: %w", id, err))
+			continue
+		}
+		if diff := cachefly.DiffConfigs(opts.Baselines[id], current, cachefly.DiffIdentity()); !diff.Empty() {
+			r.Drift = append(r.Drift, ServiceDrift{ServiceID: id, Diff: diff})
+		}
+	}
+
+	if audit, err := securityAudit(ctx, client, opts.Lint); err != nil {
+		fail(SectionSecurity, err)
+	} else {
+		r.Security = audit
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// expiringCertificates lists the certificates expiring before now+window,
+// soonest first.
+func expiringCertificates(ctx context.Context, client *cachefly.Client, now time.Time, window time.Duration) ([]CertificateExpiry, error) {
+	var expiring []CertificateExpiry
+	for offset := 0; ; offset += 100 {
+		page, err := client.Certificates.List(ctx, api.ListCertificatesOptions{Offset: offset, Limit: 100})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list certificates: %w", err)
+		}
+		for _, cert := range page.Certificates {
+			notAfter, err := time.Parse(time.RFC3339, cert.NotAfter)
+			if err != nil || notAfter.After(now.Add(window)) {
+				continue
+			}
+			expiring = append(expiring, CertificateExpiry{
+				ID:         cert.ID,
+				CommonName: cert.SubjectCommonName,
+				NotAfter:   notAfter,
+				DaysLeft:   int(notAfter.Sub(now).Hours() / 24),
+				Expired:    !notAfter.After(now),
+				InUse:      cert.InUse,
+				Managed:    cert.Managed,
+			})
+		}
+		if len(page.Certificates) < 100 {
+			break
+		}
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool { return expiring[i].NotAfter.Before(expiring[j].NotAfter) })
+	return expiring, nil
+}
+
+// securityAudit checks the account's security settings and lints every
+// active service.
+func securityAudit(ctx context.Context, client *cachefly.Client, opts []lint.Option) (*SecurityAudit, error) {
+	security, err := client.AccountSecurity.Get(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account security: %w", err)
+	}
+
+	audit := &SecurityAudit{
+		TwoFactorEnforced: security.TwoFactor.Enforced,
+		SAMLEnabled:       security.SAML.Enabled,
+		AllowedIPRanges:   security.AllowedIPRanges,
+	}
+	if !security.TwoFactor.Enforced && !(security.SAML.Enabled && security.SAML.EnforceSSO) {
+		audit.Findings = append(audit.Findings, SecurityFinding{Finding: lint.Finding{
+			Rule:     "account-2fa",
+			Severity: lint.SeverityWarning,
+			Message:  "two-factor authentication is not enforced",
+		}})
+	}
+	if len(security.AllowedIPRanges) == 0 {
+		audit.Findings = append(audit.Findings, SecurityFinding{Finding: lint.Finding{
+			Rule:     "account-ip-ranges",
+			Severity: lint.SeverityInfo,
+			Message:  "logins and API access are allowed from any address",
+		}})
+	}
+
+	err = client.Services.ListEach(ctx, api.ListOptions{Status: "ACTIVE"}, func(svc *api.Service) error {
+		findings, err := lint.Service(ctx, client, svc.ID, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to lint service %s: %w", svc.ID, err)
+		}
+		for _, f := range findings {
+			audit.Findings = append(audit.Findings, SecurityFinding{ServiceID: svc.ID, Finding: f})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+func sortedKeys(m map[string]*api.ServiceConfig) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,143 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+func TestGenerate(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/billing/usage": `{"periodStart":"2025-06-01","periodEnd":"2025-06-30","bandwidthBytes":2500000000,
+			"plan":{"name":"Pro","bandwidthBytes":2000000000},"overage":{"bandwidthBytes":500000000,"amount":1250,"currency":"USD"}}`,
+		"/api/2.5/billing/usage/services": `{"data":[{"serviceId":"svc-2","bandwidthBytes":10},{"serviceId":"svc-1","serviceName":"Shop","bandwidthBytes":900},{"serviceId":"svc-3","bandwidthBytes":5}]}`,
+		"/api/2.5/certificates": `{"data":[
+			{"_id":"cert-1","subjectCommonName":"late.example.com","notAfter":"2025-08-01T00:00:00Z"},
+			{"_id":"cert-2","subjectCommonName":"soon.example.com","notAfter":"2025-06-20T00:00:00Z","inUse":true},
+			{"_id":"cert-3","subjectCommonName":"gone.example.com","notAfter":"2025-06-01T00:00:00Z"}]}`,
+		"/api/2.5/services":                        `{"data":[{"_id":"svc-1","name":"Shop","status":"ACTIVE"}]}`,
+		"/api/2.5/services/svc-1":                  `{"_id":"svc-1","name":"Shop","status":"ACTIVE"}`,
+		"/api/2.5/services/svc-1/options":          `{"autoRedirect":true,"hsts":300}`,
+		"/api/2.5/services/svc-1/options/metadata": `{"data":[]}`,
+		"/api/2.5/services/svc-1/domains":          `{"data":[]}`,
+		"/api/2.5/services/svc-1/rules":            `{"data":[]}`,
+		"/api/2.5/origins":                         `{"data":[]}`,
+		"/api/2.5/scriptConfigs":                   `{"data":[]}`,
+		"/api/2.5/accounts/me":                     `{"_id":"acc-1"}`,
+		"/api/2.5/accounts/me/security":            `{"twoFactor":{"enforced":false},"allowedIpRanges":["203.0.113.0/24"]}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	report, err := Generate(context.Background(), client, Options{
+		TopServices: 2,
+		Baselines: map[string]*api.ServiceConfig{
+			"svc-1": {Service: api.Service{Name: "Shop"}, Options: api.ServiceOptions{"autoRedirect": false, "hsts": 300}},
+		},
+		Now: func() time.Time { return time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Errors) != 0 {
+		t.Fatalf("Expected no section errors, got %+v", report.Errors)
+	}
+
+	if report.Usage == nil || report.Usage.Overage.Amount != 1250 {
+		t.Errorf("Expected usage with overage, got %+v", report.Usage)
+	}
+	if len(report.TopServices) != 2 || report.TopServices[0].ServiceID != "svc-1" {
+		t.Errorf("Expected the top 2 services led by svc-1, got %+v", report.TopServices)
+	}
+	if len(report.Certificates) != 2 || report.Certificates[0].ID != "cert-3" || !report.Certificates[0].Expired {
+		t.Errorf("Expected the expired and expiring certificates, got %+v", report.Certificates)
+	}
+	if c := report.Certificates[1]; c.ID != "cert-2" || c.DaysLeft != 10 || c.Expired {
+		t.Errorf("Expected cert-2 expiring in 10 days, got %+v", c)
+	}
+	if len(report.Drift) != 1 || len(report.Drift[0].Diff.Options) != 1 {
+		t.Errorf("Expected drift of autoRedirect, got %+v", report.Drift)
+	}
+	if report.Security == nil || report.Security.TwoFactorEnforced {
+		t.Fatalf("Expected security audit without 2FA, got %+v", report.Security)
+	}
+	if f := report.Security.Findings[0]; f.Rule != "account-2fa" || f.ServiceID != "" {
+		t.Errorf("Expected the account 2FA finding first, got %+v", f)
+	}
+
+	md := report.Markdown()
+	for _, want := range []string{"# CacheFly report 2025-06-10", "| Bandwidth | 2.5 GB | 2.0 GB | 500.0 MB |", "12.50 USD", "Shop (svc-1)", "soon.example.com", "option autoRedirect", "account-2fa"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Expected Markdown to contain %q, got:\n%s", want, md)
+		}
+	}
+
+	html, err := report.HTML()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(html, "<td>soon.example.com</td>") || !strings.Contains(html, "<h3>svc-1</h3>") {
+		t.Errorf("Expected HTML tables, got:\n%s", html)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(data), `"rule":"account-2fa"`) {
+		t.Errorf("Expected findings flattened in JSON, got %s", data)
+	}
+}
+
+func TestGenerate_SectionErrors(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/billing/usage":          `{"periodStart":`,
+		"/api/2.5/billing/usage/services": `{"data":`,
+		"/api/2.5/certificates":           `{"data":[]}`,
+		"/api/2.5/services":               `{"data":[]}`,
+		"/api/2.5/accounts/me/security":   `{"twoFactor":{"enforced":true}}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	report, err := Generate(context.Background(), client, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Errors) != 2 || report.Errors[0].Section != SectionUsage || report.Errors[1].Section != SectionTopServices {
+		t.Errorf("Expected usage and top services errors, got %+v", report.Errors)
+	}
+	if report.Security == nil {
+		t.Error("Expected the security section despite the billing errors")
+	}
+	if !strings.Contains(report.Markdown(), "## Incomplete sections") {
+		t.Error("Expected the errors in the Markdown report")
+	}
+}
+
+func TestGenerate_UnavailableSections(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/certificates":         `{"data":[]}`,
+		"/api/2.5/services":             `{"data":[]}`,
+		"/api/2.5/accounts/me/security": `{"twoFactor":{"enforced":true}}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	report, err := Generate(context.Background(), client, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(report.Errors) != 0 {
+		t.Errorf("Expected no section errors, got %+v", report.Errors)
+	}
+	if len(report.Unavailable) != 2 || report.Unavailable[0] != SectionUsage || report.Unavailable[1] != SectionTopServices {
+		t.Errorf("Expected usage and top services to be unavailable, got %v", report.Unavailable)
+	}
+	if !strings.Contains(report.Markdown(), "## Not available for this account\n\n- usage\n- topServices\n") {
+		t.Errorf("Expected the unavailable sections in the Markdown report, got %s", report.Markdown())
+	}
+}
@@ -0,0 +1,45 @@
+// Package resourceops exposes CacheFly resources through a uniform
+// Create/Read/Update/Delete/Diff interface over plain structs.
+//
+// It is designed as the shared core for infrastructure-as-code providers:
+// a Terraform or Pulumi provider can map its resource schema onto the plain
+// structs in this package and delegate every lifecycle call to a Resource.
+//
+// Example:
+//
+//	services := resourceops.NewServices(client)
+//
+//	current, err := services.Read(ctx, "srv_123")
+//	desired := current
+//	desired.Description = "managed by terraform"
+//
+//	for _, change := range services.Diff(current, desired) {
+//		fmt.Printf("%s: %v -> %v\n", change.Field, change.Old, change.New)
+//	}
+//	updated, err := services.Update(ctx, current.ID, desired)
+//
+// For simple scripts, EnsureService and EnsureDomain create a resource when
+// it is missing, update it when it drifted and otherwise do nothing,
+// reporting the action taken. The reconcile package builds on them:
+//
+//	result, err := resourceops.EnsureDomain(ctx, client, resourceops.Domain{
+//		ServiceID: "srv_123",
+//		Name:      "cdn.example.com",
+//	})
+//	if err == nil && result.Action == resourceops.EnsureCreated {
+//		fmt.Println("created", result.Resource.ID)
+//	}
+//
+// Providers apply the configured state with Upsert, which unlike the Ensure
+// helpers also applies zero values and like EnsureService reactivates a
+// deactivated service, and detect drift with Drift. Read,
+// Update and Delete report missing resources, including deactivated
+// services, as a NotFoundError matching ErrNotFound, so a provider can drop
+// them from its state:
+//
+//	current, changes, err := resourceops.Drift[resourceops.Origin](ctx, origins, id, state)
+//	if resourceops.IsNotFound(err) {
+//		d.SetId("")
+//		return nil
+//	}
+package resourceops
@@ -0,0 +1,117 @@
+package resourceops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Domain is the plain representation of a service domain.
+//
+// Domains are scoped to a service, so their ID is the composite
+// "<serviceID>/<domainID>" returned by DomainID.
+type Domain struct {
+	ID               string `json:"id" resource:"computed"`
+	ServiceID        string `json:"serviceId" resource:"forcenew"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	ValidationMode   string `json:"validationMode"`
+	ValidationStatus string `json:"validationStatus" resource:"computed"`
+}
+
+// Domains implements Resource for service domains.
+type Domains struct {
+	client *cachefly.Client
+}
+
+var _ Resource[Domain] = (*Domains)(nil)
+
+// NewDomains returns the service domain resource backed by client.
+func NewDomains(client *cachefly.Client) *Domains {
+	return &Domains{client: client}
+}
+
+// DomainID builds the composite ID of a service domain.
+func DomainID(serviceID, domainID string) string {
+	return serviceID + "/" + domainID
+}
+
+// ParseDomainID splits a composite domain ID into service and domain IDs.
+func ParseDomainID(id string) (serviceID, domainID string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid domain ID %q, expected <serviceID>/<domainID>", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Create adds the domain to desired.ServiceID.
+func (r *Domains) Create(ctx context.Context, desired Domain) (Domain, error) {
+	created, err := r.client.ServiceDomains.Create(ctx, desired.ServiceID, api.CreateServiceDomainRequest{
+		Name:           desired.Name,
+		Description:    desired.Description,
+		ValidationMode: desired.ValidationMode,
+	})
+	if err != nil {
+		return Domain{}, err
+	}
+	return domainFromAPI(desired.ServiceID, created), nil
+}
+
+// Read returns the current state of the domain.
+func (r *Domains) Read(ctx context.Context, id string) (Domain, error) {
+	serviceID, domainID, err := ParseDomainID(id)
+	if err != nil {
+		return Domain{}, err
+	}
+	domain, err := r.client.ServiceDomains.GetByID(ctx, serviceID, domainID, "")
+	if err != nil {
+		return Domain{}, notFound("domain", id, err)
+	}
+	return domainFromAPI(serviceID, domain), nil
+}
+
+// Update changes the domain in place.
+func (r *Domains) Update(ctx context.Context, id string, desired Domain) (Domain, error) {
+	serviceID, domainID, err := ParseDomainID(id)
+	if err != nil {
+		return Domain{}, err
+	}
+	updated, err := r.client.ServiceDomains.UpdateByID(ctx, serviceID, domainID, api.UpdateServiceDomainRequest{
+		Name:           desired.Name,
+		Description:    desired.Description,
+		ValidationMode: desired.ValidationMode,
+	})
+	if err != nil {
+		return Domain{}, notFound("domain", id, err)
+	}
+	return domainFromAPI(serviceID, updated), nil
+}
+
+// Delete removes the domain from its service.
+func (r *Domains) Delete(ctx context.Context, id string) error {
+	serviceID, domainID, err := ParseDomainID(id)
+	if err != nil {
+		return err
+	}
+	return notFound("domain", id, r.client.ServiceDomains.DeleteByID(ctx, serviceID, domainID))
+}
+
+// Diff lists the fields that differ between current and desired.
+func (r *Domains) Diff(current, desired Domain) []Change {
+	return diffStructs(current, desired)
+}
+
+func domainFromAPI(serviceID string, d *api.ServiceDomain) Domain {
+	return Domain{
+		ID:               DomainID(serviceID, d.ID),
+		ServiceID:        serviceID,
+		Name:             d.Name,
+		Description:      d.Description,
+		ValidationMode:   d.ValidationMode,
+		ValidationStatus: d.ValidationStatus,
+	}
+}
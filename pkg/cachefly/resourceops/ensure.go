@@ -0,0 +1,205 @@
+package resourceops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/validate"
+)
+
+// ErrRequiresReplace is returned by the Ensure helpers when the resource
+// differs in a field that cannot be changed in place. Ensure never deletes
+// and recreates a resource.
+var ErrRequiresReplace = errors.New("resource requires replacement")
+
+const listPageSize = 100
+
+// EnsureAction describes what an Ensure helper did.
+type EnsureAction string
+
+// Actions reported in EnsureResult.
+const (
+	EnsureNone    EnsureAction = "none"
+	EnsureCreated EnsureAction = "created"
+	EnsureUpdated EnsureAction = "updated"
+)
+
+// EnsureResult reports the outcome of an Ensure helper.
+type EnsureResult[T any] struct {
+	// Resource is the state of the resource after the call
+	Resource T
+
+	// Action is what the call did
+	Action EnsureAction
+
+	// Changes lists the fields that were updated
+	Changes []Change
+}
+
+// EnsureService makes sure a service with desired.UniqueName exists and
+// matches desired. It is created when missing, reactivated when it was
+// deactivated, and updated when its mutable settings drift; otherwise
+// nothing is changed. Reactivation is reported as a change of status.
+//
+// Zero-valued fields of desired keep their current value, so callers only
+// set the fields they care about. A differing Name returns
+// ErrRequiresReplace, since services cannot be renamed.
+func EnsureService(ctx context.Context, client *cachefly.Client, desired Service) (EnsureResult[Service], error) {
+	if err := validate.UniqueName("uniqueName", desired.UniqueName); err != nil {
+		return EnsureResult[Service]{}, err
+	}
+
+	r := NewServices(client)
+	var current *Service
+	for offset := 0; current == nil; offset += listPageSize {
+		page, err := client.Services.List(ctx, api.ListOptions{Offset: offset, Limit: listPageSize})
+		if err != nil {
+			return EnsureResult[Service]{}, fmt.Errorf("failed to list services: %w", err)
+		}
+		for i := range page.Services {
+			if page.Services[i].UniqueName != desired.UniqueName {
+				continue
+			}
+			// List entries may omit settings; read the full service
+			svc, err := r.get(ctx, page.Services[i].ID)
+			if err != nil {
+				return EnsureResult[Service]{}, err
+			}
+			current = &svc
+			break
+		}
+		if len(page.Services) < listPageSize {
+			break
+		}
+	}
+
+	if current == nil {
+		if desired.Name == "" {
+			desired.Name = desired.UniqueName
+		}
+		return ensure[Service](ctx, r, nil, desired, func(s Service) string { return s.ID })
+	}
+
+	activated, err := r.activate(ctx, current)
+	if err != nil {
+		return EnsureResult[Service]{Resource: *current, Action: EnsureNone}, err
+	}
+	result, err := ensure[Service](ctx, r, current, desired, func(s Service) string { return s.ID })
+	return withActivation(result, activated), err
+}
+
+// withActivation adds the reactivation of the resource, if any, to result.
+func withActivation[T any](result EnsureResult[T], activated *Change) EnsureResult[T] {
+	if activated == nil {
+		return result
+	}
+	result.Changes = append([]Change{*activated}, result.Changes...)
+	if result.Action == EnsureNone {
+		result.Action = EnsureUpdated
+	}
+	return result
+}
+
+// EnsureDomain makes sure a domain named desired.Name exists on
+// desired.ServiceID and matches desired. It is created when missing and
+// updated when it drifts; otherwise nothing is changed. Names are matched
+// case-insensitively.
+//
+// Zero-valued fields of desired keep their current value.
+func EnsureDomain(ctx context.Context, client *cachefly.Client, desired Domain) (EnsureResult[Domain], error) {
+	if desired.ServiceID == "" || desired.Name == "" {
+		return EnsureResult[Domain]{}, fmt.Errorf("serviceId and name are required")
+	}
+
+	r := NewDomains(client)
+	var current *Domain
+	for offset := 0; current == nil; offset += listPageSize {
+		page, err := client.ServiceDomains.List(ctx, desired.ServiceID, api.ListServiceDomainsOptions{Offset: offset, Limit: listPageSize})
+		if err != nil {
+			return EnsureResult[Domain]{}, fmt.Errorf("failed to list domains: %w", err)
+		}
+		for i := range page.Domains {
+			if strings.EqualFold(page.Domains[i].Name, desired.Name) {
+				d := domainFromAPI(desired.ServiceID, &page.Domains[i])
+				current = &d
+				break
+			}
+		}
+		if len(page.Domains) < listPageSize {
+			break
+		}
+	}
+
+	if current != nil {
+		// Keep the stored spelling when only the case differs
+		desired.Name = current.Name
+	}
+	return ensure[Domain](ctx, r, current, desired, func(d Domain) string { return d.ID })
+}
+
+// ensure creates desired when current is nil and otherwise updates current
+// in place when it differs from desired. Zero-valued fields of desired keep
+// their current value.
+//
+// ensure and Upsert both go through apply, which every create-or-update in
+// this package and in the reconcile package uses.
+func ensure[T any](ctx context.Context, r Resource[T], current *T, desired T, id func(T) string) (EnsureResult[T], error) {
+	if current != nil {
+		fillZeroFields(&desired, *current)
+	}
+	return apply(ctx, r, current, desired, id)
+}
+
+// apply creates desired when current is nil and otherwise updates current
+// in place when it differs from desired. A resource that was created before
+// Create failed is still reported as created, so callers keep its ID.
+func apply[T any](ctx context.Context, r Resource[T], current *T, desired T, id func(T) string) (EnsureResult[T], error) {
+	if current == nil {
+		created, err := r.Create(ctx, desired)
+		if err != nil {
+			if reflect.ValueOf(&created).Elem().IsZero() {
+				return EnsureResult[T]{}, fmt.Errorf("failed to create: %w", err)
+			}
+			return EnsureResult[T]{Resource: created, Action: EnsureCreated}, fmt.Errorf("failed to create: %w", err)
+		}
+		return EnsureResult[T]{Resource: created, Action: EnsureCreated}, nil
+	}
+
+	changes := r.Diff(*current, desired)
+	if len(changes) == 0 {
+		return EnsureResult[T]{Resource: *current, Action: EnsureNone}, nil
+	}
+	if RequiresReplace(changes) {
+		var fields []string
+		for _, c := range changes {
+			if c.RequiresReplace {
+				fields = append(fields, c.Field)
+			}
+		}
+		return EnsureResult[T]{Resource: *current, Action: EnsureNone, Changes: changes},
+			fmt.Errorf("%w: %s changed", ErrRequiresReplace, strings.Join(fields, ", "))
+	}
+
+	updated, err := r.Update(ctx, id(*current), desired)
+	if err != nil {
+		return EnsureResult[T]{Resource: *current, Action: EnsureNone}, fmt.Errorf("failed to update: %w", err)
+	}
+	return EnsureResult[T]{Resource: updated, Action: EnsureUpdated, Changes: changes}, nil
+}
+
+// fillZeroFields copies the fields of current into desired wherever desired
+// holds the zero value.
+func fillZeroFields[T any](desired *T, current T) {
+	dv := reflect.ValueOf(desired).Elem()
+	cv := reflect.ValueOf(current)
+	for i := 0; i < dv.NumField(); i++ {
+		if dv.Type().Field(i).IsExported() && dv.Field(i).IsZero() {
+			dv.Field(i).Set(cv.Field(i))
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package resourceops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func TestEnsureService(t *testing.T) {
+	var puts int
+	description := "old"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /services":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"svc-1","name":"site","uniqueName":"my-site"}]}`))
+		case "GET /services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"site","uniqueName":"my-site","description":"` + description + `","tlsProfile":"modern"}`))
+		case "PUT /services/svc-1":
+			puts++
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["tlsProfile"] != "modern" {
+				t.Errorf("Expected unset tlsProfile to keep its value, got %v", body["tlsProfile"])
+			}
+			description = body["description"].(string)
+			w.Write([]byte(`{"_id":"svc-1","name":"site","uniqueName":"my-site","description":"` + description + `","tlsProfile":"modern"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL))
+	desired := Service{UniqueName: "my-site", Description: "new"}
+
+	result, err := EnsureService(context.Background(), client, desired)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != EnsureUpdated || len(result.Changes) != 1 || result.Changes[0].Field != "description" {
+		t.Errorf("Expected description update, got %s %v", result.Action, result.Changes)
+	}
+
+	result, err = EnsureService(context.Background(), client, desired)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != EnsureNone || puts != 1 {
+		t.Errorf("Expected no-op on second call, got %s after %d updates", result.Action, puts)
+	}
+
+	_, err = EnsureService(context.Background(), client, Service{UniqueName: "my-site", Name: "renamed"})
+	if !errors.Is(err, ErrRequiresReplace) {
+		t.Errorf("Expected ErrRequiresReplace for rename, got %v", err)
+	}
+}
+
+func TestEnsureDomain(t *testing.T) {
+	var created bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /services/svc-1/domains":
+			if created {
+				w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"dom-1","name":"cdn.example.com","validationMode":"HTTP"}]}`))
+				return
+			}
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case "POST /services/svc-1/domains":
+			created = true
+			w.Write([]byte(`{"_id":"dom-1","name":"cdn.example.com","validationMode":"HTTP"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL))
+	desired := Domain{ServiceID: "svc-1", Name: "cdn.example.com"}
+
+	result, err := EnsureDomain(context.Background(), client, desired)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != EnsureCreated || result.Resource.ID != "svc-1/dom-1" {
+		t.Errorf("Expected domain to be created, got %s %+v", result.Action, result.Resource)
+	}
+
+	desired.Name = "CDN.example.com"
+	result, err = EnsureDomain(context.Background(), client, desired)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != EnsureNone {
+		t.Errorf("Expected no-op for existing domain, got %s %v", result.Action, result.Changes)
+	}
+}
+
+func TestEnsureService_Reactivates(t *testing.T) {
+	status := "inactive"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /services":
+			w.Write([]byte(`{"meta":{"count":1},"data":[{"_id":"svc-1","uniqueName":"my-site","status":"` + status + `"}]}`))
+		case "GET /services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"site","uniqueName":"my-site","status":"` + status + `"}`))
+		case "PUT /services/svc-1/activate":
+			status = "active"
+			w.Write([]byte(`{"_id":"svc-1","name":"site","uniqueName":"my-site","status":"active"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL))
+
+	result, err := EnsureService(context.Background(), client, Service{UniqueName: "my-site"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != EnsureUpdated || len(result.Changes) != 1 || result.Changes[0].Field != "status" || result.Resource.Status != "active" {
+		t.Errorf("Expected the service to be reactivated, got %s %v %+v", result.Action, result.Changes, result.Resource)
+	}
+}
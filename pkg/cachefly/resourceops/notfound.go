@@ -0,0 +1,56 @@
+package resourceops
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+// ErrNotFound is matched by errors.Is when a resource does not exist, was
+// deleted or, for services, was deactivated. Providers should remove the
+// resource from their state when Read returns it.
+var ErrNotFound = errors.New("resource not found")
+
+// NotFoundError is returned by Read, Update and Delete when the resource
+// does not exist.
+type NotFoundError struct {
+	// Kind is the resource type, such as "service" or "domain"
+	Kind string
+
+	// ID is the ID that was looked up
+	ID string
+
+	// Err is the API error, if any; nil for deactivated services
+	Err error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Kind, e.ID)
+}
+
+// Is reports whether target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Unwrap returns the underlying API error.
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err means the resource does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// notFound turns a 404 response into a NotFoundError and returns other
+// errors unchanged.
+func notFound(kind, id string, err error) error {
+	var apiErr *cachefly.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return &NotFoundError{Kind: kind, ID: id, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package resourceops
+
+import (
+	"context"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Origin is the plain representation of an origin server.
+type Origin struct {
+	ID                string `json:"id" resource:"computed"`
+	Type              string `json:"type" resource:"forcenew"`
+	Name              string `json:"name"`
+	Hostname          string `json:"hostname"`
+	Scheme            string `json:"scheme"`
+	Gzip              bool   `json:"gzip"`
+	CacheByQueryParam bool   `json:"cacheByQueryParam"`
+	TTL               int    `json:"ttl"`
+	MissedTTL         int    `json:"missedTtl"`
+}
+
+// Origins implements Resource for origin servers.
+type Origins struct {
+	client *cachefly.Client
+}
+
+var _ Resource[Origin] = (*Origins)(nil)
+
+// NewOrigins returns the origin resource backed by client.
+func NewOrigins(client *cachefly.Client) *Origins {
+	return &Origins{client: client}
+}
+
+// Create adds the origin.
+func (r *Origins) Create(ctx context.Context, desired Origin) (Origin, error) {
+	created, err := r.client.Origins.Create(ctx, api.CreateOriginRequest{
+		Type:              desired.Type,
+		Name:              desired.Name,
+		Hostname:          desired.Hostname,
+		Scheme:            desired.Scheme,
+		Gzip:              desired.Gzip,
+		CacheByQueryParam: desired.CacheByQueryParam,
+		TTL:               desired.TTL,
+		MissedTTL:         desired.MissedTTL,
+	})
+	if err != nil {
+		return Origin{}, err
+	}
+	return originFromAPI(created), nil
+}
+
+// Read returns the current state of the origin.
+func (r *Origins) Read(ctx context.Context, id string) (Origin, error) {
+	origin, err := r.client.Origins.GetByID(ctx, id, "")
+	if err != nil {
+		return Origin{}, notFound("origin", id, err)
+	}
+	return originFromAPI(origin), nil
+}
+
+// Update changes the origin in place.
+func (r *Origins) Update(ctx context.Context, id string, desired Origin) (Origin, error) {
+	updated, err := r.client.Origins.UpdateByID(ctx, id, api.UpdateOriginRequest{
+		Type:              desired.Type,
+		Name:              desired.Name,
+		Hostname:          desired.Hostname,
+		Scheme:            desired.Scheme,
+		Gzip:              desired.Gzip,
+		CacheByQueryParam: desired.CacheByQueryParam,
+		TTL:               desired.TTL,
+		MissedTTL:         desired.MissedTTL,
+	})
+	if err != nil {
+		return Origin{}, notFound("origin", id, err)
+	}
+	return originFromAPI(updated), nil
+}
+
+// Delete removes the origin.
+func (r *Origins) Delete(ctx context.Context, id string) error {
+	return notFound("origin", id, r.client.Origins.Delete(ctx, id))
+}
+
+// Diff lists the fields that differ between current and desired.
+func (r *Origins) Diff(current, desired Origin) []Change {
+	return diffStructs(current, desired)
+}
+
+func originFromAPI(o *api.Origin) Origin {
+	return Origin{
+		ID:                o.ID,
+		Type:              o.Type,
+		Name:              o.Name,
+		Hostname:          o.Hostname,
+		Scheme:            o.Scheme,
+		Gzip:              o.Gzip,
+		CacheByQueryParam: o.CacheByQueryParam,
+		TTL:               o.TTL,
+		MissedTTL:         o.MissedTTL,
+	}
+}
@@ -0,0 +1,87 @@
+package resourceops
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// Resource is the lifecycle contract implemented for every resource type.
+//
+// T is a plain struct describing the resource. Fields tagged
+// `resource:"computed"` are set by the API and ignored by Diff; fields tagged
+// `resource:"forcenew"` cannot be changed in place and require replacement.
+type Resource[T any] interface {
+	// Create creates the resource and returns its state, including the ID.
+	// When the resource was created but a later step failed, the state of
+	// the created resource is returned with the error.
+	Create(ctx context.Context, desired T) (T, error)
+
+	// Read returns the current state of the resource.
+	Read(ctx context.Context, id string) (T, error)
+
+	// Update changes the resource in place and returns its new state.
+	Update(ctx context.Context, id string, desired T) (T, error)
+
+	// Delete removes the resource.
+	Delete(ctx context.Context, id string) error
+
+	// Diff lists the fields that differ between current and desired.
+	Diff(current, desired T) []Change
+}
+
+// Change describes a single field difference reported by Diff.
+type Change struct {
+	Field           string      `json:"field"`
+	Old             interface{} `json:"old"`
+	New             interface{} `json:"new"`
+	RequiresReplace bool        `json:"requiresReplace"`
+}
+
+// RequiresReplace reports whether any change forces the resource to be recreated.
+func RequiresReplace(changes []Change) bool {
+	for _, c := range changes {
+		if c.RequiresReplace {
+			return true
+		}
+	}
+	return false
+}
+
+// diffStructs compares the exported fields of two structs of the same type.
+// Field names are reported using their json tag.
+func diffStructs(current, desired interface{}) []Change {
+	cv := reflect.ValueOf(current)
+	dv := reflect.ValueOf(desired)
+	t := cv.Type()
+
+	var changes []Change
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("resource")
+		if tag == "computed" {
+			continue
+		}
+
+		old := cv.Field(i).Interface()
+		new := dv.Field(i).Interface()
+		if reflect.DeepEqual(old, new) {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		changes = append(changes, Change{
+			Field:           name,
+			Old:             old,
+			New:             new,
+			RequiresReplace: tag == "forcenew",
+		})
+	}
+	return changes
+}
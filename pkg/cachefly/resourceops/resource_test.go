@@ -0,0 +1,92 @@
+package resourceops
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func TestServices_Diff(t *testing.T) {
+	r := NewServices(nil)
+
+	current := Service{ID: "srv-1", Name: "site", Description: "old", Status: "ACTIVE"}
+	desired := Service{Name: "site-renamed", Description: "new"}
+
+	changes := r.Diff(current, desired)
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes (computed fields ignored), got %v", changes)
+	}
+	if changes[0].Field != "name" || !changes[0].RequiresReplace {
+		t.Errorf("Expected name change to require replacement, got %v", changes[0])
+	}
+	if changes[1].Field != "description" || changes[1].RequiresReplace {
+		t.Errorf("Expected in-place description change, got %v", changes[1])
+	}
+	if !RequiresReplace(changes) {
+		t.Error("Expected RequiresReplace to be true")
+	}
+}
+
+func TestServices_Delete(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /services/srv-1":
+			w.Write([]byte(`{"_id":"srv-1","name":"site","status":"ACTIVE"}`))
+		case "GET /services/srv-1/options":
+			w.Write([]byte(`{}`))
+		case "GET /services/srv-1/domains":
+			w.Write([]byte(`{"meta":{"count":0},"data":[]}`))
+		case "PUT /services/srv-1/deactivate", "DELETE /services/srv-1":
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{"_id":"srv-1","status":"inactive"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL))
+	if err := NewServices(client).Delete(context.Background(), "srv-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(requests) != 2 || requests[1] != "DELETE /services/srv-1" {
+		t.Errorf("Expected the service to be deactivated and deleted, got %v", requests)
+	}
+}
+
+func TestDomains_Read(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.5/services/svc-1/domains/dom-1" {
+			t.Errorf("Expected path /api/2.5/services/svc-1/domains/dom-1, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"dom-1","name":"cdn.example.com","validationStatus":"VALID"}`))
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	domain, err := NewDomains(client).Read(context.Background(), DomainID("svc-1", "dom-1"))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if domain.ID != "svc-1/dom-1" || domain.ServiceID != "svc-1" {
+		t.Errorf("Expected composite ID svc-1/dom-1, got %s", domain.ID)
+	}
+	if domain.Name != "cdn.example.com" {
+		t.Errorf("Expected name cdn.example.com, got %s", domain.Name)
+	}
+}
+
+func TestParseDomainID_Invalid(t *testing.T) {
+	if _, _, err := ParseDomainID("dom-1"); err == nil {
+		t.Error("Expected error for ID without service prefix")
+	}
+}
@@ -0,0 +1,139 @@
+package resourceops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Service is the plain representation of a CacheFly service.
+type Service struct {
+	ID                string `json:"id" resource:"computed"`
+	Name              string `json:"name" resource:"forcenew"`
+	UniqueName        string `json:"uniqueName" resource:"forcenew"`
+	Description       string `json:"description"`
+	AutoSSL           bool   `json:"autoSsl"`
+	ConfigurationMode string `json:"configurationMode"`
+	TLSProfile        string `json:"tlsProfile"`
+	DeliveryRegion    string `json:"deliveryRegion"`
+	Status            string `json:"status" resource:"computed"`
+}
+
+// ServiceStatusInactive is the status of deactivated services.
+const ServiceStatusInactive = "inactive"
+
+// Services implements Resource for CacheFly services.
+type Services struct {
+	client *cachefly.Client
+}
+
+var (
+	_ Resource[Service]    = (*Services)(nil)
+	_ reactivator[Service] = (*Services)(nil)
+)
+
+// NewServices returns the service resource backed by client.
+func NewServices(client *cachefly.Client) *Services {
+	return &Services{client: client}
+}
+
+// Create creates the service and applies the remaining settings. When
+// applying them fails, the created service is returned with the error, so
+// callers keep its ID.
+func (r *Services) Create(ctx context.Context, desired Service) (Service, error) {
+	created, err := r.client.Services.Create(ctx, api.CreateServiceRequest{
+		Name:        desired.Name,
+		UniqueName:  desired.UniqueName,
+		Description: desired.Description,
+	})
+	if err != nil {
+		return Service{}, err
+	}
+	updated, err := r.Update(ctx, created.ID, desired)
+	if err != nil {
+		return serviceFromAPI(created), fmt.Errorf("failed to apply settings of created service %s: %w", created.ID, err)
+	}
+	return updated, nil
+}
+
+// Read returns the current state of the service. Deactivated services are
+// reported as not found.
+func (r *Services) Read(ctx context.Context, id string) (Service, error) {
+	svc, err := r.get(ctx, id)
+	if err != nil {
+		return Service{}, err
+	}
+	if strings.EqualFold(svc.Status, ServiceStatusInactive) {
+		return Service{}, &NotFoundError{Kind: "service", ID: id}
+	}
+	return svc, nil
+}
+
+// get returns the service regardless of its status.
+func (r *Services) get(ctx context.Context, id string) (Service, error) {
+	svc, err := r.client.Services.GetByID(ctx, id)
+	if err != nil {
+		return Service{}, notFound("service", id, err)
+	}
+	return serviceFromAPI(svc), nil
+}
+
+// activate activates current when it is deactivated and returns the change
+// of status, or nil when it was active.
+func (r *Services) activate(ctx context.Context, current *Service) (*Change, error) {
+	if !strings.EqualFold(current.Status, ServiceStatusInactive) {
+		return nil, nil
+	}
+	svc, err := r.client.Services.ActivateServiceByID(ctx, current.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate service: %w", err)
+	}
+	activated := &Change{Field: "status", Old: current.Status, New: svc.Status}
+	current.Status = svc.Status
+	return activated, nil
+}
+
+// Update applies the mutable settings of desired to the service.
+func (r *Services) Update(ctx context.Context, id string, desired Service) (Service, error) {
+	updated, err := r.client.Services.UpdateServiceByID(ctx, id, api.UpdateServiceRequest{
+		Description:       desired.Description,
+		TLSProfile:        desired.TLSProfile,
+		AutoSSL:           desired.AutoSSL,
+		DeliveryRegion:    desired.DeliveryRegion,
+		ConfigurationMode: desired.ConfigurationMode,
+	})
+	if err != nil {
+		return Service{}, notFound("service", id, err)
+	}
+	return serviceFromAPI(updated), nil
+}
+
+// Delete deactivates and deletes the service with Services.Delete. It fails
+// while the service has domains or deletion protection enabled, so
+// providers remove dependent domains first.
+func (r *Services) Delete(ctx context.Context, id string) error {
+	_, err := r.client.Services.Delete(ctx, id, api.DeleteOptions{Force: true})
+	return notFound("service", id, err)
+}
+
+// Diff lists the fields that differ between current and desired.
+func (r *Services) Diff(current, desired Service) []Change {
+	return diffStructs(current, desired)
+}
+
+func serviceFromAPI(svc *api.Service) Service {
+	return Service{
+		ID:                svc.ID,
+		Name:              svc.Name,
+		UniqueName:        svc.UniqueName,
+		Description:       svc.Description,
+		AutoSSL:           svc.AutoSSL,
+		ConfigurationMode: svc.ConfigurationMode,
+		TLSProfile:        svc.TLSProfile,
+		DeliveryRegion:    svc.DeliveryRegion,
+		Status:            svc.Status,
+	}
+}
@@ -0,0 +1,65 @@
+package resourceops
+
+import (
+	"context"
+	"fmt"
+)
+
+// Upsert makes the resource identified by id match desired exactly, in the
+// way a Terraform apply does. The resource is created when id is empty or
+// the resource no longer exists, updated in place when it differs, and left
+// alone otherwise. Unlike the Ensure helpers, zero-valued fields of desired
+// are applied as well.
+//
+// A deactivated service is reactivated and updated rather than recreated,
+// as EnsureService does; the reactivation is reported as a change of
+// status. A difference in a field that cannot be changed in place returns
+// ErrRequiresReplace; Upsert never deletes a resource.
+func Upsert[T any](ctx context.Context, r Resource[T], id string, desired T) (EnsureResult[T], error) {
+	var current *T
+	var activated *Change
+	if id != "" {
+		var state T
+		var err error
+		re, reactivates := r.(reactivator[T])
+		if reactivates {
+			state, err = re.get(ctx, id)
+		} else {
+			state, err = r.Read(ctx, id)
+		}
+		if err != nil && !IsNotFound(err) {
+			return EnsureResult[T]{}, fmt.Errorf("failed to read: %w", err)
+		}
+		if err == nil {
+			current = &state
+			if reactivates {
+				if activated, err = re.activate(ctx, current); err != nil {
+					return EnsureResult[T]{Resource: state, Action: EnsureNone}, err
+				}
+			}
+		}
+	}
+	result, err := apply(ctx, r, current, desired, func(T) string { return id })
+	return withActivation(result, activated), err
+}
+
+// reactivator is implemented by resources whose Read reports deactivated
+// resources as not found, such as Services. get reads the resource whatever
+// its status and activate activates it when it is deactivated, returning
+// the change of status if it did.
+type reactivator[T any] interface {
+	get(ctx context.Context, id string) (T, error)
+	activate(ctx context.Context, current *T) (*Change, error)
+}
+
+// Drift reads the resource identified by id and lists how it differs from
+// desired, the state last applied. It returns an error matching ErrNotFound
+// when the resource was deleted outside the provider.
+func Drift[T any](ctx context.Context, r Resource[T], id string, desired T) (T, []Change, error) {
+	current, err := r.Read(ctx, id)
+	if err != nil {
+		var zero T
+		return zero, nil, err
+	}
+	return current, r.Diff(current, desired), nil
+}
@@ -0,0 +1,147 @@
+package resourceops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func TestRead_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/2.5/services/srv-gone":
+			w.Write([]byte(`{"_id":"srv-gone","uniqueName":"gone","status":"inactive"}`))
+		default:
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	_, err := NewOrigins(client).Read(context.Background(), "org-1")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || notFound.Kind != "origin" || notFound.ID != "org-1" {
+		t.Fatalf("Expected NotFoundError for origin org-1, got %v", err)
+	}
+	var apiErr *cachefly.APIError
+	if !errors.As(err, &apiErr) {
+		t.Error("Expected NotFoundError to unwrap to the APIError")
+	}
+
+	_, err = NewDomains(client).Read(context.Background(), DomainID("svc-1", "dom-1"))
+	if !IsNotFound(err) {
+		t.Errorf("Expected domain to be not found, got %v", err)
+	}
+
+	_, err = NewServices(client).Read(context.Background(), "srv-gone")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected deactivated service to be not found, got %v", err)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	var created, updated bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/origins/org-gone":
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+		case r.Method == "GET" && r.URL.Path == "/api/2.5/origins/org-1":
+			w.Write([]byte(`{"_id":"org-1","type":"http","name":"web","hostname":"origin.example.com","ttl":3600}`))
+		case r.Method == "POST" && r.URL.Path == "/api/2.5/origins":
+			created = true
+			w.Write([]byte(`{"_id":"org-2","type":"http","name":"web","hostname":"origin.example.com"}`))
+		case r.Method == "PUT" && r.URL.Path == "/api/2.5/origins/org-1":
+			updated = true
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["hostname"] != "origin.example.com" {
+				t.Errorf("Expected hostname origin.example.com, got %v", body["hostname"])
+			}
+			w.Write([]byte(`{"_id":"org-1","type":"http","name":"web","hostname":"origin.example.com"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+	origins := NewOrigins(client)
+	desired := Origin{Type: "http", Name: "web", Hostname: "origin.example.com"}
+
+	result, err := Upsert[Origin](context.Background(), origins, "org-gone", desired)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !created || result.Action != EnsureCreated || result.Resource.ID != "org-2" {
+		t.Errorf("Expected missing origin to be recreated, got %+v", result)
+	}
+
+	desired.ID = "org-1"
+	result, err = Upsert[Origin](context.Background(), origins, "org-1", desired)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !updated || result.Action != EnsureUpdated {
+		t.Errorf("Expected TTL drift to be updated, got %+v", result)
+	}
+}
+
+func TestDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"_id":"org-1","type":"http","name":"web","hostname":"changed.example.com"}`))
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	_, changes, err := Drift[Origin](context.Background(), NewOrigins(client), "org-1",
+		Origin{ID: "org-1", Type: "http", Name: "web", Hostname: "origin.example.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(changes) != 1 || changes[0].Field != "hostname" {
+		t.Errorf("Expected hostname drift, got %v", changes)
+	}
+}
+
+func TestUpsert_ReactivatesService(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"site","uniqueName":"my-site","status":"inactive"}`))
+		case "PUT /services/svc-1/activate":
+			w.Write([]byte(`{"_id":"svc-1","name":"site","uniqueName":"my-site","status":"active"}`))
+		case "PUT /services/svc-1":
+			w.Write([]byte(`{"_id":"svc-1","name":"site","uniqueName":"my-site","description":"Main","status":"active"}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL))
+
+	result, err := Upsert[Service](context.Background(), NewServices(client), "svc-1", Service{Name: "site", UniqueName: "my-site", Description: "Main"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Action != EnsureUpdated || len(result.Changes) != 2 || result.Changes[0].Field != "status" || result.Changes[1].Field != "description" {
+		t.Errorf("Expected the service to be reactivated and updated, got %s %+v", result.Action, result.Changes)
+	}
+	expected := []string{"GET /services/svc-1", "PUT /services/svc-1/activate", "PUT /services/svc-1"}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,40 @@
+package cachefly
+
+import (
+	"time"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+)
+
+// RetryPolicy controls how failed requests are retried. Network errors and
+// 429, 502, 503 and 504 responses are retried with exponential backoff,
+// honouring Retry-After. POST requests are retried only when RetryPost is set.
+type RetryPolicy = httpclient.RetryPolicy
+
+// Attempt records a single failed request attempt.
+type Attempt = httpclient.Attempt
+
+// RetryExhaustedError is returned when every attempt allowed by the retry
+// policy failed. Attempts holds the time, status and wait of each attempt,
+// and errors.Is/As see the last attempt's error.
+type RetryExhaustedError = httpclient.RetryExhaustedError
+
+// DefaultRetryPolicy returns a policy of 3 attempts backing off from 500ms to 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return httpclient.DefaultRetryPolicy()
+}
+
+// Policy is a timeout and retry override applied with WithEndpointPolicy.
+type Policy struct {
+	// Timeout overrides the client timeout. Zero keeps it, negative disables it.
+	Timeout time.Duration
+
+	// Retry overrides the client retry policy
+	Retry *RetryPolicy
+
+	// NoRetry disables retries for matching requests
+	NoRetry bool
+}
+
+// EndpointPolicy is a Policy bound to an endpoint pattern.
+type EndpointPolicy = httpclient.EndpointPolicy
@@ -0,0 +1,15 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// Scheduler caps the number of concurrent requests across every client that
+// shares it. When the cap is reached, waiting requests are served
+// round-robin by account, so one account's large sync cannot starve the
+// others. Share one Scheduler between the clients of a multi-account pool
+// with WithScheduler.
+type Scheduler = httpclient.Scheduler
+
+// NewScheduler returns a Scheduler allowing maxConcurrent requests at once.
+func NewScheduler(maxConcurrent int) *Scheduler {
+	return httpclient.NewScheduler(maxConcurrent)
+}
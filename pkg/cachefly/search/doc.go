@@ -0,0 +1,17 @@
+// Package search finds resources across an account by name.
+//
+// Find lists services, their domains, certificates and users and matches
+// the query against service names and uniqueNames, domain names,
+// certificate common names and user emails, ignoring case:
+//
+//	hits, err := search.Find(ctx, client, "shop")
+//	if err != nil {
+//		return err
+//	}
+//	for _, hit := range hits {
+//		fmt.Printf("%s %s: %s\n", hit.Ref.Kind, hit.Ref.ID, hit.Value)
+//	}
+//
+// Hits are ordered by how well they match: exact matches first, then
+// prefix matches, then matches anywhere in the value.
+package search
@@ -0,0 +1,159 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+	api "github.com/cachefly/cachefly-go-sdk/pkg/cachefly/api/v2_5"
+)
+
+// Kind is the type of resource a hit refers to.
+type Kind string
+
+// Resource kinds, in the order hits of equal quality are listed.
+const (
+	KindService     Kind = "service"
+	KindDomain      Kind = "domain"
+	KindCertificate Kind = "certificate"
+	KindUser        Kind = "user"
+)
+
+var kindOrder = map[Kind]int{KindService: 0, KindDomain: 1, KindCertificate: 2, KindUser: 3}
+
+// Fields matched by Find, as reported in Hit.Field.
+const (
+	FieldName       = "name"
+	FieldUniqueName = "uniqueName"
+	FieldDomain     = "domain"
+	FieldCommonName = "commonName"
+	FieldEmail      = "email"
+)
+
+// Match describes how a value matched the query.
+type Match int
+
+// Match qualities, best first.
+const (
+	MatchExact Match = iota
+	MatchPrefix
+	MatchContains
+)
+
+// Ref identifies the resource of a hit. ServiceID is set for domains, which
+// are fetched through their service.
+type Ref struct {
+	Kind      Kind   `json:"kind"`
+	ID        string `json:"id"`
+	ServiceID string `json:"serviceId,omitempty"`
+}
+
+// Hit is a resource field matching the query.
+type Hit struct {
+	Ref   Ref    `json:"ref"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+	Match Match  `json:"match"`
+}
+
+const pageSize = 100
+
+// Find returns the resources of the account matching query, best matches
+// first. A resource matching in several fields is reported once for each
+// field. Any listing that fails stops the search.
+func Find(ctx context.Context, client *cachefly.Client, query string) ([]Hit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	m := matcher{query: strings.ToLower(query)}
+
+	var serviceIDs []string
+	err := client.Services.ListEach(ctx, api.ListOptions{}, func(svc *api.Service) error {
+		serviceIDs = append(serviceIDs, svc.ID)
+		ref := Ref{Kind: KindService, ID: svc.ID}
+		m.check(ref, FieldName, svc.Name)
+		m.check(ref, FieldUniqueName, svc.UniqueName)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	for _, sid := range serviceIDs {
+		for offset := 0; ; offset += pageSize {
+			page, err := client.ServiceDomains.List(ctx, sid, api.ListServiceDomainsOptions{Offset: offset, Limit: pageSize})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list domains of service %s: %w", sid, err)
+			}
+			for _, d := range page.Domains {
+				m.check(Ref{Kind: KindDomain, ID: d.ID, ServiceID: sid}, FieldDomain, d.Name)
+			}
+			if len(page.Domains) < pageSize {
+				break
+			}
+		}
+	}
+
+	for offset := 0; ; offset += pageSize {
+		page, err := client.Certificates.List(ctx, api.ListCertificatesOptions{Offset: offset, Limit: pageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list certificates: %w", err)
+		}
+		for _, c := range page.Certificates {
+			m.check(Ref{Kind: KindCertificate, ID: c.ID}, FieldCommonName, c.SubjectCommonName)
+		}
+		if len(page.Certificates) < pageSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += pageSize {
+		page, err := client.Users.List(ctx, api.ListUsersOptions{Offset: offset, Limit: pageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		for _, u := range page.Users {
+			m.check(Ref{Kind: KindUser, ID: u.ID}, FieldEmail, u.Email)
+		}
+		if len(page.Users) < pageSize {
+			break
+		}
+	}
+
+	sort.SliceStable(m.hits, func(i, j int) bool {
+		a, b := m.hits[i], m.hits[j]
+		if a.Match != b.Match {
+			return a.Match < b.Match
+		}
+		if a.Ref.Kind != b.Ref.Kind {
+			return kindOrder[a.Ref.Kind] < kindOrder[b.Ref.Kind]
+		}
+		return strings.ToLower(a.Value) < strings.ToLower(b.Value)
+	})
+	return m.hits, nil
+}
+
+// matcher collects the hits of a lower-cased query.
+type matcher struct {
+	query string
+	hits  []Hit
+}
+
+func (m *matcher) check(ref Ref, field, value string) {
+	v := strings.ToLower(value)
+	var match Match
+	switch {
+	case v == "" || !strings.Contains(v, m.query):
+		return
+	case v == m.query:
+		match = MatchExact
+	case strings.HasPrefix(v, m.query):
+		match = MatchPrefix
+	default:
+		match = MatchContains
+	}
+	m.hits = append(m.hits, Hit{Ref: ref, Field: field, Value: value, Match: match})
+}
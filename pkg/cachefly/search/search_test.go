@@ -0,0 +1,64 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cachefly/cachefly-go-sdk/internal/fakeapi"
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly"
+)
+
+func TestFind(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/services":               `{"data":[{"_id":"svc-1","name":"Web Shop","uniqueName":"shop"},{"_id":"svc-2","name":"Blog","uniqueName":"blog"}]}`,
+		"/api/2.5/services/svc-1/domains": `{"data":[{"_id":"dom-1","name":"shop.example.com"}]}`,
+		"/api/2.5/services/svc-2/domains": `{"data":[{"_id":"dom-2","name":"blog.example.com"}]}`,
+		"/api/2.5/certificates":           `{"data":[{"_id":"cert-1","subjectCommonName":"*.SHOP.example.com"}]}`,
+		"/api/2.5/users":                  `{"data":[{"_id":"usr-1","email":"shopkeeper@example.com"},{"_id":"usr-2","email":"editor@example.com"}]}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	hits, err := Find(context.Background(), client, " Shop ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		kind  Kind
+		id    string
+		field string
+		match Match
+	}{
+		{KindService, "svc-1", FieldUniqueName, MatchExact},
+		{KindDomain, "dom-1", FieldDomain, MatchPrefix},
+		{KindUser, "usr-1", FieldEmail, MatchPrefix},
+		{KindService, "svc-1", FieldName, MatchContains},
+		{KindCertificate, "cert-1", FieldCommonName, MatchContains},
+	}
+	if len(hits) != len(expected) {
+		t.Fatalf("Expected %d hits, got %+v", len(expected), hits)
+	}
+	for i, e := range expected {
+		h := hits[i]
+		if h.Ref.Kind != e.kind || h.Ref.ID != e.id || h.Field != e.field || h.Match != e.match {
+			t.Errorf("Hit %d: expected %s %s %s, got %+v", i, e.kind, e.id, e.field, h)
+		}
+	}
+	if hits[1].Ref.ServiceID != "svc-1" {
+		t.Errorf("Expected domain hit to reference svc-1, got %s", hits[1].Ref.ServiceID)
+	}
+}
+
+func TestFind_Errors(t *testing.T) {
+	server := fakeapi.Serve(t, map[string]string{
+		"/api/2.5/services": `{"data":[]}`,
+	})
+	client := cachefly.NewClient(cachefly.WithToken("test-token"), cachefly.WithBaseURL(server.URL+"/api/2.5"))
+
+	if _, err := Find(context.Background(), client, "  "); err == nil {
+		t.Error("Expected error for empty query")
+	}
+	if _, err := Find(context.Background(), client, "shop"); err == nil {
+		t.Error("Expected error when certificates cannot be listed")
+	}
+}
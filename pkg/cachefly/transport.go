@@ -0,0 +1,7 @@
+package cachefly
+
+import "github.com/cachefly/cachefly-go-sdk/internal/httpclient"
+
+// TransportOptions tunes the HTTP connection pool of a client; see
+// WithTransportOptions. Zero values keep the defaults of http.DefaultTransport.
+type TransportOptions = httpclient.TransportOptions
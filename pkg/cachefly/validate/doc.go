@@ -0,0 +1,21 @@
+// Package validate holds the client-side validation rules of the SDK: IDs,
+// hostnames, uniqueNames, CIDR ranges, PEM certificates and keys, and enum
+// values.
+//
+// The SDK runs these checks before sending requests, and frontends can run
+// the same checks on user input before calling the SDK at all. Every
+// function returns nil or an *Error carrying the field, a machine-readable
+// Code and a human-readable message:
+//
+//	if err := validate.Hostname("name", input); err != nil {
+//		switch validate.CodeOf(err) {
+//		case validate.CodeRequired:
+//			...
+//		case validate.CodeInvalidHostname:
+//			...
+//		}
+//	}
+//
+// Errors carry the parameters of their message and a key made of the Code
+// and the violated Rule, so they can be translated with a messages.Catalog.
+package validate
@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Limits of service uniqueNames.
+const (
+	MinUniqueNameLength = 3
+	MaxUniqueNameLength = 63
+)
+
+// UniqueName checks that name is a valid service uniqueName: 3 to 63
+// lowercase letters, digits and single hyphens, starting with a letter and
+// not ending with a hyphen.
+func UniqueName(field, name string) error {
+	invalid := func(rule string, params map[string]string, format string, args ...interface{}) error {
+		return newError(field, CodeInvalidUniqueName, rule, name, params, "%s %s", field, fmt.Sprintf(format, args...))
+	}
+
+	if err := Required(field, name); err != nil {
+		return err
+	}
+	if len(name) < MinUniqueNameLength || len(name) > MaxUniqueNameLength {
+		return invalid("length", map[string]string{"min": strconv.Itoa(MinUniqueNameLength), "max": strconv.Itoa(MaxUniqueNameLength)}, "must be %d to %d characters, got %d", MinUniqueNameLength, MaxUniqueNameLength, len(name))
+	}
+	if name[0] < 'a' || name[0] > 'z' {
+		return invalid("start", nil, "must start with a lowercase letter")
+	}
+	if name[len(name)-1] == '-' {
+		return invalid("end", nil, "must not end with a hyphen")
+	}
+	for i, r := range name {
+		if r == '-' {
+			if name[i-1] == '-' {
+				return invalid("consecutive_hyphens", nil, "must not contain consecutive hyphens")
+			}
+			continue
+		}
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9') {
+			return invalid("character", map[string]string{"character": string(r)}, "contains invalid character %q", r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"net"
+	"strings"
+)
+
+// Hostname limits from RFC 1035.
+const (
+	MaxHostnameLength = 253
+	MaxLabelLength    = 63
+)
+
+// Hostname checks that name is a fully qualified domain name such as
+// "cdn.example.com". A single leading "*." wildcard label is accepted; IP
+// addresses and trailing dots are not.
+func Hostname(field, name string) error {
+	if err := Required(field, name); err != nil {
+		return err
+	}
+	invalid := func(rule, reason string) error {
+		return newError(field, CodeInvalidHostname, rule, name, nil, "%s %q is not a valid hostname: %s", field, name, reason)
+	}
+
+	if len(name) > MaxHostnameLength {
+		return invalid("length", "longer than 253 characters")
+	}
+	if net.ParseIP(name) != nil {
+		return invalid("ip_address", "IP addresses are not allowed")
+	}
+	labels := strings.Split(strings.TrimPrefix(name, "*."), ".")
+	if len(labels) < 2 {
+		return invalid("labels", "must contain at least two labels")
+	}
+	for _, label := range labels {
+		if label == "" {
+			return invalid("empty_label", "empty label")
+		}
+		if len(label) > MaxLabelLength {
+			return invalid("label_length", "label longer than 63 characters")
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return invalid("hyphen", "labels must not start or end with a hyphen")
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return invalid("characters", "labels may only contain letters, digits and hyphens")
+			}
+		}
+	}
+	return nil
+}
+
+// CIDR checks that s is an IPv4 or IPv6 range in CIDR notation.
+func CIDR(field, s string) error {
+	if err := Required(field, s); err != nil {
+		return err
+	}
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return newError(field, CodeInvalidCIDR, "", s, nil, "%s %q is not a valid CIDR range", field, s)
+	}
+	return nil
+}
+
+// IPOrCIDR checks that s is either an IP address or a CIDR range.
+func IPOrCIDR(field, s string) error {
+	if err := Required(field, s); err != nil {
+		return err
+	}
+	if net.ParseIP(s) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return newError(field, CodeInvalidCIDR, "ip_or_cidr", s, nil, "%s %q is not a valid IP address or CIDR range", field, s)
+	}
+	return nil
+}
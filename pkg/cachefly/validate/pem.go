@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+)
+
+// PEMCertificate checks that data holds one or more PEM encoded X.509
+// certificates, such as a leaf certificate followed by its chain, and
+// nothing else.
+func PEMCertificate(field, data string) error {
+	if err := Required(field, data); err != nil {
+		return err
+	}
+	rest := []byte(data)
+	count := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return newError(field, CodeInvalidPEM, "type", "", map[string]string{"type": block.Type}, "%s contains a %s block, expected CERTIFICATE", field, block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return newError(field, CodeInvalidPEM, "parse", "", nil, "%s contains an invalid certificate: %v", field, err)
+		}
+		count++
+	}
+	if count == 0 {
+		return newError(field, CodeInvalidPEM, "missing", "", nil, "%s is not a PEM encoded certificate", field)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return newError(field, CodeInvalidPEM, "trailing", "", nil, "%s contains data after the last certificate", field)
+	}
+	return nil
+}
+
+// PEMPrivateKey checks that data holds a single PEM encoded PKCS #1, PKCS #8
+// or SEC 1 private key. The key is never included in the returned error.
+func PEMPrivateKey(field, data string) error {
+	if err := Required(field, data); err != nil {
+		return err
+	}
+	block, rest := pem.Decode([]byte(data))
+	if block == nil {
+		return newError(field, CodeInvalidPEM, "missing", "", nil, "%s is not a PEM encoded private key", field)
+	}
+	if strings.TrimSpace(string(rest)) != "" {
+		return newError(field, CodeInvalidPEM, "trailing", "", nil, "%s must contain a single private key", field)
+	}
+
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		_, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		_, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		_, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return newError(field, CodeInvalidPEM, "type", "", map[string]string{"type": block.Type}, "%s contains a %s block, expected a private key", field, block.Type)
+	}
+	if err != nil {
+		return newError(field, CodeInvalidPEM, "parse", "", nil, "%s contains an invalid private key", field)
+	}
+	return nil
+}
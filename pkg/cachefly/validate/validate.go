@@ -0,0 +1,144 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cachefly/cachefly-go-sdk/pkg/cachefly/apispec"
+)
+
+// Code identifies the rule a value failed.
+type Code string
+
+// Validation error codes.
+const (
+	CodeRequired          Code = apispec.ErrorCodeRequired
+	CodeInvalidID         Code = apispec.ErrorCodeInvalidID
+	CodeInvalidHostname   Code = apispec.ErrorCodeInvalidHostname
+	CodeInvalidUniqueName Code = apispec.ErrorCodeInvalidUniqueName
+	CodeInvalidCIDR       Code = apispec.ErrorCodeInvalidCIDR
+	CodeInvalidPEM        Code = apispec.ErrorCodeInvalidPEM
+	CodeInvalidEnum       Code = apispec.ErrorCodeInvalidEnum
+	CodeOutOfRange        Code = apispec.ErrorCodeOutOfRange
+)
+
+// Error describes a value that failed validation.
+type Error struct {
+	// Field is the name of the validated field, as passed by the caller
+	Field string
+
+	// Code identifies the failed check
+	Code Code
+
+	// Rule narrows Code down to the violated rule, such as "hyphen" for
+	// CodeInvalidHostname; empty when Code says it all
+	Rule string
+
+	// Value is the rejected value; empty for secrets such as private keys
+	Value string
+
+	// Message is a human-readable explanation including the field name
+	Message string
+
+	// Params holds the values the message is built from, for rendering it
+	// from a messages.Catalog. It always includes "field" and "value".
+	Params map[string]string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// MessageKey returns the Code, followed by the Rule if any, such as
+// "invalid_hostname.hyphen".
+func (e *Error) MessageKey() string {
+	if e.Rule == "" {
+		return string(e.Code)
+	}
+	return string(e.Code) + "." + e.Rule
+}
+
+// MessageParams returns Params.
+func (e *Error) MessageParams() map[string]string {
+	return e.Params
+}
+
+// CodeOf returns the Code of the first *Error in err's chain, or "" if there
+// is none.
+func CodeOf(err error) Code {
+	var verr *Error
+	if errors.As(err, &verr) {
+		return verr.Code
+	}
+	return ""
+}
+
+// newError builds an Error. params may be nil; field and value are added.
+func newError(field string, code Code, rule, value string, params map[string]string, format string, args ...interface{}) *Error {
+	if params == nil {
+		params = make(map[string]string, 2)
+	}
+	params["field"] = field
+	params["value"] = value
+	return &Error{Field: field, Code: code, Rule: rule, Value: value, Message: fmt.Sprintf(format, args...), Params: params}
+}
+
+// Required checks that value is not empty.
+func Required(field, value string) error {
+	if value == "" {
+		return newError(field, CodeRequired, "", value, nil, "%s is required", field)
+	}
+	return nil
+}
+
+// ID checks that id is a non-empty resource ID that can be placed in a
+// request path as is: it must not contain slashes, query or fragment
+// delimiters, percent signs or whitespace, and must not be "." or "..".
+func ID(field, id string) error {
+	if err := Required(field, id); err != nil {
+		return err
+	}
+	if id == "." || id == ".." || strings.ContainsAny(id, "/?#% \t\r\n") {
+		return newError(field, CodeInvalidID, "", id, nil, "%s %q is not a valid ID", field, id)
+	}
+	return nil
+}
+
+// OneOf checks that value is one of allowed.
+func OneOf(field, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	list := strings.Join(allowed, ", ")
+	return newError(field, CodeInvalidEnum, "", value, map[string]string{"allowed": list}, "%s must be one of %s, got %q", field, list, value)
+}
+
+// Range checks that value lies within [min, max].
+func Range(field string, value, min, max int) error {
+	if value < min || value > max {
+		params := map[string]string{"min": strconv.Itoa(min), "max": strconv.Itoa(max)}
+		return newError(field, CodeOutOfRange, "", strconv.Itoa(value), params, "%s must be between %d and %d, got %d", field, min, max, value)
+	}
+	return nil
+}
+
+// FloatRange checks that value lies within [min, max]. An infinite max only
+// checks the lower bound and reports violations with the Rule "min".
+func FloatRange(field string, value, min, max float64) error {
+	if value >= min && value <= max {
+		return nil
+	}
+	v := strconv.FormatFloat(value, 'g', -1, 64)
+	lo := strconv.FormatFloat(min, 'g', -1, 64)
+	if math.IsInf(max, 1) {
+		return newError(field, CodeOutOfRange, "min", v, map[string]string{"min": lo}, "%s must be at least %s, got %s", field, lo, v)
+	}
+	hi := strconv.FormatFloat(max, 'g', -1, 64)
+	params := map[string]string{"min": lo, "max": hi}
+	return newError(field, CodeOutOfRange, "", v, params, "%s must be between %s and %s, got %s", field, lo, hi, v)
+}
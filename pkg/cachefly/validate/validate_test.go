@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestID(t *testing.T) {
+	for _, id := range []string{"svc-123", "5f2b8c9e1a2b3c4d5e6f7a8b"} {
+		if err := ID("id", id); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", id, err)
+		}
+	}
+	if err := ID("service ID", ""); CodeOf(err) != CodeRequired || err.Error() != "service ID is required" {
+		t.Errorf("Expected required error, got %v", err)
+	}
+	for _, id := range []string{"a/b", "..", "a?b", "a b", "a%2F"} {
+		if err := ID("id", id); CodeOf(err) != CodeInvalidID {
+			t.Errorf("Expected %q to be rejected with %s, got %v", id, CodeInvalidID, err)
+		}
+	}
+}
+
+func TestHostname(t *testing.T) {
+	for _, name := range []string{"example.com", "cdn.Example.com", "*.example.com", "a-b.c1.example.co.uk"} {
+		if err := Hostname("name", name); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", name, err)
+		}
+	}
+	invalid := []string{"localhost", "example.com.", "-a.example.com", "a..example.com", "a_b.example.com", "*.*.example.com", "10.0.0.1", strings.Repeat("a", 64) + ".com"}
+	for _, name := range invalid {
+		err := Hostname("name", name)
+		if CodeOf(err) != CodeInvalidHostname {
+			t.Errorf("Expected %q to be rejected with %s, got %v", name, CodeInvalidHostname, err)
+		}
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	if err := CIDR("range", "10.0.0.0/8"); err != nil {
+		t.Errorf("Expected valid range, got %v", err)
+	}
+	if err := CIDR("range", "2001:db8::/32"); err != nil {
+		t.Errorf("Expected valid IPv6 range, got %v", err)
+	}
+	if err := CIDR("range", "10.0.0.1"); CodeOf(err) != CodeInvalidCIDR {
+		t.Errorf("Expected bare address to be rejected, got %v", err)
+	}
+	if err := IPOrCIDR("range", "10.0.0.1"); err != nil {
+		t.Errorf("Expected bare address to be accepted, got %v", err)
+	}
+	if err := IPOrCIDR("range", "10.0.0.0/33"); CodeOf(err) != CodeInvalidCIDR {
+		t.Errorf("Expected invalid prefix length to be rejected, got %v", err)
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	if err := UniqueName("uniqueName", "my-site-2"); err != nil {
+		t.Errorf("Expected valid uniqueName, got %v", err)
+	}
+	for _, name := range []string{"ab", "2site", "my--site", "my-site-", "My-site"} {
+		if err := UniqueName("uniqueName", name); CodeOf(err) != CodeInvalidUniqueName {
+			t.Errorf("Expected %q to be rejected with %s, got %v", name, CodeInvalidUniqueName, err)
+		}
+	}
+}
+
+func TestOneOfAndRange(t *testing.T) {
+	if err := OneOf("mode", "API", "WEB", "API"); err != nil {
+		t.Errorf("Expected valid value, got %v", err)
+	}
+	err := OneOf("mode", "FTP", "WEB", "API")
+	if CodeOf(err) != CodeInvalidEnum {
+		t.Errorf("Expected %s, got %v", CodeInvalidEnum, err)
+	}
+	if err.Error() != `mode must be one of WEB, API, got "FTP"` {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+	if err := Range("quality", 101, 1, 100); CodeOf(err) != CodeOutOfRange {
+		t.Errorf("Expected %s, got %v", CodeOutOfRange, err)
+	}
+	if err := FloatRange("threshold", 100.5, 0, 100); err == nil || err.Error() != "threshold must be between 0 and 100, got 100.5" {
+		t.Errorf("Unexpected error %v", err)
+	}
+	err = FloatRange("threshold", -1, 0, math.Inf(1))
+	var verr *Error
+	if !errors.As(err, &verr) || verr.MessageKey() != "out_of_range.min" || verr.Message != "threshold must be at least 0, got -1" {
+		t.Errorf("Expected out_of_range.min, got %v", err)
+	}
+	if err := FloatRange("threshold", 1e12, 0, math.Inf(1)); err != nil {
+		t.Errorf("Expected valid value, got %v", err)
+	}
+}
+
+func TestPEM(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+
+	if err := PEMCertificate("certificate", cert+cert); err != nil {
+		t.Errorf("Expected valid certificate chain, got %v", err)
+	}
+	if err := PEMPrivateKey("certificateKey", privateKey); err != nil {
+		t.Errorf("Expected valid private key, got %v", err)
+	}
+
+	if err := PEMCertificate("certificate", privateKey); CodeOf(err) != CodeInvalidPEM {
+		t.Errorf("Expected key to be rejected as certificate, got %v", err)
+	}
+	if err := PEMCertificate("certificate", "not pem"); CodeOf(err) != CodeInvalidPEM {
+		t.Errorf("Expected %s, got %v", CodeInvalidPEM, err)
+	}
+	err = PEMPrivateKey("certificateKey", cert)
+	if CodeOf(err) != CodeInvalidPEM {
+		t.Errorf("Expected certificate to be rejected as key, got %v", err)
+	}
+	if verr := err.(*Error); verr.Value != "" {
+		t.Errorf("Expected PEM values to be omitted from errors, got %q", verr.Value)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	err := fmt.Errorf("failed to create domain: %w", Hostname("name", "bad"))
+	if CodeOf(err) != CodeInvalidHostname {
+		t.Errorf("Expected %s through wrapping, got %q", CodeInvalidHostname, CodeOf(err))
+	}
+	if CodeOf(fmt.Errorf("other")) != "" {
+		t.Error("Expected no code for other errors")
+	}
+}